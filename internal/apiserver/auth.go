@@ -1,40 +1,95 @@
 package apiserver
 
 import (
-	"context"
 	"encoding/base64"
+	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	jwt "github.com/appleboy/gin-jwt/v2"
 	"github.com/gin-gonic/gin"
+	"github.com/skeleton1231/gotal/internal/apiserver/authz"
+	srvv1 "github.com/skeleton1231/gotal/internal/apiserver/service/v1"
 	"github.com/skeleton1231/gotal/internal/apiserver/store"
 	"github.com/skeleton1231/gotal/internal/apiserver/store/model"
+	"github.com/skeleton1231/gotal/internal/pkg/code"
+	"github.com/skeleton1231/gotal/internal/pkg/errors"
 	"github.com/skeleton1231/gotal/internal/pkg/middleware"
 	"github.com/skeleton1231/gotal/internal/pkg/middleware/auth"
+	"github.com/skeleton1231/gotal/internal/pkg/options"
+	"github.com/skeleton1231/gotal/internal/pkg/response"
 	"github.com/skeleton1231/gotal/pkg/log"
 	"github.com/spf13/viper"
 )
 
+// refreshTokenKey defines the key in gin context which holds the refresh token issued by the request.
+const refreshTokenKey = "refreshToken"
+
+// rolesKey defines the key in gin context which holds the role names of a user authenticated
+// without a token. Token users carry them in the `roles` claim.
+const rolesKey = "roles"
+
+// adminRole is the role required by the administrator-only endpoints.
+const adminRole = "admin"
+
 type loginInfo struct {
 	Username string `json:"username" binding:"required"`
 	Password string `json:"password" binding:"required"`
 }
 
+type twoFactorLoginInfo struct {
+	Challenge string `json:"challenge" binding:"required"`
+	Code      string `json:"code" binding:"required"`
+}
+
+type refreshInfo struct {
+	RefreshToken string `json:"refreshToken" binding:"required"`
+}
+
+// sessionUser is the identity a token is issued for: the user and its login session.
+type sessionUser struct {
+	*model.User
+	session *model.Session
+}
+
 func newBasicAuth() middleware.AuthStrategy {
-	return auth.NewBasicStrategy(func(username string, password string) bool {
+	return auth.NewBasicStrategy(func(c *gin.Context, username string, password string) error {
 		// fetch user from database
-		user, err := store.Client().Users().GetByUsername(context.TODO(), username, model.GetOptions{})
+		user, err := store.Client().Users().GetByUsername(c, username, model.GetOptions{})
 		if err != nil {
-			return false
+			return errors.WithCode(code.ErrSignatureInvalid, "Authorization header format is wrong.")
 		}
 
 		// Compare the login password with the user password.
 		if err := user.Compare(password); err != nil {
-			return false
+			return errors.WithCode(code.ErrSignatureInvalid, "Authorization header format is wrong.")
+		}
+		if viper.GetBool("verification.required") && !user.EmailVerified(time.Now()) {
+			return errors.WithCode(code.ErrSignatureInvalid, "Authorization header format is wrong.")
+		}
+
+		// A password alone does not let in a user with 2FA enabled, there is no place for the code.
+		required, err := srvv1.NewService(store.Client()).TwoFactors().Required(c, user.ID)
+		if err != nil {
+			log.Record(c).Errorf("check two-factor authentication of user `%s` failed: %s", user.Name, err.Error())
+
+			return err
 		}
-		return true
+		if required {
+			return errors.WithCode(code.ErrTwoFactorRequired, "user `%s` has two-factor authentication enabled", user.Name)
+		}
+
+		roles, err := store.Client().Roles().ListUserRoles(c, user.ID)
+		if err != nil {
+			log.Record(c).Errorf("list roles of user `%s` failed: %s", user.Name, err.Error())
+		} else {
+			user.Roles = roles.RoleNames()
+		}
+
+		return authorizeUser(c, user)
 	})
 }
 
@@ -50,16 +105,21 @@ func newJWTAuth() middleware.AuthStrategy {
 		LogoutResponse: func(c *gin.Context, code int) {
 			c.JSON(http.StatusOK, nil)
 		},
-		RefreshResponse: refreshResponse(),
-		PayloadFunc:     payloadFunc(),
+		PayloadFunc: payloadFunc(),
 		IdentityHandler: func(c *gin.Context) interface{} {
 			claims := jwt.ExtractClaims(c)
 			return claims[jwt.IdentityKey]
 		},
 		IdentityKey:  middleware.UsernameKey,
 		Authorizator: authorizator(),
-		Unauthorized: func(c *gin.Context, code int, message string) {
-			c.JSON(code, gin.H{
+		Unauthorized: func(c *gin.Context, status int, message string) {
+			if status == http.StatusForbidden {
+				response.WriteResponse(c, errors.WithCode(code.ErrPermissionDenied, message), nil)
+
+				return
+			}
+
+			c.JSON(status, gin.H{
 				"message": message,
 			})
 		},
@@ -69,11 +129,55 @@ func newJWTAuth() middleware.AuthStrategy {
 		TimeFunc:      time.Now,
 	})
 
-	return auth.NewJWTStrategy(*ginjwt)
+	return auth.NewJWTStrategy(*ginjwt, auth.NewRedisTokenDenylist())
 }
 
 func newAutoAuth() middleware.AuthStrategy {
-	return auth.NewAutoStrategy(newBasicAuth().(auth.BasicStrategy), newJWTAuth().(auth.JWTStrategy))
+	return auth.NewAutoStrategy(
+		newBasicAuth().(auth.BasicStrategy),
+		newJWTAuth().(auth.JWTStrategy),
+		newAPIKeyAuth().(auth.APIKeyStrategy),
+	)
+}
+
+func newAPIKeyAuth() middleware.AuthStrategy {
+	return auth.NewAPIKeyStrategy(func(c *gin.Context, key string) (string, error) {
+		user, err := srvv1.NewService(store.Client()).APIKeys().Authenticate(c, key)
+		if err != nil {
+			log.Record(c).Errorf("authenticate api key failed: %s", err.Error())
+
+			return "", err
+		}
+
+		if err := authorizeUser(c, user); err != nil {
+			return "", err
+		}
+
+		return user.Name, nil
+	})
+}
+
+// authorizeUser asks the authz service whether the authenticated user may access the
+// request, with the `userID` and `roles` attributes of the user.
+func authorizeUser(c *gin.Context, user *model.User) error {
+	c.Set(rolesKey, user.Roles)
+
+	attrs := map[string]string{"userID": strconv.FormatUint(user.ID, 10)}
+	if len(user.Roles) > 0 {
+		attrs["roles"] = strings.Join(user.Roles, ",")
+	}
+
+	allowed, err := checkAuthzPermission(c, user.Name, c.Request.Method, c.Request.URL.Path, attrs)
+	if err != nil {
+		log.Record(c).Errorf("check permission of user `%s` failed: %s", user.Name, err.Error())
+
+		return errors.WithCode(code.ErrPermissionDenied, "permission check failed")
+	}
+	if !allowed {
+		return errors.WithCode(code.ErrPermissionDenied, "you don't have permission to access this resource")
+	}
+
+	return nil
 }
 
 func authenticator() func(c *gin.Context) (interface{}, error) {
@@ -107,10 +211,133 @@ func authenticator() func(c *gin.Context) (interface{}, error) {
 			return "", jwt.ErrFailedAuthentication
 		}
 
+		// Only checked after the password, so it does not reveal which accounts exist.
+		if viper.GetBool("verification.required") && !user.EmailVerified(time.Now()) {
+			return "", errors.WithCode(code.ErrEmailNotVerified, "email `%s` has not been verified", user.Email)
+		}
+
 		return user, nil
 	}
 }
 
+// loginHandler authenticates the user with a password. Users with 2FA enabled receive a
+// challenge, which is exchanged for the tokens at /login/2fa.
+func loginHandler(j auth.JWTStrategy) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		data, err := j.Authenticator(c)
+		// Clients tell an unverified address from wrong credentials by the code.
+		if errors.IsCode(err, code.ErrEmailNotVerified) {
+			response.WriteResponse(c, err, nil)
+
+			return
+		}
+		if err != nil {
+			c.Header("WWW-Authenticate", "JWT realm="+j.Realm)
+			c.Abort()
+			j.Unauthorized(c, http.StatusUnauthorized, j.HTTPStatusMessageFunc(err, c))
+
+			return
+		}
+
+		completeLogin(c, j, data.(*model.User))
+	}
+}
+
+// twoFactorLoginHandler completes a login challenged for the second factor.
+func twoFactorLoginHandler(j auth.JWTStrategy) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var r twoFactorLoginInfo
+		if err := c.ShouldBindJSON(&r); err != nil {
+			response.WriteResponse(c, errors.WithCode(code.ErrBind, err.Error()), nil)
+
+			return
+		}
+
+		user, err := srvv1.NewService(store.Client()).TwoFactors().Verify(c, r.Challenge, r.Code)
+		if err != nil {
+			response.WriteResponse(c, err, nil)
+
+			return
+		}
+
+		issueLoginTokens(c, j, user)
+	}
+}
+
+// completeLogin responds to an authenticated login with the tokens, or with a challenge
+// when the user has 2FA enabled.
+func completeLogin(c *gin.Context, j auth.JWTStrategy, user *model.User) {
+	srv := srvv1.NewService(store.Client())
+
+	required, err := srv.TwoFactors().Required(c, user.ID)
+	if err != nil {
+		log.Record(c).Errorf("check two-factor authentication of user `%s` failed: %s", user.Name, err.Error())
+		response.WriteResponse(c, err, nil)
+
+		return
+	}
+
+	if !required {
+		issueLoginTokens(c, j, user)
+
+		return
+	}
+
+	challenge, expire, err := srv.TwoFactors().Challenge(c, user)
+	if err != nil {
+		response.WriteResponse(c, err, nil)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"twoFactorRequired": true,
+		"challenge":         challenge,
+		"expire":            expire.Format(time.RFC3339),
+	})
+}
+
+// issueLoginTokens starts a login session of the user and responds with its token pair.
+func issueLoginTokens(c *gin.Context, j auth.JWTStrategy, user *model.User) {
+	su, err := newSessionUser(c, user)
+	if err != nil {
+		response.WriteResponse(c, err, nil)
+
+		return
+	}
+
+	token, expire, err := j.IssueToken(c, su)
+	if err != nil {
+		response.WriteResponse(c, errors.WithCode(code.ErrUnknown, err.Error()), nil)
+
+		return
+	}
+
+	j.LoginResponse(c, http.StatusOK, token, expire)
+}
+
+// newSessionUser starts a login session of the authenticated user. The refresh token of
+// the session is set in the gin context for the login response.
+func newSessionUser(c *gin.Context, user *model.User) (*sessionUser, error) {
+	// Carry the role names in the token so that authorization needs no extra lookup.
+	roles, err := store.Client().Roles().ListUserRoles(c, user.ID)
+	if err != nil {
+		log.Errorf("list roles of user `%s` failed: %s", user.Name, err.Error())
+	} else {
+		user.Roles = roles.RoleNames()
+	}
+
+	session, err := srvv1.NewService(store.Client()).Sessions().Create(c, user, c.Request.UserAgent(), c.ClientIP())
+	if err != nil {
+		log.Errorf("create session of user `%s` failed: %s", user.Name, err.Error())
+
+		return nil, err
+	}
+	c.Set(refreshTokenKey, session.Token)
+
+	return &sessionUser{User: user, session: session}, nil
+}
+
 func parseWithHeader(c *gin.Context) (loginInfo, error) {
 	auth := strings.SplitN(c.Request.Header.Get("Authorization"), " ", 2)
 	if len(auth) != 2 || auth[0] != "Basic" {
@@ -151,30 +378,73 @@ func parseWithBody(c *gin.Context) (loginInfo, error) {
 	return login, nil
 }
 
-func refreshResponse() func(c *gin.Context, code int, token string, expire time.Time) {
+func loginResponse() func(c *gin.Context, code int, token string, expire time.Time) {
 	return func(c *gin.Context, code int, token string, expire time.Time) {
 		c.JSON(http.StatusOK, gin.H{
-			"token":  token,
-			"expire": expire.Format(time.RFC3339),
+			"token":        token,
+			"expire":       expire.Format(time.RFC3339),
+			"refreshToken": c.GetString(refreshTokenKey),
 		})
 	}
 }
 
-func loginResponse() func(c *gin.Context, code int, token string, expire time.Time) {
-	return func(c *gin.Context, code int, token string, expire time.Time) {
-		c.JSON(http.StatusOK, gin.H{
-			"token":  token,
-			"expire": expire.Format(time.RFC3339),
-		})
+// refreshHandler exchanges a refresh token for a new token pair. The refresh token is rotated,
+// so every refresh token can only be used once.
+func refreshHandler(j auth.JWTStrategy) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var r refreshInfo
+		if err := c.ShouldBindJSON(&r); err != nil {
+			response.WriteResponse(c, errors.WithCode(code.ErrBind, err.Error()), nil)
+
+			return
+		}
+
+		session, user, err := srvv1.NewService(store.Client()).Sessions().Refresh(c, r.RefreshToken)
+		if err != nil {
+			response.WriteResponse(c, err, nil)
+
+			return
+		}
+
+		token, expire, err := j.IssueToken(c, &sessionUser{User: user, session: session})
+		if err != nil {
+			response.WriteResponse(c, errors.WithCode(code.ErrUnknown, err.Error()), nil)
+
+			return
+		}
+		c.Set(refreshTokenKey, session.Token)
+
+		j.LoginResponse(c, http.StatusOK, token, expire)
+	}
+}
+
+// logoutHandler ends the login session of the token, then revokes the token itself.
+func logoutHandler(j auth.JWTStrategy) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if claims, err := j.GetClaimsFromJWT(c); err == nil {
+			jti, _ := claims[auth.ClaimTokenID].(string)
+			username, _ := claims[middleware.UsernameKey].(string)
+			if id, ok := srvv1.ParseSessionTokenID(jti); ok {
+				if err := srvv1.NewService(store.Client()).Sessions().Revoke(c, username, id); err != nil {
+					log.Record(c).Errorf("revoke session %d failed: %s", id, err.Error())
+				}
+			}
+		}
+
+		j.LogoutHandler(c)
 	}
 }
 
 func payloadFunc() func(data interface{}) jwt.MapClaims {
 	return func(data interface{}) jwt.MapClaims {
 		claims := jwt.MapClaims{}
-		if u, ok := data.(*model.User); ok {
+		if u, ok := data.(*sessionUser); ok {
 			claims[jwt.IdentityKey] = u.Name // 用户名
 			claims["userID"] = u.ID          // 用户ID
+			claims["roles"] = u.Roles        // 角色名称
+			// Both stay the same for every token of the session and are used to revoke them.
+			claims[auth.ClaimTokenID] = srvv1.SessionTokenID(u.session)
+			claims[auth.ClaimAuthTime] = float64(u.session.CreatedAt.UnixMilli()) / 1000
 		}
 		return claims
 	}
@@ -196,11 +466,11 @@ func authorizator() func(data interface{}, c *gin.Context) bool {
 	return func(data interface{}, c *gin.Context) bool {
 		if username, ok := data.(string); ok {
 			log.Record(c).Infof("user `%s` is authenticated.", username)
-			// 用户已经认证，现在检查他们是否有权限
-			// 例如，这里可以构建一个请求到 authz 服务
-			allowed, err := checkAuthzPermission(username, c.Request.Method, c.Request.URL.Path)
+
+			allowed, err := checkAuthzPermission(c, username, c.Request.Method, c.Request.URL.Path, claimsContext(c))
 			if err != nil {
-				// 处理可能的错误
+				log.Record(c).Errorf("check permission of user `%s` failed: %s", username, err.Error())
+
 				return false
 			}
 
@@ -211,9 +481,139 @@ func authorizator() func(data interface{}, c *gin.Context) bool {
 	}
 }
 
-func checkAuthzPermission(username, method, path string) (bool, error) {
-	// 构造请求到 authz 服务
-	// 您需要根据实际的 authz API 接口来调整这里的代码
-	// ...
-	return true, nil
+var (
+	authorizer     authz.Authorizer
+	authorizerErr  error
+	authorizerOnce sync.Once
+)
+
+// getAuthorizer builds the authz client from the `authz.*` configuration on first use.
+func getAuthorizer() (authz.Authorizer, error) {
+	authorizerOnce.Do(func() {
+		opts := options.NewAuthzOptions()
+		if viper.IsSet("authz.protocol") {
+			opts.Protocol = viper.GetString("authz.protocol")
+		}
+		if viper.IsSet("authz.address") {
+			opts.Address = viper.GetString("authz.address")
+		}
+		if viper.IsSet("authz.client-ca-file") {
+			opts.ClientCA = viper.GetString("authz.client-ca-file")
+		}
+		if viper.IsSet("authz.timeout") {
+			opts.Timeout = viper.GetDuration("authz.timeout")
+		}
+		if viper.IsSet("authz.cache-ttl") {
+			opts.CacheTTL = viper.GetDuration("authz.cache-ttl")
+		}
+		if viper.IsSet("authz.fail-open") {
+			opts.FailOpen = viper.GetBool("authz.fail-open")
+		}
+
+		authorizer, authorizerErr = authz.New(opts)
+	})
+
+	return authorizer, authorizerErr
+}
+
+// checkAuthzPermission asks the authz service whether the user may access the path.
+// attrs are the subject attributes, such as `userID` and `roles`, used by conditional rules.
+func checkAuthzPermission(c *gin.Context, username, method, path string, attrs map[string]string) (bool, error) {
+	a, err := getAuthorizer()
+	if err != nil {
+		return false, err
+	}
+
+	req := &authz.Request{
+		Subject:  username,
+		Action:   method,
+		Resource: path,
+		Context:  attrs,
+	}
+
+	decision, err := a.Authorize(c, req)
+	if err != nil {
+		return false, err
+	}
+
+	if !decision.Allowed {
+		log.Record(c).Infof("user `%s` is not allowed to %s %s: %s", username, method, path, decision.Reason)
+	}
+
+	return decision.Allowed, nil
+}
+
+// requireRole lets the request through only when the authenticated user has the role. It guards
+// the endpoints which must stay closed whatever the authz policy allows.
+func requireRole(role string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		for _, name := range requestRoles(c) {
+			if name == role {
+				c.Next()
+
+				return
+			}
+		}
+
+		log.Record(c).Infof("user `%s` without the `%s` role is not allowed to %s %s",
+			c.GetString(middleware.UsernameKey), role, c.Request.Method, c.Request.URL.Path)
+		response.WriteResponse(c, errors.WithCode(code.ErrPermissionDenied, "the `%s` role is required", role), nil)
+		c.Abort()
+	}
+}
+
+// requestRoles returns the role names of the authenticated user.
+func requestRoles(c *gin.Context) []string {
+	if roles, ok := c.Get(rolesKey); ok {
+		names, _ := roles.([]string)
+
+		return names
+	}
+
+	return claimRoles(jwt.ExtractClaims(c))
+}
+
+// claimsContext returns the subject attributes carried by the token.
+func claimsContext(c *gin.Context) map[string]string {
+	claims := jwt.ExtractClaims(c)
+	attrs := map[string]string{}
+	if userID, ok := claimString(claims, "userID"); ok {
+		attrs["userID"] = userID
+	}
+	if roles := claimRoles(claims); len(roles) > 0 {
+		attrs["roles"] = strings.Join(roles, ",")
+	}
+
+	return attrs
+}
+
+// claimString returns the claim as a string. Numeric claims are decoded from JSON as float64.
+func claimString(claims jwt.MapClaims, key string) (string, bool) {
+	switch v := claims[key].(type) {
+	case string:
+		return v, true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case nil:
+		return "", false
+	default:
+		return fmt.Sprint(v), true
+	}
+}
+
+// claimRoles returns the role names carried by the token.
+func claimRoles(claims jwt.MapClaims) []string {
+	values, ok := claims["roles"].([]interface{})
+	if !ok {
+		return nil
+	}
+
+	roles := make([]string, 0, len(values))
+	for _, v := range values {
+		if name, ok := v.(string); ok && name != "" {
+			roles = append(roles, name)
+		}
+	}
+
+	return roles
 }
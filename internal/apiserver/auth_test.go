@@ -0,0 +1,229 @@
+package apiserver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	jwt "github.com/appleboy/gin-jwt/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/skeleton1231/gotal/internal/apiserver/authz"
+	"github.com/skeleton1231/gotal/internal/apiserver/store"
+	"github.com/skeleton1231/gotal/internal/apiserver/store/model"
+	"github.com/skeleton1231/gotal/internal/pkg/code"
+	"github.com/skeleton1231/gotal/internal/pkg/errors"
+	"github.com/skeleton1231/gotal/internal/user_service/store/mock_store"
+	"github.com/skeleton1231/gotal/pkg/util/common"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeAuthorizer struct {
+	allowed  bool
+	requests []*authz.Request
+}
+
+func (f *fakeAuthorizer) Authorize(ctx context.Context, req *authz.Request) (*authz.Decision, error) {
+	f.requests = append(f.requests, req)
+
+	return &authz.Decision{Allowed: f.allowed, Reason: "test"}, nil
+}
+
+// setAuthorizer replaces the authz client built from the configuration.
+func setAuthorizer(t *testing.T, a authz.Authorizer) {
+	authorizerOnce.Do(func() {})
+	previous, previousErr := authorizer, authorizerErr
+	authorizer, authorizerErr = a, nil
+	t.Cleanup(func() { authorizer, authorizerErr = previous, previousErr })
+}
+
+// setStore replaces the store client.
+func setStore(t *testing.T, factory store.Factory) {
+	previous := store.Client()
+	store.SetClient(factory)
+	t.Cleanup(func() { store.SetClient(previous) })
+}
+
+func newTestUser(t *testing.T) *model.User {
+	password, err := common.Encrypt("secret")
+	assert.NoError(t, err)
+
+	user := &model.User{Name: "alice", Password: password}
+	user.ID = 42
+
+	return user
+}
+
+func serveBasic(username, password string) *httptest.ResponseRecorder {
+	r := gin.New()
+	r.GET("/v1/users", newBasicAuth().AuthFunc(), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/users", nil)
+	req.SetBasicAuth(username, password)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	return w
+}
+
+func responseCode(t *testing.T, w *httptest.ResponseRecorder) int {
+	var body struct {
+		Code int `json:"code"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+
+	return body.Code
+}
+
+func TestBasicAuth_Authorize(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		allowed    bool
+		wantStatus int
+	}{
+		{name: "allowed", allowed: true, wantStatus: http.StatusOK},
+		{name: "denied", allowed: false, wantStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUserStore := mock_store.NewMockUserStore(ctrl)
+			mockRoleStore := mock_store.NewMockRoleStore(ctrl)
+			mockTwoFactorStore := mock_store.NewMockTwoFactorStore(ctrl)
+			mockStoreFactory := mock_store.NewMockFactory(ctrl)
+			mockStoreFactory.EXPECT().Users().Return(mockUserStore).AnyTimes()
+			mockStoreFactory.EXPECT().Roles().Return(mockRoleStore).AnyTimes()
+			mockStoreFactory.EXPECT().TwoFactors().Return(mockTwoFactorStore).AnyTimes()
+			mockUserStore.EXPECT().GetByUsername(gomock.Any(), "alice", gomock.Any()).Return(newTestUser(t), nil)
+			mockTwoFactorStore.EXPECT().Get(gomock.Any(), uint64(42)).
+				Return(nil, errors.WithCode(code.ErrTwoFactorNotFound, "not enrolled"))
+			mockRoleStore.EXPECT().ListUserRoles(gomock.Any(), uint64(42)).
+				Return(&model.RoleList{Items: []*model.Role{{Name: "viewer"}}}, nil)
+			setStore(t, mockStoreFactory)
+
+			a := &fakeAuthorizer{allowed: tt.allowed}
+			setAuthorizer(t, a)
+
+			w := serveBasic("alice", "secret")
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if !tt.allowed {
+				assert.Equal(t, code.ErrPermissionDenied, responseCode(t, w))
+			}
+
+			if assert.Len(t, a.requests, 1) {
+				req := a.requests[0]
+				assert.Equal(t, "alice", req.Subject)
+				assert.Equal(t, http.MethodGet, req.Action)
+				assert.Equal(t, "/v1/users", req.Resource)
+				assert.Equal(t, map[string]string{"userID": "42", "roles": "viewer"}, req.Context)
+			}
+		})
+	}
+}
+
+func TestBasicAuth_WrongPassword(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserStore := mock_store.NewMockUserStore(ctrl)
+	mockStoreFactory := mock_store.NewMockFactory(ctrl)
+	mockStoreFactory.EXPECT().Users().Return(mockUserStore).AnyTimes()
+	mockUserStore.EXPECT().GetByUsername(gomock.Any(), "alice", gomock.Any()).Return(newTestUser(t), nil)
+	setStore(t, mockStoreFactory)
+
+	a := &fakeAuthorizer{allowed: true}
+	setAuthorizer(t, a)
+
+	w := serveBasic("alice", "wrong")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Equal(t, code.ErrSignatureInvalid, responseCode(t, w))
+	assert.Empty(t, a.requests)
+}
+
+func TestBasicAuth_TwoFactorEnabled(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserStore := mock_store.NewMockUserStore(ctrl)
+	mockTwoFactorStore := mock_store.NewMockTwoFactorStore(ctrl)
+	mockStoreFactory := mock_store.NewMockFactory(ctrl)
+	mockStoreFactory.EXPECT().Users().Return(mockUserStore).AnyTimes()
+	mockStoreFactory.EXPECT().TwoFactors().Return(mockTwoFactorStore).AnyTimes()
+	mockUserStore.EXPECT().GetByUsername(gomock.Any(), "alice", gomock.Any()).Return(newTestUser(t), nil)
+	mockTwoFactorStore.EXPECT().Get(gomock.Any(), uint64(42)).Return(&model.TwoFactor{UserID: 42, Enabled: true}, nil)
+	setStore(t, mockStoreFactory)
+
+	a := &fakeAuthorizer{allowed: true}
+	setAuthorizer(t, a)
+
+	w := serveBasic("alice", "secret")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Equal(t, code.ErrTwoFactorRequired, responseCode(t, w))
+	assert.Empty(t, a.requests)
+}
+
+func TestRequireRole(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		setup      func(c *gin.Context)
+		wantStatus int
+	}{
+		{
+			name:       "admin token",
+			setup:      func(c *gin.Context) { c.Set("JWT_PAYLOAD", jwt.MapClaims{"roles": []interface{}{"viewer", "admin"}}) },
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "token without admin",
+			setup:      func(c *gin.Context) { c.Set("JWT_PAYLOAD", jwt.MapClaims{"roles": []interface{}{"viewer"}}) },
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "admin api key",
+			setup:      func(c *gin.Context) { c.Set(rolesKey, []string{"admin"}) },
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "api key without roles",
+			setup:      func(c *gin.Context) { c.Set(rolesKey, []string(nil)) },
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "no roles",
+			setup:      func(c *gin.Context) {},
+			wantStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			r.DELETE("/v1/users", tt.setup, requireRole(adminRole), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/v1/users?ids=1,2", nil))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantStatus == http.StatusForbidden {
+				assert.Equal(t, code.ErrPermissionDenied, responseCode(t, w))
+			}
+		})
+	}
+}
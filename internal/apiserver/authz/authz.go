@@ -0,0 +1,186 @@
+// Copyright 2023 Talhuang<talhuang1231@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+// Package authz implements the client apiserver uses to ask the authz service for policy decisions.
+package authz
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/skeleton1231/gotal/internal/pkg/options"
+	"github.com/skeleton1231/gotal/pkg/log"
+)
+
+// Request describes the access being checked.
+type Request struct {
+	Subject  string            `json:"subject"`
+	Action   string            `json:"action"`
+	Resource string            `json:"resource"`
+	Context  map[string]string `json:"context,omitempty"`
+}
+
+// Decision is the policy decision returned by the authz service.
+type Decision struct {
+	Allowed bool   `json:"allowed"`
+	Reason  string `json:"reason"`
+}
+
+// Authorizer asks for policy decisions.
+type Authorizer interface {
+	Authorize(ctx context.Context, req *Request) (*Decision, error)
+}
+
+// New creates an Authorizer talking to the authz service with the configured
+// protocol. Decisions are cached for opts.CacheTTL and transport errors are turned
+// into allow or deny decisions according to opts.FailOpen.
+func New(opts *options.AuthzOptions) (Authorizer, error) {
+	var (
+		remote Authorizer
+		err    error
+	)
+
+	switch opts.Protocol {
+	case options.AuthzProtocolHTTP:
+		remote = newHTTPAuthorizer(opts.Address, opts.Timeout)
+	case options.AuthzProtocolGRPC:
+		remote, err = newGRPCAuthorizer(opts.Address, opts.ClientCA, opts.Timeout)
+	default:
+		err = fmt.Errorf("unsupported authz protocol: %s", opts.Protocol)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return newCachedAuthorizer(remote, opts.CacheTTL, opts.FailOpen), nil
+}
+
+const maxCachedDecisions = 10000
+
+type cachedDecision struct {
+	decision *Decision
+	expireAt time.Time
+}
+
+// cachedAuthorizer caches decisions of another Authorizer for a short TTL.
+type cachedAuthorizer struct {
+	remote   Authorizer
+	ttl      time.Duration
+	failOpen bool
+	now      func() time.Time
+
+	mu        sync.Mutex
+	decisions map[string]cachedDecision
+}
+
+func newCachedAuthorizer(remote Authorizer, ttl time.Duration, failOpen bool) *cachedAuthorizer {
+	return &cachedAuthorizer{
+		remote:    remote,
+		ttl:       ttl,
+		failOpen:  failOpen,
+		now:       time.Now,
+		decisions: make(map[string]cachedDecision),
+	}
+}
+
+// Authorize implements Authorizer. It never returns an error: when the authz service
+// fails the fail-open setting decides, and that fallback decision is not cached.
+func (a *cachedAuthorizer) Authorize(ctx context.Context, req *Request) (*Decision, error) {
+	key := cacheKey(req)
+
+	if d, ok := a.get(key); ok {
+		return d, nil
+	}
+
+	d, err := a.remote.Authorize(ctx, req)
+	if err != nil {
+		log.Errorf("call authz service failed, fail-open=%t: %s", a.failOpen, err.Error())
+
+		return &Decision{
+			Allowed: a.failOpen,
+			Reason:  fmt.Sprintf("authz service unavailable: %s", err.Error()),
+		}, nil
+	}
+
+	a.set(key, d)
+
+	return d, nil
+}
+
+func (a *cachedAuthorizer) get(key string) (*Decision, bool) {
+	if a.ttl <= 0 {
+		return nil, false
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	cd, ok := a.decisions[key]
+	if !ok {
+		return nil, false
+	}
+
+	if a.now().After(cd.expireAt) {
+		delete(a.decisions, key)
+
+		return nil, false
+	}
+
+	return cd.decision, true
+}
+
+func (a *cachedAuthorizer) set(key string, d *Decision) {
+	if a.ttl <= 0 {
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	now := a.now()
+	if len(a.decisions) >= maxCachedDecisions {
+		for k, cd := range a.decisions {
+			if now.After(cd.expireAt) {
+				delete(a.decisions, k)
+			}
+		}
+		// Everything is still fresh, start over rather than growing unbounded.
+		if len(a.decisions) >= maxCachedDecisions {
+			a.decisions = make(map[string]cachedDecision)
+		}
+	}
+
+	a.decisions[key] = cachedDecision{decision: d, expireAt: now.Add(a.ttl)}
+}
+
+func cacheKey(req *Request) string {
+	var b strings.Builder
+	b.WriteString(req.Subject)
+	b.WriteByte('\x00')
+	b.WriteString(req.Action)
+	b.WriteByte('\x00')
+	b.WriteString(req.Resource)
+
+	// Context only carries a handful of attributes; keep the key deterministic.
+	if len(req.Context) > 0 {
+		keys := make([]string, 0, len(req.Context))
+		for k := range req.Context {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			b.WriteByte('\x00')
+			b.WriteString(k)
+			b.WriteByte('=')
+			b.WriteString(req.Context[k])
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,101 @@
+package authz
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/skeleton1231/gotal/internal/pkg/options"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestServer(t *testing.T, calls *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(calls, 1)
+		assert.Equal(t, authzPath, r.URL.Path)
+
+		var req Request
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+		allowed := req.Subject == "admin" || req.Context["userID"] == "1"
+		_ = json.NewEncoder(w).Encode(apiResponse{Data: &Decision{Allowed: allowed, Reason: "test"}})
+	}))
+}
+
+func TestAuthorizer_HTTP(t *testing.T) {
+	var calls int32
+	srv := newTestServer(t, &calls)
+	defer srv.Close()
+
+	opts := options.NewAuthzOptions()
+	opts.Address = srv.URL
+	opts.CacheTTL = time.Minute
+
+	a, err := New(opts)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		req     *Request
+		allowed bool
+	}{
+		{name: "admin", req: &Request{Subject: "admin", Action: "GET", Resource: "/v1/users/2"}, allowed: true},
+		{name: "owner", req: &Request{Subject: "alice", Action: "GET", Resource: "/v1/users/1", Context: map[string]string{"userID": "1"}}, allowed: true},
+		{name: "other", req: &Request{Subject: "bob", Action: "GET", Resource: "/v1/users/1", Context: map[string]string{"userID": "2"}}, allowed: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := a.Authorize(context.Background(), tt.req)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.allowed, d.Allowed)
+		})
+	}
+
+	// The same requests are answered from the local cache.
+	for _, tt := range tests {
+		_, _ = a.Authorize(context.Background(), tt.req)
+	}
+	assert.Equal(t, int32(len(tests)), atomic.LoadInt32(&calls))
+}
+
+func TestAuthorizer_CacheExpires(t *testing.T) {
+	var calls int32
+	srv := newTestServer(t, &calls)
+	defer srv.Close()
+
+	a := newCachedAuthorizer(newHTTPAuthorizer(srv.URL, time.Second), time.Second, false)
+	now := time.Now()
+	a.now = func() time.Time { return now }
+
+	req := &Request{Subject: "admin", Action: "GET", Resource: "/v1/users"}
+	_, _ = a.Authorize(context.Background(), req)
+	_, _ = a.Authorize(context.Background(), req)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	now = now.Add(2 * time.Second)
+	_, _ = a.Authorize(context.Background(), req)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestAuthorizer_Unavailable(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	srv.Close()
+
+	for _, failOpen := range []bool{true, false} {
+		opts := options.NewAuthzOptions()
+		opts.Address = srv.URL
+		opts.FailOpen = failOpen
+
+		a, err := New(opts)
+		assert.NoError(t, err)
+
+		d, err := a.Authorize(context.Background(), &Request{Subject: "admin", Action: "GET", Resource: "/v1/users"})
+		assert.NoError(t, err)
+		assert.Equal(t, failOpen, d.Allowed)
+	}
+}
@@ -0,0 +1,52 @@
+package authz
+
+import (
+	"context"
+	"time"
+
+	pb "github.com/skeleton1231/gotal/internal/proto/authz"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+type grpcAuthorizer struct {
+	client  pb.AuthzServiceClient
+	timeout time.Duration
+}
+
+func newGRPCAuthorizer(address, clientCA string, timeout time.Duration) (*grpcAuthorizer, error) {
+	creds := insecure.NewCredentials()
+	if clientCA != "" {
+		tlsCreds, err := credentials.NewClientTLSFromFile(clientCA, "")
+		if err != nil {
+			return nil, err
+		}
+		creds = tlsCreds
+	}
+
+	conn, err := grpc.Dial(address, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, err
+	}
+
+	return &grpcAuthorizer{client: pb.NewAuthzServiceClient(conn), timeout: timeout}, nil
+}
+
+// Authorize implements Authorizer.
+func (a *grpcAuthorizer) Authorize(ctx context.Context, req *Request) (*Decision, error) {
+	ctx, cancel := context.WithTimeout(ctx, a.timeout)
+	defer cancel()
+
+	resp, err := a.client.Authorize(ctx, &pb.AuthorizeRequest{
+		Subject:  req.Subject,
+		Action:   req.Action,
+		Resource: req.Resource,
+		Context:  req.Context,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Decision{Allowed: resp.GetAllowed(), Reason: resp.GetReason()}, nil
+}
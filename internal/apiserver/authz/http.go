@@ -0,0 +1,63 @@
+package authz
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const authzPath = "/v1/authz"
+
+type httpAuthorizer struct {
+	url    string
+	client *http.Client
+}
+
+func newHTTPAuthorizer(address string, timeout time.Duration) *httpAuthorizer {
+	return &httpAuthorizer{
+		url:    strings.TrimSuffix(address, "/") + authzPath,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// apiResponse mirrors response.APIResponse returned by the authz server.
+type apiResponse struct {
+	Code    int       `json:"code"`
+	Message string    `json:"message"`
+	Data    *Decision `json:"data,omitempty"`
+}
+
+// Authorize implements Authorizer.
+func (a *httpAuthorizer) Authorize(ctx context.Context, req *Request) (*Decision, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, a.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := a.client.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var r apiResponse
+	if err := json.NewDecoder(resp.Body).Decode(&r); err != nil {
+		return nil, fmt.Errorf("decode authz response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK || r.Data == nil {
+		return nil, fmt.Errorf("authz service returned status %d: %s", resp.StatusCode, r.Message)
+	}
+
+	return r.Data, nil
+}
@@ -0,0 +1,214 @@
+// Copyright 2023 Talhuang<talhuang1231@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+// Package billingtest provides a fake Stripe API for tests.
+package billingtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/skeleton1231/gotal/internal/apiserver/billing"
+	"github.com/skeleton1231/gotal/internal/pkg/options"
+)
+
+const (
+	// SecretKey is the API key the fake server accepts.
+	SecretKey = "sk_test_fake"
+	// WebhookSecret is the signing secret of the webhook endpoint in Options.
+	WebhookSecret = "whsec_fake"
+)
+
+// Server is a fake Stripe API keeping its objects in memory.
+type Server struct {
+	*httptest.Server
+
+	mu             sync.Mutex
+	customers      map[string]*billing.Customer
+	subscriptions  map[string]*billing.Subscription
+	paymentMethods map[string]*billing.PaymentMethod
+	idempotency    map[string]*billing.Customer
+}
+
+// NewServer starts a fake Stripe API, close it when done.
+func NewServer() *Server {
+	s := &Server{
+		customers:      map[string]*billing.Customer{},
+		subscriptions:  map[string]*billing.Subscription{},
+		paymentMethods: map[string]*billing.PaymentMethod{},
+		idempotency:    map[string]*billing.Customer{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/customers", s.createCustomer)
+	mux.HandleFunc("/v1/customers/", func(w http.ResponseWriter, r *http.Request) {
+		s.get(w, r, "/v1/customers/", func(id string) (interface{}, bool) {
+			customer, ok := s.customers[id]
+
+			return customer, ok
+		})
+	})
+	mux.HandleFunc("/v1/subscriptions/", s.subscription)
+	mux.HandleFunc("/v1/payment_methods/", func(w http.ResponseWriter, r *http.Request) {
+		s.get(w, r, "/v1/payment_methods/", func(id string) (interface{}, bool) {
+			method, ok := s.paymentMethods[id]
+
+			return method, ok
+		})
+	})
+	s.Server = httptest.NewServer(s.authorized(mux))
+
+	return s
+}
+
+// Options returns Stripe options pointing at the fake server.
+func (s *Server) Options() *options.StripeOptions {
+	opts := options.NewStripeOptions()
+	opts.SecretKey = SecretKey
+	opts.WebhookSecret = WebhookSecret
+	opts.APIURL = s.URL
+
+	return opts
+}
+
+// AddSubscription stores a subscription.
+func (s *Server) AddSubscription(subscription *billing.Subscription) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.subscriptions[subscription.ID] = subscription
+}
+
+// AddPaymentMethod stores a card payment method.
+func (s *Server) AddPaymentMethod(id, customer, brand, last4 string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.paymentMethods[id] = &billing.PaymentMethod{
+		ID:       id,
+		Customer: customer,
+		Type:     "card",
+		Card:     &billing.Card{Brand: brand, Last4: last4},
+	}
+}
+
+// Customers returns the number of customers created.
+func (s *Server) Customers() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.customers)
+}
+
+// Event returns the payload of an event of the type wrapping the object.
+func Event(id, eventType string, object interface{}) []byte {
+	data, _ := json.Marshal(object)
+	payload, _ := json.Marshal(map[string]interface{}{
+		"id":      id,
+		"object":  "event",
+		"type":    eventType,
+		"created": time.Now().Unix(),
+		"data":    map[string]json.RawMessage{"object": data},
+	})
+
+	return payload
+}
+
+func (s *Server) authorized(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+SecretKey {
+			writeError(w, http.StatusUnauthorized, "invalid_request_error", "Invalid API Key provided")
+
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) createCustomer(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.ParseForm() != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request_error", "Invalid request")
+
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := r.Header.Get("Idempotency-Key")
+	if customer, ok := s.idempotency[key]; ok && key != "" {
+		_ = json.NewEncoder(w).Encode(customer)
+
+		return
+	}
+
+	customer := &billing.Customer{
+		ID:       fmt.Sprintf("cus_%d", len(s.customers)+1),
+		Email:    r.PostForm.Get("email"),
+		Name:     r.PostForm.Get("name"),
+		Metadata: map[string]string{"user_id": r.PostForm.Get("metadata[user_id]")},
+	}
+	s.customers[customer.ID] = customer
+	if key != "" {
+		s.idempotency[key] = customer
+	}
+	_ = json.NewEncoder(w).Encode(customer)
+}
+
+func (s *Server) subscription(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		s.get(w, r, "/v1/subscriptions/", func(id string) (interface{}, bool) {
+			subscription, ok := s.subscriptions[id]
+
+			return subscription, ok
+		})
+
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	subscription, ok := s.subscriptions[strings.TrimPrefix(r.URL.Path, "/v1/subscriptions/")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "invalid_request_error", "No such subscription")
+
+		return
+	}
+	subscription.Status = "canceled"
+	subscription.EndedAt = time.Now().Unix()
+	_ = json.NewEncoder(w).Encode(subscription)
+}
+
+func (s *Server) get(w http.ResponseWriter, r *http.Request, prefix string, find func(id string) (interface{}, bool)) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "invalid_request_error", "Unsupported method")
+
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	object, ok := find(strings.TrimPrefix(r.URL.Path, prefix))
+	if !ok {
+		writeError(w, http.StatusNotFound, "invalid_request_error", "No such object")
+
+		return
+	}
+	_ = json.NewEncoder(w).Encode(object)
+}
+
+func writeError(w http.ResponseWriter, status int, errType, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"error": map[string]string{"type": errType, "message": message},
+	})
+}
@@ -0,0 +1,210 @@
+// Copyright 2023 Talhuang<talhuang1231@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+// Package billing talks to Stripe, which bills the users: it calls the API and verifies
+// the events sent to the webhook endpoint.
+package billing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/skeleton1231/gotal/internal/pkg/options"
+)
+
+// Customer is the Stripe customer of a user.
+type Customer struct {
+	ID              string            `json:"id"`
+	Email           string            `json:"email"`
+	Name            string            `json:"name"`
+	Metadata        map[string]string `json:"metadata"`
+	Deleted         bool              `json:"deleted"`
+	InvoiceSettings struct {
+		DefaultPaymentMethod string `json:"default_payment_method"`
+	} `json:"invoice_settings"`
+}
+
+// CustomerParams are the fields of a new customer.
+type CustomerParams struct {
+	Email  string
+	Name   string
+	UserID uint64
+	// IdempotencyKey makes Stripe create a single customer when the call is retried.
+	IdempotencyKey string
+}
+
+// Subscription is a subscription of a customer. Times are unix timestamps, zero when unset.
+type Subscription struct {
+	ID               string `json:"id"`
+	Customer         string `json:"customer"`
+	Status           string `json:"status"`
+	TrialEnd         int64  `json:"trial_end"`
+	CurrentPeriodEnd int64  `json:"current_period_end"`
+	EndedAt          int64  `json:"ended_at"`
+}
+
+// PaymentMethod is a payment method of a customer. Card is only set for cards.
+type PaymentMethod struct {
+	ID       string `json:"id"`
+	Customer string `json:"customer"`
+	Type     string `json:"type"`
+	Card     *Card  `json:"card"`
+}
+
+// Card is the card of a payment method.
+type Card struct {
+	Brand string `json:"brand"`
+	Last4 string `json:"last4"`
+}
+
+// CheckoutSession is a completed Stripe Checkout, its client reference is the user id.
+type CheckoutSession struct {
+	ID                string `json:"id"`
+	Customer          string `json:"customer"`
+	ClientReferenceID string `json:"client_reference_id"`
+	Subscription      string `json:"subscription"`
+}
+
+// Client is the part of the Stripe API used to manage the customers and their subscriptions.
+type Client interface {
+	CreateCustomer(ctx context.Context, params *CustomerParams) (*Customer, error)
+	GetCustomer(ctx context.Context, id string) (*Customer, error)
+	GetSubscription(ctx context.Context, id string) (*Subscription, error)
+	CancelSubscription(ctx context.Context, id string) (*Subscription, error)
+	GetPaymentMethod(ctx context.Context, id string) (*PaymentMethod, error)
+}
+
+// Error is an error answered by the Stripe API.
+type Error struct {
+	Status  int    `json:"-"`
+	Type    string `json:"type"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("stripe: %s (status %d, type %s)", e.Message, e.Status, e.Type)
+}
+
+// Stripe is a Client calling the Stripe REST API with the secret key.
+type Stripe struct {
+	opts   *options.StripeOptions
+	client *http.Client
+}
+
+var _ Client = (*Stripe)(nil)
+
+// NewStripe creates a Stripe client with the given options.
+func NewStripe(opts *options.StripeOptions) *Stripe {
+	return &Stripe{
+		opts:   opts,
+		client: &http.Client{Timeout: opts.Timeout},
+	}
+}
+
+// CreateCustomer creates a customer for the user, the user id is kept in its metadata.
+func (s *Stripe) CreateCustomer(ctx context.Context, params *CustomerParams) (*Customer, error) {
+	form := url.Values{}
+	form.Set("email", params.Email)
+	form.Set("name", params.Name)
+	form.Set("metadata[user_id]", strconv.FormatUint(params.UserID, 10))
+
+	customer := &Customer{}
+	if err := s.call(ctx, http.MethodPost, "/v1/customers", form, params.IdempotencyKey, customer); err != nil {
+		return nil, fmt.Errorf("create stripe customer: %w", err)
+	}
+
+	return customer, nil
+}
+
+// GetCustomer returns the customer with the id. Deleted customers are returned with Deleted set.
+func (s *Stripe) GetCustomer(ctx context.Context, id string) (*Customer, error) {
+	customer := &Customer{}
+	if err := s.call(ctx, http.MethodGet, "/v1/customers/"+url.PathEscape(id), nil, "", customer); err != nil {
+		return nil, fmt.Errorf("get stripe customer: %w", err)
+	}
+
+	return customer, nil
+}
+
+// GetSubscription returns the subscription with the id.
+func (s *Stripe) GetSubscription(ctx context.Context, id string) (*Subscription, error) {
+	subscription := &Subscription{}
+	if err := s.call(ctx, http.MethodGet, "/v1/subscriptions/"+url.PathEscape(id), nil, "", subscription); err != nil {
+		return nil, fmt.Errorf("get stripe subscription: %w", err)
+	}
+
+	return subscription, nil
+}
+
+// CancelSubscription cancels the subscription immediately.
+func (s *Stripe) CancelSubscription(ctx context.Context, id string) (*Subscription, error) {
+	subscription := &Subscription{}
+	if err := s.call(ctx, http.MethodDelete, "/v1/subscriptions/"+url.PathEscape(id), nil, "", subscription); err != nil {
+		return nil, fmt.Errorf("cancel stripe subscription: %w", err)
+	}
+
+	return subscription, nil
+}
+
+// GetPaymentMethod returns the payment method with the id.
+func (s *Stripe) GetPaymentMethod(ctx context.Context, id string) (*PaymentMethod, error) {
+	method := &PaymentMethod{}
+	if err := s.call(ctx, http.MethodGet, "/v1/payment_methods/"+url.PathEscape(id), nil, "", method); err != nil {
+		return nil, fmt.Errorf("get stripe payment method: %w", err)
+	}
+
+	return method, nil
+}
+
+func (s *Stripe) call(ctx context.Context, method, path string, form url.Values, idempotencyKey string, v interface{}) error {
+	var body io.Reader
+	if form != nil {
+		body = strings.NewReader(form.Encode())
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(s.opts.APIURL, "/")+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+s.opts.SecretKey)
+	req.Header.Set("Accept", "application/json")
+	if form != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	if idempotencyKey != "" {
+		req.Header.Set("Idempotency-Key", idempotencyKey)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var answer struct {
+			Error *Error `json:"error"`
+		}
+		if err := json.Unmarshal(data, &answer); err != nil || answer.Error == nil {
+			return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(data)))
+		}
+		answer.Error.Status = resp.StatusCode
+
+		return answer.Error
+	}
+
+	return json.Unmarshal(data, v)
+}
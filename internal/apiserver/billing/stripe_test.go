@@ -0,0 +1,71 @@
+package billing_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/skeleton1231/gotal/internal/apiserver/billing"
+	"github.com/skeleton1231/gotal/internal/apiserver/billing/billingtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStripe_Customers(t *testing.T) {
+	srv := billingtest.NewServer()
+	defer srv.Close()
+	client := billing.NewStripe(srv.Options())
+
+	params := &billing.CustomerParams{Email: "alice@example.com", Name: "alice", UserID: 42, IdempotencyKey: "user-42"}
+	customer, err := client.CreateCustomer(context.Background(), params)
+	require.NoError(t, err)
+	assert.Equal(t, "42", customer.Metadata["user_id"])
+
+	// A retried call creates nothing new.
+	again, err := client.CreateCustomer(context.Background(), params)
+	require.NoError(t, err)
+	assert.Equal(t, customer.ID, again.ID)
+	assert.Equal(t, 1, srv.Customers())
+
+	got, err := client.GetCustomer(context.Background(), customer.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "alice@example.com", got.Email)
+}
+
+func TestStripe_Subscriptions(t *testing.T) {
+	srv := billingtest.NewServer()
+	defer srv.Close()
+	client := billing.NewStripe(srv.Options())
+
+	srv.AddSubscription(&billing.Subscription{ID: "sub_1", Customer: "cus_1", Status: "trialing", TrialEnd: 1700000000})
+	subscription, err := client.GetSubscription(context.Background(), "sub_1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1700000000), subscription.TrialEnd)
+
+	canceled, err := client.CancelSubscription(context.Background(), "sub_1")
+	require.NoError(t, err)
+	assert.Equal(t, "canceled", canceled.Status)
+	assert.NotZero(t, canceled.EndedAt)
+
+	srv.AddPaymentMethod("pm_1", "cus_1", "visa", "4242")
+	method, err := client.GetPaymentMethod(context.Background(), "pm_1")
+	require.NoError(t, err)
+	assert.Equal(t, &billing.Card{Brand: "visa", Last4: "4242"}, method.Card)
+}
+
+func TestStripe_Errors(t *testing.T) {
+	srv := billingtest.NewServer()
+	defer srv.Close()
+
+	var stripeErr *billing.Error
+	_, err := billing.NewStripe(srv.Options()).GetSubscription(context.Background(), "sub_missing")
+	require.True(t, errors.As(err, &stripeErr), err)
+	assert.Equal(t, http.StatusNotFound, stripeErr.Status)
+
+	opts := srv.Options()
+	opts.SecretKey = "sk_test_wrong"
+	_, err = billing.NewStripe(opts).GetCustomer(context.Background(), "cus_1")
+	require.True(t, errors.As(err, &stripeErr), err)
+	assert.Equal(t, http.StatusUnauthorized, stripeErr.Status)
+}
@@ -0,0 +1,127 @@
+// Copyright 2023 Talhuang<talhuang1231@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package billing
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Types of the events handled by the webhook endpoint.
+const (
+	EventCheckoutSessionCompleted = "checkout.session.completed"
+	EventCustomerUpdated          = "customer.updated"
+	EventCustomerDeleted          = "customer.deleted"
+	EventSubscriptionCreated      = "customer.subscription.created"
+	EventSubscriptionUpdated      = "customer.subscription.updated"
+	EventSubscriptionDeleted      = "customer.subscription.deleted"
+	EventPaymentMethodAttached    = "payment_method.attached"
+)
+
+var (
+	// ErrInvalidSignature is returned when no signature of the header matches the payload.
+	ErrInvalidSignature = errors.New("stripe signature does not match the payload")
+
+	// ErrSignatureExpired is returned when the signature was made outside the tolerance.
+	ErrSignatureExpired = errors.New("stripe signature timestamp is outside the tolerance")
+)
+
+// Event is a notification sent by Stripe to the webhook endpoint. The object depends on
+// the type of the event.
+type Event struct {
+	ID      string `json:"id"`
+	Type    string `json:"type"`
+	Created int64  `json:"created"`
+	Data    struct {
+		Object json.RawMessage `json:"object"`
+	} `json:"data"`
+}
+
+// Object decodes the object of the event into v.
+func (e *Event) Object(v interface{}) error {
+	if err := json.Unmarshal(e.Data.Object, v); err != nil {
+		return fmt.Errorf("decode object of stripe event %s: %w", e.ID, err)
+	}
+
+	return nil
+}
+
+// ConstructEvent verifies the `Stripe-Signature` header of a webhook request and decodes the
+// payload. The header is `t=<unix time>,v1=<signature>[,v1=...]`, where each signature is the
+// HMAC-SHA256 of `<unix time>.<payload>` keyed with the signing secret of the endpoint.
+// Several v1 signatures are sent while the secret is being rolled.
+func ConstructEvent(payload []byte, header, secret string, tolerance time.Duration, now time.Time) (*Event, error) {
+	var (
+		timestamp  string
+		signatures []string
+	)
+	for _, item := range strings.Split(header, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(item), "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+
+	signedAt, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || len(signatures) == 0 {
+		return nil, ErrInvalidSignature
+	}
+
+	expected := sign(payload, secret, timestamp)
+	matched := false
+	for _, signature := range signatures {
+		if decoded, err := hex.DecodeString(signature); err == nil && hmac.Equal(decoded, expected) {
+			matched = true
+
+			break
+		}
+	}
+	if !matched {
+		return nil, ErrInvalidSignature
+	}
+
+	if age := now.Sub(time.Unix(signedAt, 0)); age > tolerance || age < -tolerance {
+		return nil, ErrSignatureExpired
+	}
+
+	event := &Event{}
+	if err := json.Unmarshal(payload, event); err != nil {
+		return nil, fmt.Errorf("decode stripe event: %w", err)
+	}
+	if event.ID == "" || event.Type == "" {
+		return nil, fmt.Errorf("decode stripe event: missing id or type")
+	}
+
+	return event, nil
+}
+
+// SignatureHeader returns the `Stripe-Signature` header Stripe sends with the payload.
+func SignatureHeader(payload []byte, secret string, at time.Time) string {
+	timestamp := strconv.FormatInt(at.Unix(), 10)
+
+	return "t=" + timestamp + ",v1=" + hex.EncodeToString(sign(payload, secret, timestamp))
+}
+
+func sign(payload []byte, secret, timestamp string) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+
+	return mac.Sum(nil)
+}
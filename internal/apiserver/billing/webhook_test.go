@@ -0,0 +1,55 @@
+package billing
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConstructEvent(t *testing.T) {
+	payload := []byte(`{"id":"evt_1","type":"customer.updated","data":{"object":{"id":"cus_1"}}}`)
+	now := time.Unix(1700000000, 0)
+
+	tests := []struct {
+		name   string
+		header string
+		err    error
+	}{
+		{name: "valid", header: SignatureHeader(payload, "secret", now)},
+		{
+			name:   "rolled secret",
+			header: SignatureHeader(payload, "old", now) + ",v1=" + strings.Split(SignatureHeader(payload, "secret", now), "v1=")[1],
+		},
+		{name: "other secret", header: SignatureHeader(payload, "other", now), err: ErrInvalidSignature},
+		{name: "too old", header: SignatureHeader(payload, "secret", now.Add(-6*time.Minute)), err: ErrSignatureExpired},
+		{name: "from the future", header: SignatureHeader(payload, "secret", now.Add(6*time.Minute)), err: ErrSignatureExpired},
+		{name: "no signature", header: "t=1700000000", err: ErrInvalidSignature},
+		{name: "empty", header: "", err: ErrInvalidSignature},
+		{name: "v0 only", header: strings.Replace(SignatureHeader(payload, "secret", now), "v1=", "v0=", 1), err: ErrInvalidSignature},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event, err := ConstructEvent(payload, tt.header, "secret", 5*time.Minute, now)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "evt_1", event.ID)
+			assert.Equal(t, EventCustomerUpdated, event.Type)
+		})
+	}
+}
+
+func TestConstructEvent_TamperedPayload(t *testing.T) {
+	now := time.Now()
+	header := SignatureHeader([]byte(`{"id":"evt_1","type":"customer.deleted"}`), "secret", now)
+
+	_, err := ConstructEvent([]byte(`{"id":"evt_2","type":"customer.deleted"}`), header, "secret", 5*time.Minute, now)
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+}
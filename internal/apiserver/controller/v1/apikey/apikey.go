@@ -0,0 +1,18 @@
+package apikey
+
+import (
+	srvv1 "github.com/skeleton1231/gotal/internal/apiserver/service/v1"
+	"github.com/skeleton1231/gotal/internal/apiserver/store"
+)
+
+// APIKeyController create an API key handler used to handle request for API key resource.
+type APIKeyController struct {
+	srv srvv1.Service
+}
+
+// NewAPIKeyController creates an API key handler.
+func NewAPIKeyController(store store.Factory) *APIKeyController {
+	return &APIKeyController{
+		srv: srvv1.NewService(store),
+	}
+}
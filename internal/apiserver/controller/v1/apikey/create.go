@@ -0,0 +1,53 @@
+package apikey
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/skeleton1231/gotal/internal/apiserver/store/model"
+	"github.com/skeleton1231/gotal/internal/pkg/code"
+	"github.com/skeleton1231/gotal/internal/pkg/errors"
+	"github.com/skeleton1231/gotal/internal/pkg/middleware"
+	"github.com/skeleton1231/gotal/internal/pkg/response"
+	"github.com/skeleton1231/gotal/internal/pkg/validation"
+	"github.com/skeleton1231/gotal/pkg/log"
+)
+
+// CreateAPIKeyRequest is the request body used to create an API key.
+type CreateAPIKeyRequest struct {
+	Name      string     `json:"name" validate:"required,min=1,max=64"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+// Create creates a new API key for the current user. The key is only returned by this call.
+func (a *APIKeyController) Create(c *gin.Context) {
+	log.Record(c).Info("api key create function called.")
+
+	var r CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&r); err != nil {
+		response.WriteResponse(c, errors.WithCode(code.ErrBind, err.Error()), nil)
+
+		return
+	}
+
+	if validationErrors, err := validation.CheckModel(&r); err != nil {
+		response.WriteResponse(c, errors.WithCode(code.ErrValidation, err.Error()), validationErrors)
+
+		return
+	}
+
+	if r.ExpiresAt != nil && !r.ExpiresAt.After(time.Now()) {
+		response.WriteResponse(c, errors.WithCode(code.ErrValidation, "expiresAt must be in the future"), nil)
+
+		return
+	}
+
+	key := &model.APIKey{Name: r.Name, ExpiresAt: r.ExpiresAt}
+	if err := a.srv.APIKeys().Create(c, c.GetString(middleware.UsernameKey), key); err != nil {
+		response.WriteResponse(c, err, nil)
+
+		return
+	}
+
+	response.WriteResponse(c, nil, key)
+}
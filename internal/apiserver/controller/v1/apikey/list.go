@@ -0,0 +1,22 @@
+package apikey
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/skeleton1231/gotal/internal/pkg/middleware"
+	"github.com/skeleton1231/gotal/internal/pkg/response"
+	"github.com/skeleton1231/gotal/pkg/log"
+)
+
+// List list the API keys of the current user.
+func (a *APIKeyController) List(c *gin.Context) {
+	log.Record(c).Info("list api key function called.")
+
+	keys, err := a.srv.APIKeys().List(c, c.GetString(middleware.UsernameKey))
+	if err != nil {
+		response.WriteResponse(c, err, nil)
+
+		return
+	}
+
+	response.WriteResponse(c, nil, keys)
+}
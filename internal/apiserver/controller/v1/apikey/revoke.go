@@ -0,0 +1,32 @@
+package apikey
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/skeleton1231/gotal/internal/pkg/code"
+	"github.com/skeleton1231/gotal/internal/pkg/errors"
+	"github.com/skeleton1231/gotal/internal/pkg/middleware"
+	"github.com/skeleton1231/gotal/internal/pkg/response"
+	"github.com/skeleton1231/gotal/pkg/log"
+)
+
+// Revoke revokes an API key of the current user.
+func (a *APIKeyController) Revoke(c *gin.Context) {
+	log.Record(c).Info("revoke api key function called.")
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.WriteResponse(c, errors.WithCode(code.ErrBind, err.Error()), nil)
+
+		return
+	}
+
+	if err := a.srv.APIKeys().Revoke(c, c.GetString(middleware.UsernameKey), id); err != nil {
+		response.WriteResponse(c, err, nil)
+
+		return
+	}
+
+	response.WriteResponse(c, nil, nil)
+}
@@ -0,0 +1,37 @@
+package role
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/skeleton1231/gotal/internal/apiserver/store/model"
+	"github.com/skeleton1231/gotal/internal/pkg/code"
+	"github.com/skeleton1231/gotal/internal/pkg/errors"
+	"github.com/skeleton1231/gotal/internal/pkg/response"
+	"github.com/skeleton1231/gotal/internal/pkg/validation"
+	"github.com/skeleton1231/gotal/pkg/log"
+)
+
+// Create add new role to the storage.
+func (r *RoleController) Create(c *gin.Context) {
+	log.Record(c).Info("role create function called.")
+
+	var role model.Role
+	if err := c.ShouldBindJSON(&role); err != nil {
+		response.WriteResponse(c, errors.WithCode(code.ErrBind, err.Error()), nil)
+
+		return
+	}
+
+	if validationErrors, err := validation.CheckModel(&role); err != nil {
+		response.WriteResponse(c, errors.WithCode(code.ErrValidation, err.Error()), validationErrors)
+
+		return
+	}
+
+	if err := r.srv.Roles().Create(c, &role, model.CreateOptions{}); err != nil {
+		response.WriteResponse(c, err, nil)
+
+		return
+	}
+
+	response.WriteResponse(c, nil, role)
+}
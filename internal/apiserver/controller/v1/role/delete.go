@@ -0,0 +1,32 @@
+package role
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/skeleton1231/gotal/internal/apiserver/store/model"
+	"github.com/skeleton1231/gotal/internal/pkg/code"
+	"github.com/skeleton1231/gotal/internal/pkg/errors"
+	"github.com/skeleton1231/gotal/internal/pkg/response"
+	"github.com/skeleton1231/gotal/pkg/log"
+)
+
+// Delete delete a role by the role identifier and revoke it from every user.
+func (r *RoleController) Delete(c *gin.Context) {
+	log.Record(c).Info("delete role function called.")
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.WriteResponse(c, errors.WithCode(code.ErrBind, err.Error()), nil)
+
+		return
+	}
+
+	if err := r.srv.Roles().Delete(c, id, model.DeleteOptions{Unscoped: true}); err != nil {
+		response.WriteResponse(c, err, nil)
+
+		return
+	}
+
+	response.WriteResponse(c, nil, nil)
+}
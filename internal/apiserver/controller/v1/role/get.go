@@ -0,0 +1,30 @@
+package role
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/skeleton1231/gotal/internal/apiserver/store/model"
+	"github.com/skeleton1231/gotal/internal/pkg/code"
+	"github.com/skeleton1231/gotal/internal/pkg/errors"
+	"github.com/skeleton1231/gotal/internal/pkg/response"
+)
+
+// Get get a role by the role identifier.
+func (r *RoleController) Get(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.WriteResponse(c, errors.WithCode(code.ErrBind, err.Error()), nil)
+
+		return
+	}
+
+	role, err := r.srv.Roles().Get(c, id, model.GetOptions{})
+	if err != nil {
+		response.WriteResponse(c, err, nil)
+
+		return
+	}
+
+	response.WriteResponse(c, nil, role)
+}
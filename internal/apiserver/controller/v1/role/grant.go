@@ -0,0 +1,82 @@
+package role
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/skeleton1231/gotal/internal/pkg/code"
+	"github.com/skeleton1231/gotal/internal/pkg/errors"
+	"github.com/skeleton1231/gotal/internal/pkg/response"
+	"github.com/skeleton1231/gotal/pkg/log"
+)
+
+// Grant grants the role to the user.
+func (r *RoleController) Grant(c *gin.Context) {
+	log.Record(c).Info("grant role function called.")
+
+	roleID, userID, err := parseGrantParams(c)
+	if err != nil {
+		response.WriteResponse(c, errors.WithCode(code.ErrBind, err.Error()), nil)
+
+		return
+	}
+
+	if err := r.srv.Roles().Grant(c, userID, roleID); err != nil {
+		response.WriteResponse(c, err, nil)
+
+		return
+	}
+
+	response.WriteResponse(c, nil, nil)
+}
+
+// Revoke revokes the role from the user.
+func (r *RoleController) Revoke(c *gin.Context) {
+	log.Record(c).Info("revoke role function called.")
+
+	roleID, userID, err := parseGrantParams(c)
+	if err != nil {
+		response.WriteResponse(c, errors.WithCode(code.ErrBind, err.Error()), nil)
+
+		return
+	}
+
+	if err := r.srv.Roles().Revoke(c, userID, roleID); err != nil {
+		response.WriteResponse(c, err, nil)
+
+		return
+	}
+
+	response.WriteResponse(c, nil, nil)
+}
+
+// ListUserRoles list the roles granted to the user.
+func (r *RoleController) ListUserRoles(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("userId"), 10, 64)
+	if err != nil {
+		response.WriteResponse(c, errors.WithCode(code.ErrBind, err.Error()), nil)
+
+		return
+	}
+
+	roles, err := r.srv.Roles().ListUserRoles(c, userID)
+	if err != nil {
+		response.WriteResponse(c, err, nil)
+
+		return
+	}
+
+	response.WriteResponse(c, nil, roles)
+}
+
+func parseGrantParams(c *gin.Context) (roleID uint64, userID uint64, err error) {
+	if roleID, err = strconv.ParseUint(c.Param("id"), 10, 64); err != nil {
+		return 0, 0, err
+	}
+
+	if userID, err = strconv.ParseUint(c.Param("userId"), 10, 64); err != nil {
+		return 0, 0, err
+	}
+
+	return roleID, userID, nil
+}
@@ -0,0 +1,31 @@
+package role
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/skeleton1231/gotal/internal/apiserver/store/model"
+	"github.com/skeleton1231/gotal/internal/pkg/code"
+	"github.com/skeleton1231/gotal/internal/pkg/errors"
+	"github.com/skeleton1231/gotal/internal/pkg/response"
+	"github.com/skeleton1231/gotal/pkg/log"
+)
+
+// List list the roles in the storage.
+func (r *RoleController) List(c *gin.Context) {
+	log.Record(c).Info("list role function called.")
+
+	var opts model.ListOptions
+	if err := c.ShouldBindQuery(&opts); err != nil {
+		response.WriteResponse(c, errors.WithCode(code.ErrBind, err.Error()), nil)
+
+		return
+	}
+
+	roles, err := r.srv.Roles().List(c, opts)
+	if err != nil {
+		response.WriteResponse(c, err, nil)
+
+		return
+	}
+
+	response.WriteResponse(c, nil, roles)
+}
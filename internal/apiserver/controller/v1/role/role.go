@@ -0,0 +1,18 @@
+package role
+
+import (
+	srvv1 "github.com/skeleton1231/gotal/internal/apiserver/service/v1"
+	"github.com/skeleton1231/gotal/internal/apiserver/store"
+)
+
+// RoleController create a role handler used to handle request for role resource.
+type RoleController struct {
+	srv srvv1.Service
+}
+
+// NewRoleController creates a role handler.
+func NewRoleController(store store.Factory) *RoleController {
+	return &RoleController{
+		srv: srvv1.NewService(store),
+	}
+}
@@ -0,0 +1,32 @@
+package session
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/skeleton1231/gotal/internal/pkg/code"
+	"github.com/skeleton1231/gotal/internal/pkg/errors"
+	"github.com/skeleton1231/gotal/internal/pkg/middleware"
+	"github.com/skeleton1231/gotal/internal/pkg/response"
+	"github.com/skeleton1231/gotal/pkg/log"
+)
+
+// Delete revokes a session of the current user, its refresh and access tokens stop working.
+func (s *SessionController) Delete(c *gin.Context) {
+	log.Record(c).Info("delete session function called.")
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.WriteResponse(c, errors.WithCode(code.ErrBind, err.Error()), nil)
+
+		return
+	}
+
+	if err := s.srv.Sessions().Revoke(c, c.GetString(middleware.UsernameKey), id); err != nil {
+		response.WriteResponse(c, err, nil)
+
+		return
+	}
+
+	response.WriteResponse(c, nil, nil)
+}
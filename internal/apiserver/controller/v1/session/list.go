@@ -0,0 +1,22 @@
+package session
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/skeleton1231/gotal/internal/pkg/middleware"
+	"github.com/skeleton1231/gotal/internal/pkg/response"
+	"github.com/skeleton1231/gotal/pkg/log"
+)
+
+// List list the active sessions of the current user.
+func (s *SessionController) List(c *gin.Context) {
+	log.Record(c).Info("list session function called.")
+
+	sessions, err := s.srv.Sessions().List(c, c.GetString(middleware.UsernameKey))
+	if err != nil {
+		response.WriteResponse(c, err, nil)
+
+		return
+	}
+
+	response.WriteResponse(c, nil, sessions)
+}
@@ -0,0 +1,18 @@
+package session
+
+import (
+	srvv1 "github.com/skeleton1231/gotal/internal/apiserver/service/v1"
+	"github.com/skeleton1231/gotal/internal/apiserver/store"
+)
+
+// SessionController create a session handler used to handle request for the login sessions of the current user.
+type SessionController struct {
+	srv srvv1.Service
+}
+
+// NewSessionController creates a session handler.
+func NewSessionController(store store.Factory) *SessionController {
+	return &SessionController{
+		srv: srvv1.NewService(store),
+	}
+}
@@ -0,0 +1,32 @@
+package twofactor
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/skeleton1231/gotal/internal/pkg/code"
+	"github.com/skeleton1231/gotal/internal/pkg/errors"
+	"github.com/skeleton1231/gotal/internal/pkg/middleware"
+	"github.com/skeleton1231/gotal/internal/pkg/response"
+	"github.com/skeleton1231/gotal/pkg/log"
+)
+
+// Disable turns 2FA off for the current user. Once enabled, a code or a recovery code is required.
+func (t *TwoFactorController) Disable(c *gin.Context) {
+	log.Record(c).Info("disable two-factor function called.")
+
+	var r struct {
+		Code string `json:"code"`
+	}
+	if err := c.ShouldBindJSON(&r); err != nil {
+		response.WriteResponse(c, errors.WithCode(code.ErrBind, err.Error()), nil)
+
+		return
+	}
+
+	if err := t.srv.TwoFactors().Disable(c, c.GetString(middleware.UsernameKey), r.Code); err != nil {
+		response.WriteResponse(c, err, nil)
+
+		return
+	}
+
+	response.WriteResponse(c, nil, nil)
+}
@@ -0,0 +1,31 @@
+package twofactor
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/skeleton1231/gotal/internal/pkg/code"
+	"github.com/skeleton1231/gotal/internal/pkg/errors"
+	"github.com/skeleton1231/gotal/internal/pkg/middleware"
+	"github.com/skeleton1231/gotal/internal/pkg/response"
+	"github.com/skeleton1231/gotal/pkg/log"
+)
+
+// Enable verifies a first code and enables 2FA. The recovery codes are only returned once.
+func (t *TwoFactorController) Enable(c *gin.Context) {
+	log.Record(c).Info("enable two-factor function called.")
+
+	var r codeRequest
+	if err := c.ShouldBindJSON(&r); err != nil {
+		response.WriteResponse(c, errors.WithCode(code.ErrBind, err.Error()), nil)
+
+		return
+	}
+
+	codes, err := t.srv.TwoFactors().Enable(c, c.GetString(middleware.UsernameKey), r.Code)
+	if err != nil {
+		response.WriteResponse(c, err, nil)
+
+		return
+	}
+
+	response.WriteResponse(c, nil, gin.H{"recoveryCodes": codes})
+}
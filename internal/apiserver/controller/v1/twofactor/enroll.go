@@ -0,0 +1,23 @@
+package twofactor
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/skeleton1231/gotal/internal/pkg/middleware"
+	"github.com/skeleton1231/gotal/internal/pkg/response"
+	"github.com/skeleton1231/gotal/pkg/log"
+)
+
+// Enroll creates a new secret for the current user. The provisioning uri is meant to be
+// shown as a QR code, 2FA is enabled once a code is verified with Enable.
+func (t *TwoFactorController) Enroll(c *gin.Context) {
+	log.Record(c).Info("enroll two-factor function called.")
+
+	enrollment, err := t.srv.TwoFactors().Enroll(c, c.GetString(middleware.UsernameKey))
+	if err != nil {
+		response.WriteResponse(c, err, nil)
+
+		return
+	}
+
+	response.WriteResponse(c, nil, enrollment)
+}
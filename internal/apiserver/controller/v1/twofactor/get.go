@@ -0,0 +1,22 @@
+package twofactor
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/skeleton1231/gotal/internal/pkg/middleware"
+	"github.com/skeleton1231/gotal/internal/pkg/response"
+	"github.com/skeleton1231/gotal/pkg/log"
+)
+
+// Get returns whether the current user has enrolled or enabled two-factor authentication.
+func (t *TwoFactorController) Get(c *gin.Context) {
+	log.Record(c).Info("get two-factor function called.")
+
+	tf, err := t.srv.TwoFactors().Get(c, c.GetString(middleware.UsernameKey))
+	if err != nil {
+		response.WriteResponse(c, err, nil)
+
+		return
+	}
+
+	response.WriteResponse(c, nil, tf)
+}
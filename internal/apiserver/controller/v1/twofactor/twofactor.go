@@ -0,0 +1,23 @@
+package twofactor
+
+import (
+	srvv1 "github.com/skeleton1231/gotal/internal/apiserver/service/v1"
+	"github.com/skeleton1231/gotal/internal/apiserver/store"
+)
+
+// TwoFactorController create a two-factor handler used to handle request for the second factor of the current user.
+type TwoFactorController struct {
+	srv srvv1.Service
+}
+
+// NewTwoFactorController creates a two-factor handler.
+func NewTwoFactorController(store store.Factory) *TwoFactorController {
+	return &TwoFactorController{
+		srv: srvv1.NewService(store),
+	}
+}
+
+// codeRequest carries a code from an authenticator app, or a recovery code.
+type codeRequest struct {
+	Code string `json:"code" binding:"required"`
+}
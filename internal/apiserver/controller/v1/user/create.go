@@ -24,28 +24,47 @@ func (u *UserController) Create(c *gin.Context) {
 		return
 	}
 
+	var opts model.CreateOptions
+	if err := c.ShouldBindQuery(&opts); err != nil {
+		response.WriteResponse(c, errors.WithCode(code.ErrBind, err.Error()), nil)
+
+		return
+	}
+	if err := model.ValidateDryRun(opts.DryRun); err != nil {
+		response.WriteResponse(c, errors.WithCode(code.ErrValidation, err.Error()), nil)
+
+		return
+	}
+
 	user.Password, _ = common.Encrypt(c.Param("password"))
 	user.Status = 1
-	// defaultTime := time.Date(1970, time.January, 1, 0, 0, 0, 0, time.UTC)
-
-	// 获取当前时间
-	currentTime := time.Now()
-	defaultTime := currentTime.AddDate(0, 1, 0)
-	log.Infof("defaultTime: %+v\n", defaultTime)
-	user.EmailVerifiedAt = defaultTime
-	user.TrialEndsAt = defaultTime
+	// The address is verified through the link emailed below.
+	user.EmailVerifiedAt = model.EmailUnverified
+	user.TrialEndsAt = time.Now().AddDate(0, 1, 0)
 
 	if validationErrors, err := validation.CheckModel(&user); err != nil {
 		response.WriteResponse(c, errors.WithCode(code.ErrValidation, err.Error()), validationErrors)
 		return
 	}
 
-	if err := u.srv.Users().Create(c, &user, model.CreateOptions{}); err != nil {
+	if err := u.srv.Users().Create(c, &user, opts); err != nil {
 		response.WriteResponse(c, err, nil)
 
 		return
 	}
 
+	// Nothing was saved, so there is nobody to issue a token for.
+	if model.IsDryRun(opts.DryRun) {
+		response.WriteResponse(c, nil, user)
+
+		return
+	}
+
+	// The user can ask for another link, so a failure does not fail the sign up.
+	if err := u.srv.Verifications().Send(c, user.ID); err != nil {
+		log.Record(c).Warnf("send verification email to user %d failed: %s", user.ID, err.Error())
+	}
+
 	token, _, err := generateJWTToken(&user)
 	if err != nil {
 		log.Errorf("generateJWTToken error is %s", err.Error())
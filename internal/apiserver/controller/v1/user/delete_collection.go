@@ -0,0 +1,63 @@
+package user
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/skeleton1231/gotal/internal/apiserver/store/model"
+	"github.com/skeleton1231/gotal/internal/pkg/code"
+	"github.com/skeleton1231/gotal/internal/pkg/errors"
+	"github.com/skeleton1231/gotal/internal/pkg/response"
+	"github.com/skeleton1231/gotal/pkg/log"
+)
+
+// DeleteCollection batch deletes the users given by `ids`, a comma separated
+// list which may be repeated, and/or matched by `fieldSelector`.
+// Only administrator can call this function.
+func (u *UserController) DeleteCollection(c *gin.Context) {
+	log.Record(c).Info("batch delete user function called.")
+
+	var opts model.DeleteCollectionOptions
+	if err := c.ShouldBindQuery(&opts); err != nil {
+		response.WriteResponse(c, errors.WithCode(code.ErrBind, err.Error()), nil)
+
+		return
+	}
+
+	ids, err := parseUserIDs(c.QueryArray("ids"))
+	if err != nil {
+		response.WriteResponse(c, errors.WithCode(code.ErrBind, err.Error()), nil)
+
+		return
+	}
+
+	results, err := u.srv.Users().DeleteCollection(c, ids, opts)
+	if err != nil {
+		response.WriteResponse(c, err, nil)
+
+		return
+	}
+
+	response.WriteResponse(c, nil, gin.H{"results": results})
+}
+
+func parseUserIDs(values []string) ([]uint64, error) {
+	var ids []uint64
+	for _, value := range values {
+		for _, s := range strings.Split(value, ",") {
+			s = strings.TrimSpace(s)
+			if s == "" {
+				continue
+			}
+
+			id, err := strconv.ParseUint(s, 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			ids = append(ids, id)
+		}
+	}
+
+	return ids, nil
+}
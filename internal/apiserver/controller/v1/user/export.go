@@ -0,0 +1,83 @@
+package user
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/skeleton1231/gotal/internal/apiserver/store/model"
+	"github.com/skeleton1231/gotal/internal/pkg/code"
+	"github.com/skeleton1231/gotal/internal/pkg/errors"
+	"github.com/skeleton1231/gotal/internal/pkg/response"
+	"github.com/skeleton1231/gotal/pkg/log"
+)
+
+// Export streams the users matched by `fieldSelector` and `labelSelector` as NDJSON,
+// or as CSV when the Accept header asks for `text/csv`. Passwords are never exported.
+// Only administrator can call this function.
+func (u *UserController) Export(c *gin.Context) {
+	log.Record(c).Info("export users function called.")
+
+	var opts model.ListOptions
+	if err := c.ShouldBindQuery(&opts); err != nil {
+		response.WriteResponse(c, errors.WithCode(code.ErrBind, err.Error()), nil)
+
+		return
+	}
+
+	format := c.NegotiateFormat(mimeNDJSON, mimeCSV)
+	if format == "" {
+		response.WriteResponse(c, errors.WithCode(code.ErrBind,
+			"unsupported Accept header `%s`, accept `%s` or `%s`", c.GetHeader("Accept"), mimeNDJSON, mimeCSV), nil)
+
+		return
+	}
+
+	// The response starts with the first user, so that an error found before,
+	// such as an invalid selector, is still reported with its status code.
+	var writer userRecordWriter
+	start := func() error {
+		ext := "ndjson"
+		if format == mimeCSV {
+			ext = "csv"
+		}
+		c.Header("Content-Type", format)
+		c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="users.%s"`, ext))
+		c.Status(http.StatusOK)
+
+		var err error
+		writer, err = newUserRecordWriter(format, c.Writer)
+
+		return err
+	}
+
+	err := u.srv.Users().Export(c, opts, func(user *model.User) error {
+		if writer == nil {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+
+		return writer.Write(newUserRecord(user))
+	})
+	if err == nil && writer == nil {
+		err = start()
+	}
+	if err != nil {
+		if writer == nil {
+			response.WriteResponse(c, err, nil)
+
+			return
+		}
+
+		// The status has been sent already, the client gets a truncated body.
+		log.Record(c).Errorf("export users interrupted: %s", err.Error())
+		c.Abort()
+
+		return
+	}
+
+	if err := writer.Flush(); err != nil {
+		log.Record(c).Errorf("flush exported users failed: %s", err.Error())
+	}
+}
@@ -0,0 +1,283 @@
+package user
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/skeleton1231/gotal/internal/apiserver/store/model"
+	"github.com/skeleton1231/gotal/internal/pkg/errors"
+)
+
+// Media types of the user import and export.
+const (
+	mimeCSV    = "text/csv"
+	mimeNDJSON = "application/x-ndjson"
+)
+
+// maxNDJSONLine is the longest line an NDJSON import may contain.
+const maxNDJSONLine = 1 << 20
+
+// userExportColumns are the CSV columns of an export, in order. An import accepts
+// them along with `password`, the identifier and creation time are ignored.
+var userExportColumns = []string{"id", "name", "email", "stripeId", "discordId", "status", "extend", "createdAt"}
+
+// userRecord is a user as it is imported and exported, one CSV row or NDJSON line.
+type userRecord struct {
+	ID        uint64       `json:"id,omitempty"`
+	Name      string       `json:"name"`
+	Email     string       `json:"email"`
+	Password  string       `json:"password,omitempty"`
+	StripeID  string       `json:"stripeId,omitempty"`
+	DiscordID uint64       `json:"discordId,omitempty"`
+	Status    *int         `json:"status,omitempty"`
+	Extend    model.Extend `json:"extend,omitempty"`
+	CreatedAt *time.Time   `json:"createdAt,omitempty"`
+}
+
+func newUserRecord(user *model.User) *userRecord {
+	status := user.Status
+	createdAt := user.CreatedAt
+
+	return &userRecord{
+		ID:        user.ID,
+		Name:      user.Name,
+		Email:     user.Email,
+		StripeID:  user.StripeID,
+		DiscordID: user.DiscordID,
+		Status:    &status,
+		Extend:    user.Extend,
+		CreatedAt: &createdAt,
+	}
+}
+
+// set assigns the value of a CSV column. Empty values leave the field unset.
+func (r *userRecord) set(column, value string) error {
+	switch column {
+	case "name":
+		r.Name = value
+	case "email":
+		r.Email = value
+	case "password":
+		r.Password = value
+	case "stripeId":
+		r.StripeID = value
+	case "discordId":
+		if value == "" {
+			return nil
+		}
+		id, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid discordId `%s`", value)
+		}
+		r.DiscordID = id
+	case "status":
+		if value == "" {
+			return nil
+		}
+		status, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid status `%s`", value)
+		}
+		r.Status = &status
+	case "extend":
+		if value == "" {
+			return nil
+		}
+		if err := json.Unmarshal([]byte(value), &r.Extend); err != nil {
+			return fmt.Errorf("invalid extend: %s", err.Error())
+		}
+	}
+
+	return nil
+}
+
+// get returns the value of a CSV column.
+func (r *userRecord) get(column string) string {
+	switch column {
+	case "id":
+		return strconv.FormatUint(r.ID, 10)
+	case "name":
+		return r.Name
+	case "email":
+		return r.Email
+	case "stripeId":
+		return r.StripeID
+	case "discordId":
+		return strconv.FormatUint(r.DiscordID, 10)
+	case "status":
+		if r.Status == nil {
+			return ""
+		}
+
+		return strconv.Itoa(*r.Status)
+	case "extend":
+		if len(r.Extend) == 0 {
+			return ""
+		}
+
+		return r.Extend.String()
+	case "createdAt":
+		if r.CreatedAt == nil {
+			return ""
+		}
+
+		return r.CreatedAt.Format(time.RFC3339)
+	}
+
+	return ""
+}
+
+// decodeUserRecords calls fn with every record of the body, numbered from 1. A record
+// which cannot be parsed is passed along with its error, the following ones are still read.
+// An error is only returned when the body cannot be read at all.
+func decodeUserRecords(contentType string, body io.Reader, fn func(row int, record *userRecord, err error)) error {
+	switch contentType {
+	case mimeCSV:
+		return decodeUserCSV(body, fn)
+	case mimeNDJSON:
+		return decodeUserNDJSON(body, fn)
+	default:
+		return fmt.Errorf("unsupported content type `%s`, use `%s` or `%s`", contentType, mimeCSV, mimeNDJSON)
+	}
+}
+
+// decodeUserCSV reads a CSV body whose first row names the columns.
+func decodeUserCSV(body io.Reader, fn func(row int, record *userRecord, err error)) error {
+	reader := csv.NewReader(body)
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return fmt.Errorf("missing csv header")
+		}
+
+		return err
+	}
+
+	known := map[string]bool{"password": true}
+	for _, column := range userExportColumns {
+		known[column] = true
+	}
+	hasName := false
+	for i, column := range header {
+		column = strings.TrimSpace(column)
+		if !known[column] {
+			return fmt.Errorf("unknown csv column `%s`", column)
+		}
+		hasName = hasName || column == "name"
+		header[i] = column
+	}
+	if !hasName {
+		return fmt.Errorf("csv header must contain the `name` column")
+	}
+
+	for row := 1; ; row++ {
+		values, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			fn(row, nil, parseErr.Err)
+
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		record := &userRecord{}
+		for i, value := range values {
+			if err = record.set(header[i], strings.TrimSpace(value)); err != nil {
+				break
+			}
+		}
+		fn(row, record, err)
+	}
+}
+
+// decodeUserNDJSON reads one JSON object per line. Blank lines are skipped.
+func decodeUserNDJSON(body io.Reader, fn func(row int, record *userRecord, err error)) error {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxNDJSONLine)
+
+	row := 0
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		row++
+
+		record := &userRecord{}
+		if err := json.Unmarshal([]byte(line), record); err != nil {
+			fn(row, nil, err)
+
+			continue
+		}
+		fn(row, record, nil)
+	}
+
+	return scanner.Err()
+}
+
+// userRecordWriter writes exported users in one of the supported media types.
+type userRecordWriter interface {
+	Write(record *userRecord) error
+	Flush() error
+}
+
+func newUserRecordWriter(contentType string, w io.Writer) (userRecordWriter, error) {
+	switch contentType {
+	case mimeCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(userExportColumns); err != nil {
+			return nil, err
+		}
+
+		return &csvUserWriter{w: cw}, nil
+	case mimeNDJSON:
+		return &ndjsonUserWriter{enc: json.NewEncoder(w)}, nil
+	default:
+		return nil, fmt.Errorf("unsupported content type `%s`, accept `%s` or `%s`", contentType, mimeCSV, mimeNDJSON)
+	}
+}
+
+type csvUserWriter struct {
+	w *csv.Writer
+}
+
+func (c *csvUserWriter) Write(record *userRecord) error {
+	values := make([]string, 0, len(userExportColumns))
+	for _, column := range userExportColumns {
+		values = append(values, record.get(column))
+	}
+
+	return c.w.Write(values)
+}
+
+func (c *csvUserWriter) Flush() error {
+	c.w.Flush()
+
+	return c.w.Error()
+}
+
+type ndjsonUserWriter struct {
+	enc *json.Encoder
+}
+
+// Write implements userRecordWriter. The encoder ends every record with a newline.
+func (n *ndjsonUserWriter) Write(record *userRecord) error {
+	return n.enc.Encode(record)
+}
+
+func (n *ndjsonUserWriter) Flush() error {
+	return nil
+}
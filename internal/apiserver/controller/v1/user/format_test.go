@@ -0,0 +1,118 @@
+package user
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/skeleton1231/gotal/internal/apiserver/store/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type decodedRow struct {
+	row    int
+	record *userRecord
+	err    error
+}
+
+func decodeAll(t *testing.T, contentType, body string) []decodedRow {
+	var rows []decodedRow
+	err := decodeUserRecords(contentType, strings.NewReader(body), func(row int, record *userRecord, err error) {
+		rows = append(rows, decodedRow{row: row, record: record, err: err})
+	})
+	require.NoError(t, err)
+
+	return rows
+}
+
+func TestDecodeUserRecords_CSV(t *testing.T) {
+	rows := decodeAll(t, mimeCSV, "name,email,password,discordId,extend\n"+
+		"alice,alice@example.com,secret,42,\"{\"\"plan\"\":\"\"pro\"\"}\"\n"+
+		"bob,bob@example.com,,not-a-number,\n"+
+		"carol,carol@example.com\n"+
+		"dave,dave@example.com,,,\n")
+
+	require.Len(t, rows, 4)
+
+	assert.NoError(t, rows[0].err)
+	assert.Equal(t, &userRecord{
+		Name: "alice", Email: "alice@example.com", Password: "secret", DiscordID: 42,
+		Extend: model.Extend{"plan": "pro"},
+	}, rows[0].record)
+
+	assert.EqualError(t, rows[1].err, "invalid discordId `not-a-number`")
+	assert.Equal(t, "bob", rows[1].record.Name)
+
+	// A row with missing fields is reported and the next one is still read.
+	assert.Equal(t, 3, rows[2].row)
+	assert.Error(t, rows[2].err)
+
+	assert.Equal(t, 4, rows[3].row)
+	assert.NoError(t, rows[3].err)
+	assert.Nil(t, rows[3].record.Status)
+}
+
+func TestDecodeUserRecords_Header(t *testing.T) {
+	noop := func(int, *userRecord, error) {}
+
+	assert.EqualError(t, decodeUserRecords(mimeCSV, strings.NewReader("name,nickname\n"), noop), "unknown csv column `nickname`")
+	assert.EqualError(t, decodeUserRecords(mimeCSV, strings.NewReader("email\n"), noop), "csv header must contain the `name` column")
+	assert.EqualError(t, decodeUserRecords(mimeCSV, strings.NewReader(""), noop), "missing csv header")
+	assert.Error(t, decodeUserRecords("application/json", strings.NewReader("{}"), noop))
+}
+
+func TestDecodeUserRecords_NDJSON(t *testing.T) {
+	rows := decodeAll(t, mimeNDJSON, `{"name":"alice","email":"alice@example.com","status":0}`+"\n\n"+
+		`{"name":"bob",`+"\n"+
+		`{"name":"carol","email":"carol@example.com","id":9}`+"\n")
+
+	require.Len(t, rows, 3)
+
+	assert.NoError(t, rows[0].err)
+	assert.Equal(t, "alice", rows[0].record.Name)
+	require.NotNil(t, rows[0].record.Status)
+	assert.Equal(t, 0, *rows[0].record.Status)
+
+	// Blank lines are not counted.
+	assert.Equal(t, 2, rows[1].row)
+	assert.Error(t, rows[1].err)
+
+	assert.Equal(t, 3, rows[2].row)
+	assert.Equal(t, "carol", rows[2].record.Name)
+}
+
+func TestUserRecordWriter(t *testing.T) {
+	createdAt := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+	user := &model.User{
+		ObjectMeta: model.ObjectMeta{ID: 3, Status: 1, CreatedAt: createdAt, Extend: model.Extend{"plan": "pro"}},
+		Name:       "alice",
+		Email:      "alice@example.com",
+		Password:   "hash",
+		DiscordID:  42,
+	}
+
+	var csvOut bytes.Buffer
+	w, err := newUserRecordWriter(mimeCSV, &csvOut)
+	require.NoError(t, err)
+	require.NoError(t, w.Write(newUserRecord(user)))
+	require.NoError(t, w.Flush())
+	assert.Equal(t, "id,name,email,stripeId,discordId,status,extend,createdAt\n"+
+		"3,alice,alice@example.com,,42,1,\"{\"\"plan\"\":\"\"pro\"\"}\",2024-05-01T08:00:00Z\n", csvOut.String())
+
+	var ndjsonOut bytes.Buffer
+	w, err = newUserRecordWriter(mimeNDJSON, &ndjsonOut)
+	require.NoError(t, err)
+	require.NoError(t, w.Write(newUserRecord(user)))
+	require.NoError(t, w.Flush())
+	assert.Equal(t, `{"id":3,"name":"alice","email":"alice@example.com","discordId":42,"status":1,`+
+		`"extend":{"plan":"pro"},"createdAt":"2024-05-01T08:00:00Z"}`+"\n", ndjsonOut.String())
+
+	// An export can be imported again.
+	rows := decodeAll(t, mimeCSV, csvOut.String())
+	require.Len(t, rows, 1)
+	assert.NoError(t, rows[0].err)
+	assert.Equal(t, "alice", rows[0].record.Name)
+	assert.Equal(t, uint64(42), rows[0].record.DiscordID)
+}
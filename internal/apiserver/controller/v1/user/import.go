@@ -0,0 +1,125 @@
+package user
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/skeleton1231/gotal/internal/apiserver/store/model"
+	"github.com/skeleton1231/gotal/internal/pkg/code"
+	"github.com/skeleton1231/gotal/internal/pkg/errors"
+	"github.com/skeleton1231/gotal/internal/pkg/response"
+	"github.com/skeleton1231/gotal/pkg/log"
+	"github.com/skeleton1231/gotal/pkg/util/common"
+)
+
+// Import creates the users of a CSV or NDJSON body, as told by its Content-Type.
+// Users whose name is taken are skipped, or overwritten with `onConflict=upsert`.
+// A row which cannot be imported is reported in the summary by its position, the
+// CSV header not counted, and does not abort the others.
+// Only administrator can call this function.
+func (u *UserController) Import(c *gin.Context) {
+	log.Record(c).Info("import users function called.")
+
+	var opts model.ImportOptions
+	if err := c.ShouldBindQuery(&opts); err != nil {
+		response.WriteResponse(c, errors.WithCode(code.ErrBind, err.Error()), nil)
+
+		return
+	}
+	if err := opts.Validate(); err != nil {
+		response.WriteResponse(c, errors.WithCode(code.ErrValidation, err.Error()), nil)
+
+		return
+	}
+
+	importer := &userImporter{now: time.Now()}
+	summary := &model.UserImportSummary{}
+
+	var users []*model.User
+	var rows []int
+	err := decodeUserRecords(c.ContentType(), c.Request.Body, func(row int, record *userRecord, err error) {
+		var user *model.User
+		if err == nil {
+			user, err = importer.user(record)
+		}
+		if err != nil {
+			var name string
+			if record != nil {
+				name = record.Name
+			}
+			summary.Fail(row, name, err.Error())
+
+			return
+		}
+
+		users = append(users, user)
+		rows = append(rows, row)
+	})
+	if err != nil {
+		response.WriteResponse(c, errors.WithCode(code.ErrBind, err.Error()), nil)
+
+		return
+	}
+
+	if len(users) > 0 {
+		imported, err := u.srv.Users().Import(c, users, opts)
+		if err != nil {
+			response.WriteResponse(c, err, nil)
+
+			return
+		}
+		summary.Merge(imported, rows)
+	}
+	summary.SortErrors()
+
+	response.WriteResponse(c, nil, summary)
+}
+
+// userImporter turns imported records into users, set up like users who signed up.
+type userImporter struct {
+	now time.Time
+
+	// unusable is the password of the users imported without one. Its secret is thrown
+	// away, they set a password through a reset. Hashing it once spares a bcrypt per row.
+	unusable string
+}
+
+func (i *userImporter) user(record *userRecord) (*model.User, error) {
+	password, err := i.password(record.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	status := 1
+	if record.Status != nil {
+		status = *record.Status
+	}
+
+	return &model.User{
+		ObjectMeta:  model.ObjectMeta{Status: status, Extend: record.Extend},
+		Name:        record.Name,
+		Email:       record.Email,
+		Password:    password,
+		StripeID:    record.StripeID,
+		DiscordID:   record.DiscordID,
+		TrialEndsAt: i.now.AddDate(0, 1, 0),
+	}, nil
+}
+
+func (i *userImporter) password(plain string) (string, error) {
+	if plain != "" {
+		return common.Encrypt(plain)
+	}
+
+	if i.unusable == "" {
+		secret, err := common.GenerateSecretKey(32)
+		if err != nil {
+			return "", err
+		}
+		if i.unusable, err = common.Encrypt(secret); err != nil {
+			return "", err
+		}
+	}
+
+	return i.unusable, nil
+}
@@ -0,0 +1,58 @@
+package user
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/skeleton1231/gotal/internal/pkg/code"
+	"github.com/skeleton1231/gotal/internal/pkg/errors"
+	"github.com/skeleton1231/gotal/internal/pkg/response"
+	"github.com/skeleton1231/gotal/pkg/log"
+)
+
+type forgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type resetPasswordRequest struct {
+	Token string `json:"token" binding:"required"`
+	// Bcrypt ignores what follows the first 72 bytes.
+	Password string `json:"password" binding:"required,min=8,max=72"`
+}
+
+// ForgotPassword emails a password reset link. It answers the same whether the address
+// belongs to a user or not.
+func (u *UserController) ForgotPassword(c *gin.Context) {
+	log.Record(c).Info("forgot password function called.")
+
+	var r forgotPasswordRequest
+	if err := c.ShouldBindJSON(&r); err != nil {
+		response.WriteResponse(c, errors.WithCode(code.ErrBind, err.Error()), nil)
+
+		return
+	}
+
+	if err := u.srv.Passwords().Forgot(c, r.Email, c.ClientIP()); err != nil {
+		log.Record(c).Warnf("password reset for `%s` not sent: %s", r.Email, err.Error())
+	}
+
+	response.WriteResponse(c, nil, nil)
+}
+
+// ResetPassword sets a new password with the token of a reset link.
+func (u *UserController) ResetPassword(c *gin.Context) {
+	log.Record(c).Info("reset password function called.")
+
+	var r resetPasswordRequest
+	if err := c.ShouldBindJSON(&r); err != nil {
+		response.WriteResponse(c, errors.WithCode(code.ErrBind, err.Error()), nil)
+
+		return
+	}
+
+	if err := u.srv.Passwords().Reset(c, r.Token, r.Password, c.ClientIP()); err != nil {
+		response.WriteResponse(c, err, nil)
+
+		return
+	}
+
+	response.WriteResponse(c, nil, nil)
+}
@@ -0,0 +1,63 @@
+package user
+
+import (
+	"mime"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/skeleton1231/gotal/internal/apiserver/store/model"
+	"github.com/skeleton1231/gotal/internal/pkg/code"
+	"github.com/skeleton1231/gotal/internal/pkg/errors"
+	"github.com/skeleton1231/gotal/internal/pkg/response"
+	"github.com/skeleton1231/gotal/pkg/log"
+)
+
+// mergePatchContentType is the media type of RFC 7396 JSON Merge Patch documents.
+const mergePatchContentType = "application/merge-patch+json"
+
+// Patch applies a JSON merge patch to the user. Only allowlisted fields may be patched,
+// and a field set to null is cleared.
+func (u *UserController) Patch(c *gin.Context) {
+	log.Record(c).Info("patch user function called.")
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.WriteResponse(c, errors.WithCode(code.ErrBind, err.Error()), nil)
+
+		return
+	}
+
+	if mediaType, _, _ := mime.ParseMediaType(c.ContentType()); mediaType != mergePatchContentType && mediaType != gin.MIMEJSON {
+		response.WriteResponse(c, errors.WithCode(code.ErrBind, "unsupported content type `%s`", c.ContentType()), nil)
+
+		return
+	}
+
+	var opts model.PatchOptions
+	if err := c.ShouldBindQuery(&opts); err != nil {
+		response.WriteResponse(c, errors.WithCode(code.ErrBind, err.Error()), nil)
+
+		return
+	}
+	if err := model.ValidateDryRun(opts.DryRun); err != nil {
+		response.WriteResponse(c, errors.WithCode(code.ErrValidation, err.Error()), nil)
+
+		return
+	}
+
+	patch, err := c.GetRawData()
+	if err != nil {
+		response.WriteResponse(c, errors.WithCode(code.ErrBind, err.Error()), nil)
+
+		return
+	}
+
+	user, err := u.srv.Users().Patch(c, id, patch, opts)
+	if err != nil {
+		response.WriteResponse(c, err, nil)
+
+		return
+	}
+
+	response.WriteResponse(c, nil, user)
+}
@@ -18,6 +18,8 @@ func (u *UserController) Update(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
 		response.WriteResponse(c, errors.WithCode(code.ErrBind, err.Error()), nil)
+
+		return
 	}
 
 	var r model.User
@@ -28,6 +30,18 @@ func (u *UserController) Update(c *gin.Context) {
 		return
 	}
 
+	var opts model.UpdateOptions
+	if err := c.ShouldBindQuery(&opts); err != nil {
+		response.WriteResponse(c, errors.WithCode(code.ErrBind, err.Error()), nil)
+
+		return
+	}
+	if err := model.ValidateDryRun(opts.DryRun); err != nil {
+		response.WriteResponse(c, errors.WithCode(code.ErrValidation, err.Error()), nil)
+
+		return
+	}
+
 	user, err := u.srv.Users().Get(c, id, model.GetOptions{})
 	if err != nil {
 		response.WriteResponse(c, err, nil)
@@ -44,7 +58,7 @@ func (u *UserController) Update(c *gin.Context) {
 	}
 
 	// Save changed fields.
-	if err := u.srv.Users().Update(c, user, model.UpdateOptions{}); err != nil {
+	if err := u.srv.Users().Update(c, user, opts); err != nil {
 		response.WriteResponse(c, err, nil)
 
 		return
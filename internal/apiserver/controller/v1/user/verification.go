@@ -0,0 +1,52 @@
+package user
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/skeleton1231/gotal/internal/pkg/code"
+	"github.com/skeleton1231/gotal/internal/pkg/errors"
+	"github.com/skeleton1231/gotal/internal/pkg/response"
+	"github.com/skeleton1231/gotal/pkg/log"
+)
+
+// SendVerification emails a new verification link to the user.
+func (u *UserController) SendVerification(c *gin.Context) {
+	log.Record(c).Info("send verification function called.")
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.WriteResponse(c, errors.WithCode(code.ErrBind, err.Error()), nil)
+
+		return
+	}
+
+	if err := u.srv.Verifications().Send(c, id); err != nil {
+		response.WriteResponse(c, err, nil)
+
+		return
+	}
+
+	response.WriteResponse(c, nil, nil)
+}
+
+// Verify confirms the email address with the token of a verification link.
+func (u *UserController) Verify(c *gin.Context) {
+	log.Record(c).Info("verify email function called.")
+
+	token := c.Query("token")
+	if token == "" {
+		response.WriteResponse(c, errors.WithCode(code.ErrBind, "missing token"), nil)
+
+		return
+	}
+
+	user, err := u.srv.Verifications().Verify(c, token)
+	if err != nil {
+		response.WriteResponse(c, err, nil)
+
+		return
+	}
+
+	response.WriteResponse(c, nil, user)
+}
@@ -0,0 +1,49 @@
+package webhook
+
+import (
+	"io"
+
+	"github.com/gin-gonic/gin"
+	srvv1 "github.com/skeleton1231/gotal/internal/apiserver/service/v1"
+	"github.com/skeleton1231/gotal/internal/apiserver/store"
+	"github.com/skeleton1231/gotal/internal/pkg/code"
+	"github.com/skeleton1231/gotal/internal/pkg/errors"
+	"github.com/skeleton1231/gotal/internal/pkg/response"
+	"github.com/skeleton1231/gotal/pkg/log"
+)
+
+// maxPayloadBytes bounds the body of a webhook request, Stripe events are far smaller.
+const maxPayloadBytes = 1 << 20
+
+// WebhookController receives the events sent by third-party services.
+type WebhookController struct {
+	srv srvv1.Service
+}
+
+// NewWebhookController creates a webhook controller.
+func NewWebhookController(store store.Factory) *WebhookController {
+	return &WebhookController{
+		srv: srvv1.NewService(store),
+	}
+}
+
+// Stripe processes an event sent by Stripe. The signature covers the raw body, so the
+// body is read as it is instead of being bound.
+func (w *WebhookController) Stripe(c *gin.Context) {
+	log.Record(c).Info("stripe webhook function called.")
+
+	payload, err := io.ReadAll(io.LimitReader(c.Request.Body, maxPayloadBytes))
+	if err != nil {
+		response.WriteResponse(c, errors.WithCode(code.ErrBind, err.Error()), nil)
+
+		return
+	}
+
+	if err := w.srv.Billing().HandleWebhook(c, payload, c.GetHeader("Stripe-Signature")); err != nil {
+		response.WriteResponse(c, err, nil)
+
+		return
+	}
+
+	response.WriteResponse(c, nil, nil)
+}
@@ -0,0 +1,133 @@
+package apiserver
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/skeleton1231/gotal/internal/apiserver/oauth"
+	srvv1 "github.com/skeleton1231/gotal/internal/apiserver/service/v1"
+	"github.com/skeleton1231/gotal/internal/apiserver/store"
+	"github.com/skeleton1231/gotal/internal/pkg/code"
+	"github.com/skeleton1231/gotal/internal/pkg/errors"
+	"github.com/skeleton1231/gotal/internal/pkg/middleware/auth"
+	"github.com/skeleton1231/gotal/internal/pkg/options"
+	"github.com/skeleton1231/gotal/internal/pkg/response"
+	"github.com/skeleton1231/gotal/pkg/log"
+	"github.com/spf13/viper"
+)
+
+const (
+	// discordFlowCookie holds the signed state of a Discord login between start and callback.
+	discordFlowCookie = "discord_oauth"
+	discordFlowPath   = "/v1/oauth/discord"
+)
+
+var (
+	discordOpts     *options.DiscordOptions
+	discordOptsOnce sync.Once
+)
+
+// getDiscordOptions reads the `discord.*` configuration on first use.
+func getDiscordOptions() *options.DiscordOptions {
+	discordOptsOnce.Do(func() {
+		discordOpts = options.NewDiscordOptions()
+		if err := viper.UnmarshalKey("discord", discordOpts); err != nil {
+			log.Errorf("read discord options failed: %s", err.Error())
+		}
+	})
+
+	return discordOpts
+}
+
+// discordStartHandler redirects to Discord to authorize the login. A signed in user
+// starting the login links the Discord account to their own account.
+func discordStartHandler(j auth.JWTStrategy, opts *options.DiscordOptions) gin.HandlerFunc {
+	provider := oauth.NewDiscord(opts)
+
+	return func(c *gin.Context) {
+		state, err := oauth.NewVerifier()
+		if err != nil {
+			response.WriteResponse(c, errors.WithCode(code.ErrUnknown, err.Error()), nil)
+
+			return
+		}
+		verifier, err := oauth.NewVerifier()
+		if err != nil {
+			response.WriteResponse(c, errors.WithCode(code.ErrUnknown, err.Error()), nil)
+
+			return
+		}
+
+		flow := &oauth.Flow{
+			State:     state,
+			Verifier:  verifier,
+			ExpiresAt: time.Now().Add(opts.StateTTL).Unix(),
+		}
+		if username, ok := j.Identity(c); ok {
+			flow.Link = username
+		}
+
+		value, err := flow.Encode(j.Key)
+		if err != nil {
+			response.WriteResponse(c, errors.WithCode(code.ErrUnknown, err.Error()), nil)
+
+			return
+		}
+
+		c.SetSameSite(http.SameSiteLaxMode)
+		c.SetCookie(discordFlowCookie, value, int(opts.StateTTL.Seconds()), discordFlowPath, "", c.Request.TLS != nil, true)
+		c.Redirect(http.StatusFound, provider.AuthCodeURL(state, verifier))
+	}
+}
+
+// discordCallbackHandler completes the login authorized by Discord and responds
+// the same as a password login, including the 2FA challenge.
+func discordCallbackHandler(j auth.JWTStrategy, opts *options.DiscordOptions) gin.HandlerFunc {
+	provider := oauth.NewDiscord(opts)
+
+	return func(c *gin.Context) {
+		value, _ := c.Cookie(discordFlowCookie)
+		// The flow can only be completed once.
+		c.SetCookie(discordFlowCookie, "", -1, discordFlowPath, "", c.Request.TLS != nil, true)
+
+		flow, err := oauth.DecodeFlow(value, j.Key, time.Now())
+		if err != nil || c.Query("state") != flow.State {
+			response.WriteResponse(c, errors.WithCode(code.ErrOAuthStateInvalid, "invalid oauth state"), nil)
+
+			return
+		}
+
+		if reason := c.Query("error"); reason != "" {
+			response.WriteResponse(c, errors.WithCode(code.ErrOAuthFailed, "discord authorization failed: %s", reason), nil)
+
+			return
+		}
+
+		token, err := provider.Exchange(c, c.Query("code"), flow.Verifier)
+		if err != nil {
+			log.Record(c).Errorf("discord login failed: %s", err.Error())
+			response.WriteResponse(c, errors.WithCode(code.ErrOAuthFailed, "discord authorization failed"), nil)
+
+			return
+		}
+
+		account, err := provider.User(c, token)
+		if err != nil {
+			log.Record(c).Errorf("discord login failed: %s", err.Error())
+			response.WriteResponse(c, errors.WithCode(code.ErrOAuthFailed, "discord authorization failed"), nil)
+
+			return
+		}
+
+		user, err := srvv1.NewService(store.Client()).Users().LoginWithDiscord(c, account, flow.Link)
+		if err != nil {
+			response.WriteResponse(c, err, nil)
+
+			return
+		}
+
+		completeLogin(c, j, user)
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright 2023 Talhuang<talhuang1231@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+// Package oauth implements the client side of OAuth2 logins.
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/skeleton1231/gotal/internal/pkg/options"
+)
+
+// DiscordUser is the Discord account which authorized the login.
+type DiscordUser struct {
+	ID       uint64
+	Username string
+	Email    string
+	Verified bool
+}
+
+// Discord is an OAuth2 authorization-code client of Discord, using PKCE.
+type Discord struct {
+	opts   *options.DiscordOptions
+	client *http.Client
+}
+
+// NewDiscord creates a Discord client with the given options.
+func NewDiscord(opts *options.DiscordOptions) *Discord {
+	return &Discord{
+		opts:   opts,
+		client: &http.Client{Timeout: opts.Timeout},
+	}
+}
+
+// AuthCodeURL returns the url the user is redirected to in order to authorize the login.
+func (d *Discord) AuthCodeURL(state, verifier string) string {
+	v := url.Values{}
+	v.Set("response_type", "code")
+	v.Set("client_id", d.opts.ClientID)
+	v.Set("redirect_uri", d.opts.RedirectURL)
+	v.Set("scope", strings.Join(d.opts.Scopes, " "))
+	v.Set("state", state)
+	v.Set("code_challenge", Challenge(verifier))
+	v.Set("code_challenge_method", "S256")
+	v.Set("prompt", "none")
+
+	sep := "?"
+	if strings.Contains(d.opts.AuthURL, "?") {
+		sep = "&"
+	}
+
+	return d.opts.AuthURL + sep + v.Encode()
+}
+
+// Exchange trades the authorization code for an access token.
+func (d *Discord) Exchange(ctx context.Context, code, verifier string) (string, error) {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", d.opts.RedirectURL)
+	form.Set("code_verifier", verifier)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.opts.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.SetBasicAuth(url.QueryEscape(d.opts.ClientID), url.QueryEscape(d.opts.ClientSecret))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+		TokenType   string `json:"token_type"`
+	}
+	if err := d.do(req, &token); err != nil {
+		return "", fmt.Errorf("exchange authorization code: %w", err)
+	}
+
+	if token.AccessToken == "" {
+		return "", fmt.Errorf("exchange authorization code: no access token returned")
+	}
+
+	return token.AccessToken, nil
+}
+
+// User returns the Discord account the access token belongs to.
+func (d *Discord) User(ctx context.Context, accessToken string) (*DiscordUser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, d.opts.UserURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Accept", "application/json")
+
+	var user struct {
+		ID       string `json:"id"`
+		Username string `json:"username"`
+		Email    string `json:"email"`
+		Verified bool   `json:"verified"`
+	}
+	if err := d.do(req, &user); err != nil {
+		return nil, fmt.Errorf("get discord user: %w", err)
+	}
+
+	// Discord ids are snowflakes sent as strings.
+	id, err := strconv.ParseUint(user.ID, 10, 64)
+	if err != nil || id == 0 {
+		return nil, fmt.Errorf("get discord user: invalid id `%s`", user.ID)
+	}
+
+	return &DiscordUser{
+		ID:       id,
+		Username: user.Username,
+		Email:    user.Email,
+		Verified: user.Verified,
+	}, nil
+}
+
+func (d *Discord) do(req *http.Request, v interface{}) error {
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	return json.Unmarshal(body, v)
+}
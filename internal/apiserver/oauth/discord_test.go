@@ -0,0 +1,109 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/skeleton1231/gotal/internal/pkg/options"
+	"github.com/stretchr/testify/assert"
+)
+
+func newDiscordServer(t *testing.T, verifier string) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		id, secret, ok := r.BasicAuth()
+		if !ok || id != "client" || secret != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+		assert.NoError(t, r.ParseForm())
+		if r.Form.Get("code") != "good-code" || r.Form.Get("code_verifier") != verifier {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"invalid_grant"}`))
+
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"access_token": "access", "token_type": "Bearer"})
+	})
+	mux.HandleFunc("/users/@me", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer access" {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"80351110224678912","username":"nelly","email":"nelly@example.com","verified":true}`))
+	})
+
+	return httptest.NewServer(mux)
+}
+
+func newTestDiscord(srv *httptest.Server) *Discord {
+	opts := options.NewDiscordOptions()
+	opts.ClientID = "client"
+	opts.ClientSecret = "secret"
+	opts.RedirectURL = "http://localhost/v1/oauth/discord/callback"
+	opts.AuthURL = srv.URL + "/authorize"
+	opts.TokenURL = srv.URL + "/token"
+	opts.UserURL = srv.URL + "/users/@me"
+
+	return NewDiscord(opts)
+}
+
+func TestDiscord_Login(t *testing.T) {
+	verifier, err := NewVerifier()
+	assert.NoError(t, err)
+
+	srv := newDiscordServer(t, verifier)
+	defer srv.Close()
+	d := newTestDiscord(srv)
+
+	u, err := url.Parse(d.AuthCodeURL("state", verifier))
+	assert.NoError(t, err)
+	assert.Equal(t, "state", u.Query().Get("state"))
+	assert.Equal(t, "S256", u.Query().Get("code_challenge_method"))
+	assert.Equal(t, Challenge(verifier), u.Query().Get("code_challenge"))
+	assert.Equal(t, "identify email", u.Query().Get("scope"))
+
+	token, err := d.Exchange(context.Background(), "good-code", verifier)
+	assert.NoError(t, err)
+
+	user, err := d.User(context.Background(), token)
+	assert.NoError(t, err)
+	assert.Equal(t, &DiscordUser{ID: 80351110224678912, Username: "nelly", Email: "nelly@example.com", Verified: true}, user)
+}
+
+func TestDiscord_ExchangeWrongVerifier(t *testing.T) {
+	srv := newDiscordServer(t, "expected")
+	defer srv.Close()
+
+	_, err := newTestDiscord(srv).Exchange(context.Background(), "good-code", "other")
+	assert.Error(t, err)
+}
+
+func TestFlow_EncodeDecode(t *testing.T) {
+	key := []byte("key")
+	now := time.Now()
+	flow := &Flow{State: "s", Verifier: "v", Link: "alice", ExpiresAt: now.Add(time.Minute).Unix()}
+
+	value, err := flow.Encode(key)
+	assert.NoError(t, err)
+
+	got, err := DecodeFlow(value, key, now)
+	assert.NoError(t, err)
+	assert.Equal(t, flow, got)
+
+	_, err = DecodeFlow(value, []byte("other"), now)
+	assert.Error(t, err)
+
+	_, err = DecodeFlow(value+"x", key, now)
+	assert.Error(t, err)
+
+	_, err = DecodeFlow(value, key, now.Add(2*time.Minute))
+	assert.Error(t, err)
+}
@@ -0,0 +1,84 @@
+// Copyright 2023 Talhuang<talhuang1231@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package oauth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// NewVerifier returns a random PKCE code verifier, which can also be used as a state.
+func NewVerifier() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// Challenge returns the S256 PKCE code challenge of the verifier.
+func Challenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// Flow is the state of a started login, kept by the browser between the start and the callback.
+type Flow struct {
+	State     string `json:"s"`
+	Verifier  string `json:"v"`
+	Link      string `json:"l,omitempty"` // username of the account to link, if the login was started by a signed in user
+	ExpiresAt int64  `json:"e"`
+}
+
+// Encode returns the flow signed with key.
+func (f *Flow) Encode(key []byte) (string, error) {
+	payload, err := json.Marshal(f)
+	if err != nil {
+		return "", err
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+
+	return encoded + "." + sign(encoded, key), nil
+}
+
+// DecodeFlow verifies the signature and expiry of a flow returned by Flow.Encode.
+func DecodeFlow(value string, key []byte, now time.Time) (*Flow, error) {
+	encoded, signature, ok := strings.Cut(value, ".")
+	if !ok || !hmac.Equal([]byte(signature), []byte(sign(encoded, key))) {
+		return nil, fmt.Errorf("oauth flow signature mismatch")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	flow := &Flow{}
+	if err := json.Unmarshal(payload, flow); err != nil {
+		return nil, err
+	}
+
+	if now.Unix() >= flow.ExpiresAt {
+		return nil, fmt.Errorf("oauth flow expired")
+	}
+
+	return flow, nil
+}
+
+func sign(value string, key []byte) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(value))
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
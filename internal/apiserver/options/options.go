@@ -24,8 +24,14 @@ type Options struct {
 	MySQLOptions            *options.MySQLOptions           `json:"mysql"    mapstructure:"mysql"`
 	RedisOptions            *options.RedisOptions           `json:"redis"    mapstructure:"redis"`
 	JwtOptions              *options.JwtOptions             `json:"jwt"      mapstructure:"jwt"`
+	AuthzOptions            *options.AuthzOptions           `json:"authz"    mapstructure:"authz"`
+	DiscordOptions          *options.DiscordOptions         `json:"discord"  mapstructure:"discord"`
 	FeatureOptions          *options.FeatureOptions         `json:"feature"  mapstructure:"feature"`
 	RateLimitOptions        *options.RateLimitOptions       `json:"ratelimit"  mapstructure:"ratelimit"`
+	MailOptions             *options.MailOptions            `json:"mail"     mapstructure:"mail"`
+	VerificationOptions     *options.VerificationOptions    `json:"verification" mapstructure:"verification"`
+	PasswordResetOptions    *options.PasswordResetOptions   `json:"password-reset" mapstructure:"password-reset"`
+	StripeOptions           *options.StripeOptions          `json:"stripe"   mapstructure:"stripe"`
 	Log                     *log.Options                    `json:"log"      mapstructure:"log"`
 }
 
@@ -39,8 +45,14 @@ func NewOptions() *Options {
 		MySQLOptions:            options.NewMySQLOptions(),
 		RedisOptions:            options.NewRedisOptions(),
 		JwtOptions:              options.NewJwtOptions(),
+		AuthzOptions:            options.NewAuthzOptions(),
+		DiscordOptions:          options.NewDiscordOptions(),
 		FeatureOptions:          options.NewFeatureOptions(),
 		RateLimitOptions:        options.NewRateLimitOptions(),
+		MailOptions:             options.NewMailOptions(),
+		VerificationOptions:     options.NewVerificationOptions(),
+		PasswordResetOptions:    options.NewPasswordResetOptions(),
+		StripeOptions:           options.NewStripeOptions(),
 	}
 }
 
@@ -51,10 +63,16 @@ func (o *Options) ApplyTo(c *server.Config) error {
 func (o *Options) Flags() (fss flag.NamedFlagSets) {
 	o.GenericServerRunOptions.AddFlags(fss.FlagSet("generic"))
 	o.JwtOptions.AddFlags(fss.FlagSet("jwt"))
+	o.AuthzOptions.AddFlags(fss.FlagSet("authz"))
+	o.DiscordOptions.AddFlags(fss.FlagSet("discord"))
 	o.GRPCOptions.AddFlags(fss.FlagSet("grpc"))
 	o.MySQLOptions.AddFlags(fss.FlagSet("mysql"))
 	o.RedisOptions.AddFlags(fss.FlagSet("redis"))
 	o.FeatureOptions.AddFlags(fss.FlagSet("features"))
+	o.MailOptions.AddFlags(fss.FlagSet("mail"))
+	o.VerificationOptions.AddFlags(fss.FlagSet("verification"))
+	o.PasswordResetOptions.AddFlags(fss.FlagSet("password reset"))
+	o.StripeOptions.AddFlags(fss.FlagSet("stripe"))
 	o.InsecureServing.AddFlags(fss.FlagSet("insecure serving"))
 	o.SecureServing.AddFlags(fss.FlagSet("secure serving"))
 	// o.RateLimitOptions.AddFlags(fss.FlagSet("ratelimit"))
@@ -4,6 +4,8 @@
 
 package options
 
+import "fmt"
+
 // Validate checks Options and return a slice of found errs.
 func (o *Options) Validate() []error {
 	var errs []error
@@ -18,13 +20,24 @@ func (o *Options) Validate() []error {
 		o.MySQLOptions,
 		o.RedisOptions,
 		o.JwtOptions,
+		o.AuthzOptions,
+		o.DiscordOptions,
 		o.FeatureOptions,
 		o.RateLimitOptions,
+		o.MailOptions,
+		o.VerificationOptions,
+		o.PasswordResetOptions,
+		o.StripeOptions,
 	}
 
 	for _, validator := range validators {
 		errs = append(errs, validator.Validate()...)
 	}
 
+	// Users could never verify their address, and so never log in.
+	if o.VerificationOptions.Required && !o.MailOptions.Enabled() {
+		errs = append(errs, fmt.Errorf("verification.required needs a mail driver to send the verification emails"))
+	}
+
 	return errs
 }
@@ -2,10 +2,16 @@ package apiserver
 
 import (
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/skeleton1231/gotal/internal/apiserver/controller/v1/apikey"
+	"github.com/skeleton1231/gotal/internal/apiserver/controller/v1/role"
+	"github.com/skeleton1231/gotal/internal/apiserver/controller/v1/session"
+	"github.com/skeleton1231/gotal/internal/apiserver/controller/v1/twofactor"
 	"github.com/skeleton1231/gotal/internal/apiserver/controller/v1/user"
+	"github.com/skeleton1231/gotal/internal/apiserver/controller/v1/webhook"
 	"github.com/skeleton1231/gotal/internal/apiserver/store/rpc_service"
 	"github.com/skeleton1231/gotal/internal/pkg/code"
 	"github.com/skeleton1231/gotal/internal/pkg/errors"
@@ -29,10 +35,18 @@ func installController(g *gin.Engine) *gin.Engine {
 
 	// Middlewares.
 	jwtStrategy, _ := newJWTAuth().(auth.JWTStrategy)
-	g.POST("/login", jwtStrategy.LoginHandler)
-	g.POST("/logout", jwtStrategy.LogoutHandler)
-	// Refresh time can be longer than token timeout
-	g.POST("/refresh", jwtStrategy.RefreshHandler)
+	g.POST("/login", loginHandler(jwtStrategy))
+	g.POST("/login/2fa", twoFactorLoginHandler(jwtStrategy))
+	g.POST("/logout", logoutHandler(jwtStrategy))
+	// Refresh tokens live for jwt.max-refresh and are rotated on every use
+	g.POST("/refresh", refreshHandler(jwtStrategy))
+
+	// Login with Discord, only when a client is configured
+	if discord := getDiscordOptions(); discord.Enabled() {
+		oauthv1 := g.Group("/v1/oauth/discord")
+		oauthv1.GET("/start", discordStartHandler(jwtStrategy, discord))
+		oauthv1.GET("/callback", discordCallbackHandler(jwtStrategy, discord))
+	}
 
 	auto := newAutoAuth()
 	g.NoRoute(auto.AuthFunc(), func(c *gin.Context) {
@@ -42,16 +56,67 @@ func installController(g *gin.Engine) *gin.Engine {
 	//storeIns, _ := database.GetMySQLFactoryOr(nil)
 	storeIns, _ := rpc_service.GetRPCServerFactory("", "")
 	userController := user.NewUserController(storeIns)
+	roleController := role.NewRoleController(storeIns)
+	apiKeyController := apikey.NewAPIKeyController(storeIns)
+	sessionController := session.NewSessionController(storeIns)
+	twoFactorController := twofactor.NewTwoFactorController(storeIns)
+	webhookController := webhook.NewWebhookController(storeIns)
 	testController(g)
 
+	// Events of third-party services, authenticated by their signatures
+	g.POST("/webhooks/stripe", webhookController.Stripe)
+
 	authGroup := g.Group("/v1")
 	authGroup.Use(auto.AuthFunc())
 	{
 		// user RESTful resource
 		userv1 := authGroup.Group("/users")
 		{
+			userv1.GET("", userController.List)
 			userv1.PUT("/:id", userController.Update)
+			userv1.PATCH("/:id", userController.Patch)
+			userv1.DELETE("", requireRole(adminRole), userController.DeleteCollection)
 			userv1.DELETE("/:id", userController.Delete)
+			userv1.POST("/:id/verification", userController.SendVerification)
+		}
+		// bulk import and export of users
+		authGroup.POST("/users:verb", requireRole(adminRole), customMethods(map[string]gin.HandlerFunc{"import": userController.Import}))
+		authGroup.GET("/users:verb", requireRole(adminRole), customMethods(map[string]gin.HandlerFunc{"export": userController.Export}))
+
+		// role RESTful resource
+		rolev1 := authGroup.Group("/roles")
+		{
+			rolev1.POST("", requireRole(adminRole), roleController.Create)
+			rolev1.GET("", roleController.List)
+			rolev1.GET("/:id", roleController.Get)
+			rolev1.DELETE("/:id", requireRole(adminRole), roleController.Delete)
+			rolev1.POST("/:id/users/:userId", requireRole(adminRole), roleController.Grant)
+			rolev1.DELETE("/:id/users/:userId", requireRole(adminRole), roleController.Revoke)
+			rolev1.GET("/users/:userId", roleController.ListUserRoles)
+		}
+
+		// api key RESTful resource
+		apikeyv1 := authGroup.Group("/apikeys")
+		{
+			apikeyv1.POST("", apiKeyController.Create)
+			apikeyv1.GET("", apiKeyController.List)
+			apikeyv1.DELETE("/:id", apiKeyController.Revoke)
+		}
+
+		// login sessions of the current user
+		sessionv1 := authGroup.Group("/me/sessions")
+		{
+			sessionv1.GET("", sessionController.List)
+			sessionv1.DELETE("/:id", sessionController.Delete)
+		}
+
+		// two-factor authentication of the current user
+		twofactorv1 := authGroup.Group("/me/2fa")
+		{
+			twofactorv1.GET("", twoFactorController.Get)
+			twofactorv1.POST("", twoFactorController.Enroll)
+			twofactorv1.POST("/enable", twoFactorController.Enable)
+			twofactorv1.POST("/disable", twoFactorController.Disable)
 		}
 	}
 
@@ -59,12 +124,31 @@ func installController(g *gin.Engine) *gin.Engine {
 	{
 		noAuthGroup.POST("/users", userController.Create)
 		noAuthGroup.GET("/users/:id", userController.Get)
+		noAuthGroup.GET("/verify", userController.Verify)
+		noAuthGroup.POST("/password/forgot", userController.ForgotPassword)
+		noAuthGroup.POST("/password/reset", userController.ResetPassword)
 
 	}
 
 	return g
 }
 
+// customMethods routes `/<resource>:<method>` paths. Gin has no way to escape the colon,
+// so the method is matched as a path parameter and looked up here.
+func customMethods(methods map[string]gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if method, ok := strings.CutPrefix(c.Param("verb"), ":"); ok {
+			if handler, ok := methods[method]; ok {
+				handler(c)
+
+				return
+			}
+		}
+
+		response.WriteResponse(c, errors.WithCode(code.ErrPageNotFound, "Page not found."), nil)
+	}
+}
+
 func testController(g *gin.Engine) {
 
 	// if gin.Mode() != "debug" {
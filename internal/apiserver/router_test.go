@@ -0,0 +1,54 @@
+package apiserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/skeleton1231/gotal/internal/apiserver/store/model"
+	"github.com/skeleton1231/gotal/internal/pkg/code"
+	"github.com/skeleton1231/gotal/internal/pkg/errors"
+	"github.com/skeleton1231/gotal/internal/user_service/store/mock_store"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouter_GrantRoleRequiresAdmin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	viper.Set("jwt.key", "0123456789abcdef0123456789abcdef")
+	t.Cleanup(func() { viper.Set("jwt.key", nil) })
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserStore := mock_store.NewMockUserStore(ctrl)
+	mockRoleStore := mock_store.NewMockRoleStore(ctrl)
+	mockTwoFactorStore := mock_store.NewMockTwoFactorStore(ctrl)
+	mockStoreFactory := mock_store.NewMockFactory(ctrl)
+	mockStoreFactory.EXPECT().Users().Return(mockUserStore).AnyTimes()
+	mockStoreFactory.EXPECT().Roles().Return(mockRoleStore).AnyTimes()
+	mockStoreFactory.EXPECT().TwoFactors().Return(mockTwoFactorStore).AnyTimes()
+	mockUserStore.EXPECT().GetByUsername(gomock.Any(), "alice", gomock.Any()).Return(newTestUser(t), nil)
+	mockTwoFactorStore.EXPECT().Get(gomock.Any(), uint64(42)).
+		Return(nil, errors.WithCode(code.ErrTwoFactorNotFound, "not enrolled"))
+	mockRoleStore.EXPECT().ListUserRoles(gomock.Any(), uint64(42)).
+		Return(&model.RoleList{Items: []*model.Role{{Name: "viewer"}}}, nil)
+	// The grant itself must never be reached.
+	mockRoleStore.EXPECT().Grant(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	setStore(t, mockStoreFactory)
+
+	// Even a policy letting the user in does not let them grant themselves a role.
+	setAuthorizer(t, &fakeAuthorizer{allowed: true})
+
+	g := installController(gin.New())
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/roles/1/users/42", nil)
+	req.SetBasicAuth("alice", "secret")
+	w := httptest.NewRecorder()
+	g.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Equal(t, code.ErrPermissionDenied, responseCode(t, w))
+}
@@ -9,12 +9,13 @@ import (
 	"fmt"
 
 	"github.com/skeleton1231/gotal/internal/apiserver/config"
-
+	srvv1 "github.com/skeleton1231/gotal/internal/apiserver/service/v1"
 	"github.com/skeleton1231/gotal/internal/apiserver/store"
 	"github.com/skeleton1231/gotal/internal/apiserver/store/rpc_service"
 	"github.com/skeleton1231/gotal/internal/pkg/options"
 	"github.com/skeleton1231/gotal/internal/pkg/server"
 	"github.com/skeleton1231/gotal/pkg/cache"
+	"github.com/skeleton1231/gotal/pkg/log"
 	"github.com/skeleton1231/gotal/pkg/shutdown"
 	posix "github.com/skeleton1231/gotal/pkg/shutdown/managers"
 )
@@ -89,6 +90,9 @@ func (s *apiServer) PrepareRun() preparedAPIServer {
 		// s.gRPCAPIServer.Close()
 		s.httpAPIServer.Close()
 
+		// The password reset links are sent after their requests were answered.
+		srvv1.WaitResetMails()
+
 		return nil
 	}))
 
@@ -99,10 +103,10 @@ func (s preparedAPIServer) Run() error {
 	// // Start GRPC Server
 	// go s.gRPCAPIServer.Run()
 
-	// // start shutdown managers
-	// if err := s.gs.Start(); err != nil {
-	// 	log.Fatalf("start shutdown manager failed: %s", err.Error())
-	// }
+	// start shutdown managers
+	if err := s.gs.Start(); err != nil {
+		log.Fatalf("start shutdown manager failed: %s", err.Error())
+	}
 
 	// Start Http/Https Server
 	return s.httpAPIServer.Run()
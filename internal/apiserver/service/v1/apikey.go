@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/skeleton1231/gotal/internal/apiserver/store"
+	"github.com/skeleton1231/gotal/internal/apiserver/store/model"
+	"github.com/skeleton1231/gotal/internal/pkg/code"
+	"github.com/skeleton1231/gotal/internal/pkg/errors"
+	"github.com/skeleton1231/gotal/pkg/cache"
+	"github.com/skeleton1231/gotal/pkg/log"
+)
+
+// apiKeyCacheTTL bounds how long a revoked key stays usable on other replicas
+// when the cache entry could not be dropped.
+const apiKeyCacheTTL = 5 * time.Minute
+
+// APIKeySrv defines functions used to handle API key request.
+type APIKeySrv interface {
+	Create(ctx context.Context, username string, key *model.APIKey) error
+	List(ctx context.Context, username string) (*model.APIKeyList, error)
+	Revoke(ctx context.Context, username string, id uint64) error
+	Authenticate(ctx context.Context, token string) (*model.User, error)
+}
+
+// apiKeyIdentity is the cached result of a successful key lookup.
+type apiKeyIdentity struct {
+	UserID    uint64     `json:"userId"`
+	Username  string     `json:"username"`
+	Roles     []string   `json:"roles,omitempty"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+type apiKeyService struct {
+	store store.Factory
+	cache *cache.RedisClusterV2
+}
+
+var _ APIKeySrv = (*apiKeyService)(nil)
+
+func newAPIKeys(srv *service) *apiKeyService {
+	return &apiKeyService{
+		store: srv.store,
+		cache: &cache.RedisClusterV2{KeyPrefix: "gotal-apikey-"},
+	}
+}
+
+// Create implements APIKeySrv. The generated key is returned in key.Token and never stored.
+func (a *apiKeyService) Create(ctx context.Context, username string, key *model.APIKey) error {
+	user, err := a.store.Users().GetByUsername(ctx, username, model.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	token, err := cache.GenerateToken(strconv.FormatUint(user.ID, 10), "", cache.HashSha256)
+	if err != nil {
+		return errors.WithCode(code.ErrUnknown, err.Error())
+	}
+
+	key.UserID = user.ID
+	key.Hash = cache.HashKey(token)
+	key.CreatedAt = time.Now()
+	key.Token = token
+
+	return a.store.APIKeys().Create(ctx, key)
+}
+
+// List implements APIKeySrv.
+func (a *apiKeyService) List(ctx context.Context, username string) (*model.APIKeyList, error) {
+	user, err := a.store.Users().GetByUsername(ctx, username, model.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return a.store.APIKeys().List(ctx, user.ID)
+}
+
+// Revoke implements APIKeySrv.
+func (a *apiKeyService) Revoke(ctx context.Context, username string, id uint64) error {
+	user, err := a.store.Users().GetByUsername(ctx, username, model.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	key, err := a.store.APIKeys().Delete(ctx, user.ID, id)
+	if err != nil {
+		return err
+	}
+
+	a.cache.DeleteKey(ctx, key.Hash)
+
+	return nil
+}
+
+// Authenticate implements APIKeySrv. It returns the owner of the key with its granted roles.
+func (a *apiKeyService) Authenticate(ctx context.Context, token string) (*model.User, error) {
+	hash := cache.HashKey(token)
+
+	identity, err := a.cachedIdentity(ctx, hash)
+	if err != nil {
+		identity, err = a.lookupIdentity(ctx, hash)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if identity.ExpiresAt != nil && !time.Now().Before(*identity.ExpiresAt) {
+		return nil, errors.WithCode(code.ErrExpired, "api key expired")
+	}
+
+	user := &model.User{Roles: identity.Roles}
+	user.ID = identity.UserID
+	user.Name = identity.Username
+
+	return user, nil
+}
+
+func (a *apiKeyService) cachedIdentity(ctx context.Context, hash string) (*apiKeyIdentity, error) {
+	value, err := a.cache.GetKey(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	identity := &apiKeyIdentity{}
+	if err := json.Unmarshal([]byte(value), identity); err != nil {
+		return nil, err
+	}
+
+	return identity, nil
+}
+
+func (a *apiKeyService) lookupIdentity(ctx context.Context, hash string) (*apiKeyIdentity, error) {
+	key, err := a.store.APIKeys().GetByHash(ctx, hash)
+	if err != nil {
+		if errors.IsCode(err, code.ErrAPIKeyNotFound) {
+			return nil, errors.WithCode(code.ErrTokenInvalid, "invalid api key")
+		}
+
+		return nil, err
+	}
+
+	user, err := a.store.Users().Get(ctx, key.UserID, model.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	roles, err := a.store.Roles().ListUserRoles(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	identity := &apiKeyIdentity{
+		UserID:    user.ID,
+		Username:  user.Name,
+		Roles:     roles.RoleNames(),
+		ExpiresAt: key.ExpiresAt,
+	}
+
+	ttl := apiKeyCacheTTL
+	if key.ExpiresAt != nil && time.Until(*key.ExpiresAt) < ttl {
+		ttl = time.Until(*key.ExpiresAt)
+	}
+	if ttl > 0 {
+		if value, err := json.Marshal(identity); err == nil {
+			if err := a.cache.SetKey(ctx, hash, string(value), ttl); err != nil {
+				log.Debugf("cache api key lookup failed: %s", err.Error())
+			}
+		}
+	}
+
+	return identity, nil
+}
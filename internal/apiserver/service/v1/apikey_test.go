@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/skeleton1231/gotal/internal/apiserver/store/model"
+	"github.com/skeleton1231/gotal/internal/pkg/code"
+	pkgerrors "github.com/skeleton1231/gotal/internal/pkg/errors"
+	"github.com/skeleton1231/gotal/internal/user_service/store/mock_store"
+	"github.com/skeleton1231/gotal/pkg/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIKeyService_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserStore := mock_store.NewMockUserStore(ctrl)
+	mockAPIKeyStore := mock_store.NewMockAPIKeyStore(ctrl)
+	mockStoreFactory := mock_store.NewMockFactory(ctrl)
+
+	user := &model.User{Name: "alice"}
+	user.ID = 42
+	mockStoreFactory.EXPECT().Users().Return(mockUserStore)
+	mockStoreFactory.EXPECT().APIKeys().Return(mockAPIKeyStore)
+	mockUserStore.EXPECT().GetByUsername(gomock.Any(), "alice", gomock.Any()).Return(user, nil)
+	mockAPIKeyStore.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, key *model.APIKey) error {
+		assert.Equal(t, uint64(42), key.UserID)
+		assert.NotEqual(t, key.Token, key.Hash)
+
+		return nil
+	})
+
+	key := &model.APIKey{Name: "ci"}
+	err := NewService(mockStoreFactory).APIKeys().Create(context.Background(), "alice", key)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, key.Token)
+	assert.Equal(t, cache.HashKey(key.Token), key.Hash)
+	assert.Equal(t, cache.HashSha256, cache.TokenHashAlgo(key.Token))
+}
+
+func TestAPIKeyService_Authenticate(t *testing.T) {
+	token, _ := cache.GenerateToken("42", "", cache.HashSha256)
+	past := time.Now().Add(-time.Minute)
+
+	tests := []struct {
+		name     string
+		key      *model.APIKey
+		keyErr   error
+		wantCode int
+	}{
+		{
+			name: "valid key",
+			key:  &model.APIKey{ID: 1, UserID: 42, Hash: cache.HashKey(token)},
+		},
+		{
+			name:     "expired key",
+			key:      &model.APIKey{ID: 1, UserID: 42, Hash: cache.HashKey(token), ExpiresAt: &past},
+			wantCode: code.ErrExpired,
+		},
+		{
+			name:     "unknown key",
+			keyErr:   pkgerrors.WithCode(code.ErrAPIKeyNotFound, "record not found"),
+			wantCode: code.ErrTokenInvalid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUserStore := mock_store.NewMockUserStore(ctrl)
+			mockRoleStore := mock_store.NewMockRoleStore(ctrl)
+			mockAPIKeyStore := mock_store.NewMockAPIKeyStore(ctrl)
+			mockStoreFactory := mock_store.NewMockFactory(ctrl)
+
+			// Redis is not connected in tests, so every lookup goes to the store.
+			mockStoreFactory.EXPECT().APIKeys().Return(mockAPIKeyStore)
+			mockAPIKeyStore.EXPECT().GetByHash(gomock.Any(), cache.HashKey(token)).Return(tt.key, tt.keyErr)
+			if tt.keyErr == nil {
+				user := &model.User{Name: "alice"}
+				user.ID = 42
+				mockStoreFactory.EXPECT().Users().Return(mockUserStore)
+				mockStoreFactory.EXPECT().Roles().Return(mockRoleStore)
+				mockUserStore.EXPECT().Get(gomock.Any(), uint64(42), gomock.Any()).Return(user, nil)
+				mockRoleStore.EXPECT().ListUserRoles(gomock.Any(), uint64(42)).
+					Return(&model.RoleList{Items: []*model.Role{{Name: "admin"}}}, nil)
+			}
+
+			user, err := NewService(mockStoreFactory).APIKeys().Authenticate(context.Background(), token)
+			if tt.wantCode != 0 {
+				assert.True(t, pkgerrors.IsCode(err, tt.wantCode), err)
+
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "alice", user.Name)
+			assert.Equal(t, uint64(42), user.ID)
+			assert.Equal(t, []string{"admin"}, user.Roles)
+		})
+	}
+}
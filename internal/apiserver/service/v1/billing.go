@@ -0,0 +1,340 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/skeleton1231/gotal/internal/apiserver/billing"
+	"github.com/skeleton1231/gotal/internal/apiserver/store"
+	"github.com/skeleton1231/gotal/internal/apiserver/store/model"
+	"github.com/skeleton1231/gotal/internal/pkg/code"
+	"github.com/skeleton1231/gotal/internal/pkg/errors"
+	"github.com/skeleton1231/gotal/internal/pkg/options"
+	"github.com/skeleton1231/gotal/pkg/cache"
+	"github.com/skeleton1231/gotal/pkg/log"
+	"github.com/spf13/viper"
+)
+
+const (
+	// stripeEventLease is how long a replica holds an event it is processing. The event is
+	// processed again when Stripe redelivers it after the replica died.
+	stripeEventLease = 5 * time.Minute
+
+	stripeEventProcessing = "processing"
+	stripeEventProcessed  = "processed"
+)
+
+// BillingSrv defines functions used to keep the users in sync with their Stripe billing.
+type BillingSrv interface {
+	// HandleWebhook verifies the signature of a Stripe webhook request and processes its
+	// event, once whatever the number of deliveries.
+	HandleWebhook(ctx context.Context, payload []byte, signature string) error
+}
+
+// eventLog remembers the ids of the events being processed or already processed.
+type eventLog interface {
+	GetRawKey(ctx context.Context, keyName string) (string, error)
+	SetRawKeyIfNotExists(ctx context.Context, keyName, value string, timeout time.Duration) (bool, error)
+	SetRawKey(ctx context.Context, keyName, value string, timeout time.Duration) error
+	DeleteRawKey(ctx context.Context, keyName string) bool
+}
+
+type billingService struct {
+	store  store.Factory
+	client billing.Client
+	opts   *options.StripeOptions
+	events eventLog
+	prefix string
+}
+
+var _ BillingSrv = (*billingService)(nil)
+
+var (
+	stripeOpts     *options.StripeOptions
+	stripeOptsOnce sync.Once
+)
+
+// getStripeOptions reads the `stripe.*` configuration on first use.
+func getStripeOptions() *options.StripeOptions {
+	stripeOptsOnce.Do(func() {
+		stripeOpts = options.NewStripeOptions()
+		if err := viper.UnmarshalKey("stripe", stripeOpts); err != nil {
+			log.Errorf("read stripe options failed: %s", err.Error())
+		}
+	})
+
+	return stripeOpts
+}
+
+func newBilling(srv *service) *billingService {
+	opts := getStripeOptions()
+
+	return &billingService{
+		store:  srv.store,
+		client: billing.NewStripe(opts),
+		opts:   opts,
+		events: &cache.RedisClusterV2{},
+		prefix: "gotal-stripe-event-",
+	}
+}
+
+// HandleWebhook implements BillingSrv. An error makes Stripe deliver the event again later,
+// so events which can never be processed, such as those of unknown customers, are only logged.
+func (b *billingService) HandleWebhook(ctx context.Context, payload []byte, signature string) error {
+	if !b.opts.Enabled() {
+		return errors.WithCode(code.ErrPageNotFound, "stripe webhooks are not configured")
+	}
+
+	event, err := billing.ConstructEvent(payload, signature, b.opts.WebhookSecret, b.opts.Tolerance, time.Now())
+	if err != nil {
+		if errors.Is(err, billing.ErrInvalidSignature) || errors.Is(err, billing.ErrSignatureExpired) {
+			return errors.WithCode(code.ErrWebhookSignatureInvalid, err.Error())
+		}
+
+		return errors.WithCode(code.ErrBind, err.Error())
+	}
+
+	// Only one replica gets the event. Redeliveries are acknowledged once it is processed,
+	// and sent back while it is still being processed, in case the processing fails.
+	key := b.prefix + event.ID
+	claimed, err := b.events.SetRawKeyIfNotExists(ctx, key, stripeEventProcessing, stripeEventLease)
+	if err != nil {
+		return errors.WithCode(code.ErrDatabase, err.Error())
+	}
+	if !claimed {
+		return b.redelivered(ctx, key, event)
+	}
+
+	if err := b.process(ctx, event); err != nil {
+		// Let the next delivery try again.
+		b.events.DeleteRawKey(ctx, key)
+
+		return err
+	}
+
+	if err := b.events.SetRawKey(ctx, key, stripeEventProcessed, b.opts.EventTTL); err != nil {
+		log.Record(ctx).Warnf("remember stripe event %s failed: %s", event.ID, err.Error())
+	}
+	log.Record(ctx).Infof("processed stripe event %s of type %s", event.ID, event.Type)
+
+	return nil
+}
+
+// redelivered answers a delivery of an event claimed before.
+func (b *billingService) redelivered(ctx context.Context, key string, event *billing.Event) error {
+	state, err := b.events.GetRawKey(ctx, key)
+	if err != nil {
+		if errors.Is(err, cache.ErrKeyNotFound) {
+			// The processing failed in the meantime, the next delivery claims it again.
+			return errors.WithCode(code.ErrWebhookEventProcessing, "stripe event %s was released", event.ID)
+		}
+
+		return errors.WithCode(code.ErrDatabase, err.Error())
+	}
+
+	if state != stripeEventProcessed {
+		return errors.WithCode(code.ErrWebhookEventProcessing, "stripe event %s is being processed", event.ID)
+	}
+	log.Record(ctx).Infof("skip stripe event %s, already processed", event.ID)
+
+	return nil
+}
+
+func (b *billingService) process(ctx context.Context, event *billing.Event) error {
+	var err error
+	switch event.Type {
+	case billing.EventCheckoutSessionCompleted:
+		err = b.checkoutCompleted(ctx, event)
+	case billing.EventSubscriptionCreated, billing.EventSubscriptionUpdated, billing.EventSubscriptionDeleted:
+		err = b.subscriptionChanged(ctx, event)
+	case billing.EventPaymentMethodAttached:
+		err = b.paymentMethodAttached(ctx, event)
+	case billing.EventCustomerUpdated:
+		err = b.customerUpdated(ctx, event)
+	case billing.EventCustomerDeleted:
+		err = b.customerDeleted(ctx, event)
+	default:
+		log.Record(ctx).Debugf("ignore stripe event %s of type %s", event.ID, event.Type)
+	}
+
+	if errors.IsCode(err, code.ErrUserNotFound) {
+		log.Record(ctx).Warnf("ignore stripe event %s: %s", event.ID, err.Error())
+
+		return nil
+	}
+
+	return err
+}
+
+// checkoutCompleted links the user, the client reference of the checkout, to the customer.
+// The subscription is fetched, its events may have been delivered before the user was linked.
+func (b *billingService) checkoutCompleted(ctx context.Context, event *billing.Event) error {
+	session := &billing.CheckoutSession{}
+	if err := event.Object(session); err != nil {
+		return errors.WithCode(code.ErrBind, err.Error())
+	}
+
+	userId, err := strconv.ParseUint(session.ClientReferenceID, 10, 64)
+	if err != nil || session.Customer == "" {
+		return errors.WithCode(code.ErrUserNotFound, "checkout session %s has no user or customer", session.ID)
+	}
+
+	user, err := b.store.Users().Get(ctx, userId, model.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	user.StripeID = session.Customer
+	fields := []string{"stripeId"}
+	if session.Subscription != "" {
+		subscription, err := b.client.GetSubscription(ctx, session.Subscription)
+		if err != nil {
+			return errors.WithCode(code.ErrBillingProvider, err.Error())
+		}
+		if applyTrial(user, subscription) {
+			fields = append(fields, "trialEndsAt")
+		}
+	}
+
+	return b.store.Users().Patch(ctx, user, fields, model.PatchOptions{})
+}
+
+// subscriptionChanged applies the current state of the subscription. Stripe does not deliver
+// the events in order, so the subscription is fetched rather than taken from the event, which
+// may be older than the state already applied.
+func (b *billingService) subscriptionChanged(ctx context.Context, event *billing.Event) error {
+	changed := &billing.Subscription{}
+	if err := event.Object(changed); err != nil {
+		return errors.WithCode(code.ErrBind, err.Error())
+	}
+
+	user, err := b.userOfCustomer(ctx, changed.Customer)
+	if err != nil {
+		return err
+	}
+
+	subscription, err := b.client.GetSubscription(ctx, changed.ID)
+	if err != nil {
+		return errors.WithCode(code.ErrBillingProvider, err.Error())
+	}
+
+	if !applyTrial(user, subscription) {
+		return nil
+	}
+
+	return b.store.Users().Patch(ctx, user, []string{"trialEndsAt"}, model.PatchOptions{})
+}
+
+// paymentMethodAttached records the first payment method of a user. Later ones only replace
+// it once they become the default, which is told by customer.updated.
+func (b *billingService) paymentMethodAttached(ctx context.Context, event *billing.Event) error {
+	method := &billing.PaymentMethod{}
+	if err := event.Object(method); err != nil {
+		return errors.WithCode(code.ErrBind, err.Error())
+	}
+
+	user, err := b.userOfCustomer(ctx, method.Customer)
+	if err != nil {
+		return err
+	}
+
+	if user.PMType != "" {
+		return nil
+	}
+	applyPaymentMethod(user, method)
+
+	return b.store.Users().Patch(ctx, user, []string{"pmType", "pmLastFour"}, model.PatchOptions{})
+}
+
+func (b *billingService) customerUpdated(ctx context.Context, event *billing.Event) error {
+	customer := &billing.Customer{}
+	if err := event.Object(customer); err != nil {
+		return errors.WithCode(code.ErrBind, err.Error())
+	}
+
+	if customer.InvoiceSettings.DefaultPaymentMethod == "" {
+		return nil
+	}
+
+	user, err := b.userOfCustomer(ctx, customer.ID)
+	if err != nil {
+		return err
+	}
+
+	method, err := b.client.GetPaymentMethod(ctx, customer.InvoiceSettings.DefaultPaymentMethod)
+	if err != nil {
+		return errors.WithCode(code.ErrBillingProvider, err.Error())
+	}
+	applyPaymentMethod(user, method)
+
+	return b.store.Users().Patch(ctx, user, []string{"pmType", "pmLastFour"}, model.PatchOptions{})
+}
+
+func (b *billingService) customerDeleted(ctx context.Context, event *billing.Event) error {
+	customer := &billing.Customer{}
+	if err := event.Object(customer); err != nil {
+		return errors.WithCode(code.ErrBind, err.Error())
+	}
+
+	user, err := b.userOfCustomer(ctx, customer.ID)
+	if err != nil {
+		return err
+	}
+
+	user.StripeID = ""
+	user.PMType = ""
+	user.PMLastFour = ""
+
+	return b.store.Users().Patch(ctx, user, []string{"stripeId", "pmType", "pmLastFour"}, model.PatchOptions{})
+}
+
+// userOfCustomer returns the user linked to the Stripe customer.
+func (b *billingService) userOfCustomer(ctx context.Context, customer string) (*model.User, error) {
+	if customer == "" {
+		return nil, errors.WithCode(code.ErrUserNotFound, "no stripe customer")
+	}
+
+	limit := int64(1)
+	users, err := b.store.Users().List(ctx, model.ListOptions{
+		FieldSelector: "stripeId=" + customer,
+		Limit:         &limit,
+		SkipCount:     true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(users.Items) == 0 {
+		return nil, errors.WithCode(code.ErrUserNotFound, "no user with stripe customer %s", customer)
+	}
+
+	return users.Items[0], nil
+}
+
+// applyTrial sets the end of the trial of the subscription, and reports whether it has one.
+// A subscription ended during its trial ends the trial too.
+func applyTrial(user *model.User, subscription *billing.Subscription) bool {
+	if subscription.TrialEnd == 0 {
+		return false
+	}
+
+	end := subscription.TrialEnd
+	if subscription.EndedAt != 0 && subscription.EndedAt < end {
+		end = subscription.EndedAt
+	}
+	user.TrialEndsAt = time.Unix(end, 0).UTC()
+
+	return true
+}
+
+// applyPaymentMethod sets the payment method fields of the user, the brand and last digits
+// for a card and the type of the method otherwise.
+func applyPaymentMethod(user *model.User, method *billing.PaymentMethod) {
+	user.PMType = method.Type
+	user.PMLastFour = ""
+	if method.Card != nil {
+		user.PMType = method.Card.Brand
+		user.PMLastFour = method.Card.Last4
+	}
+}
@@ -0,0 +1,221 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/skeleton1231/gotal/internal/apiserver/billing"
+	"github.com/skeleton1231/gotal/internal/apiserver/billing/billingtest"
+	"github.com/skeleton1231/gotal/internal/apiserver/store/model"
+	"github.com/skeleton1231/gotal/internal/pkg/code"
+	pkgerrors "github.com/skeleton1231/gotal/internal/pkg/errors"
+	"github.com/skeleton1231/gotal/internal/user_service/store/mock_store"
+	"github.com/skeleton1231/gotal/pkg/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryEventLog keeps the event ids in memory and never expires them.
+type memoryEventLog map[string]string
+
+func (m memoryEventLog) GetRawKey(_ context.Context, keyName string) (string, error) {
+	value, ok := m[keyName]
+	if !ok {
+		return "", cache.ErrKeyNotFound
+	}
+
+	return value, nil
+}
+
+func (m memoryEventLog) SetRawKeyIfNotExists(_ context.Context, keyName, value string, _ time.Duration) (bool, error) {
+	if _, ok := m[keyName]; ok {
+		return false, nil
+	}
+	m[keyName] = value
+
+	return true, nil
+}
+
+func (m memoryEventLog) SetRawKey(_ context.Context, keyName, value string, _ time.Duration) error {
+	m[keyName] = value
+
+	return nil
+}
+
+func (m memoryEventLog) DeleteRawKey(_ context.Context, keyName string) bool {
+	_, ok := m[keyName]
+	delete(m, keyName)
+
+	return ok
+}
+
+func newTestBilling(t *testing.T) (*billingService, *billingtest.Server, *mock_store.MockUserStore) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockUserStore := mock_store.NewMockUserStore(ctrl)
+	mockStoreFactory := mock_store.NewMockFactory(ctrl)
+	mockStoreFactory.EXPECT().Users().Return(mockUserStore).AnyTimes()
+
+	srv := billingtest.NewServer()
+	t.Cleanup(srv.Close)
+	opts := srv.Options()
+
+	return &billingService{
+		store:  mockStoreFactory,
+		client: billing.NewStripe(opts),
+		opts:   opts,
+		events: memoryEventLog{},
+		prefix: "stripe-event-",
+	}, srv, mockUserStore
+}
+
+// deliver sends the event the way Stripe does.
+func deliver(b *billingService, payload []byte) error {
+	return b.HandleWebhook(context.Background(), payload, billing.SignatureHeader(payload, billingtest.WebhookSecret, time.Now()))
+}
+
+func TestBillingService_CheckoutCompleted(t *testing.T) {
+	b, srv, mockUserStore := newTestBilling(t)
+
+	trialEnd := time.Now().Add(14 * 24 * time.Hour).Unix()
+	srv.AddSubscription(&billing.Subscription{ID: "sub_1", Customer: "cus_1", Status: "trialing", TrialEnd: trialEnd})
+
+	user := &model.User{Name: "alice"}
+	user.ID = 42
+	mockUserStore.EXPECT().Get(gomock.Any(), uint64(42), gomock.Any()).Return(user, nil)
+	mockUserStore.EXPECT().Patch(gomock.Any(), user, []string{"stripeId", "trialEndsAt"}, gomock.Any()).Return(nil)
+
+	payload := billingtest.Event("evt_1", billing.EventCheckoutSessionCompleted, &billing.CheckoutSession{
+		ID:                "cs_1",
+		Customer:          "cus_1",
+		ClientReferenceID: "42",
+		Subscription:      "sub_1",
+	})
+	require.NoError(t, deliver(b, payload))
+	assert.Equal(t, "cus_1", user.StripeID)
+	assert.Equal(t, trialEnd, user.TrialEndsAt.Unix())
+
+	// Redeliveries are acknowledged without touching the user again.
+	require.NoError(t, deliver(b, payload))
+}
+
+func TestBillingService_RetryAfterFailure(t *testing.T) {
+	b, srv, mockUserStore := newTestBilling(t)
+
+	user := &model.User{Name: "alice", StripeID: "cus_1"}
+	list := &model.UserList{Items: []*model.User{user}}
+	mockUserStore.EXPECT().List(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, opts model.ListOptions) (*model.UserList, error) {
+			assert.Equal(t, "stripeId=cus_1", opts.FieldSelector)
+
+			return list, nil
+		}).Times(2)
+	failed := mockUserStore.EXPECT().Patch(gomock.Any(), user, []string{"trialEndsAt"}, gomock.Any()).
+		Return(pkgerrors.WithCode(code.ErrDatabase, "unavailable"))
+	mockUserStore.EXPECT().Patch(gomock.Any(), user, []string{"trialEndsAt"}, gomock.Any()).Return(nil).After(failed)
+
+	// The subscription was canceled during its trial.
+	endedAt := time.Now().Unix()
+	subscription := &billing.Subscription{
+		ID:       "sub_1",
+		Customer: "cus_1",
+		Status:   "canceled",
+		TrialEnd: endedAt + 3600,
+		EndedAt:  endedAt,
+	}
+	srv.AddSubscription(subscription)
+	payload := billingtest.Event("evt_2", billing.EventSubscriptionDeleted, subscription)
+	err := deliver(b, payload)
+	assert.True(t, pkgerrors.IsCode(err, code.ErrDatabase), err)
+
+	require.NoError(t, deliver(b, payload))
+	assert.Equal(t, endedAt, user.TrialEndsAt.Unix())
+}
+
+func TestBillingService_OutOfOrderEvents(t *testing.T) {
+	b, srv, mockUserStore := newTestBilling(t)
+
+	user := &model.User{Name: "alice", StripeID: "cus_1"}
+	mockUserStore.EXPECT().List(gomock.Any(), gomock.Any()).Return(&model.UserList{Items: []*model.User{user}}, nil).Times(2)
+	mockUserStore.EXPECT().Patch(gomock.Any(), user, []string{"trialEndsAt"}, gomock.Any()).Return(nil).Times(2)
+
+	// The trial was extended, and the event of the extension arrives before the one of the creation.
+	created := &billing.Subscription{ID: "sub_1", Customer: "cus_1", Status: "trialing", TrialEnd: time.Now().Add(7 * 24 * time.Hour).Unix()}
+	extended := *created
+	extended.TrialEnd = time.Now().Add(14 * 24 * time.Hour).Unix()
+	srv.AddSubscription(&extended)
+
+	require.NoError(t, deliver(b, billingtest.Event("evt_10", billing.EventSubscriptionUpdated, &extended)))
+	assert.Equal(t, extended.TrialEnd, user.TrialEndsAt.Unix())
+
+	require.NoError(t, deliver(b, billingtest.Event("evt_9", billing.EventSubscriptionCreated, created)))
+	assert.Equal(t, extended.TrialEnd, user.TrialEndsAt.Unix())
+}
+
+func TestBillingService_RedeliveryWhileProcessing(t *testing.T) {
+	b, _, _ := newTestBilling(t)
+	events := b.events.(memoryEventLog)
+
+	// Another replica holds the event, the delivery is sent back until it is processed.
+	payload := billingtest.Event("evt_8", billing.EventCustomerDeleted, &billing.Customer{ID: "cus_1"})
+	events["stripe-event-evt_8"] = stripeEventProcessing
+	err := deliver(b, payload)
+	assert.True(t, pkgerrors.IsCode(err, code.ErrWebhookEventProcessing), err)
+	assert.Equal(t, http.StatusConflict, pkgerrors.ParseCoder(err).HTTPStatus())
+
+	events["stripe-event-evt_8"] = stripeEventProcessed
+	assert.NoError(t, deliver(b, payload))
+}
+
+func TestBillingService_PaymentMethods(t *testing.T) {
+	b, srv, mockUserStore := newTestBilling(t)
+	srv.AddPaymentMethod("pm_2", "cus_1", "mastercard", "4444")
+
+	user := &model.User{Name: "alice", StripeID: "cus_1", PMType: "visa", PMLastFour: "4242"}
+	mockUserStore.EXPECT().List(gomock.Any(), gomock.Any()).Return(&model.UserList{Items: []*model.User{user}}, nil).Times(3)
+	mockUserStore.EXPECT().Patch(gomock.Any(), user, []string{"pmType", "pmLastFour"}, gomock.Any()).Return(nil)
+	mockUserStore.EXPECT().Patch(gomock.Any(), user, []string{"stripeId", "pmType", "pmLastFour"}, gomock.Any()).Return(nil)
+
+	// Attaching another card keeps the default one.
+	attached := &billing.PaymentMethod{ID: "pm_2", Customer: "cus_1", Type: "card", Card: &billing.Card{Brand: "mastercard", Last4: "4444"}}
+	require.NoError(t, deliver(b, billingtest.Event("evt_3", billing.EventPaymentMethodAttached, attached)))
+	assert.Equal(t, "visa", user.PMType)
+
+	customer := &billing.Customer{ID: "cus_1"}
+	customer.InvoiceSettings.DefaultPaymentMethod = "pm_2"
+	require.NoError(t, deliver(b, billingtest.Event("evt_4", billing.EventCustomerUpdated, customer)))
+	assert.Equal(t, "mastercard", user.PMType)
+	assert.Equal(t, "4444", user.PMLastFour)
+
+	require.NoError(t, deliver(b, billingtest.Event("evt_5", billing.EventCustomerDeleted, &billing.Customer{ID: "cus_1", Deleted: true})))
+	assert.Empty(t, user.StripeID)
+	assert.Empty(t, user.PMType)
+}
+
+func TestBillingService_UnknownCustomer(t *testing.T) {
+	b, _, mockUserStore := newTestBilling(t)
+	mockUserStore.EXPECT().List(gomock.Any(), gomock.Any()).Return(&model.UserList{}, nil)
+
+	// Retrying would not help, so the event is acknowledged.
+	payload := billingtest.Event("evt_6", billing.EventSubscriptionCreated, &billing.Subscription{ID: "sub_9", Customer: "cus_9", TrialEnd: 1700000000})
+	assert.NoError(t, deliver(b, payload))
+}
+
+func TestBillingService_Signature(t *testing.T) {
+	b, _, _ := newTestBilling(t)
+	payload := billingtest.Event("evt_7", billing.EventCustomerDeleted, &billing.Customer{ID: "cus_1"})
+
+	err := b.HandleWebhook(context.Background(), payload, billing.SignatureHeader(payload, "whsec_other", time.Now()))
+	assert.True(t, pkgerrors.IsCode(err, code.ErrWebhookSignatureInvalid), err)
+
+	err = b.HandleWebhook(context.Background(), payload, billing.SignatureHeader(payload, billingtest.WebhookSecret, time.Now().Add(-time.Hour)))
+	assert.True(t, pkgerrors.IsCode(err, code.ErrWebhookSignatureInvalid), err)
+
+	b.opts.WebhookSecret = ""
+	err = deliver(b, payload)
+	assert.True(t, pkgerrors.IsCode(err, code.ErrPageNotFound), err)
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/skeleton1231/gotal/internal/apiserver/oauth"
+	"github.com/skeleton1231/gotal/internal/apiserver/store/model"
+	"github.com/skeleton1231/gotal/internal/pkg/code"
+	"github.com/skeleton1231/gotal/internal/pkg/errors"
+	"github.com/skeleton1231/gotal/pkg/log"
+	"github.com/skeleton1231/gotal/pkg/util/common"
+)
+
+// LoginWithDiscord implements UserSrv. It returns the user linked to the Discord account.
+// When username is set the account is linked to that user, otherwise an unknown account
+// signs up a new user.
+func (u *userService) LoginWithDiscord(ctx context.Context, account *oauth.DiscordUser, username string) (*model.User, error) {
+	user, err := u.store.Users().GetByDiscordID(ctx, account.ID, model.GetOptions{})
+	switch {
+	case err == nil:
+		if username != "" && user.Name != username {
+			return nil, errors.WithCode(code.ErrOAuthAccountLinked, "discord account is linked to another user")
+		}
+
+		return user, nil
+	case !errors.IsCode(err, code.ErrUserNotFound):
+		return nil, err
+	}
+
+	if username != "" {
+		return u.linkDiscord(ctx, account, username)
+	}
+
+	return u.signUpWithDiscord(ctx, account)
+}
+
+func (u *userService) linkDiscord(ctx context.Context, account *oauth.DiscordUser, username string) (*model.User, error) {
+	user, err := u.store.Users().GetByUsername(ctx, username, model.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	user.DiscordID = account.ID
+	if err := u.Update(ctx, user, model.UpdateOptions{}); err != nil {
+		return nil, discordLinked(err)
+	}
+	log.Record(ctx).Infof("user `%s` linked discord account %d", user.Name, account.ID)
+
+	return user, nil
+}
+
+func (u *userService) signUpWithDiscord(ctx context.Context, account *oauth.DiscordUser) (*model.User, error) {
+	// The user logs in through Discord only, the password is never handed out.
+	secret, err := oauth.NewVerifier()
+	if err != nil {
+		return nil, errors.WithCode(code.ErrUnknown, err.Error())
+	}
+	password, err := common.Encrypt(secret)
+	if err != nil {
+		return nil, errors.WithCode(code.ErrEncrypt, err.Error())
+	}
+
+	name := account.Username
+	if name == "" || u.nameTaken(ctx, name) {
+		name = account.Username + "_" + strconv.FormatUint(account.ID, 10)
+	}
+
+	now := time.Now()
+	user := &model.User{
+		ObjectMeta: model.ObjectMeta{Status: 1},
+		Name:       name,
+		Email:      account.Email,
+		Password:   password,
+		DiscordID:  account.ID,
+		// Same trial as a user signing up with a password.
+		TrialEndsAt: now.AddDate(0, 1, 0),
+	}
+	if account.Verified {
+		user.EmailVerifiedAt = now
+	}
+
+	if err := u.Create(ctx, user, model.CreateOptions{}); err != nil {
+		return nil, discordLinked(err)
+	}
+	log.Record(ctx).Infof("user `%s` signed up with discord account %d", user.Name, account.ID)
+
+	return user, nil
+}
+
+func (u *userService) nameTaken(ctx context.Context, name string) bool {
+	_, err := u.store.Users().GetByUsername(ctx, name, model.GetOptions{})
+
+	return err == nil
+}
+
+// discordLinked reports a Discord account linked to another user meanwhile, which idx_discord_id
+// rejects, as ErrOAuthAccountLinked.
+func discordLinked(err error) error {
+	if match, _ := regexp.MatchString("Duplicate entry '.*' for key '.*idx_discord_id'", errors.Message(err)); match {
+		return errors.WithCode(code.ErrOAuthAccountLinked, "discord account is linked to another user")
+	}
+
+	return err
+}
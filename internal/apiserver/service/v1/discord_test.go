@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/skeleton1231/gotal/internal/apiserver/oauth"
+	"github.com/skeleton1231/gotal/internal/apiserver/store/model"
+	"github.com/skeleton1231/gotal/internal/pkg/code"
+	"github.com/skeleton1231/gotal/internal/pkg/errors"
+	"github.com/skeleton1231/gotal/internal/user_service/store/mock_store"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserService_LoginWithDiscord(t *testing.T) {
+	account := &oauth.DiscordUser{ID: 42, Username: "nelly", Email: "nelly@example.com", Verified: true}
+	notFound := errors.WithCode(code.ErrUserNotFound, "record not found")
+
+	tests := []struct {
+		name     string
+		username string
+		expect   func(users *mock_store.MockUserStore)
+		wantName string
+		wantCode int
+	}{
+		{
+			name: "linked account",
+			expect: func(users *mock_store.MockUserStore) {
+				users.EXPECT().GetByDiscordID(gomock.Any(), uint64(42), gomock.Any()).
+					Return(&model.User{Name: "nelly", DiscordID: 42}, nil)
+			},
+			wantName: "nelly",
+		},
+		{
+			name:     "linked to another user",
+			username: "alice",
+			expect: func(users *mock_store.MockUserStore) {
+				users.EXPECT().GetByDiscordID(gomock.Any(), uint64(42), gomock.Any()).
+					Return(&model.User{Name: "nelly", DiscordID: 42}, nil)
+			},
+			wantCode: code.ErrOAuthAccountLinked,
+		},
+		{
+			name:     "link signed in user",
+			username: "alice",
+			expect: func(users *mock_store.MockUserStore) {
+				users.EXPECT().GetByDiscordID(gomock.Any(), uint64(42), gomock.Any()).Return(nil, notFound)
+				users.EXPECT().GetByUsername(gomock.Any(), "alice", gomock.Any()).
+					Return(&model.User{ObjectMeta: model.ObjectMeta{ID: 7}, Name: "alice"}, nil)
+				users.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, u *model.User, _ model.UpdateOptions) error {
+						assert.Equal(t, uint64(42), u.DiscordID)
+
+						return nil
+					})
+			},
+			wantName: "alice",
+		},
+		{
+			name: "sign up",
+			expect: func(users *mock_store.MockUserStore) {
+				users.EXPECT().GetByDiscordID(gomock.Any(), uint64(42), gomock.Any()).Return(nil, notFound)
+				users.EXPECT().GetByUsername(gomock.Any(), "nelly", gomock.Any()).Return(nil, notFound)
+				users.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, u *model.User, _ model.CreateOptions) error {
+						assert.Equal(t, uint64(42), u.DiscordID)
+						assert.Equal(t, "nelly@example.com", u.Email)
+						assert.False(t, u.EmailVerifiedAt.IsZero())
+						assert.NotEmpty(t, u.Password)
+
+						return nil
+					})
+			},
+			wantName: "nelly",
+		},
+		{
+			name: "sign up with taken name",
+			expect: func(users *mock_store.MockUserStore) {
+				users.EXPECT().GetByDiscordID(gomock.Any(), uint64(42), gomock.Any()).Return(nil, notFound)
+				users.EXPECT().GetByUsername(gomock.Any(), "nelly", gomock.Any()).Return(&model.User{Name: "nelly"}, nil)
+				users.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+			wantName: "nelly_42",
+		},
+		{
+			name: "linked concurrently",
+			expect: func(users *mock_store.MockUserStore) {
+				users.EXPECT().GetByDiscordID(gomock.Any(), uint64(42), gomock.Any()).Return(nil, notFound)
+				users.EXPECT().GetByUsername(gomock.Any(), "nelly", gomock.Any()).Return(nil, notFound)
+				users.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(errors.New("Error 1062 (23000): Duplicate entry '42' for key 'users.idx_discord_id'"))
+			},
+			wantCode: code.ErrOAuthAccountLinked,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUserStore := mock_store.NewMockUserStore(ctrl)
+			mockStoreFactory := mock_store.NewMockFactory(ctrl)
+			mockStoreFactory.EXPECT().Users().Return(mockUserStore).AnyTimes()
+			tt.expect(mockUserStore)
+
+			user, err := NewService(mockStoreFactory).Users().LoginWithDiscord(context.Background(), account, tt.username)
+			if tt.wantCode != 0 {
+				assert.True(t, errors.IsCode(err, tt.wantCode))
+
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantName, user.Name)
+		})
+	}
+}
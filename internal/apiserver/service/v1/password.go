@@ -0,0 +1,239 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/skeleton1231/gotal/internal/apiserver/store"
+	"github.com/skeleton1231/gotal/internal/apiserver/store/model"
+	"github.com/skeleton1231/gotal/internal/pkg/code"
+	"github.com/skeleton1231/gotal/internal/pkg/errors"
+	"github.com/skeleton1231/gotal/internal/pkg/options"
+	"github.com/skeleton1231/gotal/pkg/cache"
+	"github.com/skeleton1231/gotal/pkg/log"
+	"github.com/skeleton1231/gotal/pkg/mail"
+	"github.com/spf13/viper"
+)
+
+// PasswordSrv defines functions used to reset forgotten passwords.
+type PasswordSrv interface {
+	Forgot(ctx context.Context, email, clientIP string) error
+	Reset(ctx context.Context, token, password, clientIP string) error
+}
+
+// rateCounter counts the requests of a key within a window of expire seconds.
+type rateCounter interface {
+	IncrememntWithExpire(ctx context.Context, keyName string, expire int64) int64
+}
+
+// resetMailTimeout bounds issuing and sending a reset link in the background.
+const resetMailTimeout = time.Minute
+
+type passwordService struct {
+	users   *userService
+	store   store.Factory
+	mailer  mail.Mailer
+	from    string
+	opts    *options.PasswordResetOptions
+	counter rateCounter
+	prefix  string
+}
+
+var _ PasswordSrv = (*passwordService)(nil)
+
+// resetMails tracks the reset links being sent in the background. A password service is
+// created per request, so they are tracked for the whole process.
+var resetMails sync.WaitGroup
+
+// WaitResetMails waits for the reset links being sent in the background. Each of them takes
+// resetMailTimeout at most. Call it once the server accepts no more requests.
+func WaitResetMails() {
+	resetMails.Wait()
+}
+
+var (
+	resetOpts     *options.PasswordResetOptions
+	resetOptsOnce sync.Once
+)
+
+// getPasswordResetOptions reads the `password-reset.*` configuration on first use.
+func getPasswordResetOptions() *options.PasswordResetOptions {
+	resetOptsOnce.Do(func() {
+		resetOpts = options.NewPasswordResetOptions()
+		if err := viper.UnmarshalKey("password-reset", resetOpts); err != nil {
+			log.Errorf("read password-reset options failed: %s", err.Error())
+		}
+	})
+
+	return resetOpts
+}
+
+func newPasswords(srv *service) *passwordService {
+	mailer, mailOpts, _ := getMailer()
+	prefix := "gotal-password-reset-"
+
+	return &passwordService{
+		users:   newUsers(srv),
+		store:   srv.store,
+		mailer:  mailer,
+		from:    mailOpts.From,
+		opts:    getPasswordResetOptions(),
+		counter: &cache.RedisClusterV2{KeyPrefix: prefix},
+		prefix:  prefix,
+	}
+}
+
+// Forgot implements PasswordSrv. It emails a reset link when the address belongs to a user.
+// Callers answer the same whatever the outcome, so that nobody learns which addresses have
+// an account; the error is only meant to be logged. The link itself is sent in the background.
+func (p *passwordService) Forgot(ctx context.Context, email, clientIP string) error {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if p.limited(ctx, "ip-"+clientIP, p.opts.MaxPerIP) || p.limited(ctx, "email-"+email, p.opts.MaxPerEmail) {
+		return errors.WithCode(code.ErrPasswordResetLimited, "too many password reset requests from %s", clientIP)
+	}
+
+	user, err := p.store.Users().GetByEmail(ctx, email, model.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	if p.mailer == nil {
+		return errors.WithCode(code.ErrSendMail, "mail is not configured")
+	}
+
+	// The link is issued and sent in the background, so that the request takes about as long
+	// as one for an unknown address. The request context ends with the response.
+	resetMails.Add(1)
+	go func() {
+		defer resetMails.Done()
+
+		ctx, cancel := context.WithTimeout(context.Background(), resetMailTimeout)
+		defer cancel()
+
+		if err := p.sendResetLink(ctx, user); err != nil {
+			log.Errorf("send password reset email to user %d failed: %s", user.ID, err.Error())
+		}
+	}()
+
+	return nil
+}
+
+// sendResetLink stores a new reset token of the user and emails the link.
+func (p *passwordService) sendResetLink(ctx context.Context, user *model.User) error {
+	token, err := newResetToken(user.ID)
+	if err != nil {
+		return errors.WithCode(code.ErrUnknown, err.Error())
+	}
+
+	// Only the hash is stored, the token itself is only known to the mailbox. A new token
+	// replaces the previous one.
+	user.RememberToken = hashResetToken(token, time.Now().Add(p.opts.TokenTTL))
+	if err := p.store.Users().Patch(ctx, user, []string{"rememberToken"}, model.PatchOptions{}); err != nil {
+		return err
+	}
+
+	msg := &mail.Message{
+		From:    p.from,
+		To:      []string{user.Email},
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Hello %s,\n\nOpen the link below to choose a new password:\n\n%s\n\n"+
+			"The link expires in %s and works once. If you did not ask for it, ignore this email.\n",
+			user.Name, p.opts.URL+"?token="+token, p.opts.TokenTTL),
+	}
+	if err := p.mailer.Send(ctx, msg); err != nil {
+		return errors.WithCode(code.ErrSendMail, err.Error())
+	}
+	log.Infof("sent password reset email to user %d", user.ID)
+
+	return nil
+}
+
+// Reset implements PasswordSrv. The token is consumed by the password change, and the
+// sessions of the user are revoked.
+func (p *passwordService) Reset(ctx context.Context, token, password, clientIP string) error {
+	if p.limited(ctx, "ip-"+clientIP, p.opts.MaxPerIP) {
+		return errors.WithCode(code.ErrPasswordResetLimited, "too many password reset requests from %s", clientIP)
+	}
+
+	invalid := errors.WithCode(code.ErrPasswordResetTokenInvalid, "password reset token is invalid or has expired")
+
+	id, _, ok := strings.Cut(token, ".")
+	userId, err := strconv.ParseUint(id, 10, 64)
+	if !ok || err != nil {
+		return invalid
+	}
+
+	user, err := p.store.Users().Get(ctx, userId, model.GetOptions{})
+	if err != nil {
+		if errors.IsCode(err, code.ErrUserNotFound) {
+			return invalid
+		}
+
+		return err
+	}
+
+	if !resetTokenMatches(user.RememberToken, token, time.Now()) {
+		return invalid
+	}
+
+	// The user service only changes the password while the stored hash is unchanged,
+	// so concurrent requests with the same token succeed once.
+	if err := p.users.ChangePassword(ctx, user, password, model.ChangePasswordOptions{RememberToken: user.RememberToken}); err != nil {
+		return err
+	}
+	log.Record(ctx).Infof("user %d reset the password", user.ID)
+
+	return nil
+}
+
+// limited counts a request of the key and reports whether it goes over max in the window.
+func (p *passwordService) limited(ctx context.Context, key string, max int) bool {
+	count := p.counter.IncrememntWithExpire(ctx, p.prefix+key, int64(p.opts.Window/time.Second))
+
+	return count > int64(max)
+}
+
+// newResetToken returns a random token prefixed with the user identifier, which finds the
+// user without an index on the tokens.
+func newResetToken(userId uint64) (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return strconv.FormatUint(userId, 10) + "." + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashResetToken returns the remember token stored for a reset token: its hash and expiry.
+func hashResetToken(token string, expiresAt time.Time) string {
+	sum := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(sum[:]) + "." + strconv.FormatInt(expiresAt.Unix(), 10)
+}
+
+// resetTokenMatches reports whether the stored remember token was issued for the token
+// and has not expired yet.
+func resetTokenMatches(stored, token string, now time.Time) bool {
+	hash, expiry, ok := strings.Cut(stored, ".")
+	if !ok {
+		return false
+	}
+
+	expiresAt, err := strconv.ParseInt(expiry, 10, 64)
+	if err != nil || now.Unix() >= expiresAt {
+		return false
+	}
+
+	sum := sha256.Sum256([]byte(token))
+
+	return subtle.ConstantTimeCompare([]byte(hash), []byte(hex.EncodeToString(sum[:]))) == 1
+}
@@ -0,0 +1,225 @@
+package service
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/skeleton1231/gotal/internal/apiserver/store/model"
+	"github.com/skeleton1231/gotal/internal/pkg/code"
+	pkgerrors "github.com/skeleton1231/gotal/internal/pkg/errors"
+	"github.com/skeleton1231/gotal/internal/pkg/options"
+	"github.com/skeleton1231/gotal/internal/user_service/store/mock_store"
+	"github.com/skeleton1231/gotal/pkg/mail"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryCounter counts in memory and never expires the counts.
+type memoryCounter map[string]int64
+
+func (m memoryCounter) IncrememntWithExpire(_ context.Context, keyName string, _ int64) int64 {
+	m[keyName]++
+
+	return m[keyName]
+}
+
+// fakeDenylist records the users whose tokens were revoked.
+type fakeDenylist struct {
+	users []string
+}
+
+func (f *fakeDenylist) Revoke(context.Context, string, time.Time) error { return nil }
+
+func (f *fakeDenylist) RevokeUser(_ context.Context, username string, _ time.Time) error {
+	f.users = append(f.users, username)
+
+	return nil
+}
+
+func (f *fakeDenylist) IsRevoked(context.Context, string, string, time.Time) (bool, error) {
+	return false, nil
+}
+
+func newTestPasswords(t *testing.T, factory *mock_store.MockFactory) (*passwordService, *mail.Outbox, *fakeDenylist) {
+	outbox, err := mail.NewOutbox(t.TempDir())
+	require.NoError(t, err)
+
+	denylist := &fakeDenylist{}
+
+	return &passwordService{
+		users:   &userService{store: factory, denylist: denylist},
+		store:   factory,
+		mailer:  outbox,
+		from:    "no-reply@example.com",
+		opts:    options.NewPasswordResetOptions(),
+		counter: memoryCounter{},
+	}, outbox, denylist
+}
+
+func TestPasswordService_ForgotAndReset(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserStore := mock_store.NewMockUserStore(ctrl)
+	mockSessionStore := mock_store.NewMockSessionStore(ctrl)
+	mockStoreFactory := mock_store.NewMockFactory(ctrl)
+	mockStoreFactory.EXPECT().Users().Return(mockUserStore).AnyTimes()
+	mockStoreFactory.EXPECT().Sessions().Return(mockSessionStore).AnyTimes()
+
+	user := &model.User{Name: "alice", Email: "alice@example.com"}
+	user.ID = 42
+	mockUserStore.EXPECT().GetByEmail(gomock.Any(), "alice@example.com", gomock.Any()).Return(user, nil)
+	mockUserStore.EXPECT().Patch(gomock.Any(), user, []string{"rememberToken"}, gomock.Any()).Return(nil)
+	mockUserStore.EXPECT().Get(gomock.Any(), uint64(42), gomock.Any()).Return(user, nil).Times(2)
+	changed := mockUserStore.EXPECT().ChangePassword(gomock.Any(), uint64(42), "n3w-Secret", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ uint64, _ string, opts model.ChangePasswordOptions) error {
+			// The stored token is the hash, and the change consumes it.
+			assert.Equal(t, user.RememberToken, opts.RememberToken)
+			user.RememberToken = ""
+
+			return nil
+		})
+	mockSessionStore.EXPECT().RevokeAll(gomock.Any(), uint64(42)).Return(&model.SessionList{}, nil).After(changed)
+
+	p, outbox, denylist := newTestPasswords(t, mockStoreFactory)
+	require.NoError(t, p.Forgot(context.Background(), " Alice@example.com", "10.0.0.1"))
+	WaitResetMails()
+
+	messages, err := outbox.Messages()
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+
+	var token string
+	for _, field := range strings.Fields(messages[0].Body) {
+		if u, err := url.Parse(field); err == nil && u.Query().Has("token") {
+			token = u.Query().Get("token")
+		}
+	}
+	require.NotEmpty(t, token)
+	assert.NotContains(t, user.RememberToken, token)
+
+	require.NoError(t, p.Reset(context.Background(), token, "n3w-Secret", "10.0.0.1"))
+	assert.Equal(t, []string{"alice"}, denylist.users)
+
+	// The token works once.
+	err = p.Reset(context.Background(), token, "n3w-Secret", "10.0.0.1")
+	assert.True(t, pkgerrors.IsCode(err, code.ErrPasswordResetTokenInvalid), err)
+}
+
+// blockingMailer holds every message until released.
+type blockingMailer struct {
+	release chan struct{}
+	sent    chan *mail.Message
+}
+
+func (b *blockingMailer) Send(ctx context.Context, msg *mail.Message) error {
+	<-b.release
+	b.sent <- msg
+
+	return nil
+}
+
+func TestPasswordService_ForgotSendsInBackground(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserStore := mock_store.NewMockUserStore(ctrl)
+	mockStoreFactory := mock_store.NewMockFactory(ctrl)
+	mockStoreFactory.EXPECT().Users().Return(mockUserStore).AnyTimes()
+
+	user := &model.User{Name: "alice", Email: "alice@example.com"}
+	user.ID = 42
+	mockUserStore.EXPECT().GetByEmail(gomock.Any(), "alice@example.com", gomock.Any()).Return(user, nil)
+	mockUserStore.EXPECT().Patch(gomock.Any(), user, []string{"rememberToken"}, gomock.Any()).Return(nil)
+
+	p, _, _ := newTestPasswords(t, mockStoreFactory)
+	mailer := &blockingMailer{release: make(chan struct{}), sent: make(chan *mail.Message, 1)}
+	p.mailer = mailer
+
+	// The request does not wait for the mail server, and the mail outlives the request context.
+	ctx, cancel := context.WithCancel(context.Background())
+	require.NoError(t, p.Forgot(ctx, "alice@example.com", "10.0.0.1"))
+	cancel()
+
+	// Shutdown waits for the mail, whichever service sent it.
+	waited := make(chan struct{})
+	go func() {
+		WaitResetMails()
+		close(waited)
+	}()
+	select {
+	case <-waited:
+		t.Fatal("WaitResetMails returned before the mail was sent")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(mailer.release)
+	<-waited
+	msg := <-mailer.sent
+	assert.Equal(t, []string{"alice@example.com"}, msg.To)
+}
+
+func TestPasswordService_Reset(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name   string
+		token  string
+		stored string
+	}{
+		{name: "expired", token: "42.abc", stored: hashResetToken("42.abc", now.Add(-time.Minute))},
+		{name: "other token", token: "42.abc", stored: hashResetToken("42.xyz", now.Add(time.Hour))},
+		{name: "no token issued", token: "42.abc", stored: ""},
+		{name: "malformed", token: "abc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUserStore := mock_store.NewMockUserStore(ctrl)
+			mockStoreFactory := mock_store.NewMockFactory(ctrl)
+			mockStoreFactory.EXPECT().Users().Return(mockUserStore).AnyTimes()
+			if strings.Contains(tt.token, ".") {
+				user := &model.User{Name: "alice", RememberToken: tt.stored}
+				mockUserStore.EXPECT().Get(gomock.Any(), uint64(42), gomock.Any()).Return(user, nil)
+			}
+
+			p, _, _ := newTestPasswords(t, mockStoreFactory)
+			err := p.Reset(context.Background(), tt.token, "n3w-Secret", "10.0.0.1")
+			assert.True(t, pkgerrors.IsCode(err, code.ErrPasswordResetTokenInvalid), err)
+		})
+	}
+}
+
+func TestPasswordService_RateLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserStore := mock_store.NewMockUserStore(ctrl)
+	mockStoreFactory := mock_store.NewMockFactory(ctrl)
+	mockStoreFactory.EXPECT().Users().Return(mockUserStore).AnyTimes()
+	mockUserStore.EXPECT().GetByEmail(gomock.Any(), "bob@example.com", gomock.Any()).
+		Return(nil, pkgerrors.WithCode(code.ErrUserNotFound, "not found")).Times(3)
+
+	p, _, _ := newTestPasswords(t, mockStoreFactory)
+	p.opts.MaxPerEmail = 3
+
+	// Unknown addresses count as well, so the limit tells nothing about them.
+	for i := 0; i < 3; i++ {
+		err := p.Forgot(context.Background(), "bob@example.com", "10.0.0.1")
+		assert.True(t, pkgerrors.IsCode(err, code.ErrUserNotFound), err)
+	}
+	err := p.Forgot(context.Background(), "bob@example.com", "10.0.0.2")
+	assert.True(t, pkgerrors.IsCode(err, code.ErrPasswordResetLimited), err)
+
+	// Requests and resets share the budget of the client address.
+	p.opts.MaxPerIP = 3
+	err = p.Reset(context.Background(), "42.abc", "n3w-Secret", "10.0.0.1")
+	assert.True(t, pkgerrors.IsCode(err, code.ErrPasswordResetLimited), err)
+}
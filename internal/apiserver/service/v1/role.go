@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/skeleton1231/gotal/internal/apiserver/store"
+	"github.com/skeleton1231/gotal/internal/apiserver/store/model"
+	"github.com/skeleton1231/gotal/internal/pkg/code"
+	"github.com/skeleton1231/gotal/internal/pkg/errors"
+	"github.com/skeleton1231/gotal/pkg/log"
+)
+
+// RoleSrv defines functions used to handle role request.
+type RoleSrv interface {
+	Create(ctx context.Context, role *model.Role, opts model.CreateOptions) error
+	Get(ctx context.Context, roleId uint64, opts model.GetOptions) (*model.Role, error)
+	List(ctx context.Context, opts model.ListOptions) (*model.RoleList, error)
+	Delete(ctx context.Context, roleId uint64, opts model.DeleteOptions) error
+	Grant(ctx context.Context, userId uint64, roleId uint64) error
+	Revoke(ctx context.Context, userId uint64, roleId uint64) error
+	ListUserRoles(ctx context.Context, userId uint64) (*model.RoleList, error)
+}
+
+type roleService struct {
+	store store.Factory
+}
+
+var _ RoleSrv = (*roleService)(nil)
+
+func newRoles(srv *service) *roleService {
+	return &roleService{store: srv.store}
+}
+
+// Create implements RoleSrv.
+func (r *roleService) Create(ctx context.Context, role *model.Role, opts model.CreateOptions) error {
+	if err := r.store.Roles().Create(ctx, role, opts); err != nil {
+		if match, _ := regexp.MatchString("Duplicate entry '.*' for key '.*idx_role_name'", errors.Message(err)); match {
+			return errors.WithCode(code.ErrRoleAlreadyExist, err.Error())
+		}
+
+		return errors.WithCode(code.ErrDatabase, err.Error())
+	}
+
+	return nil
+}
+
+// Get implements RoleSrv.
+func (r *roleService) Get(ctx context.Context, roleId uint64, opts model.GetOptions) (*model.Role, error) {
+	return r.store.Roles().Get(ctx, roleId, opts)
+}
+
+// List implements RoleSrv.
+func (r *roleService) List(ctx context.Context, opts model.ListOptions) (*model.RoleList, error) {
+	roles, err := r.store.Roles().List(ctx, opts)
+	if err != nil {
+		log.Record(ctx).Errorf("list roles from storage failed: %s", err.Error())
+
+		return nil, err
+	}
+
+	return roles, nil
+}
+
+// Delete implements RoleSrv.
+func (r *roleService) Delete(ctx context.Context, roleId uint64, opts model.DeleteOptions) error {
+	return r.store.Roles().Delete(ctx, roleId, opts)
+}
+
+// Grant implements RoleSrv.
+func (r *roleService) Grant(ctx context.Context, userId uint64, roleId uint64) error {
+	return r.store.Roles().Grant(ctx, userId, roleId)
+}
+
+// Revoke implements RoleSrv.
+func (r *roleService) Revoke(ctx context.Context, userId uint64, roleId uint64) error {
+	return r.store.Roles().Revoke(ctx, userId, roleId)
+}
+
+// ListUserRoles implements RoleSrv.
+func (r *roleService) ListUserRoles(ctx context.Context, userId uint64) (*model.RoleList, error) {
+	return r.store.Roles().ListUserRoles(ctx, userId)
+}
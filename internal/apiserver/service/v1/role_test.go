@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/skeleton1231/gotal/internal/apiserver/store/model"
+	"github.com/skeleton1231/gotal/internal/pkg/code"
+	pkgerrors "github.com/skeleton1231/gotal/internal/pkg/errors"
+	"github.com/skeleton1231/gotal/internal/user_service/store/mock_store"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoleService_Create(t *testing.T) {
+	tests := []struct {
+		name     string
+		storeErr error
+		wantCode int
+	}{
+		{name: "created"},
+		{
+			name:     "duplicate name",
+			storeErr: errors.New("Error 1062 (23000): Duplicate entry 'admin' for key 'roles.idx_role_name'"),
+			wantCode: code.ErrRoleAlreadyExist,
+		},
+		{
+			name:     "duplicate name rebuilt from grpc status",
+			storeErr: pkgerrors.WithCode(1, "Error 1062 (23000): Duplicate entry 'admin' for key 'roles.idx_role_name'"),
+			wantCode: code.ErrRoleAlreadyExist,
+		},
+		{
+			name:     "database error",
+			storeErr: errors.New("connection refused"),
+			wantCode: code.ErrDatabase,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRoleStore := mock_store.NewMockRoleStore(ctrl)
+			mockStoreFactory := mock_store.NewMockFactory(ctrl)
+
+			role := &model.Role{Name: "admin"}
+			mockRoleStore.EXPECT().Create(gomock.Any(), role, gomock.Any()).Return(tt.storeErr)
+			mockStoreFactory.EXPECT().Roles().Return(mockRoleStore)
+
+			err := NewService(mockStoreFactory).Roles().Create(context.Background(), role, model.CreateOptions{})
+			if tt.wantCode == 0 {
+				assert.NoError(t, err)
+
+				return
+			}
+			assert.True(t, pkgerrors.IsCode(err, tt.wantCode), err)
+		})
+	}
+}
+
+func TestRoleService_ListUserRoles(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRoleStore := mock_store.NewMockRoleStore(ctrl)
+	mockStoreFactory := mock_store.NewMockFactory(ctrl)
+
+	roles := &model.RoleList{Items: []*model.Role{{Name: "admin"}, {Name: "support"}}}
+	mockRoleStore.EXPECT().ListUserRoles(gomock.Any(), uint64(7)).Return(roles, nil)
+	mockStoreFactory.EXPECT().Roles().Return(mockRoleStore)
+
+	got, err := NewService(mockStoreFactory).Roles().ListUserRoles(context.Background(), 7)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"admin", "support"}, got.RoleNames())
+}
@@ -4,7 +4,14 @@ import "github.com/skeleton1231/gotal/internal/apiserver/store"
 
 // Service is the interface that abstracts the functionalities of your services.
 type Service interface {
-	Users() UserSrv // Users returns an instance of UserSrv which handles user-related operations.
+	Users() UserSrv                 // Users returns an instance of UserSrv which handles user-related operations.
+	Roles() RoleSrv                 // Roles returns an instance of RoleSrv which handles role-related operations.
+	APIKeys() APIKeySrv             // APIKeys returns an instance of APIKeySrv which handles API key operations.
+	Sessions() SessionSrv           // Sessions returns an instance of SessionSrv which handles login session operations.
+	TwoFactors() TwoFactorSrv       // TwoFactors returns an instance of TwoFactorSrv which handles two-factor authentication operations.
+	Verifications() VerificationSrv // Verifications returns an instance of VerificationSrv which handles email verification.
+	Passwords() PasswordSrv         // Passwords returns an instance of PasswordSrv which handles password resets.
+	Billing() BillingSrv            // Billing returns an instance of BillingSrv which handles Stripe billing events.
 }
 
 // service is a struct that implements the Service interface.
@@ -25,3 +32,38 @@ func NewService(store store.Factory) Service {
 func (s *service) Users() UserSrv {
 	return newUsers(s) // Creating a new UserSrv using the current service instance.
 }
+
+// Roles is a method on service struct that returns a new instance of RoleSrv.
+func (s *service) Roles() RoleSrv {
+	return newRoles(s) // Creating a new RoleSrv using the current service instance.
+}
+
+// APIKeys is a method on service struct that returns a new instance of APIKeySrv.
+func (s *service) APIKeys() APIKeySrv {
+	return newAPIKeys(s) // Creating a new APIKeySrv using the current service instance.
+}
+
+// Sessions is a method on service struct that returns a new instance of SessionSrv.
+func (s *service) Sessions() SessionSrv {
+	return newSessions(s) // Creating a new SessionSrv using the current service instance.
+}
+
+// TwoFactors is a method on service struct that returns a new instance of TwoFactorSrv.
+func (s *service) TwoFactors() TwoFactorSrv {
+	return newTwoFactors(s) // Creating a new TwoFactorSrv using the current service instance.
+}
+
+// Verifications is a method on service struct that returns a new instance of VerificationSrv.
+func (s *service) Verifications() VerificationSrv {
+	return newVerifications(s) // Creating a new VerificationSrv using the current service instance.
+}
+
+// Passwords is a method on service struct that returns a new instance of PasswordSrv.
+func (s *service) Passwords() PasswordSrv {
+	return newPasswords(s) // Creating a new PasswordSrv using the current service instance.
+}
+
+// Billing is a method on service struct that returns a new instance of BillingSrv.
+func (s *service) Billing() BillingSrv {
+	return newBilling(s) // Creating a new BillingSrv using the current service instance.
+}
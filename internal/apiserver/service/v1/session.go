@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/skeleton1231/gotal/internal/apiserver/store"
+	"github.com/skeleton1231/gotal/internal/apiserver/store/model"
+	"github.com/skeleton1231/gotal/internal/pkg/code"
+	"github.com/skeleton1231/gotal/internal/pkg/errors"
+	"github.com/skeleton1231/gotal/internal/pkg/middleware/auth"
+	"github.com/skeleton1231/gotal/pkg/cache"
+	"github.com/skeleton1231/gotal/pkg/log"
+	"github.com/spf13/viper"
+)
+
+// SessionSrv defines functions used to handle login session request.
+type SessionSrv interface {
+	Create(ctx context.Context, user *model.User, userAgent, clientIP string) (*model.Session, error)
+	Refresh(ctx context.Context, refreshToken string) (*model.Session, *model.User, error)
+	List(ctx context.Context, username string) (*model.SessionList, error)
+	Revoke(ctx context.Context, username string, id uint64) error
+	RevokeAll(ctx context.Context, userId uint64) error
+}
+
+const sessionTokenIDPrefix = "session-"
+
+type sessionService struct {
+	store    store.Factory
+	denylist auth.TokenDenylist
+}
+
+var _ SessionSrv = (*sessionService)(nil)
+
+func newSessions(srv *service) *sessionService {
+	return &sessionService{store: srv.store, denylist: auth.NewRedisTokenDenylist()}
+}
+
+// Create implements SessionSrv. The refresh token is returned in session.Token and never stored.
+func (s *sessionService) Create(ctx context.Context, user *model.User, userAgent, clientIP string) (*model.Session, error) {
+	token, err := newRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	session := &model.Session{
+		UserID:      user.ID,
+		RefreshHash: cache.HashKey(token),
+		UserAgent:   userAgent,
+		ClientIP:    clientIP,
+		CreatedAt:   now,
+		LastUsedAt:  now,
+		ExpiresAt:   now.Add(viper.GetDuration("jwt.max-refresh")),
+		Token:       token,
+	}
+	if err := s.store.Sessions().Create(ctx, session); err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+// Refresh implements SessionSrv. The refresh token is rotated, the new one is returned in session.Token.
+// Presenting a rotated token revokes the whole session.
+func (s *sessionService) Refresh(ctx context.Context, refreshToken string) (*model.Session, *model.User, error) {
+	token, err := newRefreshToken()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	expiresAt := time.Now().Add(viper.GetDuration("jwt.max-refresh"))
+	session, err := s.store.Sessions().Rotate(ctx, cache.HashKey(refreshToken), cache.HashKey(token), expiresAt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if session.RevokedAt != nil {
+		log.Record(ctx).Warnf("refresh token of session %d reused, the session is revoked", session.ID)
+		s.denyAccessTokens(ctx, session)
+
+		return nil, nil, errors.WithCode(code.ErrRefreshTokenReused, "session %d has been revoked", session.ID)
+	}
+	session.Token = token
+
+	user, err := s.store.Users().Get(ctx, session.UserID, model.GetOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	roles, err := s.store.Roles().ListUserRoles(ctx, user.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	user.Roles = roles.RoleNames()
+
+	return session, user, nil
+}
+
+// List implements SessionSrv.
+func (s *sessionService) List(ctx context.Context, username string) (*model.SessionList, error) {
+	user, err := s.store.Users().GetByUsername(ctx, username, model.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.store.Sessions().List(ctx, user.ID)
+}
+
+// Revoke implements SessionSrv.
+func (s *sessionService) Revoke(ctx context.Context, username string, id uint64) error {
+	user, err := s.store.Users().GetByUsername(ctx, username, model.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	session, err := s.store.Sessions().Revoke(ctx, user.ID, id)
+	if err != nil {
+		return err
+	}
+	s.denyAccessTokens(ctx, session)
+
+	return nil
+}
+
+// RevokeAll implements SessionSrv.
+func (s *sessionService) RevokeAll(ctx context.Context, userId uint64) error {
+	sessions, err := s.store.Sessions().RevokeAll(ctx, userId)
+	if err != nil {
+		return err
+	}
+
+	for _, session := range sessions.Items {
+		s.denyAccessTokens(ctx, session)
+	}
+
+	return nil
+}
+
+// denyAccessTokens revokes the access tokens issued for the session, they carry its id as `jti`.
+func (s *sessionService) denyAccessTokens(ctx context.Context, session *model.Session) {
+	jti := SessionTokenID(session)
+	if err := s.denylist.Revoke(ctx, jti, time.Now().Add(viper.GetDuration("jwt.timeout"))); err != nil {
+		log.Record(ctx).Errorf("revoke access tokens of session %d failed: %s", session.ID, err.Error())
+	}
+}
+
+// SessionTokenID returns the `jti` claim of the access tokens issued for the session.
+func SessionTokenID(session *model.Session) string {
+	return sessionTokenIDPrefix + strconv.FormatUint(session.ID, 10)
+}
+
+// ParseSessionTokenID returns the session identifier of a `jti` claim returned by SessionTokenID.
+func ParseSessionTokenID(jti string) (uint64, bool) {
+	if !strings.HasPrefix(jti, sessionTokenIDPrefix) {
+		return 0, false
+	}
+
+	id, err := strconv.ParseUint(strings.TrimPrefix(jti, sessionTokenIDPrefix), 10, 64)
+
+	return id, err == nil
+}
+
+func newRefreshToken() (string, error) {
+	token, err := cache.GenerateToken("", "", cache.HashSha256)
+	if err != nil {
+		return "", errors.WithCode(code.ErrUnknown, err.Error())
+	}
+
+	return token, nil
+}
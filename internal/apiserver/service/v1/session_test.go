@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/skeleton1231/gotal/internal/apiserver/store/model"
+	"github.com/skeleton1231/gotal/internal/pkg/code"
+	pkgerrors "github.com/skeleton1231/gotal/internal/pkg/errors"
+	"github.com/skeleton1231/gotal/internal/user_service/store/mock_store"
+	"github.com/skeleton1231/gotal/pkg/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSessionService_Refresh(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserStore := mock_store.NewMockUserStore(ctrl)
+	mockRoleStore := mock_store.NewMockRoleStore(ctrl)
+	mockSessionStore := mock_store.NewMockSessionStore(ctrl)
+	mockStoreFactory := mock_store.NewMockFactory(ctrl)
+
+	var newHash string
+	mockStoreFactory.EXPECT().Sessions().Return(mockSessionStore)
+	mockSessionStore.EXPECT().Rotate(gomock.Any(), cache.HashKey("old-token"), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, hash string, expiresAt time.Time) (*model.Session, error) {
+			newHash = hash
+
+			return &model.Session{ID: 7, UserID: 42, RefreshHash: hash, ExpiresAt: expiresAt}, nil
+		})
+
+	user := &model.User{Name: "alice"}
+	user.ID = 42
+	mockStoreFactory.EXPECT().Users().Return(mockUserStore)
+	mockStoreFactory.EXPECT().Roles().Return(mockRoleStore)
+	mockUserStore.EXPECT().Get(gomock.Any(), uint64(42), gomock.Any()).Return(user, nil)
+	mockRoleStore.EXPECT().ListUserRoles(gomock.Any(), uint64(42)).Return(&model.RoleList{}, nil)
+
+	session, got, err := NewService(mockStoreFactory).Sessions().Refresh(context.Background(), "old-token")
+	assert.NoError(t, err)
+	assert.Equal(t, "alice", got.Name)
+	assert.NotEmpty(t, session.Token)
+	assert.NotEqual(t, "old-token", session.Token)
+	assert.Equal(t, cache.HashKey(session.Token), newHash)
+}
+
+func TestSessionService_RefreshReusedToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSessionStore := mock_store.NewMockSessionStore(ctrl)
+	mockStoreFactory := mock_store.NewMockFactory(ctrl)
+
+	revokedAt := time.Now()
+	mockStoreFactory.EXPECT().Sessions().Return(mockSessionStore)
+	mockSessionStore.EXPECT().Rotate(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&model.Session{ID: 7, UserID: 42, RevokedAt: &revokedAt}, nil)
+
+	_, _, err := NewService(mockStoreFactory).Sessions().Refresh(context.Background(), "stolen-token")
+	assert.True(t, pkgerrors.IsCode(err, code.ErrRefreshTokenReused), err)
+}
+
+func TestParseSessionTokenID(t *testing.T) {
+	id, ok := ParseSessionTokenID(SessionTokenID(&model.Session{ID: 7}))
+	assert.True(t, ok)
+	assert.Equal(t, uint64(7), id)
+
+	_, ok = ParseSessionTokenID("5f0c7a3e")
+	assert.False(t, ok)
+}
@@ -0,0 +1,284 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/skeleton1231/gotal/internal/apiserver/store"
+	"github.com/skeleton1231/gotal/internal/apiserver/store/model"
+	"github.com/skeleton1231/gotal/internal/pkg/code"
+	"github.com/skeleton1231/gotal/internal/pkg/errors"
+	"github.com/skeleton1231/gotal/pkg/cache"
+	"github.com/skeleton1231/gotal/pkg/log"
+	"github.com/skeleton1231/gotal/pkg/util/totp"
+)
+
+const (
+	// twoFactorIssuer names the account in authenticator apps.
+	twoFactorIssuer = "gotal"
+	// twoFactorSkew is the number of steps a code may be early or late, to allow for clock drift.
+	twoFactorSkew = 1
+	// recoveryCodeCount is the number of recovery codes issued when 2FA is enabled.
+	recoveryCodeCount = 10
+	// challengeTTL is how long a password login waits for the second factor.
+	challengeTTL = 5 * time.Minute
+	// challengeAttempts bounds the codes tried against a challenge, so codes cannot be guessed.
+	challengeAttempts = 5
+)
+
+var recoveryEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// TwoFactorSrv defines functions used to handle two-factor authentication request.
+type TwoFactorSrv interface {
+	Get(ctx context.Context, username string) (*model.TwoFactor, error)
+	Enroll(ctx context.Context, username string) (*model.TwoFactorEnrollment, error)
+	Enable(ctx context.Context, username, otp string) ([]string, error)
+	Disable(ctx context.Context, username, otp string) error
+	Required(ctx context.Context, userId uint64) (bool, error)
+	Challenge(ctx context.Context, user *model.User) (string, time.Time, error)
+	Verify(ctx context.Context, challenge, otp string) (*model.User, error)
+}
+
+// twoFactorChallenge is a password login waiting for the second factor.
+type twoFactorChallenge struct {
+	UserID uint64 `json:"userId"`
+}
+
+type twoFactorService struct {
+	store store.Factory
+	cache *cache.RedisClusterV2
+}
+
+var _ TwoFactorSrv = (*twoFactorService)(nil)
+
+func newTwoFactors(srv *service) *twoFactorService {
+	return &twoFactorService{
+		store: srv.store,
+		cache: &cache.RedisClusterV2{KeyPrefix: "gotal-2fa-challenge-"},
+	}
+}
+
+// Get implements TwoFactorSrv.
+func (t *twoFactorService) Get(ctx context.Context, username string) (*model.TwoFactor, error) {
+	user, err := t.store.Users().GetByUsername(ctx, username, model.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return t.store.TwoFactors().Get(ctx, user.ID)
+}
+
+// Enroll implements TwoFactorSrv. It creates a new pending secret, which is enabled once
+// a code generated from it is verified.
+func (t *twoFactorService) Enroll(ctx context.Context, username string) (*model.TwoFactorEnrollment, error) {
+	user, err := t.store.Users().GetByUsername(ctx, username, model.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, errors.WithCode(code.ErrUnknown, err.Error())
+	}
+
+	tf := &model.TwoFactor{UserID: user.ID, Secret: secret, CreatedAt: time.Now()}
+	if err := t.store.TwoFactors().Save(ctx, tf); err != nil {
+		return nil, err
+	}
+
+	return &model.TwoFactorEnrollment{
+		Secret: secret,
+		URI:    totp.URI(twoFactorIssuer, user.Name, secret),
+	}, nil
+}
+
+// Enable implements TwoFactorSrv. It returns the recovery codes, which are only stored hashed.
+func (t *twoFactorService) Enable(ctx context.Context, username, otp string) ([]string, error) {
+	user, err := t.store.Users().GetByUsername(ctx, username, model.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	tf, err := t.store.TwoFactors().Get(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if tf.Enabled {
+		return nil, errors.WithCode(code.ErrTwoFactorEnabled, "two-factor authentication is already enabled")
+	}
+
+	step, ok := totp.Validate(tf.Secret, normalizeCode(otp), time.Now(), twoFactorSkew)
+	if !ok {
+		return nil, errors.WithCode(code.ErrTwoFactorCodeInvalid, "invalid two-factor code")
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := t.store.TwoFactors().Enable(ctx, user.ID, step, hashes); err != nil {
+		return nil, err
+	}
+	log.Record(ctx).Infof("user `%s` enabled two-factor authentication", user.Name)
+
+	return codes, nil
+}
+
+// Disable implements TwoFactorSrv. A code or a recovery code must be given.
+func (t *twoFactorService) Disable(ctx context.Context, username, otp string) error {
+	user, err := t.store.Users().GetByUsername(ctx, username, model.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	tf, err := t.store.TwoFactors().Get(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+
+	if tf.Enabled {
+		if err := t.verifyCode(ctx, tf, otp); err != nil {
+			return err
+		}
+	}
+
+	if err := t.store.TwoFactors().Delete(ctx, user.ID); err != nil {
+		return err
+	}
+	log.Record(ctx).Infof("user `%s` disabled two-factor authentication", user.Name)
+
+	return nil
+}
+
+// Required implements TwoFactorSrv.
+func (t *twoFactorService) Required(ctx context.Context, userId uint64) (bool, error) {
+	tf, err := t.store.TwoFactors().Get(ctx, userId)
+	if err != nil {
+		if errors.IsCode(err, code.ErrTwoFactorNotFound) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return tf.Enabled, nil
+}
+
+// Challenge implements TwoFactorSrv. It returns a short-lived token which is exchanged
+// for the login tokens together with a code.
+func (t *twoFactorService) Challenge(ctx context.Context, user *model.User) (string, time.Time, error) {
+	token, err := cache.GenerateToken(strconv.FormatUint(user.ID, 10), "", cache.HashSha256)
+	if err != nil {
+		return "", time.Time{}, errors.WithCode(code.ErrUnknown, err.Error())
+	}
+
+	value, err := json.Marshal(&twoFactorChallenge{UserID: user.ID})
+	if err != nil {
+		return "", time.Time{}, errors.WithCode(code.ErrEncodingJSON, err.Error())
+	}
+
+	if err := t.cache.SetKey(ctx, cache.HashKey(token), string(value), challengeTTL); err != nil {
+		return "", time.Time{}, errors.WithCode(code.ErrUnknown, err.Error())
+	}
+
+	return token, time.Now().Add(challengeTTL), nil
+}
+
+// Verify implements TwoFactorSrv. It consumes the challenge and returns its user when the code is valid.
+func (t *twoFactorService) Verify(ctx context.Context, challenge, otp string) (*model.User, error) {
+	key := cache.HashKey(challenge)
+
+	value, err := t.cache.GetKey(ctx, key)
+	if err != nil {
+		return nil, errors.WithCode(code.ErrTwoFactorChallengeInvalid, "invalid two-factor challenge")
+	}
+
+	pending := &twoFactorChallenge{}
+	if err := json.Unmarshal([]byte(value), pending); err != nil {
+		return nil, errors.WithCode(code.ErrTwoFactorChallengeInvalid, "invalid two-factor challenge")
+	}
+
+	attempts := t.cache.IncrememntWithExpire(ctx, t.cache.KeyPrefix+"attempts-"+key, int64(challengeTTL/time.Second))
+	if attempts > challengeAttempts {
+		t.cache.DeleteKey(ctx, key)
+
+		return nil, errors.WithCode(code.ErrTwoFactorChallengeInvalid, "too many two-factor attempts")
+	}
+
+	tf, err := t.store.TwoFactors().Get(ctx, pending.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := t.verifyCode(ctx, tf, otp); err != nil {
+		return nil, err
+	}
+	t.cache.DeleteKey(ctx, key)
+
+	return t.store.Users().Get(ctx, pending.UserID, model.GetOptions{})
+}
+
+// verifyCode accepts a TOTP code once, or consumes a recovery code.
+func (t *twoFactorService) verifyCode(ctx context.Context, tf *model.TwoFactor, otp string) error {
+	otp = normalizeCode(otp)
+
+	if step, ok := totp.Validate(tf.Secret, otp, time.Now(), twoFactorSkew); ok {
+		accepted, err := t.store.TwoFactors().UseStep(ctx, tf.UserID, step)
+		if err != nil {
+			return err
+		}
+		if accepted {
+			return nil
+		}
+
+		return errors.WithCode(code.ErrTwoFactorCodeInvalid, "two-factor code has already been used")
+	}
+
+	accepted, err := t.store.TwoFactors().UseRecoveryCode(ctx, tf.UserID, hashRecoveryCode(otp))
+	if err != nil {
+		return err
+	}
+	if !accepted {
+		return errors.WithCode(code.ErrTwoFactorCodeInvalid, "invalid two-factor code")
+	}
+	log.Record(ctx).Infof("user %d used a recovery code", tf.UserID)
+
+	return nil
+}
+
+// newRecoveryCodes returns recovery codes formatted as `xxxxx-xxxxx` and their hashes.
+func newRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		b := make([]byte, 5)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, errors.WithCode(code.ErrUnknown, err.Error())
+		}
+
+		raw := strings.ToLower(recoveryEncoding.EncodeToString(b))
+		codes = append(codes, raw[:5]+"-"+raw[5:])
+		hashes = append(hashes, hashRecoveryCode(raw))
+	}
+
+	return codes, hashes, nil
+}
+
+// normalizeCode strips the separators users may type or copy along with a code.
+func normalizeCode(otp string) string {
+	return strings.ToLower(strings.NewReplacer(" ", "", "-", "").Replace(strings.TrimSpace(otp)))
+}
+
+func hashRecoveryCode(otp string) string {
+	sum := sha256.Sum256([]byte(normalizeCode(otp)))
+
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/skeleton1231/gotal/internal/apiserver/store/model"
+	"github.com/skeleton1231/gotal/internal/pkg/code"
+	pkgerrors "github.com/skeleton1231/gotal/internal/pkg/errors"
+	"github.com/skeleton1231/gotal/internal/user_service/store/mock_store"
+	"github.com/skeleton1231/gotal/pkg/util/totp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTwoFactorService_Enroll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserStore := mock_store.NewMockUserStore(ctrl)
+	mockTwoFactorStore := mock_store.NewMockTwoFactorStore(ctrl)
+	mockStoreFactory := mock_store.NewMockFactory(ctrl)
+
+	user := &model.User{Name: "alice"}
+	user.ID = 42
+	mockStoreFactory.EXPECT().Users().Return(mockUserStore)
+	mockStoreFactory.EXPECT().TwoFactors().Return(mockTwoFactorStore)
+	mockUserStore.EXPECT().GetByUsername(gomock.Any(), "alice", gomock.Any()).Return(user, nil)
+	mockTwoFactorStore.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, tf *model.TwoFactor) error {
+		assert.Equal(t, uint64(42), tf.UserID)
+		assert.False(t, tf.Enabled)
+
+		return nil
+	})
+
+	enrollment, err := NewService(mockStoreFactory).TwoFactors().Enroll(context.Background(), "alice")
+	assert.NoError(t, err)
+
+	u, err := url.Parse(enrollment.URI)
+	assert.NoError(t, err)
+	assert.Equal(t, enrollment.Secret, u.Query().Get("secret"))
+}
+
+func TestTwoFactorService_Enable(t *testing.T) {
+	secret, _ := totp.GenerateSecret()
+	valid, _ := totp.Code(secret, totp.Step(time.Now()))
+
+	tests := []struct {
+		name     string
+		tf       *model.TwoFactor
+		code     string
+		wantCode int
+	}{
+		{name: "valid code", tf: &model.TwoFactor{UserID: 42, Secret: secret}, code: valid},
+		{name: "invalid code", tf: &model.TwoFactor{UserID: 42, Secret: secret}, code: "000000x", wantCode: code.ErrTwoFactorCodeInvalid},
+		{name: "already enabled", tf: &model.TwoFactor{UserID: 42, Secret: secret, Enabled: true}, code: valid, wantCode: code.ErrTwoFactorEnabled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUserStore := mock_store.NewMockUserStore(ctrl)
+			mockTwoFactorStore := mock_store.NewMockTwoFactorStore(ctrl)
+			mockStoreFactory := mock_store.NewMockFactory(ctrl)
+
+			user := &model.User{Name: "alice"}
+			user.ID = 42
+			mockStoreFactory.EXPECT().Users().Return(mockUserStore)
+			mockStoreFactory.EXPECT().TwoFactors().Return(mockTwoFactorStore).AnyTimes()
+			mockUserStore.EXPECT().GetByUsername(gomock.Any(), "alice", gomock.Any()).Return(user, nil)
+			mockTwoFactorStore.EXPECT().Get(gomock.Any(), uint64(42)).Return(tt.tf, nil)
+
+			var hashes []string
+			if tt.wantCode == 0 {
+				mockTwoFactorStore.EXPECT().Enable(gomock.Any(), uint64(42), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ uint64, _ int64, h []string) error {
+						hashes = h
+
+						return nil
+					})
+			}
+
+			codes, err := NewService(mockStoreFactory).TwoFactors().Enable(context.Background(), "alice", tt.code)
+			if tt.wantCode != 0 {
+				assert.True(t, pkgerrors.IsCode(err, tt.wantCode), err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Len(t, codes, recoveryCodeCount)
+			assert.Len(t, hashes, recoveryCodeCount)
+			for i, c := range codes {
+				assert.Equal(t, hashRecoveryCode(c), hashes[i])
+				assert.NotEqual(t, c, hashes[i])
+			}
+		})
+	}
+}
+
+func TestTwoFactorService_Disable(t *testing.T) {
+	secret, _ := totp.GenerateSecret()
+
+	tests := []struct {
+		name     string
+		code     func() string
+		expect   func(store *mock_store.MockTwoFactorStore)
+		wantCode int
+	}{
+		{
+			name: "totp code",
+			code: func() string {
+				c, _ := totp.Code(secret, totp.Step(time.Now()))
+
+				return c
+			},
+			expect: func(store *mock_store.MockTwoFactorStore) {
+				store.EXPECT().UseStep(gomock.Any(), uint64(42), gomock.Any()).Return(true, nil)
+				store.EXPECT().Delete(gomock.Any(), uint64(42)).Return(nil)
+			},
+		},
+		{
+			name: "replayed totp code",
+			code: func() string {
+				c, _ := totp.Code(secret, totp.Step(time.Now()))
+
+				return c
+			},
+			expect: func(store *mock_store.MockTwoFactorStore) {
+				store.EXPECT().UseStep(gomock.Any(), uint64(42), gomock.Any()).Return(false, nil)
+			},
+			wantCode: code.ErrTwoFactorCodeInvalid,
+		},
+		{
+			name: "recovery code",
+			code: func() string { return "ABCDE-FGHIJ" },
+			expect: func(store *mock_store.MockTwoFactorStore) {
+				store.EXPECT().UseRecoveryCode(gomock.Any(), uint64(42), hashRecoveryCode("abcdefghij")).Return(true, nil)
+				store.EXPECT().Delete(gomock.Any(), uint64(42)).Return(nil)
+			},
+		},
+		{
+			name: "used recovery code",
+			code: func() string { return "abcde-fghij" },
+			expect: func(store *mock_store.MockTwoFactorStore) {
+				store.EXPECT().UseRecoveryCode(gomock.Any(), uint64(42), gomock.Any()).Return(false, nil)
+			},
+			wantCode: code.ErrTwoFactorCodeInvalid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUserStore := mock_store.NewMockUserStore(ctrl)
+			mockTwoFactorStore := mock_store.NewMockTwoFactorStore(ctrl)
+			mockStoreFactory := mock_store.NewMockFactory(ctrl)
+
+			user := &model.User{Name: "alice"}
+			user.ID = 42
+			mockStoreFactory.EXPECT().Users().Return(mockUserStore)
+			mockStoreFactory.EXPECT().TwoFactors().Return(mockTwoFactorStore).AnyTimes()
+			mockUserStore.EXPECT().GetByUsername(gomock.Any(), "alice", gomock.Any()).Return(user, nil)
+			mockTwoFactorStore.EXPECT().Get(gomock.Any(), uint64(42)).
+				Return(&model.TwoFactor{UserID: 42, Secret: secret, Enabled: true}, nil)
+			tt.expect(mockTwoFactorStore)
+
+			err := NewService(mockStoreFactory).TwoFactors().Disable(context.Background(), "alice", tt.code())
+			if tt.wantCode != 0 {
+				assert.True(t, pkgerrors.IsCode(err, tt.wantCode), err)
+
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestTwoFactorService_Required(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTwoFactorStore := mock_store.NewMockTwoFactorStore(ctrl)
+	mockStoreFactory := mock_store.NewMockFactory(ctrl)
+	mockStoreFactory.EXPECT().TwoFactors().Return(mockTwoFactorStore).AnyTimes()
+
+	mockTwoFactorStore.EXPECT().Get(gomock.Any(), uint64(1)).
+		Return(nil, pkgerrors.WithCode(code.ErrTwoFactorNotFound, "record not found"))
+	mockTwoFactorStore.EXPECT().Get(gomock.Any(), uint64(2)).Return(&model.TwoFactor{UserID: 2}, nil)
+	mockTwoFactorStore.EXPECT().Get(gomock.Any(), uint64(3)).Return(&model.TwoFactor{UserID: 3, Enabled: true}, nil)
+
+	srv := NewService(mockStoreFactory).TwoFactors()
+	for id, want := range map[uint64]bool{1: false, 2: false, 3: true} {
+		required, err := srv.Required(context.Background(), id)
+		assert.NoError(t, err)
+		assert.Equal(t, want, required, "user %d", id)
+	}
+}
@@ -2,42 +2,72 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"regexp"
 	"sync"
+	"time"
 
+	"github.com/skeleton1231/gotal/internal/apiserver/oauth"
 	"github.com/skeleton1231/gotal/internal/apiserver/store"
 	"github.com/skeleton1231/gotal/internal/apiserver/store/model"
 	"github.com/skeleton1231/gotal/internal/pkg/code"
 	"github.com/skeleton1231/gotal/internal/pkg/errors"
+	"github.com/skeleton1231/gotal/internal/pkg/middleware/auth"
+	"github.com/skeleton1231/gotal/internal/pkg/validation"
 	"github.com/skeleton1231/gotal/pkg/log"
+	"github.com/skeleton1231/gotal/pkg/util/mergepatch"
+	"github.com/spf13/viper"
 )
 
 // UserSrv defines functions used to handle user request.
 type UserSrv interface {
 	Create(ctx context.Context, user *model.User, opts model.CreateOptions) error
 	Update(ctx context.Context, user *model.User, opts model.UpdateOptions) error
+	Patch(ctx context.Context, userId uint64, patch []byte, opts model.PatchOptions) (*model.User, error)
 	Delete(ctx context.Context, userId uint64, opts model.DeleteOptions) error
-	// DeleteCollection(ctx context.Context, userIds []uint64, opts model.DeleteOptions) error
+	DeleteCollection(
+		ctx context.Context,
+		userIds []uint64,
+		opts model.DeleteCollectionOptions,
+	) ([]*model.UserDeleteResult, error)
 	Get(ctx context.Context, userId uint64, opts model.GetOptions) (*model.User, error)
 	List(ctx context.Context, opts model.ListOptions) (*model.UserList, error)
-	ChangePassword(ctx context.Context, user *model.User) error
+	Import(ctx context.Context, users []*model.User, opts model.ImportOptions) (*model.UserImportSummary, error)
+	Export(ctx context.Context, opts model.ListOptions, fn func(user *model.User) error) error
+	ChangePassword(ctx context.Context, user *model.User, password string, opts model.ChangePasswordOptions) error
+	LoginWithDiscord(ctx context.Context, account *oauth.DiscordUser, username string) (*model.User, error)
 }
 
 type userService struct {
-	store store.Factory
+	store    store.Factory
+	denylist auth.TokenDenylist
 }
 
 var _ UserSrv = (*userService)(nil)
 
 func newUsers(srv *service) *userService {
-	return &userService{store: srv.store}
+	return &userService{store: srv.store, denylist: auth.NewRedisTokenDenylist()}
 }
 
-// ChangePassword implements UserSrv.
-func (u *userService) ChangePassword(ctx context.Context, user *model.User) error {
-	// Save Password changed fields.
-	if err := u.store.Users().Update(ctx, user, model.UpdateOptions{}); err != nil {
-		return errors.WithCode(code.ErrDatabase, err.Error())
+// ChangePassword implements UserSrv. The password is given in plain text, the user service
+// encrypts it. Tokens and sessions issued before the change are revoked.
+func (u *userService) ChangePassword(ctx context.Context, user *model.User, password string, opts model.ChangePasswordOptions) error {
+	if err := u.store.Users().ChangePassword(ctx, user.ID, password, opts); err != nil {
+		return err
+	}
+
+	// A token issued before now is usable for at most its timeout, and refreshable for max-refresh.
+	lifetime := viper.GetDuration("jwt.timeout")
+	if refresh := viper.GetDuration("jwt.max-refresh"); refresh > lifetime {
+		lifetime = refresh
+	}
+	if err := u.denylist.RevokeUser(ctx, user.Name, time.Now().Add(lifetime)); err != nil {
+		log.Record(ctx).Errorf("revoke tokens of user `%s` failed: %s", user.Name, err.Error())
+	}
+
+	// Refresh tokens must not outlive the old password either.
+	if err := (&sessionService{store: u.store, denylist: u.denylist}).RevokeAll(ctx, user.ID); err != nil {
+		log.Record(ctx).Errorf("revoke sessions of user `%s` failed: %s", user.Name, err.Error())
 	}
 
 	return nil
@@ -46,7 +76,7 @@ func (u *userService) ChangePassword(ctx context.Context, user *model.User) erro
 // Create implements UserSrv.
 func (u *userService) Create(ctx context.Context, user *model.User, opts model.CreateOptions) error {
 	if err := u.store.Users().Create(ctx, user, opts); err != nil {
-		if match, _ := regexp.MatchString("Duplicate entry '.*' for key 'idx_name'", err.Error()); match {
+		if match, _ := regexp.MatchString("Duplicate entry '.*' for key '.*idx_name'", errors.Message(err)); match {
 			return errors.WithCode(code.ErrUserAlreadyExist, err.Error())
 		}
 
@@ -65,12 +95,20 @@ func (u *userService) Delete(ctx context.Context, userId uint64, opts model.Dele
 }
 
 // DeleteCollection implements UserSrv.
-// func (u *userService) DeleteCollection(ctx context.Context, userIds []uint64, opts model.DeleteOptions) error {
-// 	if err := u.store.Users().DeleteCollection(ctx, userIds, opts); err != nil {
-// 		return errors.WithCode(code.ErrDatabase, err.Error())
-// 	}
-// 	return nil
-// }
+func (u *userService) DeleteCollection(
+	ctx context.Context,
+	userIds []uint64,
+	opts model.DeleteCollectionOptions,
+) ([]*model.UserDeleteResult, error) {
+	results, err := u.store.Users().DeleteCollection(ctx, userIds, opts)
+	if err != nil {
+		log.Record(ctx).Errorf("delete users from storage failed: %s", err.Error())
+
+		return nil, err
+	}
+
+	return results, nil
+}
 
 // Get implements UserSrv.
 func (u *userService) Get(ctx context.Context, userId uint64, opts model.GetOptions) (*model.User, error) {
@@ -88,6 +126,11 @@ func (u *userService) List(ctx context.Context, opts model.ListOptions) (*model.
 	if err != nil {
 		log.Record(ctx).Errorf("list users from storage failed: %s", err.Error())
 
+		// Invalid selectors are reported by the store with their own code.
+		if errors.IsCode(err, code.ErrValidation) {
+			return nil, err
+		}
+
 		return nil, errors.WithCode(code.ErrDatabase, err.Error())
 	}
 	wg := sync.WaitGroup{}
@@ -143,6 +186,36 @@ func (u *userService) List(ctx context.Context, opts model.ListOptions) (*model.
 	return &model.UserList{ListMeta: users.ListMeta, Items: infos}, nil
 }
 
+// Import implements UserSrv. Rows which fail are reported in the summary,
+// an error is only returned when the import as a whole failed.
+func (u *userService) Import(
+	ctx context.Context,
+	users []*model.User,
+	opts model.ImportOptions,
+) (*model.UserImportSummary, error) {
+	summary, err := u.store.Users().Import(ctx, users, opts)
+	if err != nil {
+		log.Record(ctx).Errorf("import users failed: %s", err.Error())
+
+		return nil, err
+	}
+	log.Record(ctx).Infof("imported users, created: %d, updated: %d, skipped: %d, failed: %d",
+		summary.Created, summary.Updated, summary.Skipped, summary.Failed)
+
+	return summary, nil
+}
+
+// Export implements UserSrv.
+func (u *userService) Export(ctx context.Context, opts model.ListOptions, fn func(user *model.User) error) error {
+	if err := u.store.Users().Export(ctx, opts, fn); err != nil {
+		log.Record(ctx).Errorf("export users failed: %s", err.Error())
+
+		return err
+	}
+
+	return nil
+}
+
 // Update implements UserSrv.
 func (u *userService) Update(ctx context.Context, user *model.User, opts model.UpdateOptions) error {
 
@@ -152,3 +225,82 @@ func (u *userService) Update(ctx context.Context, user *model.User, opts model.U
 
 	return nil
 }
+
+// patchableUserField is a member of the user JSON which clients may change with a merge patch.
+type patchableUserField struct {
+	path string // field mask path in the User message
+	set  func(dst, src *model.User)
+}
+
+// patchableUserFields is the allowlist of merge patch members. The name is the login identity,
+// and password, credits, trial and payment state are only changed by dedicated flows. So are
+// the linked accounts: the Stripe customer is set by the Stripe webhook and the Discord account
+// by the Discord login, both pick the user of their events by it.
+var patchableUserFields = map[string]patchableUserField{
+	"email":  {path: "email", set: func(dst, src *model.User) { dst.Email = src.Email }},
+	"extend": {path: "meta.extend", set: func(dst, src *model.User) { dst.Extend = src.Extend }},
+}
+
+// Patch implements UserSrv. It applies an RFC 7396 merge patch to the user and writes
+// only the patched fields, so a member set to null clears the field.
+func (u *userService) Patch(ctx context.Context, userId uint64, patch []byte, opts model.PatchOptions) (*model.User, error) {
+	members, err := mergepatch.Keys(patch)
+	if err != nil {
+		return nil, errors.WithCode(code.ErrBind, err.Error())
+	}
+
+	var violations []errors.FieldViolation
+	fields := make([]string, 0, len(members))
+	for _, member := range members {
+		field, ok := patchableUserFields[member]
+		if !ok {
+			violations = append(violations, errors.FieldViolation{Field: member, Description: "cannot be patched"})
+
+			continue
+		}
+		fields = append(fields, field.path)
+	}
+	if len(violations) > 0 {
+		return nil, errors.WithFieldViolations(errors.WithCode(code.ErrValidation, "patch contains protected fields"), violations...)
+	}
+
+	user, err := u.store.Users().Get(ctx, userId, model.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := json.Marshal(user)
+	if err != nil {
+		return nil, errors.WithCode(code.ErrEncodingJSON, err.Error())
+	}
+
+	merged, err := mergepatch.Apply(doc, patch)
+	if err != nil {
+		return nil, errors.WithCode(code.ErrBind, err.Error())
+	}
+
+	var patched model.User
+	if err := json.Unmarshal(merged, &patched); err != nil {
+		return nil, errors.WithCode(code.ErrBind, err.Error())
+	}
+
+	email := user.Email
+	for _, member := range members {
+		patchableUserFields[member].set(user, &patched)
+	}
+	// A new address has to be verified again.
+	if user.Email != email && user.EmailVerifiedAt.Unix() > 0 {
+		user.EmailVerifiedAt = model.EmailUnverified
+		fields = append(fields, "emailVerifiedAt")
+	}
+
+	if _, err := validation.CheckModel(user); err != nil {
+		return nil, errors.WithCode(code.ErrValidation, err.Error())
+	}
+
+	if err := u.store.Users().Patch(ctx, user, fields, opts); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
@@ -7,6 +7,8 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/skeleton1231/gotal/internal/apiserver/store/model"
+	"github.com/skeleton1231/gotal/internal/pkg/code"
+	"github.com/skeleton1231/gotal/internal/pkg/errors"
 	"github.com/skeleton1231/gotal/internal/user_service/store/mock_store"
 	"github.com/stretchr/testify/assert"
 )
@@ -36,6 +38,27 @@ func TestUserService_Create(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestUserService_Create_Duplicate(t *testing.T) {
+	// MySQL 8 qualifies the key with the table name, MySQL 5.7 does not.
+	for _, key := range []string{"idx_name", "users.idx_name"} {
+		t.Run(key, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUserStore := mock_store.NewMockUserStore(ctrl)
+			mockStoreFactory := mock_store.NewMockFactory(ctrl)
+
+			user := &model.User{Name: "test user"}
+			mockUserStore.EXPECT().Create(gomock.Any(), user, gomock.Any()).
+				Return(errors.WithCode(code.ErrDatabase, "Error 1062 (23000): Duplicate entry 'test user' for key '%s'", key))
+			mockStoreFactory.EXPECT().Users().Return(mockUserStore)
+
+			err := NewService(mockStoreFactory).Users().Create(context.Background(), user, model.CreateOptions{})
+			assert.True(t, errors.IsCode(err, code.ErrUserAlreadyExist), err)
+		})
+	}
+}
+
 func TestUserService_Update(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -127,3 +150,121 @@ func TestUserService_List(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, expectedUsers, users)
 }
+
+func TestUserService_Patch(t *testing.T) {
+	current := func() *model.User {
+		return &model.User{
+			ObjectMeta: model.ObjectMeta{ID: 1, Extend: model.Extend{"theme": "dark", "lang": "en"}, Status: 1},
+			Name:       "john",
+			Email:      "john@example.com",
+			Password:   "$2a$10$hash",
+			StripeID:   "cus_123",
+			DiscordID:  42,
+			// Verified an hour ago.
+			EmailVerifiedAt: time.Now().Add(-time.Hour),
+		}
+	}
+
+	tests := []struct {
+		name   string
+		patch  string
+		fields []string
+		check  func(t *testing.T, user *model.User)
+		code   int
+	}{
+		{
+			name:   "clear a field with null",
+			patch:  `{"extend":null}`,
+			fields: []string{"meta.extend"},
+			check: func(t *testing.T, user *model.User) {
+				assert.Empty(t, user.Extend)
+				assert.Equal(t, "john@example.com", user.Email)
+			},
+		},
+		{
+			name:   "merge extend",
+			patch:  `{"extend":{"lang":null,"tz":"UTC"}}`,
+			fields: []string{"meta.extend"},
+			check: func(t *testing.T, user *model.User) {
+				assert.Equal(t, model.Extend{"theme": "dark", "tz": "UTC"}, user.Extend)
+				assert.Equal(t, "$2a$10$hash", user.Password)
+			},
+		},
+		{
+			name:   "changed email is no longer verified",
+			patch:  `{"email":"new@example.com"}`,
+			fields: []string{"email", "emailVerifiedAt"},
+			check: func(t *testing.T, user *model.User) {
+				assert.Equal(t, "new@example.com", user.Email)
+				assert.False(t, user.EmailVerified(time.Now()))
+			},
+		},
+		{
+			name:  "protected fields",
+			patch: `{"password":"secret","totalCredits":100,"email":"new@example.com"}`,
+			code:  code.ErrValidation,
+		},
+		{
+			name:  "linked accounts",
+			patch: `{"stripeId":"cus_other"}`,
+			code:  code.ErrValidation,
+		},
+		{
+			name:  "linked discord account",
+			patch: `{"discordId":7}`,
+			code:  code.ErrValidation,
+		},
+		{
+			name:  "invalid email",
+			patch: `{"email":"not-an-email"}`,
+			code:  code.ErrValidation,
+		},
+		{
+			name:  "not an object",
+			patch: `["email"]`,
+			code:  code.ErrBind,
+		},
+		{
+			name:  "wrong type",
+			patch: `{"email":7}`,
+			code:  code.ErrBind,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUserStore := mock_store.NewMockUserStore(ctrl)
+			mockStoreFactory := mock_store.NewMockFactory(ctrl)
+			mockStoreFactory.EXPECT().Users().Return(mockUserStore).AnyTimes()
+			mockUserStore.EXPECT().Get(gomock.Any(), uint64(1), gomock.Any()).Return(current(), nil).AnyTimes()
+			if tt.fields != nil {
+				mockUserStore.EXPECT().Patch(gomock.Any(), gomock.Any(), tt.fields, gomock.Any()).Return(nil)
+			}
+
+			user, err := NewService(mockStoreFactory).Users().Patch(context.Background(), 1, []byte(tt.patch), model.PatchOptions{})
+			if tt.code != 0 {
+				assert.True(t, errors.IsCode(err, tt.code), "%v", err)
+
+				return
+			}
+			assert.NoError(t, err)
+			tt.check(t, user)
+		})
+	}
+}
+
+func TestUserService_Patch_Violations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStoreFactory := mock_store.NewMockFactory(ctrl)
+
+	_, err := NewService(mockStoreFactory).Users().Patch(context.Background(), 1, []byte(`{"password":"x","totalCredits":1}`), model.PatchOptions{})
+	assert.Equal(t, []errors.FieldViolation{
+		{Field: "password", Description: "cannot be patched"},
+		{Field: "totalCredits", Description: "cannot be patched"},
+	}, errors.FieldViolations(err))
+}
@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/skeleton1231/gotal/internal/apiserver/store"
+	"github.com/skeleton1231/gotal/internal/apiserver/store/model"
+	"github.com/skeleton1231/gotal/internal/pkg/code"
+	"github.com/skeleton1231/gotal/internal/pkg/errors"
+	"github.com/skeleton1231/gotal/internal/pkg/options"
+	"github.com/skeleton1231/gotal/pkg/log"
+	"github.com/skeleton1231/gotal/pkg/mail"
+	"github.com/spf13/viper"
+)
+
+// verificationPurpose separates the signatures of verification tokens from the other
+// values signed with the JWT key.
+const verificationPurpose = "email-verification:"
+
+// VerificationSrv defines functions used to verify the email address of users.
+type VerificationSrv interface {
+	Send(ctx context.Context, userId uint64) error
+	Verify(ctx context.Context, token string) (*model.User, error)
+}
+
+// verificationToken is the signed content of a verification link. The address is part
+// of it, so a link stops working when the address is changed.
+type verificationToken struct {
+	UserID    uint64 `json:"uid"`
+	Email     string `json:"email"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+type verificationService struct {
+	store  store.Factory
+	mailer mail.Mailer
+	from   string
+	opts   *options.VerificationOptions
+	key    []byte
+}
+
+var _ VerificationSrv = (*verificationService)(nil)
+
+var (
+	mailer     mail.Mailer
+	mailOpts   *options.MailOptions
+	verifyOpts *options.VerificationOptions
+	mailOnce   sync.Once
+)
+
+// getMailer reads the `mail.*` and `verification.*` configuration and creates the mailer
+// on first use. The mailer is nil when no driver is configured.
+func getMailer() (mail.Mailer, *options.MailOptions, *options.VerificationOptions) {
+	mailOnce.Do(func() {
+		mailOpts = options.NewMailOptions()
+		if err := viper.UnmarshalKey("mail", mailOpts); err != nil {
+			log.Errorf("read mail options failed: %s", err.Error())
+		}
+		verifyOpts = options.NewVerificationOptions()
+		if err := viper.UnmarshalKey("verification", verifyOpts); err != nil {
+			log.Errorf("read verification options failed: %s", err.Error())
+		}
+
+		var err error
+		if mailer, err = mailOpts.NewMailer(); err != nil {
+			log.Errorf("create mailer failed: %s", err.Error())
+		}
+	})
+
+	return mailer, mailOpts, verifyOpts
+}
+
+func newVerifications(srv *service) *verificationService {
+	mailer, mailOpts, verifyOpts := getMailer()
+
+	return &verificationService{
+		store:  srv.store,
+		mailer: mailer,
+		from:   mailOpts.From,
+		opts:   verifyOpts,
+		key:    []byte(viper.GetString("jwt.key")),
+	}
+}
+
+// Send implements VerificationSrv. It emails a link confirming the current address of the user.
+func (v *verificationService) Send(ctx context.Context, userId uint64) error {
+	user, err := v.store.Users().Get(ctx, userId, model.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	if user.EmailVerified(time.Now()) {
+		return errors.WithCode(code.ErrEmailAlreadyVerified, "email `%s` is already verified", user.Email)
+	}
+
+	if v.mailer == nil {
+		return errors.WithCode(code.ErrSendMail, "mail is not configured")
+	}
+
+	token, err := v.encode(&verificationToken{
+		UserID:    user.ID,
+		Email:     user.Email,
+		ExpiresAt: time.Now().Add(v.opts.TokenTTL).Unix(),
+	})
+	if err != nil {
+		return errors.WithCode(code.ErrEncodingJSON, err.Error())
+	}
+
+	link := v.opts.URL + "?token=" + url.QueryEscape(token)
+	msg := &mail.Message{
+		From:    v.from,
+		To:      []string{user.Email},
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf("Hello %s,\n\nOpen the link below to verify your email address:\n\n%s\n\n"+
+			"The link expires in %s. If you did not sign up, ignore this email.\n",
+			user.Name, link, v.opts.TokenTTL),
+	}
+	if err := v.mailer.Send(ctx, msg); err != nil {
+		log.Record(ctx).Errorf("send verification email to user %d failed: %s", user.ID, err.Error())
+
+		return errors.WithCode(code.ErrSendMail, err.Error())
+	}
+	log.Record(ctx).Infof("sent verification email to user %d", user.ID)
+
+	return nil
+}
+
+// Verify implements VerificationSrv. Verifying an address twice succeeds, so following
+// the same link again is harmless.
+func (v *verificationService) Verify(ctx context.Context, token string) (*model.User, error) {
+	now := time.Now()
+
+	t, err := v.decode(token, now)
+	if err != nil {
+		return nil, errors.WithCode(code.ErrVerificationTokenInvalid, err.Error())
+	}
+
+	user, err := v.store.Users().Get(ctx, t.UserID, model.GetOptions{})
+	if err != nil {
+		if errors.IsCode(err, code.ErrUserNotFound) {
+			return nil, errors.WithCode(code.ErrVerificationTokenInvalid, "user of the token no longer exists")
+		}
+
+		return nil, err
+	}
+
+	if user.Email != t.Email {
+		return nil, errors.WithCode(code.ErrVerificationTokenInvalid, "email address has changed since the token was issued")
+	}
+
+	if user.EmailVerified(now) {
+		return user, nil
+	}
+
+	user.EmailVerifiedAt = now
+	if err := v.store.Users().Patch(ctx, user, []string{"emailVerifiedAt"}, model.PatchOptions{}); err != nil {
+		return nil, err
+	}
+	log.Record(ctx).Infof("user %d verified email address", user.ID)
+
+	return user, nil
+}
+
+// encode returns the token signed with the JWT key.
+func (v *verificationService) encode(t *verificationToken) (string, error) {
+	payload, err := json.Marshal(t)
+	if err != nil {
+		return "", err
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+
+	return encoded + "." + v.sign(encoded), nil
+}
+
+// decode verifies the signature and expiry of a token returned by encode.
+func (v *verificationService) decode(value string, now time.Time) (*verificationToken, error) {
+	encoded, signature, ok := strings.Cut(value, ".")
+	if !ok || !hmac.Equal([]byte(signature), []byte(v.sign(encoded))) {
+		return nil, fmt.Errorf("verification token signature mismatch")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &verificationToken{}
+	if err := json.Unmarshal(payload, t); err != nil {
+		return nil, err
+	}
+
+	if now.Unix() >= t.ExpiresAt {
+		return nil, fmt.Errorf("verification token expired")
+	}
+
+	return t, nil
+}
+
+func (v *verificationService) sign(value string) string {
+	mac := hmac.New(sha256.New, v.key)
+	mac.Write([]byte(verificationPurpose + value))
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/skeleton1231/gotal/internal/apiserver/store/model"
+	"github.com/skeleton1231/gotal/internal/pkg/code"
+	pkgerrors "github.com/skeleton1231/gotal/internal/pkg/errors"
+	"github.com/skeleton1231/gotal/internal/pkg/options"
+	"github.com/skeleton1231/gotal/internal/user_service/store/mock_store"
+	"github.com/skeleton1231/gotal/pkg/mail"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestVerifications(t *testing.T, factory *mock_store.MockFactory) (*verificationService, *mail.Outbox) {
+	outbox, err := mail.NewOutbox(t.TempDir())
+	require.NoError(t, err)
+
+	return &verificationService{
+		store:  factory,
+		mailer: outbox,
+		from:   "no-reply@example.com",
+		opts:   options.NewVerificationOptions(),
+		key:    []byte("test-key"),
+	}, outbox
+}
+
+// tokenOf extracts the token of the link in a verification email.
+func tokenOf(t *testing.T, msg *mail.Message) string {
+	for _, field := range strings.Fields(msg.Body) {
+		if u, err := url.Parse(field); err == nil && u.Query().Has("token") {
+			return u.Query().Get("token")
+		}
+	}
+	t.Fatalf("no verification link in %q", msg.Body)
+
+	return ""
+}
+
+func TestVerificationService_SendAndVerify(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserStore := mock_store.NewMockUserStore(ctrl)
+	mockStoreFactory := mock_store.NewMockFactory(ctrl)
+	mockStoreFactory.EXPECT().Users().Return(mockUserStore).AnyTimes()
+
+	user := &model.User{Name: "alice", Email: "alice@example.com", EmailVerifiedAt: model.EmailUnverified}
+	user.ID = 42
+	mockUserStore.EXPECT().Get(gomock.Any(), uint64(42), gomock.Any()).Return(user, nil).Times(3)
+	mockUserStore.EXPECT().Patch(gomock.Any(), user, []string{"emailVerifiedAt"}, gomock.Any()).Return(nil)
+
+	v, outbox := newTestVerifications(t, mockStoreFactory)
+	require.NoError(t, v.Send(context.Background(), 42))
+
+	messages, err := outbox.Messages()
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	assert.Equal(t, []string{"alice@example.com"}, messages[0].To)
+	token := tokenOf(t, messages[0])
+
+	verified, err := v.Verify(context.Background(), token)
+	require.NoError(t, err)
+	assert.True(t, verified.EmailVerified(time.Now()))
+
+	// Following the link again is harmless and writes nothing.
+	_, err = v.Verify(context.Background(), token)
+	assert.NoError(t, err)
+}
+
+func TestVerificationService_Verify(t *testing.T) {
+	tests := []struct {
+		name  string
+		token func(v *verificationService) string
+		email string
+	}{
+		{
+			name: "expired",
+			token: func(v *verificationService) string {
+				token, _ := v.encode(&verificationToken{UserID: 42, Email: "alice@example.com", ExpiresAt: time.Now().Add(-time.Minute).Unix()})
+
+				return token
+			},
+		},
+		{
+			name: "tampered",
+			token: func(v *verificationService) string {
+				token, _ := v.encode(&verificationToken{UserID: 42, Email: "alice@example.com", ExpiresAt: time.Now().Add(time.Hour).Unix()})
+				forged, _ := v.encode(&verificationToken{UserID: 7, Email: "alice@example.com", ExpiresAt: time.Now().Add(time.Hour).Unix()})
+				payload, _, _ := strings.Cut(forged, ".")
+				_, signature, _ := strings.Cut(token, ".")
+
+				return payload + "." + signature
+			},
+		},
+		{
+			name: "signed with another key",
+			token: func(v *verificationService) string {
+				other := &verificationService{key: []byte("other-key")}
+				token, _ := other.encode(&verificationToken{UserID: 42, Email: "alice@example.com", ExpiresAt: time.Now().Add(time.Hour).Unix()})
+
+				return token
+			},
+		},
+		{
+			name: "email changed",
+			token: func(v *verificationService) string {
+				token, _ := v.encode(&verificationToken{UserID: 42, Email: "alice@example.com", ExpiresAt: time.Now().Add(time.Hour).Unix()})
+
+				return token
+			},
+			email: "alice@example.org",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUserStore := mock_store.NewMockUserStore(ctrl)
+			mockStoreFactory := mock_store.NewMockFactory(ctrl)
+			mockStoreFactory.EXPECT().Users().Return(mockUserStore).AnyTimes()
+			if tt.email != "" {
+				user := &model.User{Name: "alice", Email: tt.email, EmailVerifiedAt: model.EmailUnverified}
+				mockUserStore.EXPECT().Get(gomock.Any(), uint64(42), gomock.Any()).Return(user, nil)
+			}
+
+			v, _ := newTestVerifications(t, mockStoreFactory)
+			_, err := v.Verify(context.Background(), tt.token(v))
+			assert.True(t, pkgerrors.IsCode(err, code.ErrVerificationTokenInvalid), err)
+		})
+	}
+}
+
+func TestVerificationService_SendVerified(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserStore := mock_store.NewMockUserStore(ctrl)
+	mockStoreFactory := mock_store.NewMockFactory(ctrl)
+	mockStoreFactory.EXPECT().Users().Return(mockUserStore)
+
+	user := &model.User{Name: "alice", Email: "alice@example.com", EmailVerifiedAt: time.Now().Add(-time.Hour)}
+	mockUserStore.EXPECT().Get(gomock.Any(), uint64(42), gomock.Any()).Return(user, nil)
+
+	v, outbox := newTestVerifications(t, mockStoreFactory)
+	err := v.Send(context.Background(), 42)
+	assert.True(t, pkgerrors.IsCode(err, code.ErrEmailAlreadyVerified), err)
+
+	messages, err := outbox.Messages()
+	require.NoError(t, err)
+	assert.Empty(t, messages)
+}
@@ -0,0 +1,15 @@
+package store
+
+import (
+	"context"
+
+	"github.com/skeleton1231/gotal/internal/apiserver/store/model"
+)
+
+// APIKeyStore defines the API key storage interface.
+type APIKeyStore interface {
+	Create(ctx context.Context, key *model.APIKey) error
+	List(ctx context.Context, userId uint64) (*model.APIKeyList, error)
+	Delete(ctx context.Context, userId uint64, id uint64) (*model.APIKey, error)
+	GetByHash(ctx context.Context, hash string) (*model.APIKey, error)
+}
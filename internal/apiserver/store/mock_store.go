@@ -51,6 +51,54 @@ func (mr *MockFactoryMockRecorder) Users() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Users", reflect.TypeOf((*MockFactory)(nil).Users))
 }
 
+func (m *MockFactory) Roles() RoleStore {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Roles")
+	ret0, _ := ret[0].(RoleStore)
+	return ret0
+}
+
+func (mr *MockFactoryMockRecorder) Roles() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Roles", reflect.TypeOf((*MockFactory)(nil).Roles))
+}
+
+func (m *MockFactory) APIKeys() APIKeyStore {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "APIKeys")
+	ret0, _ := ret[0].(APIKeyStore)
+	return ret0
+}
+
+func (mr *MockFactoryMockRecorder) APIKeys() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "APIKeys", reflect.TypeOf((*MockFactory)(nil).APIKeys))
+}
+
+func (m *MockFactory) Sessions() SessionStore {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sessions")
+	ret0, _ := ret[0].(SessionStore)
+	return ret0
+}
+
+func (mr *MockFactoryMockRecorder) Sessions() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sessions", reflect.TypeOf((*MockFactory)(nil).Sessions))
+}
+
+func (m *MockFactory) TwoFactors() TwoFactorStore {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TwoFactors")
+	ret0, _ := ret[0].(TwoFactorStore)
+	return ret0
+}
+
+func (mr *MockFactoryMockRecorder) TwoFactors() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TwoFactors", reflect.TypeOf((*MockFactory)(nil).TwoFactors))
+}
+
 type MockUserStore struct {
 	ctrl     *gomock.Controller
 	recorder *MockUserStoreMockRecorder
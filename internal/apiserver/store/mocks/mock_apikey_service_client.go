@@ -0,0 +1,247 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/proto/apikey/apikey_service_grpc.pb.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	apikey "github.com/skeleton1231/gotal/internal/proto/apikey"
+	grpc "google.golang.org/grpc"
+)
+
+// MockAPIKeyServiceClient is a mock of APIKeyServiceClient interface.
+type MockAPIKeyServiceClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIKeyServiceClientMockRecorder
+}
+
+// MockAPIKeyServiceClientMockRecorder is the mock recorder for MockAPIKeyServiceClient.
+type MockAPIKeyServiceClientMockRecorder struct {
+	mock *MockAPIKeyServiceClient
+}
+
+// NewMockAPIKeyServiceClient creates a new mock instance.
+func NewMockAPIKeyServiceClient(ctrl *gomock.Controller) *MockAPIKeyServiceClient {
+	mock := &MockAPIKeyServiceClient{ctrl: ctrl}
+	mock.recorder = &MockAPIKeyServiceClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPIKeyServiceClient) EXPECT() *MockAPIKeyServiceClientMockRecorder {
+	return m.recorder
+}
+
+// CreateAPIKey mocks base method.
+func (m *MockAPIKeyServiceClient) CreateAPIKey(ctx context.Context, in *apikey.CreateAPIKeyRequest, opts ...grpc.CallOption) (*apikey.CreateAPIKeyResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateAPIKey", varargs...)
+	ret0, _ := ret[0].(*apikey.CreateAPIKeyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAPIKey indicates an expected call of CreateAPIKey.
+func (mr *MockAPIKeyServiceClientMockRecorder) CreateAPIKey(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIKey", reflect.TypeOf((*MockAPIKeyServiceClient)(nil).CreateAPIKey), varargs...)
+}
+
+// GetAPIKeyByHash mocks base method.
+func (m *MockAPIKeyServiceClient) GetAPIKeyByHash(ctx context.Context, in *apikey.GetAPIKeyByHashRequest, opts ...grpc.CallOption) (*apikey.GetAPIKeyByHashResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetAPIKeyByHash", varargs...)
+	ret0, _ := ret[0].(*apikey.GetAPIKeyByHashResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAPIKeyByHash indicates an expected call of GetAPIKeyByHash.
+func (mr *MockAPIKeyServiceClientMockRecorder) GetAPIKeyByHash(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIKeyByHash", reflect.TypeOf((*MockAPIKeyServiceClient)(nil).GetAPIKeyByHash), varargs...)
+}
+
+// ListAPIKeys mocks base method.
+func (m *MockAPIKeyServiceClient) ListAPIKeys(ctx context.Context, in *apikey.ListAPIKeysRequest, opts ...grpc.CallOption) (*apikey.ListAPIKeysResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListAPIKeys", varargs...)
+	ret0, _ := ret[0].(*apikey.ListAPIKeysResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAPIKeys indicates an expected call of ListAPIKeys.
+func (mr *MockAPIKeyServiceClientMockRecorder) ListAPIKeys(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAPIKeys", reflect.TypeOf((*MockAPIKeyServiceClient)(nil).ListAPIKeys), varargs...)
+}
+
+// RevokeAPIKey mocks base method.
+func (m *MockAPIKeyServiceClient) RevokeAPIKey(ctx context.Context, in *apikey.RevokeAPIKeyRequest, opts ...grpc.CallOption) (*apikey.RevokeAPIKeyResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RevokeAPIKey", varargs...)
+	ret0, _ := ret[0].(*apikey.RevokeAPIKeyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeAPIKey indicates an expected call of RevokeAPIKey.
+func (mr *MockAPIKeyServiceClientMockRecorder) RevokeAPIKey(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKey", reflect.TypeOf((*MockAPIKeyServiceClient)(nil).RevokeAPIKey), varargs...)
+}
+
+// MockAPIKeyServiceServer is a mock of APIKeyServiceServer interface.
+type MockAPIKeyServiceServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIKeyServiceServerMockRecorder
+}
+
+// MockAPIKeyServiceServerMockRecorder is the mock recorder for MockAPIKeyServiceServer.
+type MockAPIKeyServiceServerMockRecorder struct {
+	mock *MockAPIKeyServiceServer
+}
+
+// NewMockAPIKeyServiceServer creates a new mock instance.
+func NewMockAPIKeyServiceServer(ctrl *gomock.Controller) *MockAPIKeyServiceServer {
+	mock := &MockAPIKeyServiceServer{ctrl: ctrl}
+	mock.recorder = &MockAPIKeyServiceServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPIKeyServiceServer) EXPECT() *MockAPIKeyServiceServerMockRecorder {
+	return m.recorder
+}
+
+// CreateAPIKey mocks base method.
+func (m *MockAPIKeyServiceServer) CreateAPIKey(arg0 context.Context, arg1 *apikey.CreateAPIKeyRequest) (*apikey.CreateAPIKeyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIKey", arg0, arg1)
+	ret0, _ := ret[0].(*apikey.CreateAPIKeyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAPIKey indicates an expected call of CreateAPIKey.
+func (mr *MockAPIKeyServiceServerMockRecorder) CreateAPIKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIKey", reflect.TypeOf((*MockAPIKeyServiceServer)(nil).CreateAPIKey), arg0, arg1)
+}
+
+// GetAPIKeyByHash mocks base method.
+func (m *MockAPIKeyServiceServer) GetAPIKeyByHash(arg0 context.Context, arg1 *apikey.GetAPIKeyByHashRequest) (*apikey.GetAPIKeyByHashResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPIKeyByHash", arg0, arg1)
+	ret0, _ := ret[0].(*apikey.GetAPIKeyByHashResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAPIKeyByHash indicates an expected call of GetAPIKeyByHash.
+func (mr *MockAPIKeyServiceServerMockRecorder) GetAPIKeyByHash(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIKeyByHash", reflect.TypeOf((*MockAPIKeyServiceServer)(nil).GetAPIKeyByHash), arg0, arg1)
+}
+
+// ListAPIKeys mocks base method.
+func (m *MockAPIKeyServiceServer) ListAPIKeys(arg0 context.Context, arg1 *apikey.ListAPIKeysRequest) (*apikey.ListAPIKeysResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAPIKeys", arg0, arg1)
+	ret0, _ := ret[0].(*apikey.ListAPIKeysResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAPIKeys indicates an expected call of ListAPIKeys.
+func (mr *MockAPIKeyServiceServerMockRecorder) ListAPIKeys(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAPIKeys", reflect.TypeOf((*MockAPIKeyServiceServer)(nil).ListAPIKeys), arg0, arg1)
+}
+
+// RevokeAPIKey mocks base method.
+func (m *MockAPIKeyServiceServer) RevokeAPIKey(arg0 context.Context, arg1 *apikey.RevokeAPIKeyRequest) (*apikey.RevokeAPIKeyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIKey", arg0, arg1)
+	ret0, _ := ret[0].(*apikey.RevokeAPIKeyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeAPIKey indicates an expected call of RevokeAPIKey.
+func (mr *MockAPIKeyServiceServerMockRecorder) RevokeAPIKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKey", reflect.TypeOf((*MockAPIKeyServiceServer)(nil).RevokeAPIKey), arg0, arg1)
+}
+
+// mustEmbedUnimplementedAPIKeyServiceServer mocks base method.
+func (m *MockAPIKeyServiceServer) mustEmbedUnimplementedAPIKeyServiceServer() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "mustEmbedUnimplementedAPIKeyServiceServer")
+}
+
+// mustEmbedUnimplementedAPIKeyServiceServer indicates an expected call of mustEmbedUnimplementedAPIKeyServiceServer.
+func (mr *MockAPIKeyServiceServerMockRecorder) mustEmbedUnimplementedAPIKeyServiceServer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "mustEmbedUnimplementedAPIKeyServiceServer", reflect.TypeOf((*MockAPIKeyServiceServer)(nil).mustEmbedUnimplementedAPIKeyServiceServer))
+}
+
+// MockUnsafeAPIKeyServiceServer is a mock of UnsafeAPIKeyServiceServer interface.
+type MockUnsafeAPIKeyServiceServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockUnsafeAPIKeyServiceServerMockRecorder
+}
+
+// MockUnsafeAPIKeyServiceServerMockRecorder is the mock recorder for MockUnsafeAPIKeyServiceServer.
+type MockUnsafeAPIKeyServiceServerMockRecorder struct {
+	mock *MockUnsafeAPIKeyServiceServer
+}
+
+// NewMockUnsafeAPIKeyServiceServer creates a new mock instance.
+func NewMockUnsafeAPIKeyServiceServer(ctrl *gomock.Controller) *MockUnsafeAPIKeyServiceServer {
+	mock := &MockUnsafeAPIKeyServiceServer{ctrl: ctrl}
+	mock.recorder = &MockUnsafeAPIKeyServiceServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUnsafeAPIKeyServiceServer) EXPECT() *MockUnsafeAPIKeyServiceServerMockRecorder {
+	return m.recorder
+}
+
+// mustEmbedUnimplementedAPIKeyServiceServer mocks base method.
+func (m *MockUnsafeAPIKeyServiceServer) mustEmbedUnimplementedAPIKeyServiceServer() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "mustEmbedUnimplementedAPIKeyServiceServer")
+}
+
+// mustEmbedUnimplementedAPIKeyServiceServer indicates an expected call of mustEmbedUnimplementedAPIKeyServiceServer.
+func (mr *MockUnsafeAPIKeyServiceServerMockRecorder) mustEmbedUnimplementedAPIKeyServiceServer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "mustEmbedUnimplementedAPIKeyServiceServer", reflect.TypeOf((*MockUnsafeAPIKeyServiceServer)(nil).mustEmbedUnimplementedAPIKeyServiceServer))
+}
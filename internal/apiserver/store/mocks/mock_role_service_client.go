@@ -0,0 +1,352 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/proto/role/role_service_grpc.pb.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	role "github.com/skeleton1231/gotal/internal/proto/role"
+	grpc "google.golang.org/grpc"
+)
+
+// MockRoleServiceClient is a mock of RoleServiceClient interface.
+type MockRoleServiceClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockRoleServiceClientMockRecorder
+}
+
+// MockRoleServiceClientMockRecorder is the mock recorder for MockRoleServiceClient.
+type MockRoleServiceClientMockRecorder struct {
+	mock *MockRoleServiceClient
+}
+
+// NewMockRoleServiceClient creates a new mock instance.
+func NewMockRoleServiceClient(ctrl *gomock.Controller) *MockRoleServiceClient {
+	mock := &MockRoleServiceClient{ctrl: ctrl}
+	mock.recorder = &MockRoleServiceClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRoleServiceClient) EXPECT() *MockRoleServiceClientMockRecorder {
+	return m.recorder
+}
+
+// CreateRole mocks base method.
+func (m *MockRoleServiceClient) CreateRole(ctx context.Context, in *role.CreateRoleRequest, opts ...grpc.CallOption) (*role.CreateRoleResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateRole", varargs...)
+	ret0, _ := ret[0].(*role.CreateRoleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRole indicates an expected call of CreateRole.
+func (mr *MockRoleServiceClientMockRecorder) CreateRole(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRole", reflect.TypeOf((*MockRoleServiceClient)(nil).CreateRole), varargs...)
+}
+
+// DeleteRole mocks base method.
+func (m *MockRoleServiceClient) DeleteRole(ctx context.Context, in *role.DeleteRoleRequest, opts ...grpc.CallOption) (*role.DeleteRoleResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteRole", varargs...)
+	ret0, _ := ret[0].(*role.DeleteRoleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteRole indicates an expected call of DeleteRole.
+func (mr *MockRoleServiceClientMockRecorder) DeleteRole(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRole", reflect.TypeOf((*MockRoleServiceClient)(nil).DeleteRole), varargs...)
+}
+
+// GetRole mocks base method.
+func (m *MockRoleServiceClient) GetRole(ctx context.Context, in *role.GetRoleRequest, opts ...grpc.CallOption) (*role.GetRoleResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetRole", varargs...)
+	ret0, _ := ret[0].(*role.GetRoleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRole indicates an expected call of GetRole.
+func (mr *MockRoleServiceClientMockRecorder) GetRole(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRole", reflect.TypeOf((*MockRoleServiceClient)(nil).GetRole), varargs...)
+}
+
+// GrantRole mocks base method.
+func (m *MockRoleServiceClient) GrantRole(ctx context.Context, in *role.GrantRoleRequest, opts ...grpc.CallOption) (*role.GrantRoleResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GrantRole", varargs...)
+	ret0, _ := ret[0].(*role.GrantRoleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GrantRole indicates an expected call of GrantRole.
+func (mr *MockRoleServiceClientMockRecorder) GrantRole(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrantRole", reflect.TypeOf((*MockRoleServiceClient)(nil).GrantRole), varargs...)
+}
+
+// ListRoles mocks base method.
+func (m *MockRoleServiceClient) ListRoles(ctx context.Context, in *role.ListRolesRequest, opts ...grpc.CallOption) (*role.ListRolesResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListRoles", varargs...)
+	ret0, _ := ret[0].(*role.ListRolesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRoles indicates an expected call of ListRoles.
+func (mr *MockRoleServiceClientMockRecorder) ListRoles(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRoles", reflect.TypeOf((*MockRoleServiceClient)(nil).ListRoles), varargs...)
+}
+
+// ListUserRoles mocks base method.
+func (m *MockRoleServiceClient) ListUserRoles(ctx context.Context, in *role.ListUserRolesRequest, opts ...grpc.CallOption) (*role.ListUserRolesResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListUserRoles", varargs...)
+	ret0, _ := ret[0].(*role.ListUserRolesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserRoles indicates an expected call of ListUserRoles.
+func (mr *MockRoleServiceClientMockRecorder) ListUserRoles(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserRoles", reflect.TypeOf((*MockRoleServiceClient)(nil).ListUserRoles), varargs...)
+}
+
+// RevokeRole mocks base method.
+func (m *MockRoleServiceClient) RevokeRole(ctx context.Context, in *role.RevokeRoleRequest, opts ...grpc.CallOption) (*role.RevokeRoleResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RevokeRole", varargs...)
+	ret0, _ := ret[0].(*role.RevokeRoleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeRole indicates an expected call of RevokeRole.
+func (mr *MockRoleServiceClientMockRecorder) RevokeRole(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeRole", reflect.TypeOf((*MockRoleServiceClient)(nil).RevokeRole), varargs...)
+}
+
+// MockRoleServiceServer is a mock of RoleServiceServer interface.
+type MockRoleServiceServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockRoleServiceServerMockRecorder
+}
+
+// MockRoleServiceServerMockRecorder is the mock recorder for MockRoleServiceServer.
+type MockRoleServiceServerMockRecorder struct {
+	mock *MockRoleServiceServer
+}
+
+// NewMockRoleServiceServer creates a new mock instance.
+func NewMockRoleServiceServer(ctrl *gomock.Controller) *MockRoleServiceServer {
+	mock := &MockRoleServiceServer{ctrl: ctrl}
+	mock.recorder = &MockRoleServiceServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRoleServiceServer) EXPECT() *MockRoleServiceServerMockRecorder {
+	return m.recorder
+}
+
+// CreateRole mocks base method.
+func (m *MockRoleServiceServer) CreateRole(arg0 context.Context, arg1 *role.CreateRoleRequest) (*role.CreateRoleResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRole", arg0, arg1)
+	ret0, _ := ret[0].(*role.CreateRoleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRole indicates an expected call of CreateRole.
+func (mr *MockRoleServiceServerMockRecorder) CreateRole(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRole", reflect.TypeOf((*MockRoleServiceServer)(nil).CreateRole), arg0, arg1)
+}
+
+// DeleteRole mocks base method.
+func (m *MockRoleServiceServer) DeleteRole(arg0 context.Context, arg1 *role.DeleteRoleRequest) (*role.DeleteRoleResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRole", arg0, arg1)
+	ret0, _ := ret[0].(*role.DeleteRoleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteRole indicates an expected call of DeleteRole.
+func (mr *MockRoleServiceServerMockRecorder) DeleteRole(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRole", reflect.TypeOf((*MockRoleServiceServer)(nil).DeleteRole), arg0, arg1)
+}
+
+// GetRole mocks base method.
+func (m *MockRoleServiceServer) GetRole(arg0 context.Context, arg1 *role.GetRoleRequest) (*role.GetRoleResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRole", arg0, arg1)
+	ret0, _ := ret[0].(*role.GetRoleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRole indicates an expected call of GetRole.
+func (mr *MockRoleServiceServerMockRecorder) GetRole(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRole", reflect.TypeOf((*MockRoleServiceServer)(nil).GetRole), arg0, arg1)
+}
+
+// GrantRole mocks base method.
+func (m *MockRoleServiceServer) GrantRole(arg0 context.Context, arg1 *role.GrantRoleRequest) (*role.GrantRoleResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GrantRole", arg0, arg1)
+	ret0, _ := ret[0].(*role.GrantRoleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GrantRole indicates an expected call of GrantRole.
+func (mr *MockRoleServiceServerMockRecorder) GrantRole(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrantRole", reflect.TypeOf((*MockRoleServiceServer)(nil).GrantRole), arg0, arg1)
+}
+
+// ListRoles mocks base method.
+func (m *MockRoleServiceServer) ListRoles(arg0 context.Context, arg1 *role.ListRolesRequest) (*role.ListRolesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRoles", arg0, arg1)
+	ret0, _ := ret[0].(*role.ListRolesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRoles indicates an expected call of ListRoles.
+func (mr *MockRoleServiceServerMockRecorder) ListRoles(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRoles", reflect.TypeOf((*MockRoleServiceServer)(nil).ListRoles), arg0, arg1)
+}
+
+// ListUserRoles mocks base method.
+func (m *MockRoleServiceServer) ListUserRoles(arg0 context.Context, arg1 *role.ListUserRolesRequest) (*role.ListUserRolesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserRoles", arg0, arg1)
+	ret0, _ := ret[0].(*role.ListUserRolesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserRoles indicates an expected call of ListUserRoles.
+func (mr *MockRoleServiceServerMockRecorder) ListUserRoles(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserRoles", reflect.TypeOf((*MockRoleServiceServer)(nil).ListUserRoles), arg0, arg1)
+}
+
+// RevokeRole mocks base method.
+func (m *MockRoleServiceServer) RevokeRole(arg0 context.Context, arg1 *role.RevokeRoleRequest) (*role.RevokeRoleResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeRole", arg0, arg1)
+	ret0, _ := ret[0].(*role.RevokeRoleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeRole indicates an expected call of RevokeRole.
+func (mr *MockRoleServiceServerMockRecorder) RevokeRole(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeRole", reflect.TypeOf((*MockRoleServiceServer)(nil).RevokeRole), arg0, arg1)
+}
+
+// mustEmbedUnimplementedRoleServiceServer mocks base method.
+func (m *MockRoleServiceServer) mustEmbedUnimplementedRoleServiceServer() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "mustEmbedUnimplementedRoleServiceServer")
+}
+
+// mustEmbedUnimplementedRoleServiceServer indicates an expected call of mustEmbedUnimplementedRoleServiceServer.
+func (mr *MockRoleServiceServerMockRecorder) mustEmbedUnimplementedRoleServiceServer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "mustEmbedUnimplementedRoleServiceServer", reflect.TypeOf((*MockRoleServiceServer)(nil).mustEmbedUnimplementedRoleServiceServer))
+}
+
+// MockUnsafeRoleServiceServer is a mock of UnsafeRoleServiceServer interface.
+type MockUnsafeRoleServiceServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockUnsafeRoleServiceServerMockRecorder
+}
+
+// MockUnsafeRoleServiceServerMockRecorder is the mock recorder for MockUnsafeRoleServiceServer.
+type MockUnsafeRoleServiceServerMockRecorder struct {
+	mock *MockUnsafeRoleServiceServer
+}
+
+// NewMockUnsafeRoleServiceServer creates a new mock instance.
+func NewMockUnsafeRoleServiceServer(ctrl *gomock.Controller) *MockUnsafeRoleServiceServer {
+	mock := &MockUnsafeRoleServiceServer{ctrl: ctrl}
+	mock.recorder = &MockUnsafeRoleServiceServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUnsafeRoleServiceServer) EXPECT() *MockUnsafeRoleServiceServerMockRecorder {
+	return m.recorder
+}
+
+// mustEmbedUnimplementedRoleServiceServer mocks base method.
+func (m *MockUnsafeRoleServiceServer) mustEmbedUnimplementedRoleServiceServer() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "mustEmbedUnimplementedRoleServiceServer")
+}
+
+// mustEmbedUnimplementedRoleServiceServer indicates an expected call of mustEmbedUnimplementedRoleServiceServer.
+func (mr *MockUnsafeRoleServiceServerMockRecorder) mustEmbedUnimplementedRoleServiceServer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "mustEmbedUnimplementedRoleServiceServer", reflect.TypeOf((*MockUnsafeRoleServiceServer)(nil).mustEmbedUnimplementedRoleServiceServer))
+}
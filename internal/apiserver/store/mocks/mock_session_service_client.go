@@ -0,0 +1,282 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/proto/session/session_service_grpc.pb.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	session "github.com/skeleton1231/gotal/internal/proto/session"
+	grpc "google.golang.org/grpc"
+)
+
+// MockSessionServiceClient is a mock of SessionServiceClient interface.
+type MockSessionServiceClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockSessionServiceClientMockRecorder
+}
+
+// MockSessionServiceClientMockRecorder is the mock recorder for MockSessionServiceClient.
+type MockSessionServiceClientMockRecorder struct {
+	mock *MockSessionServiceClient
+}
+
+// NewMockSessionServiceClient creates a new mock instance.
+func NewMockSessionServiceClient(ctrl *gomock.Controller) *MockSessionServiceClient {
+	mock := &MockSessionServiceClient{ctrl: ctrl}
+	mock.recorder = &MockSessionServiceClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSessionServiceClient) EXPECT() *MockSessionServiceClientMockRecorder {
+	return m.recorder
+}
+
+// CreateSession mocks base method.
+func (m *MockSessionServiceClient) CreateSession(ctx context.Context, in *session.CreateSessionRequest, opts ...grpc.CallOption) (*session.CreateSessionResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateSession", varargs...)
+	ret0, _ := ret[0].(*session.CreateSessionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSession indicates an expected call of CreateSession.
+func (mr *MockSessionServiceClientMockRecorder) CreateSession(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockSessionServiceClient)(nil).CreateSession), varargs...)
+}
+
+// ListSessions mocks base method.
+func (m *MockSessionServiceClient) ListSessions(ctx context.Context, in *session.ListSessionsRequest, opts ...grpc.CallOption) (*session.ListSessionsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListSessions", varargs...)
+	ret0, _ := ret[0].(*session.ListSessionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSessions indicates an expected call of ListSessions.
+func (mr *MockSessionServiceClientMockRecorder) ListSessions(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSessions", reflect.TypeOf((*MockSessionServiceClient)(nil).ListSessions), varargs...)
+}
+
+// RevokeSession mocks base method.
+func (m *MockSessionServiceClient) RevokeSession(ctx context.Context, in *session.RevokeSessionRequest, opts ...grpc.CallOption) (*session.RevokeSessionResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RevokeSession", varargs...)
+	ret0, _ := ret[0].(*session.RevokeSessionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockSessionServiceClientMockRecorder) RevokeSession(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockSessionServiceClient)(nil).RevokeSession), varargs...)
+}
+
+// RevokeUserSessions mocks base method.
+func (m *MockSessionServiceClient) RevokeUserSessions(ctx context.Context, in *session.RevokeUserSessionsRequest, opts ...grpc.CallOption) (*session.RevokeUserSessionsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RevokeUserSessions", varargs...)
+	ret0, _ := ret[0].(*session.RevokeUserSessionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeUserSessions indicates an expected call of RevokeUserSessions.
+func (mr *MockSessionServiceClientMockRecorder) RevokeUserSessions(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUserSessions", reflect.TypeOf((*MockSessionServiceClient)(nil).RevokeUserSessions), varargs...)
+}
+
+// RotateSession mocks base method.
+func (m *MockSessionServiceClient) RotateSession(ctx context.Context, in *session.RotateSessionRequest, opts ...grpc.CallOption) (*session.RotateSessionResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RotateSession", varargs...)
+	ret0, _ := ret[0].(*session.RotateSessionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateSession indicates an expected call of RotateSession.
+func (mr *MockSessionServiceClientMockRecorder) RotateSession(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateSession", reflect.TypeOf((*MockSessionServiceClient)(nil).RotateSession), varargs...)
+}
+
+// MockSessionServiceServer is a mock of SessionServiceServer interface.
+type MockSessionServiceServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockSessionServiceServerMockRecorder
+}
+
+// MockSessionServiceServerMockRecorder is the mock recorder for MockSessionServiceServer.
+type MockSessionServiceServerMockRecorder struct {
+	mock *MockSessionServiceServer
+}
+
+// NewMockSessionServiceServer creates a new mock instance.
+func NewMockSessionServiceServer(ctrl *gomock.Controller) *MockSessionServiceServer {
+	mock := &MockSessionServiceServer{ctrl: ctrl}
+	mock.recorder = &MockSessionServiceServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSessionServiceServer) EXPECT() *MockSessionServiceServerMockRecorder {
+	return m.recorder
+}
+
+// CreateSession mocks base method.
+func (m *MockSessionServiceServer) CreateSession(arg0 context.Context, arg1 *session.CreateSessionRequest) (*session.CreateSessionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSession", arg0, arg1)
+	ret0, _ := ret[0].(*session.CreateSessionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSession indicates an expected call of CreateSession.
+func (mr *MockSessionServiceServerMockRecorder) CreateSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockSessionServiceServer)(nil).CreateSession), arg0, arg1)
+}
+
+// ListSessions mocks base method.
+func (m *MockSessionServiceServer) ListSessions(arg0 context.Context, arg1 *session.ListSessionsRequest) (*session.ListSessionsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSessions", arg0, arg1)
+	ret0, _ := ret[0].(*session.ListSessionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSessions indicates an expected call of ListSessions.
+func (mr *MockSessionServiceServerMockRecorder) ListSessions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSessions", reflect.TypeOf((*MockSessionServiceServer)(nil).ListSessions), arg0, arg1)
+}
+
+// RevokeSession mocks base method.
+func (m *MockSessionServiceServer) RevokeSession(arg0 context.Context, arg1 *session.RevokeSessionRequest) (*session.RevokeSessionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSession", arg0, arg1)
+	ret0, _ := ret[0].(*session.RevokeSessionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockSessionServiceServerMockRecorder) RevokeSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockSessionServiceServer)(nil).RevokeSession), arg0, arg1)
+}
+
+// RevokeUserSessions mocks base method.
+func (m *MockSessionServiceServer) RevokeUserSessions(arg0 context.Context, arg1 *session.RevokeUserSessionsRequest) (*session.RevokeUserSessionsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeUserSessions", arg0, arg1)
+	ret0, _ := ret[0].(*session.RevokeUserSessionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeUserSessions indicates an expected call of RevokeUserSessions.
+func (mr *MockSessionServiceServerMockRecorder) RevokeUserSessions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUserSessions", reflect.TypeOf((*MockSessionServiceServer)(nil).RevokeUserSessions), arg0, arg1)
+}
+
+// RotateSession mocks base method.
+func (m *MockSessionServiceServer) RotateSession(arg0 context.Context, arg1 *session.RotateSessionRequest) (*session.RotateSessionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateSession", arg0, arg1)
+	ret0, _ := ret[0].(*session.RotateSessionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateSession indicates an expected call of RotateSession.
+func (mr *MockSessionServiceServerMockRecorder) RotateSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateSession", reflect.TypeOf((*MockSessionServiceServer)(nil).RotateSession), arg0, arg1)
+}
+
+// mustEmbedUnimplementedSessionServiceServer mocks base method.
+func (m *MockSessionServiceServer) mustEmbedUnimplementedSessionServiceServer() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "mustEmbedUnimplementedSessionServiceServer")
+}
+
+// mustEmbedUnimplementedSessionServiceServer indicates an expected call of mustEmbedUnimplementedSessionServiceServer.
+func (mr *MockSessionServiceServerMockRecorder) mustEmbedUnimplementedSessionServiceServer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "mustEmbedUnimplementedSessionServiceServer", reflect.TypeOf((*MockSessionServiceServer)(nil).mustEmbedUnimplementedSessionServiceServer))
+}
+
+// MockUnsafeSessionServiceServer is a mock of UnsafeSessionServiceServer interface.
+type MockUnsafeSessionServiceServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockUnsafeSessionServiceServerMockRecorder
+}
+
+// MockUnsafeSessionServiceServerMockRecorder is the mock recorder for MockUnsafeSessionServiceServer.
+type MockUnsafeSessionServiceServerMockRecorder struct {
+	mock *MockUnsafeSessionServiceServer
+}
+
+// NewMockUnsafeSessionServiceServer creates a new mock instance.
+func NewMockUnsafeSessionServiceServer(ctrl *gomock.Controller) *MockUnsafeSessionServiceServer {
+	mock := &MockUnsafeSessionServiceServer{ctrl: ctrl}
+	mock.recorder = &MockUnsafeSessionServiceServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUnsafeSessionServiceServer) EXPECT() *MockUnsafeSessionServiceServerMockRecorder {
+	return m.recorder
+}
+
+// mustEmbedUnimplementedSessionServiceServer mocks base method.
+func (m *MockUnsafeSessionServiceServer) mustEmbedUnimplementedSessionServiceServer() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "mustEmbedUnimplementedSessionServiceServer")
+}
+
+// mustEmbedUnimplementedSessionServiceServer indicates an expected call of mustEmbedUnimplementedSessionServiceServer.
+func (mr *MockUnsafeSessionServiceServerMockRecorder) mustEmbedUnimplementedSessionServiceServer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "mustEmbedUnimplementedSessionServiceServer", reflect.TypeOf((*MockUnsafeSessionServiceServer)(nil).mustEmbedUnimplementedSessionServiceServer))
+}
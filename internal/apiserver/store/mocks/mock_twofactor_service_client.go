@@ -0,0 +1,317 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/proto/twofactor/twofactor_service_grpc.pb.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	twofactor "github.com/skeleton1231/gotal/internal/proto/twofactor"
+	grpc "google.golang.org/grpc"
+)
+
+// MockTwoFactorServiceClient is a mock of TwoFactorServiceClient interface.
+type MockTwoFactorServiceClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockTwoFactorServiceClientMockRecorder
+}
+
+// MockTwoFactorServiceClientMockRecorder is the mock recorder for MockTwoFactorServiceClient.
+type MockTwoFactorServiceClientMockRecorder struct {
+	mock *MockTwoFactorServiceClient
+}
+
+// NewMockTwoFactorServiceClient creates a new mock instance.
+func NewMockTwoFactorServiceClient(ctrl *gomock.Controller) *MockTwoFactorServiceClient {
+	mock := &MockTwoFactorServiceClient{ctrl: ctrl}
+	mock.recorder = &MockTwoFactorServiceClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTwoFactorServiceClient) EXPECT() *MockTwoFactorServiceClientMockRecorder {
+	return m.recorder
+}
+
+// DeleteTwoFactor mocks base method.
+func (m *MockTwoFactorServiceClient) DeleteTwoFactor(ctx context.Context, in *twofactor.DeleteTwoFactorRequest, opts ...grpc.CallOption) (*twofactor.DeleteTwoFactorResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteTwoFactor", varargs...)
+	ret0, _ := ret[0].(*twofactor.DeleteTwoFactorResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTwoFactor indicates an expected call of DeleteTwoFactor.
+func (mr *MockTwoFactorServiceClientMockRecorder) DeleteTwoFactor(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTwoFactor", reflect.TypeOf((*MockTwoFactorServiceClient)(nil).DeleteTwoFactor), varargs...)
+}
+
+// EnableTwoFactor mocks base method.
+func (m *MockTwoFactorServiceClient) EnableTwoFactor(ctx context.Context, in *twofactor.EnableTwoFactorRequest, opts ...grpc.CallOption) (*twofactor.EnableTwoFactorResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "EnableTwoFactor", varargs...)
+	ret0, _ := ret[0].(*twofactor.EnableTwoFactorResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnableTwoFactor indicates an expected call of EnableTwoFactor.
+func (mr *MockTwoFactorServiceClientMockRecorder) EnableTwoFactor(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableTwoFactor", reflect.TypeOf((*MockTwoFactorServiceClient)(nil).EnableTwoFactor), varargs...)
+}
+
+// GetTwoFactor mocks base method.
+func (m *MockTwoFactorServiceClient) GetTwoFactor(ctx context.Context, in *twofactor.GetTwoFactorRequest, opts ...grpc.CallOption) (*twofactor.GetTwoFactorResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetTwoFactor", varargs...)
+	ret0, _ := ret[0].(*twofactor.GetTwoFactorResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTwoFactor indicates an expected call of GetTwoFactor.
+func (mr *MockTwoFactorServiceClientMockRecorder) GetTwoFactor(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTwoFactor", reflect.TypeOf((*MockTwoFactorServiceClient)(nil).GetTwoFactor), varargs...)
+}
+
+// SaveTwoFactor mocks base method.
+func (m *MockTwoFactorServiceClient) SaveTwoFactor(ctx context.Context, in *twofactor.SaveTwoFactorRequest, opts ...grpc.CallOption) (*twofactor.SaveTwoFactorResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SaveTwoFactor", varargs...)
+	ret0, _ := ret[0].(*twofactor.SaveTwoFactorResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveTwoFactor indicates an expected call of SaveTwoFactor.
+func (mr *MockTwoFactorServiceClientMockRecorder) SaveTwoFactor(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTwoFactor", reflect.TypeOf((*MockTwoFactorServiceClient)(nil).SaveTwoFactor), varargs...)
+}
+
+// UseRecoveryCode mocks base method.
+func (m *MockTwoFactorServiceClient) UseRecoveryCode(ctx context.Context, in *twofactor.UseRecoveryCodeRequest, opts ...grpc.CallOption) (*twofactor.UseRecoveryCodeResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UseRecoveryCode", varargs...)
+	ret0, _ := ret[0].(*twofactor.UseRecoveryCodeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseRecoveryCode indicates an expected call of UseRecoveryCode.
+func (mr *MockTwoFactorServiceClientMockRecorder) UseRecoveryCode(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseRecoveryCode", reflect.TypeOf((*MockTwoFactorServiceClient)(nil).UseRecoveryCode), varargs...)
+}
+
+// UseTwoFactorStep mocks base method.
+func (m *MockTwoFactorServiceClient) UseTwoFactorStep(ctx context.Context, in *twofactor.UseTwoFactorStepRequest, opts ...grpc.CallOption) (*twofactor.UseTwoFactorStepResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UseTwoFactorStep", varargs...)
+	ret0, _ := ret[0].(*twofactor.UseTwoFactorStepResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseTwoFactorStep indicates an expected call of UseTwoFactorStep.
+func (mr *MockTwoFactorServiceClientMockRecorder) UseTwoFactorStep(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseTwoFactorStep", reflect.TypeOf((*MockTwoFactorServiceClient)(nil).UseTwoFactorStep), varargs...)
+}
+
+// MockTwoFactorServiceServer is a mock of TwoFactorServiceServer interface.
+type MockTwoFactorServiceServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockTwoFactorServiceServerMockRecorder
+}
+
+// MockTwoFactorServiceServerMockRecorder is the mock recorder for MockTwoFactorServiceServer.
+type MockTwoFactorServiceServerMockRecorder struct {
+	mock *MockTwoFactorServiceServer
+}
+
+// NewMockTwoFactorServiceServer creates a new mock instance.
+func NewMockTwoFactorServiceServer(ctrl *gomock.Controller) *MockTwoFactorServiceServer {
+	mock := &MockTwoFactorServiceServer{ctrl: ctrl}
+	mock.recorder = &MockTwoFactorServiceServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTwoFactorServiceServer) EXPECT() *MockTwoFactorServiceServerMockRecorder {
+	return m.recorder
+}
+
+// DeleteTwoFactor mocks base method.
+func (m *MockTwoFactorServiceServer) DeleteTwoFactor(arg0 context.Context, arg1 *twofactor.DeleteTwoFactorRequest) (*twofactor.DeleteTwoFactorResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTwoFactor", arg0, arg1)
+	ret0, _ := ret[0].(*twofactor.DeleteTwoFactorResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTwoFactor indicates an expected call of DeleteTwoFactor.
+func (mr *MockTwoFactorServiceServerMockRecorder) DeleteTwoFactor(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTwoFactor", reflect.TypeOf((*MockTwoFactorServiceServer)(nil).DeleteTwoFactor), arg0, arg1)
+}
+
+// EnableTwoFactor mocks base method.
+func (m *MockTwoFactorServiceServer) EnableTwoFactor(arg0 context.Context, arg1 *twofactor.EnableTwoFactorRequest) (*twofactor.EnableTwoFactorResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableTwoFactor", arg0, arg1)
+	ret0, _ := ret[0].(*twofactor.EnableTwoFactorResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnableTwoFactor indicates an expected call of EnableTwoFactor.
+func (mr *MockTwoFactorServiceServerMockRecorder) EnableTwoFactor(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableTwoFactor", reflect.TypeOf((*MockTwoFactorServiceServer)(nil).EnableTwoFactor), arg0, arg1)
+}
+
+// GetTwoFactor mocks base method.
+func (m *MockTwoFactorServiceServer) GetTwoFactor(arg0 context.Context, arg1 *twofactor.GetTwoFactorRequest) (*twofactor.GetTwoFactorResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTwoFactor", arg0, arg1)
+	ret0, _ := ret[0].(*twofactor.GetTwoFactorResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTwoFactor indicates an expected call of GetTwoFactor.
+func (mr *MockTwoFactorServiceServerMockRecorder) GetTwoFactor(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTwoFactor", reflect.TypeOf((*MockTwoFactorServiceServer)(nil).GetTwoFactor), arg0, arg1)
+}
+
+// SaveTwoFactor mocks base method.
+func (m *MockTwoFactorServiceServer) SaveTwoFactor(arg0 context.Context, arg1 *twofactor.SaveTwoFactorRequest) (*twofactor.SaveTwoFactorResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveTwoFactor", arg0, arg1)
+	ret0, _ := ret[0].(*twofactor.SaveTwoFactorResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveTwoFactor indicates an expected call of SaveTwoFactor.
+func (mr *MockTwoFactorServiceServerMockRecorder) SaveTwoFactor(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTwoFactor", reflect.TypeOf((*MockTwoFactorServiceServer)(nil).SaveTwoFactor), arg0, arg1)
+}
+
+// UseRecoveryCode mocks base method.
+func (m *MockTwoFactorServiceServer) UseRecoveryCode(arg0 context.Context, arg1 *twofactor.UseRecoveryCodeRequest) (*twofactor.UseRecoveryCodeResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseRecoveryCode", arg0, arg1)
+	ret0, _ := ret[0].(*twofactor.UseRecoveryCodeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseRecoveryCode indicates an expected call of UseRecoveryCode.
+func (mr *MockTwoFactorServiceServerMockRecorder) UseRecoveryCode(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseRecoveryCode", reflect.TypeOf((*MockTwoFactorServiceServer)(nil).UseRecoveryCode), arg0, arg1)
+}
+
+// UseTwoFactorStep mocks base method.
+func (m *MockTwoFactorServiceServer) UseTwoFactorStep(arg0 context.Context, arg1 *twofactor.UseTwoFactorStepRequest) (*twofactor.UseTwoFactorStepResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseTwoFactorStep", arg0, arg1)
+	ret0, _ := ret[0].(*twofactor.UseTwoFactorStepResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseTwoFactorStep indicates an expected call of UseTwoFactorStep.
+func (mr *MockTwoFactorServiceServerMockRecorder) UseTwoFactorStep(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseTwoFactorStep", reflect.TypeOf((*MockTwoFactorServiceServer)(nil).UseTwoFactorStep), arg0, arg1)
+}
+
+// mustEmbedUnimplementedTwoFactorServiceServer mocks base method.
+func (m *MockTwoFactorServiceServer) mustEmbedUnimplementedTwoFactorServiceServer() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "mustEmbedUnimplementedTwoFactorServiceServer")
+}
+
+// mustEmbedUnimplementedTwoFactorServiceServer indicates an expected call of mustEmbedUnimplementedTwoFactorServiceServer.
+func (mr *MockTwoFactorServiceServerMockRecorder) mustEmbedUnimplementedTwoFactorServiceServer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "mustEmbedUnimplementedTwoFactorServiceServer", reflect.TypeOf((*MockTwoFactorServiceServer)(nil).mustEmbedUnimplementedTwoFactorServiceServer))
+}
+
+// MockUnsafeTwoFactorServiceServer is a mock of UnsafeTwoFactorServiceServer interface.
+type MockUnsafeTwoFactorServiceServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockUnsafeTwoFactorServiceServerMockRecorder
+}
+
+// MockUnsafeTwoFactorServiceServerMockRecorder is the mock recorder for MockUnsafeTwoFactorServiceServer.
+type MockUnsafeTwoFactorServiceServerMockRecorder struct {
+	mock *MockUnsafeTwoFactorServiceServer
+}
+
+// NewMockUnsafeTwoFactorServiceServer creates a new mock instance.
+func NewMockUnsafeTwoFactorServiceServer(ctrl *gomock.Controller) *MockUnsafeTwoFactorServiceServer {
+	mock := &MockUnsafeTwoFactorServiceServer{ctrl: ctrl}
+	mock.recorder = &MockUnsafeTwoFactorServiceServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUnsafeTwoFactorServiceServer) EXPECT() *MockUnsafeTwoFactorServiceServerMockRecorder {
+	return m.recorder
+}
+
+// mustEmbedUnimplementedTwoFactorServiceServer mocks base method.
+func (m *MockUnsafeTwoFactorServiceServer) mustEmbedUnimplementedTwoFactorServiceServer() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "mustEmbedUnimplementedTwoFactorServiceServer")
+}
+
+// mustEmbedUnimplementedTwoFactorServiceServer indicates an expected call of mustEmbedUnimplementedTwoFactorServiceServer.
+func (mr *MockUnsafeTwoFactorServiceServerMockRecorder) mustEmbedUnimplementedTwoFactorServiceServer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "mustEmbedUnimplementedTwoFactorServiceServer", reflect.TypeOf((*MockUnsafeTwoFactorServiceServer)(nil).mustEmbedUnimplementedTwoFactorServiceServer))
+}
@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: /home/k8s0000/shared/gotal/internal/proto/user/user_service_grpc.pb.go
+// Source: internal/proto/user/user_service_grpc.pb.go
 
 // Package mocks is a generated GoMock package.
 package mocks
@@ -11,6 +11,7 @@ import (
 	gomock "github.com/golang/mock/gomock"
 	user "github.com/skeleton1231/gotal/internal/proto/user"
 	grpc "google.golang.org/grpc"
+	metadata "google.golang.org/grpc/metadata"
 )
 
 // MockUserServiceClient is a mock of UserServiceClient interface.
@@ -76,6 +77,46 @@ func (mr *MockUserServiceClientMockRecorder) Create(ctx, in interface{}, opts ..
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUserServiceClient)(nil).Create), varargs...)
 }
 
+// Credit mocks base method.
+func (m *MockUserServiceClient) Credit(ctx context.Context, in *user.CreditRequest, opts ...grpc.CallOption) (*user.CreditResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Credit", varargs...)
+	ret0, _ := ret[0].(*user.CreditResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Credit indicates an expected call of Credit.
+func (mr *MockUserServiceClientMockRecorder) Credit(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Credit", reflect.TypeOf((*MockUserServiceClient)(nil).Credit), varargs...)
+}
+
+// Debit mocks base method.
+func (m *MockUserServiceClient) Debit(ctx context.Context, in *user.CreditRequest, opts ...grpc.CallOption) (*user.CreditResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Debit", varargs...)
+	ret0, _ := ret[0].(*user.CreditResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Debit indicates an expected call of Debit.
+func (mr *MockUserServiceClientMockRecorder) Debit(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Debit", reflect.TypeOf((*MockUserServiceClient)(nil).Debit), varargs...)
+}
+
 // Delete mocks base method.
 func (m *MockUserServiceClient) Delete(ctx context.Context, in *user.DeleteRequest, opts ...grpc.CallOption) (*user.DeleteResponse, error) {
 	m.ctrl.T.Helper()
@@ -96,6 +137,46 @@ func (mr *MockUserServiceClientMockRecorder) Delete(ctx, in interface{}, opts ..
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUserServiceClient)(nil).Delete), varargs...)
 }
 
+// DeleteCollection mocks base method.
+func (m *MockUserServiceClient) DeleteCollection(ctx context.Context, in *user.DeleteCollectionRequest, opts ...grpc.CallOption) (*user.DeleteCollectionResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteCollection", varargs...)
+	ret0, _ := ret[0].(*user.DeleteCollectionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCollection indicates an expected call of DeleteCollection.
+func (mr *MockUserServiceClientMockRecorder) DeleteCollection(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCollection", reflect.TypeOf((*MockUserServiceClient)(nil).DeleteCollection), varargs...)
+}
+
+// Export mocks base method.
+func (m *MockUserServiceClient) Export(ctx context.Context, in *user.ListRequest, opts ...grpc.CallOption) (user.UserService_ExportClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Export", varargs...)
+	ret0, _ := ret[0].(user.UserService_ExportClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockUserServiceClientMockRecorder) Export(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockUserServiceClient)(nil).Export), varargs...)
+}
+
 // Get mocks base method.
 func (m *MockUserServiceClient) Get(ctx context.Context, in *user.GetRequest, opts ...grpc.CallOption) (*user.GetResponse, error) {
 	m.ctrl.T.Helper()
@@ -116,6 +197,46 @@ func (mr *MockUserServiceClientMockRecorder) Get(ctx, in interface{}, opts ...in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockUserServiceClient)(nil).Get), varargs...)
 }
 
+// GetByDiscordID mocks base method.
+func (m *MockUserServiceClient) GetByDiscordID(ctx context.Context, in *user.GetByDiscordIDRequest, opts ...grpc.CallOption) (*user.GetByDiscordIDResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetByDiscordID", varargs...)
+	ret0, _ := ret[0].(*user.GetByDiscordIDResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByDiscordID indicates an expected call of GetByDiscordID.
+func (mr *MockUserServiceClientMockRecorder) GetByDiscordID(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByDiscordID", reflect.TypeOf((*MockUserServiceClient)(nil).GetByDiscordID), varargs...)
+}
+
+// GetByEmail mocks base method.
+func (m *MockUserServiceClient) GetByEmail(ctx context.Context, in *user.GetByEmailRequest, opts ...grpc.CallOption) (*user.GetByEmailResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetByEmail", varargs...)
+	ret0, _ := ret[0].(*user.GetByEmailResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByEmail indicates an expected call of GetByEmail.
+func (mr *MockUserServiceClientMockRecorder) GetByEmail(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByEmail", reflect.TypeOf((*MockUserServiceClient)(nil).GetByEmail), varargs...)
+}
+
 // GetByUsername mocks base method.
 func (m *MockUserServiceClient) GetByUsername(ctx context.Context, in *user.GetByUsernameRequest, opts ...grpc.CallOption) (*user.GetByUsernameResponse, error) {
 	m.ctrl.T.Helper()
@@ -136,6 +257,26 @@ func (mr *MockUserServiceClientMockRecorder) GetByUsername(ctx, in interface{},
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUsername", reflect.TypeOf((*MockUserServiceClient)(nil).GetByUsername), varargs...)
 }
 
+// Import mocks base method.
+func (m *MockUserServiceClient) Import(ctx context.Context, opts ...grpc.CallOption) (user.UserService_ImportClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Import", varargs...)
+	ret0, _ := ret[0].(user.UserService_ImportClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockUserServiceClientMockRecorder) Import(ctx interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockUserServiceClient)(nil).Import), varargs...)
+}
+
 // List mocks base method.
 func (m *MockUserServiceClient) List(ctx context.Context, in *user.ListRequest, opts ...grpc.CallOption) (*user.ListResponse, error) {
 	m.ctrl.T.Helper()
@@ -149,31 +290,454 @@ func (m *MockUserServiceClient) List(ctx context.Context, in *user.ListRequest,
 	return ret0, ret1
 }
 
-// List indicates an expected call of List.
-func (mr *MockUserServiceClientMockRecorder) List(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+// List indicates an expected call of List.
+func (mr *MockUserServiceClientMockRecorder) List(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockUserServiceClient)(nil).List), varargs...)
+}
+
+// ListTransactions mocks base method.
+func (m *MockUserServiceClient) ListTransactions(ctx context.Context, in *user.ListTransactionsRequest, opts ...grpc.CallOption) (*user.ListTransactionsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListTransactions", varargs...)
+	ret0, _ := ret[0].(*user.ListTransactionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransactions indicates an expected call of ListTransactions.
+func (mr *MockUserServiceClientMockRecorder) ListTransactions(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransactions", reflect.TypeOf((*MockUserServiceClient)(nil).ListTransactions), varargs...)
+}
+
+// Update mocks base method.
+func (m *MockUserServiceClient) Update(ctx context.Context, in *user.UpdateRequest, opts ...grpc.CallOption) (*user.UpdateResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Update", varargs...)
+	ret0, _ := ret[0].(*user.UpdateResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockUserServiceClientMockRecorder) Update(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockUserServiceClient)(nil).Update), varargs...)
+}
+
+// Watch mocks base method.
+func (m *MockUserServiceClient) Watch(ctx context.Context, in *user.WatchRequest, opts ...grpc.CallOption) (user.UserService_WatchClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Watch", varargs...)
+	ret0, _ := ret[0].(user.UserService_WatchClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Watch indicates an expected call of Watch.
+func (mr *MockUserServiceClientMockRecorder) Watch(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Watch", reflect.TypeOf((*MockUserServiceClient)(nil).Watch), varargs...)
+}
+
+// MockUserService_WatchClient is a mock of UserService_WatchClient interface.
+type MockUserService_WatchClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserService_WatchClientMockRecorder
+}
+
+// MockUserService_WatchClientMockRecorder is the mock recorder for MockUserService_WatchClient.
+type MockUserService_WatchClientMockRecorder struct {
+	mock *MockUserService_WatchClient
+}
+
+// NewMockUserService_WatchClient creates a new mock instance.
+func NewMockUserService_WatchClient(ctrl *gomock.Controller) *MockUserService_WatchClient {
+	mock := &MockUserService_WatchClient{ctrl: ctrl}
+	mock.recorder = &MockUserService_WatchClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserService_WatchClient) EXPECT() *MockUserService_WatchClientMockRecorder {
+	return m.recorder
+}
+
+// CloseSend mocks base method.
+func (m *MockUserService_WatchClient) CloseSend() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseSend")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseSend indicates an expected call of CloseSend.
+func (mr *MockUserService_WatchClientMockRecorder) CloseSend() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseSend", reflect.TypeOf((*MockUserService_WatchClient)(nil).CloseSend))
+}
+
+// Context mocks base method.
+func (m *MockUserService_WatchClient) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockUserService_WatchClientMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockUserService_WatchClient)(nil).Context))
+}
+
+// Header mocks base method.
+func (m *MockUserService_WatchClient) Header() (metadata.MD, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Header")
+	ret0, _ := ret[0].(metadata.MD)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Header indicates an expected call of Header.
+func (mr *MockUserService_WatchClientMockRecorder) Header() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Header", reflect.TypeOf((*MockUserService_WatchClient)(nil).Header))
+}
+
+// Recv mocks base method.
+func (m *MockUserService_WatchClient) Recv() (*user.WatchEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recv")
+	ret0, _ := ret[0].(*user.WatchEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recv indicates an expected call of Recv.
+func (mr *MockUserService_WatchClientMockRecorder) Recv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recv", reflect.TypeOf((*MockUserService_WatchClient)(nil).Recv))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockUserService_WatchClient) RecvMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockUserService_WatchClientMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockUserService_WatchClient)(nil).RecvMsg), m)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockUserService_WatchClient) SendMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockUserService_WatchClientMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockUserService_WatchClient)(nil).SendMsg), m)
+}
+
+// Trailer mocks base method.
+func (m *MockUserService_WatchClient) Trailer() metadata.MD {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trailer")
+	ret0, _ := ret[0].(metadata.MD)
+	return ret0
+}
+
+// Trailer indicates an expected call of Trailer.
+func (mr *MockUserService_WatchClientMockRecorder) Trailer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trailer", reflect.TypeOf((*MockUserService_WatchClient)(nil).Trailer))
+}
+
+// MockUserService_ImportClient is a mock of UserService_ImportClient interface.
+type MockUserService_ImportClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserService_ImportClientMockRecorder
+}
+
+// MockUserService_ImportClientMockRecorder is the mock recorder for MockUserService_ImportClient.
+type MockUserService_ImportClientMockRecorder struct {
+	mock *MockUserService_ImportClient
+}
+
+// NewMockUserService_ImportClient creates a new mock instance.
+func NewMockUserService_ImportClient(ctrl *gomock.Controller) *MockUserService_ImportClient {
+	mock := &MockUserService_ImportClient{ctrl: ctrl}
+	mock.recorder = &MockUserService_ImportClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserService_ImportClient) EXPECT() *MockUserService_ImportClientMockRecorder {
+	return m.recorder
+}
+
+// CloseAndRecv mocks base method.
+func (m *MockUserService_ImportClient) CloseAndRecv() (*user.ImportSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseAndRecv")
+	ret0, _ := ret[0].(*user.ImportSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CloseAndRecv indicates an expected call of CloseAndRecv.
+func (mr *MockUserService_ImportClientMockRecorder) CloseAndRecv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseAndRecv", reflect.TypeOf((*MockUserService_ImportClient)(nil).CloseAndRecv))
+}
+
+// CloseSend mocks base method.
+func (m *MockUserService_ImportClient) CloseSend() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseSend")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseSend indicates an expected call of CloseSend.
+func (mr *MockUserService_ImportClientMockRecorder) CloseSend() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseSend", reflect.TypeOf((*MockUserService_ImportClient)(nil).CloseSend))
+}
+
+// Context mocks base method.
+func (m *MockUserService_ImportClient) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockUserService_ImportClientMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockUserService_ImportClient)(nil).Context))
+}
+
+// Header mocks base method.
+func (m *MockUserService_ImportClient) Header() (metadata.MD, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Header")
+	ret0, _ := ret[0].(metadata.MD)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Header indicates an expected call of Header.
+func (mr *MockUserService_ImportClientMockRecorder) Header() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Header", reflect.TypeOf((*MockUserService_ImportClient)(nil).Header))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockUserService_ImportClient) RecvMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockUserService_ImportClientMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockUserService_ImportClient)(nil).RecvMsg), m)
+}
+
+// Send mocks base method.
+func (m *MockUserService_ImportClient) Send(arg0 *user.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockUserService_ImportClientMockRecorder) Send(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockUserService_ImportClient)(nil).Send), arg0)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockUserService_ImportClient) SendMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockUserService_ImportClientMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockUserService_ImportClient)(nil).SendMsg), m)
+}
+
+// Trailer mocks base method.
+func (m *MockUserService_ImportClient) Trailer() metadata.MD {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trailer")
+	ret0, _ := ret[0].(metadata.MD)
+	return ret0
+}
+
+// Trailer indicates an expected call of Trailer.
+func (mr *MockUserService_ImportClientMockRecorder) Trailer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trailer", reflect.TypeOf((*MockUserService_ImportClient)(nil).Trailer))
+}
+
+// MockUserService_ExportClient is a mock of UserService_ExportClient interface.
+type MockUserService_ExportClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserService_ExportClientMockRecorder
+}
+
+// MockUserService_ExportClientMockRecorder is the mock recorder for MockUserService_ExportClient.
+type MockUserService_ExportClientMockRecorder struct {
+	mock *MockUserService_ExportClient
+}
+
+// NewMockUserService_ExportClient creates a new mock instance.
+func NewMockUserService_ExportClient(ctrl *gomock.Controller) *MockUserService_ExportClient {
+	mock := &MockUserService_ExportClient{ctrl: ctrl}
+	mock.recorder = &MockUserService_ExportClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserService_ExportClient) EXPECT() *MockUserService_ExportClientMockRecorder {
+	return m.recorder
+}
+
+// CloseSend mocks base method.
+func (m *MockUserService_ExportClient) CloseSend() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseSend")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseSend indicates an expected call of CloseSend.
+func (mr *MockUserService_ExportClientMockRecorder) CloseSend() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseSend", reflect.TypeOf((*MockUserService_ExportClient)(nil).CloseSend))
+}
+
+// Context mocks base method.
+func (m *MockUserService_ExportClient) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockUserService_ExportClientMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockUserService_ExportClient)(nil).Context))
+}
+
+// Header mocks base method.
+func (m *MockUserService_ExportClient) Header() (metadata.MD, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Header")
+	ret0, _ := ret[0].(metadata.MD)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Header indicates an expected call of Header.
+func (mr *MockUserService_ExportClientMockRecorder) Header() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Header", reflect.TypeOf((*MockUserService_ExportClient)(nil).Header))
+}
+
+// Recv mocks base method.
+func (m *MockUserService_ExportClient) Recv() (*user.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recv")
+	ret0, _ := ret[0].(*user.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recv indicates an expected call of Recv.
+func (mr *MockUserService_ExportClientMockRecorder) Recv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recv", reflect.TypeOf((*MockUserService_ExportClient)(nil).Recv))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockUserService_ExportClient) RecvMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockUserService_ExportClientMockRecorder) RecvMsg(m interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{ctx, in}, opts...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockUserServiceClient)(nil).List), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockUserService_ExportClient)(nil).RecvMsg), m)
 }
 
-// Update mocks base method.
-func (m *MockUserServiceClient) Update(ctx context.Context, in *user.UpdateRequest, opts ...grpc.CallOption) (*user.UpdateResponse, error) {
+// SendMsg mocks base method.
+func (m_2 *MockUserService_ExportClient) SendMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockUserService_ExportClientMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockUserService_ExportClient)(nil).SendMsg), m)
+}
+
+// Trailer mocks base method.
+func (m *MockUserService_ExportClient) Trailer() metadata.MD {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{ctx, in}
-	for _, a := range opts {
-		varargs = append(varargs, a)
-	}
-	ret := m.ctrl.Call(m, "Update", varargs...)
-	ret0, _ := ret[0].(*user.UpdateResponse)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "Trailer")
+	ret0, _ := ret[0].(metadata.MD)
+	return ret0
 }
 
-// Update indicates an expected call of Update.
-func (mr *MockUserServiceClientMockRecorder) Update(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+// Trailer indicates an expected call of Trailer.
+func (mr *MockUserService_ExportClientMockRecorder) Trailer() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{ctx, in}, opts...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockUserServiceClient)(nil).Update), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trailer", reflect.TypeOf((*MockUserService_ExportClient)(nil).Trailer))
 }
 
 // MockUserServiceServer is a mock of UserServiceServer interface.
@@ -229,6 +793,36 @@ func (mr *MockUserServiceServerMockRecorder) Create(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUserServiceServer)(nil).Create), arg0, arg1)
 }
 
+// Credit mocks base method.
+func (m *MockUserServiceServer) Credit(arg0 context.Context, arg1 *user.CreditRequest) (*user.CreditResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Credit", arg0, arg1)
+	ret0, _ := ret[0].(*user.CreditResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Credit indicates an expected call of Credit.
+func (mr *MockUserServiceServerMockRecorder) Credit(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Credit", reflect.TypeOf((*MockUserServiceServer)(nil).Credit), arg0, arg1)
+}
+
+// Debit mocks base method.
+func (m *MockUserServiceServer) Debit(arg0 context.Context, arg1 *user.CreditRequest) (*user.CreditResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Debit", arg0, arg1)
+	ret0, _ := ret[0].(*user.CreditResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Debit indicates an expected call of Debit.
+func (mr *MockUserServiceServerMockRecorder) Debit(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Debit", reflect.TypeOf((*MockUserServiceServer)(nil).Debit), arg0, arg1)
+}
+
 // Delete mocks base method.
 func (m *MockUserServiceServer) Delete(arg0 context.Context, arg1 *user.DeleteRequest) (*user.DeleteResponse, error) {
 	m.ctrl.T.Helper()
@@ -244,6 +838,35 @@ func (mr *MockUserServiceServerMockRecorder) Delete(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUserServiceServer)(nil).Delete), arg0, arg1)
 }
 
+// DeleteCollection mocks base method.
+func (m *MockUserServiceServer) DeleteCollection(arg0 context.Context, arg1 *user.DeleteCollectionRequest) (*user.DeleteCollectionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCollection", arg0, arg1)
+	ret0, _ := ret[0].(*user.DeleteCollectionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCollection indicates an expected call of DeleteCollection.
+func (mr *MockUserServiceServerMockRecorder) DeleteCollection(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCollection", reflect.TypeOf((*MockUserServiceServer)(nil).DeleteCollection), arg0, arg1)
+}
+
+// Export mocks base method.
+func (m *MockUserServiceServer) Export(arg0 *user.ListRequest, arg1 user.UserService_ExportServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockUserServiceServerMockRecorder) Export(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockUserServiceServer)(nil).Export), arg0, arg1)
+}
+
 // Get mocks base method.
 func (m *MockUserServiceServer) Get(arg0 context.Context, arg1 *user.GetRequest) (*user.GetResponse, error) {
 	m.ctrl.T.Helper()
@@ -259,6 +882,36 @@ func (mr *MockUserServiceServerMockRecorder) Get(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockUserServiceServer)(nil).Get), arg0, arg1)
 }
 
+// GetByDiscordID mocks base method.
+func (m *MockUserServiceServer) GetByDiscordID(arg0 context.Context, arg1 *user.GetByDiscordIDRequest) (*user.GetByDiscordIDResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByDiscordID", arg0, arg1)
+	ret0, _ := ret[0].(*user.GetByDiscordIDResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByDiscordID indicates an expected call of GetByDiscordID.
+func (mr *MockUserServiceServerMockRecorder) GetByDiscordID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByDiscordID", reflect.TypeOf((*MockUserServiceServer)(nil).GetByDiscordID), arg0, arg1)
+}
+
+// GetByEmail mocks base method.
+func (m *MockUserServiceServer) GetByEmail(arg0 context.Context, arg1 *user.GetByEmailRequest) (*user.GetByEmailResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByEmail", arg0, arg1)
+	ret0, _ := ret[0].(*user.GetByEmailResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByEmail indicates an expected call of GetByEmail.
+func (mr *MockUserServiceServerMockRecorder) GetByEmail(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByEmail", reflect.TypeOf((*MockUserServiceServer)(nil).GetByEmail), arg0, arg1)
+}
+
 // GetByUsername mocks base method.
 func (m *MockUserServiceServer) GetByUsername(arg0 context.Context, arg1 *user.GetByUsernameRequest) (*user.GetByUsernameResponse, error) {
 	m.ctrl.T.Helper()
@@ -274,6 +927,20 @@ func (mr *MockUserServiceServerMockRecorder) GetByUsername(arg0, arg1 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUsername", reflect.TypeOf((*MockUserServiceServer)(nil).GetByUsername), arg0, arg1)
 }
 
+// Import mocks base method.
+func (m *MockUserServiceServer) Import(arg0 user.UserService_ImportServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockUserServiceServerMockRecorder) Import(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockUserServiceServer)(nil).Import), arg0)
+}
+
 // List mocks base method.
 func (m *MockUserServiceServer) List(arg0 context.Context, arg1 *user.ListRequest) (*user.ListResponse, error) {
 	m.ctrl.T.Helper()
@@ -289,6 +956,21 @@ func (mr *MockUserServiceServerMockRecorder) List(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockUserServiceServer)(nil).List), arg0, arg1)
 }
 
+// ListTransactions mocks base method.
+func (m *MockUserServiceServer) ListTransactions(arg0 context.Context, arg1 *user.ListTransactionsRequest) (*user.ListTransactionsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransactions", arg0, arg1)
+	ret0, _ := ret[0].(*user.ListTransactionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransactions indicates an expected call of ListTransactions.
+func (mr *MockUserServiceServerMockRecorder) ListTransactions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransactions", reflect.TypeOf((*MockUserServiceServer)(nil).ListTransactions), arg0, arg1)
+}
+
 // Update mocks base method.
 func (m *MockUserServiceServer) Update(arg0 context.Context, arg1 *user.UpdateRequest) (*user.UpdateResponse, error) {
 	m.ctrl.T.Helper()
@@ -304,6 +986,20 @@ func (mr *MockUserServiceServerMockRecorder) Update(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockUserServiceServer)(nil).Update), arg0, arg1)
 }
 
+// Watch mocks base method.
+func (m *MockUserServiceServer) Watch(arg0 *user.WatchRequest, arg1 user.UserService_WatchServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Watch", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Watch indicates an expected call of Watch.
+func (mr *MockUserServiceServerMockRecorder) Watch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Watch", reflect.TypeOf((*MockUserServiceServer)(nil).Watch), arg0, arg1)
+}
+
 // mustEmbedUnimplementedUserServiceServer mocks base method.
 func (m *MockUserServiceServer) mustEmbedUnimplementedUserServiceServer() {
 	m.ctrl.T.Helper()
@@ -350,3 +1046,375 @@ func (mr *MockUnsafeUserServiceServerMockRecorder) mustEmbedUnimplementedUserSer
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "mustEmbedUnimplementedUserServiceServer", reflect.TypeOf((*MockUnsafeUserServiceServer)(nil).mustEmbedUnimplementedUserServiceServer))
 }
+
+// MockUserService_WatchServer is a mock of UserService_WatchServer interface.
+type MockUserService_WatchServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserService_WatchServerMockRecorder
+}
+
+// MockUserService_WatchServerMockRecorder is the mock recorder for MockUserService_WatchServer.
+type MockUserService_WatchServerMockRecorder struct {
+	mock *MockUserService_WatchServer
+}
+
+// NewMockUserService_WatchServer creates a new mock instance.
+func NewMockUserService_WatchServer(ctrl *gomock.Controller) *MockUserService_WatchServer {
+	mock := &MockUserService_WatchServer{ctrl: ctrl}
+	mock.recorder = &MockUserService_WatchServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserService_WatchServer) EXPECT() *MockUserService_WatchServerMockRecorder {
+	return m.recorder
+}
+
+// Context mocks base method.
+func (m *MockUserService_WatchServer) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockUserService_WatchServerMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockUserService_WatchServer)(nil).Context))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockUserService_WatchServer) RecvMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockUserService_WatchServerMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockUserService_WatchServer)(nil).RecvMsg), m)
+}
+
+// Send mocks base method.
+func (m *MockUserService_WatchServer) Send(arg0 *user.WatchEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockUserService_WatchServerMockRecorder) Send(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockUserService_WatchServer)(nil).Send), arg0)
+}
+
+// SendHeader mocks base method.
+func (m *MockUserService_WatchServer) SendHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendHeader indicates an expected call of SendHeader.
+func (mr *MockUserService_WatchServerMockRecorder) SendHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendHeader", reflect.TypeOf((*MockUserService_WatchServer)(nil).SendHeader), arg0)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockUserService_WatchServer) SendMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockUserService_WatchServerMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockUserService_WatchServer)(nil).SendMsg), m)
+}
+
+// SetHeader mocks base method.
+func (m *MockUserService_WatchServer) SetHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHeader indicates an expected call of SetHeader.
+func (mr *MockUserService_WatchServerMockRecorder) SetHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHeader", reflect.TypeOf((*MockUserService_WatchServer)(nil).SetHeader), arg0)
+}
+
+// SetTrailer mocks base method.
+func (m *MockUserService_WatchServer) SetTrailer(arg0 metadata.MD) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTrailer", arg0)
+}
+
+// SetTrailer indicates an expected call of SetTrailer.
+func (mr *MockUserService_WatchServerMockRecorder) SetTrailer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockUserService_WatchServer)(nil).SetTrailer), arg0)
+}
+
+// MockUserService_ImportServer is a mock of UserService_ImportServer interface.
+type MockUserService_ImportServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserService_ImportServerMockRecorder
+}
+
+// MockUserService_ImportServerMockRecorder is the mock recorder for MockUserService_ImportServer.
+type MockUserService_ImportServerMockRecorder struct {
+	mock *MockUserService_ImportServer
+}
+
+// NewMockUserService_ImportServer creates a new mock instance.
+func NewMockUserService_ImportServer(ctrl *gomock.Controller) *MockUserService_ImportServer {
+	mock := &MockUserService_ImportServer{ctrl: ctrl}
+	mock.recorder = &MockUserService_ImportServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserService_ImportServer) EXPECT() *MockUserService_ImportServerMockRecorder {
+	return m.recorder
+}
+
+// Context mocks base method.
+func (m *MockUserService_ImportServer) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockUserService_ImportServerMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockUserService_ImportServer)(nil).Context))
+}
+
+// Recv mocks base method.
+func (m *MockUserService_ImportServer) Recv() (*user.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recv")
+	ret0, _ := ret[0].(*user.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recv indicates an expected call of Recv.
+func (mr *MockUserService_ImportServerMockRecorder) Recv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recv", reflect.TypeOf((*MockUserService_ImportServer)(nil).Recv))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockUserService_ImportServer) RecvMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockUserService_ImportServerMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockUserService_ImportServer)(nil).RecvMsg), m)
+}
+
+// SendAndClose mocks base method.
+func (m *MockUserService_ImportServer) SendAndClose(arg0 *user.ImportSummary) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendAndClose", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendAndClose indicates an expected call of SendAndClose.
+func (mr *MockUserService_ImportServerMockRecorder) SendAndClose(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendAndClose", reflect.TypeOf((*MockUserService_ImportServer)(nil).SendAndClose), arg0)
+}
+
+// SendHeader mocks base method.
+func (m *MockUserService_ImportServer) SendHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendHeader indicates an expected call of SendHeader.
+func (mr *MockUserService_ImportServerMockRecorder) SendHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendHeader", reflect.TypeOf((*MockUserService_ImportServer)(nil).SendHeader), arg0)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockUserService_ImportServer) SendMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockUserService_ImportServerMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockUserService_ImportServer)(nil).SendMsg), m)
+}
+
+// SetHeader mocks base method.
+func (m *MockUserService_ImportServer) SetHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHeader indicates an expected call of SetHeader.
+func (mr *MockUserService_ImportServerMockRecorder) SetHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHeader", reflect.TypeOf((*MockUserService_ImportServer)(nil).SetHeader), arg0)
+}
+
+// SetTrailer mocks base method.
+func (m *MockUserService_ImportServer) SetTrailer(arg0 metadata.MD) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTrailer", arg0)
+}
+
+// SetTrailer indicates an expected call of SetTrailer.
+func (mr *MockUserService_ImportServerMockRecorder) SetTrailer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockUserService_ImportServer)(nil).SetTrailer), arg0)
+}
+
+// MockUserService_ExportServer is a mock of UserService_ExportServer interface.
+type MockUserService_ExportServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserService_ExportServerMockRecorder
+}
+
+// MockUserService_ExportServerMockRecorder is the mock recorder for MockUserService_ExportServer.
+type MockUserService_ExportServerMockRecorder struct {
+	mock *MockUserService_ExportServer
+}
+
+// NewMockUserService_ExportServer creates a new mock instance.
+func NewMockUserService_ExportServer(ctrl *gomock.Controller) *MockUserService_ExportServer {
+	mock := &MockUserService_ExportServer{ctrl: ctrl}
+	mock.recorder = &MockUserService_ExportServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserService_ExportServer) EXPECT() *MockUserService_ExportServerMockRecorder {
+	return m.recorder
+}
+
+// Context mocks base method.
+func (m *MockUserService_ExportServer) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockUserService_ExportServerMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockUserService_ExportServer)(nil).Context))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockUserService_ExportServer) RecvMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockUserService_ExportServerMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockUserService_ExportServer)(nil).RecvMsg), m)
+}
+
+// Send mocks base method.
+func (m *MockUserService_ExportServer) Send(arg0 *user.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockUserService_ExportServerMockRecorder) Send(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockUserService_ExportServer)(nil).Send), arg0)
+}
+
+// SendHeader mocks base method.
+func (m *MockUserService_ExportServer) SendHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendHeader indicates an expected call of SendHeader.
+func (mr *MockUserService_ExportServerMockRecorder) SendHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendHeader", reflect.TypeOf((*MockUserService_ExportServer)(nil).SendHeader), arg0)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockUserService_ExportServer) SendMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockUserService_ExportServerMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockUserService_ExportServer)(nil).SendMsg), m)
+}
+
+// SetHeader mocks base method.
+func (m *MockUserService_ExportServer) SetHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHeader indicates an expected call of SetHeader.
+func (mr *MockUserService_ExportServerMockRecorder) SetHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHeader", reflect.TypeOf((*MockUserService_ExportServer)(nil).SetHeader), arg0)
+}
+
+// SetTrailer mocks base method.
+func (m *MockUserService_ExportServer) SetTrailer(arg0 metadata.MD) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTrailer", arg0)
+}
+
+// SetTrailer indicates an expected call of SetTrailer.
+func (mr *MockUserService_ExportServerMockRecorder) SetTrailer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockUserService_ExportServer)(nil).SetTrailer), arg0)
+}
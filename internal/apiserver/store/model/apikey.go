@@ -0,0 +1,75 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	pb "github.com/skeleton1231/gotal/internal/proto/apikey"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// APIKey is a long-lived credential used by robot clients. Only the hash of the key is stored,
+// the key itself is returned once in Token when it is created.
+type APIKey struct {
+	ID        uint64     `json:"id" gorm:"primary_key;AUTO_INCREMENT;column:id"`
+	UserID    uint64     `json:"userId" gorm:"column:user_id;not null;index:idx_user_id"`
+	Name      string     `json:"name" gorm:"column:name;type:varchar(64);not null" validate:"required,min=1,max=64"`
+	Hash      string     `json:"-" gorm:"column:hash;type:varchar(128);not null;uniqueIndex:idx_hash"`
+	CreatedAt time.Time  `json:"createdAt" gorm:"column:created_at"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty" gorm:"column:expires_at"`
+	Token     string     `json:"token,omitempty" gorm:"-"`
+}
+
+// TableName overrides the table name used by APIKey to `api_keys`.
+func (APIKey) TableName() string {
+	return "api_keys"
+}
+
+// Expired reports whether the key is expired at the given time.
+func (k *APIKey) Expired(now time.Time) bool {
+	return k.ExpiresAt != nil && !now.Before(*k.ExpiresAt)
+}
+
+// APIKeyList is the list of the API keys of a user.
+type APIKeyList struct {
+	ListMeta `json:",inline"`
+
+	Items []*APIKey `json:"items"`
+}
+
+// APIKeyToProto converts APIKey model to protobuf message.
+func APIKeyToProto(k *APIKey) *pb.APIKey {
+	key := &pb.APIKey{
+		Id:        k.ID,
+		UserId:    k.UserID,
+		Name:      k.Name,
+		Hash:      k.Hash,
+		CreatedAt: timestamppb.New(k.CreatedAt),
+	}
+	if k.ExpiresAt != nil {
+		key.ExpiresAt = timestamppb.New(*k.ExpiresAt)
+	}
+
+	return key
+}
+
+// ProtoToAPIKey converts protobuf message to APIKey model.
+func ProtoToAPIKey(pbKey *pb.APIKey) (*APIKey, error) {
+	if pbKey == nil {
+		return nil, errors.New("apiKeyProto is nil")
+	}
+
+	key := &APIKey{
+		ID:        pbKey.GetId(),
+		UserID:    pbKey.GetUserId(),
+		Name:      pbKey.GetName(),
+		Hash:      pbKey.GetHash(),
+		CreatedAt: pbKey.GetCreatedAt().AsTime(),
+	}
+	if pbKey.GetExpiresAt() != nil {
+		expiresAt := pbKey.GetExpiresAt().AsTime()
+		key.ExpiresAt = &expiresAt
+	}
+
+	return key, nil
+}
@@ -0,0 +1,78 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	pb "github.com/skeleton1231/gotal/internal/proto/user"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// MaxIdempotencyKeyLength is the longest idempotency key a credit transaction accepts.
+const MaxIdempotencyKeyLength = 64
+
+// CreditTransaction is an entry of the credits ledger. The balance of a user, cached in
+// User.TotalCredits, is the sum of the amounts of their transactions.
+type CreditTransaction struct {
+	ID     uint64 `json:"id" gorm:"primary_key;AUTO_INCREMENT;column:id"`
+	UserID uint64 `json:"userId" gorm:"column:user_id;not null;uniqueIndex:idx_user_idempotency_key,priority:1"`
+	// Amount is positive for credits and negative for debits.
+	Amount int64 `json:"amount" gorm:"column:amount;not null"`
+	// Balance is the balance of the user right after the transaction.
+	Balance        int64     `json:"balance" gorm:"column:balance;not null"`
+	IdempotencyKey string    `json:"idempotencyKey" gorm:"column:idempotency_key;type:varchar(64);not null;uniqueIndex:idx_user_idempotency_key,priority:2"`
+	Reason         string    `json:"reason,omitempty" gorm:"column:reason;type:varchar(255)"`
+	CreatedAt      time.Time `json:"createdAt" gorm:"column:created_at"`
+	// Replayed is set when the idempotency key had already been used, the transaction is
+	// then the one recorded the first time.
+	Replayed bool `json:"replayed,omitempty" gorm:"-"`
+}
+
+// TableName overrides the table name used by CreditTransaction to `credit_transactions`.
+func (CreditTransaction) TableName() string {
+	return "credit_transactions"
+}
+
+// CreditTransactionList is the list of the transactions of a user, newest first.
+type CreditTransactionList struct {
+	ListMeta `json:",inline"`
+
+	Items []*CreditTransaction `json:"items"`
+}
+
+// CreditDrift is a user whose cached balance differs from the sum of their ledger.
+type CreditDrift struct {
+	UserID uint64 `json:"userId"`
+	Cached int64  `json:"cached"`
+	Ledger int64  `json:"ledger"`
+}
+
+// CreditTransactionToProto converts CreditTransaction model to protobuf message.
+func CreditTransactionToProto(t *CreditTransaction) *pb.CreditTransaction {
+	return &pb.CreditTransaction{
+		Id:             t.ID,
+		UserId:         t.UserID,
+		Amount:         t.Amount,
+		Balance:        t.Balance,
+		IdempotencyKey: t.IdempotencyKey,
+		Reason:         t.Reason,
+		CreatedAt:      timestamppb.New(t.CreatedAt),
+	}
+}
+
+// ProtoToCreditTransaction converts protobuf message to CreditTransaction model.
+func ProtoToCreditTransaction(pbTransaction *pb.CreditTransaction) (*CreditTransaction, error) {
+	if pbTransaction == nil {
+		return nil, errors.New("creditTransactionProto is nil")
+	}
+
+	return &CreditTransaction{
+		ID:             pbTransaction.GetId(),
+		UserID:         pbTransaction.GetUserId(),
+		Amount:         pbTransaction.GetAmount(),
+		Balance:        pbTransaction.GetBalance(),
+		IdempotencyKey: pbTransaction.GetIdempotencyKey(),
+		Reason:         pbTransaction.GetReason(),
+		CreatedAt:      pbTransaction.GetCreatedAt().AsTime(),
+	}, nil
+}
@@ -0,0 +1,118 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+
+	pb "github.com/skeleton1231/gotal/internal/proto/user"
+)
+
+const (
+	// ImportOnConflictSkip keeps an existing user with the same name untouched.
+	ImportOnConflictSkip = "skip"
+
+	// ImportOnConflictUpsert overwrites an existing user with the same name.
+	ImportOnConflictUpsert = "upsert"
+)
+
+// ImportOnConflictMetadataKey is the gRPC metadata which carries ImportOptions.OnConflict
+// along with an Import stream.
+const ImportOnConflictMetadataKey = "x-gotal-on-conflict"
+
+// ImportOptions tells how a bulk import treats users which already exist.
+type ImportOptions struct {
+	// OnConflict is either ImportOnConflictSkip, the default, or ImportOnConflictUpsert.
+	OnConflict string `json:"onConflict,omitempty" form:"onConflict"`
+}
+
+// Validate verifies the conflict policy.
+func (o ImportOptions) Validate() error {
+	switch o.OnConflict {
+	case "", ImportOnConflictSkip, ImportOnConflictUpsert:
+		return nil
+	default:
+		return fmt.Errorf("unsupported onConflict value `%s`, use `%s` or `%s`",
+			o.OnConflict, ImportOnConflictSkip, ImportOnConflictUpsert)
+	}
+}
+
+// Upsert reports whether existing users are overwritten.
+func (o ImportOptions) Upsert() bool {
+	return o.OnConflict == ImportOnConflictUpsert
+}
+
+// UserImportError is a row which could not be imported.
+type UserImportError struct {
+	// Row is the 1-based position of the user in the import.
+	Row    int    `json:"row"`
+	Name   string `json:"name,omitempty"`
+	Reason string `json:"reason"`
+}
+
+// UserImportSummary is the outcome of a bulk import.
+type UserImportSummary struct {
+	Created int                `json:"created"`
+	Updated int                `json:"updated"`
+	Skipped int                `json:"skipped"`
+	Failed  int                `json:"failed"`
+	Errors  []*UserImportError `json:"errors,omitempty"`
+}
+
+// Fail records a row which could not be imported.
+func (s *UserImportSummary) Fail(row int, name, reason string) {
+	s.Failed++
+	s.Errors = append(s.Errors, &UserImportError{Row: row, Name: name, Reason: reason})
+}
+
+// Merge adds the summary of a part of the import. The rows of the part are numbered
+// from 1, rows maps them to their position in the whole import.
+func (s *UserImportSummary) Merge(part *UserImportSummary, rows []int) {
+	s.Created += part.Created
+	s.Updated += part.Updated
+	s.Skipped += part.Skipped
+	s.Failed += part.Failed
+
+	for _, e := range part.Errors {
+		row := e.Row
+		if row > 0 && row <= len(rows) {
+			row = rows[row-1]
+		}
+		s.Errors = append(s.Errors, &UserImportError{Row: row, Name: e.Name, Reason: e.Reason})
+	}
+}
+
+// SortErrors orders the errors by row.
+func (s *UserImportSummary) SortErrors() {
+	sort.SliceStable(s.Errors, func(i, j int) bool { return s.Errors[i].Row < s.Errors[j].Row })
+}
+
+// UserImportSummaryToProto converts UserImportSummary model to protobuf message.
+func UserImportSummaryToProto(s *UserImportSummary) *pb.ImportSummary {
+	errs := make([]*pb.ImportError, 0, len(s.Errors))
+	for _, e := range s.Errors {
+		errs = append(errs, &pb.ImportError{Row: int32(e.Row), Name: e.Name, Reason: e.Reason})
+	}
+
+	return &pb.ImportSummary{
+		Created: int32(s.Created),
+		Updated: int32(s.Updated),
+		Skipped: int32(s.Skipped),
+		Failed:  int32(s.Failed),
+		Errors:  errs,
+	}
+}
+
+// ProtoToUserImportSummary converts protobuf message to UserImportSummary model.
+func ProtoToUserImportSummary(pbSummary *pb.ImportSummary) *UserImportSummary {
+	s := &UserImportSummary{
+		Created: int(pbSummary.GetCreated()),
+		Updated: int(pbSummary.GetUpdated()),
+		Skipped: int(pbSummary.GetSkipped()),
+		Failed:  int(pbSummary.GetFailed()),
+	}
+	for _, e := range pbSummary.GetErrors() {
+		s.Errors = append(s.Errors, &UserImportError{Row: int(e.GetRow()), Name: e.GetName(), Reason: e.GetReason()})
+	}
+
+	return s
+}
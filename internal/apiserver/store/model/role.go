@@ -0,0 +1,103 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	pb "github.com/skeleton1231/gotal/internal/proto/role"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Role is a named set of permissions which can be granted to users.
+type Role struct {
+	ObjectMeta
+	Name        string `json:"name" gorm:"column:name;type:varchar(64);not null;uniqueIndex:idx_role_name" validate:"required,min=1,max=64"`
+	Description string `json:"description,omitempty" gorm:"column:description;type:varchar(255)" validate:"omitempty,max=255"`
+}
+
+// TableName overrides the table name used by Role to `roles`.
+func (Role) TableName() string {
+	return "roles"
+}
+
+// RoleList is the whole list of all roles which have been stored in stroage.
+type RoleList struct {
+	ListMeta `json:",inline"`
+
+	Items []*Role `json:"items"`
+}
+
+// UserRole binds a role to a user.
+type UserRole struct {
+	UserID    uint64    `json:"userId" gorm:"column:user_id;primaryKey;autoIncrement:false"`
+	RoleID    uint64    `json:"roleId" gorm:"column:role_id;primaryKey;autoIncrement:false;index:idx_role_id"`
+	CreatedAt time.Time `json:"createdAt" gorm:"column:created_at"`
+}
+
+// TableName overrides the table name used by UserRole to `user_roles`.
+func (UserRole) TableName() string {
+	return "user_roles"
+}
+
+// RoleNames returns the names of the roles in the list.
+func (l *RoleList) RoleNames() []string {
+	names := make([]string, 0, len(l.Items))
+	for _, r := range l.Items {
+		names = append(names, r.Name)
+	}
+
+	return names
+}
+
+// RoleToProto converts Role model to protobuf message.
+func RoleToProto(r *Role) *pb.Role {
+	return &pb.Role{
+		Id:          r.ID,
+		Name:        r.Name,
+		Description: r.Description,
+		CreatedAt:   timestamppb.New(r.CreatedAt),
+		UpdatedAt:   timestamppb.New(r.UpdatedAt),
+	}
+}
+
+// ProtoToRole converts protobuf message to Role model.
+func ProtoToRole(pbRole *pb.Role) (*Role, error) {
+	if pbRole == nil {
+		return nil, errors.New("roleProto is nil")
+	}
+
+	return &Role{
+		ObjectMeta: ObjectMeta{
+			ID:        pbRole.GetId(),
+			CreatedAt: pbRole.GetCreatedAt().AsTime(),
+			UpdatedAt: pbRole.GetUpdatedAt().AsTime(),
+		},
+		Name:        pbRole.GetName(),
+		Description: pbRole.GetDescription(),
+	}, nil
+}
+
+// RoleListToProto converts RoleList model to protobuf message.
+func RoleListToProto(l *RoleList) *pb.RoleList {
+	items := make([]*pb.Role, 0, len(l.Items))
+	for _, r := range l.Items {
+		items = append(items, RoleToProto(r))
+	}
+
+	return &pb.RoleList{Items: items, TotalCount: l.TotalCount}
+}
+
+// ProtoToRoleList converts protobuf message to RoleList model.
+func ProtoToRoleList(pbList *pb.RoleList) *RoleList {
+	list := &RoleList{
+		ListMeta: ListMeta{TotalCount: pbList.GetTotalCount()},
+		Items:    make([]*Role, 0, len(pbList.GetItems())),
+	}
+	for _, item := range pbList.GetItems() {
+		if r, err := ProtoToRole(item); err == nil {
+			list.Items = append(list.Items, r)
+		}
+	}
+
+	return list
+}
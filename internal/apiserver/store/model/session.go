@@ -0,0 +1,123 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	pb "github.com/skeleton1231/gotal/internal/proto/session"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Session is a login of a user on a device. The refresh token of the session is rotated on
+// every use, only the hash of the current token is stored. Token is only set when the
+// refresh token has just been issued.
+type Session struct {
+	ID          uint64     `json:"id" gorm:"primary_key;AUTO_INCREMENT;column:id"`
+	UserID      uint64     `json:"userId" gorm:"column:user_id;not null;index:idx_user_id"`
+	RefreshHash string     `json:"-" gorm:"column:refresh_hash;type:varchar(128);not null;uniqueIndex:idx_refresh_hash"`
+	UserAgent   string     `json:"userAgent" gorm:"column:user_agent;type:varchar(255)"`
+	ClientIP    string     `json:"clientIP" gorm:"column:client_ip;type:varchar(64)"`
+	CreatedAt   time.Time  `json:"createdAt" gorm:"column:created_at"`
+	LastUsedAt  time.Time  `json:"lastUsedAt" gorm:"column:last_used_at"`
+	ExpiresAt   time.Time  `json:"expiresAt" gorm:"column:expires_at"`
+	RevokedAt   *time.Time `json:"revokedAt,omitempty" gorm:"column:revoked_at"`
+	Token       string     `json:"-" gorm:"-"`
+}
+
+// TableName overrides the table name used by Session to `sessions`.
+func (Session) TableName() string {
+	return "sessions"
+}
+
+// Active reports whether the session can still be refreshed at the given time.
+func (s *Session) Active(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
+
+// RetiredRefreshToken is a refresh token which has been rotated. Presenting it again means it
+// was stolen, so the whole session is revoked.
+type RetiredRefreshToken struct {
+	Hash      string    `gorm:"primary_key;column:hash;type:varchar(128)"`
+	SessionID uint64    `gorm:"column:session_id;not null;index:idx_session_id"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+}
+
+// TableName overrides the table name used by RetiredRefreshToken to `retired_refresh_tokens`.
+func (RetiredRefreshToken) TableName() string {
+	return "retired_refresh_tokens"
+}
+
+// SessionList is the list of the sessions of a user.
+type SessionList struct {
+	ListMeta `json:",inline"`
+
+	Items []*Session `json:"items"`
+}
+
+// SessionToProto converts Session model to protobuf message.
+func SessionToProto(s *Session) *pb.Session {
+	session := &pb.Session{
+		Id:          s.ID,
+		UserId:      s.UserID,
+		RefreshHash: s.RefreshHash,
+		UserAgent:   s.UserAgent,
+		ClientIP:    s.ClientIP,
+		CreatedAt:   timestamppb.New(s.CreatedAt),
+		LastUsedAt:  timestamppb.New(s.LastUsedAt),
+		ExpiresAt:   timestamppb.New(s.ExpiresAt),
+	}
+	if s.RevokedAt != nil {
+		session.RevokedAt = timestamppb.New(*s.RevokedAt)
+	}
+
+	return session
+}
+
+// ProtoToSession converts protobuf message to Session model.
+func ProtoToSession(pbSession *pb.Session) (*Session, error) {
+	if pbSession == nil {
+		return nil, errors.New("sessionProto is nil")
+	}
+
+	session := &Session{
+		ID:          pbSession.GetId(),
+		UserID:      pbSession.GetUserId(),
+		RefreshHash: pbSession.GetRefreshHash(),
+		UserAgent:   pbSession.GetUserAgent(),
+		ClientIP:    pbSession.GetClientIP(),
+		CreatedAt:   pbSession.GetCreatedAt().AsTime(),
+		LastUsedAt:  pbSession.GetLastUsedAt().AsTime(),
+		ExpiresAt:   pbSession.GetExpiresAt().AsTime(),
+	}
+	if pbSession.GetRevokedAt() != nil {
+		revokedAt := pbSession.GetRevokedAt().AsTime()
+		session.RevokedAt = &revokedAt
+	}
+
+	return session, nil
+}
+
+// ProtoToSessionList converts protobuf messages to SessionList model.
+func ProtoToSessionList(items []*pb.Session) (*SessionList, error) {
+	ret := &SessionList{Items: make([]*Session, 0, len(items))}
+	for _, item := range items {
+		session, err := ProtoToSession(item)
+		if err != nil {
+			return nil, err
+		}
+		ret.Items = append(ret.Items, session)
+	}
+	ret.TotalCount = int64(len(ret.Items))
+
+	return ret, nil
+}
+
+// SessionListToProto converts SessionList model to protobuf messages.
+func SessionListToProto(list *SessionList) []*pb.Session {
+	items := make([]*pb.Session, 0, len(list.Items))
+	for _, s := range list.Items {
+		items = append(items, SessionToProto(s))
+	}
+
+	return items
+}
@@ -0,0 +1,30 @@
+package model
+
+import "time"
+
+// Transitions of the trial of a user.
+const (
+	// TrialReminded is recorded when the user is about to be told that the trial ends soon.
+	TrialReminded = "reminded"
+	// TrialExpired is recorded once the user was given the post-trial status.
+	TrialExpired = "expired"
+)
+
+// TrialTransition records that a transition happened for a trial, so that it never happens
+// twice. A trial is identified by its end: an extended trial gets its own transitions.
+type TrialTransition struct {
+	ID          uint64    `json:"id" gorm:"primary_key;AUTO_INCREMENT;column:id"`
+	UserID      uint64    `json:"userId" gorm:"column:user_id;not null;uniqueIndex:idx_user_kind_trial,priority:1"`
+	Kind        string    `json:"kind" gorm:"column:kind;type:varchar(16);not null;uniqueIndex:idx_user_kind_trial,priority:2"`
+	TrialEndsAt time.Time `json:"trialEndsAt" gorm:"column:trial_ends_at;not null;uniqueIndex:idx_user_kind_trial,priority:3"`
+	CreatedAt   time.Time `json:"createdAt" gorm:"column:created_at"`
+	// ClaimedUntil is the end of the lease of the replica sending a reminder.
+	ClaimedUntil *time.Time `json:"claimedUntil,omitempty" gorm:"column:claimed_until"`
+	// NotifiedAt is when the reminder was delivered, nil until then.
+	NotifiedAt *time.Time `json:"notifiedAt,omitempty" gorm:"column:notified_at"`
+}
+
+// TableName overrides the table name used by TrialTransition to `trial_transitions`.
+func (TrialTransition) TableName() string {
+	return "trial_transitions"
+}
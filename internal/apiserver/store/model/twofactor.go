@@ -0,0 +1,83 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	pb "github.com/skeleton1231/gotal/internal/proto/twofactor"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// TwoFactor is the TOTP second factor of a user. It stays pending until the user proves
+// the secret was added to an authenticator by verifying a first code.
+type TwoFactor struct {
+	UserID    uint64     `json:"userId" gorm:"primary_key;column:user_id"`
+	Secret    string     `json:"-" gorm:"column:secret;type:varchar(64);not null"`
+	Enabled   bool       `json:"enabled" gorm:"column:enabled;not null;default:false"`
+	LastStep  int64      `json:"-" gorm:"column:last_step;not null;default:0"`
+	CreatedAt time.Time  `json:"createdAt" gorm:"column:created_at"`
+	EnabledAt *time.Time `json:"enabledAt,omitempty" gorm:"column:enabled_at"`
+}
+
+// TableName overrides the table name used by TwoFactor to `two_factors`.
+func (TwoFactor) TableName() string {
+	return "two_factors"
+}
+
+// RecoveryCode is a single use code which replaces a TOTP code when the authenticator is lost.
+// Only the hash of the code is stored.
+type RecoveryCode struct {
+	ID        uint64     `gorm:"primary_key;AUTO_INCREMENT;column:id"`
+	UserID    uint64     `gorm:"column:user_id;not null;index:idx_user_id"`
+	Hash      string     `gorm:"column:hash;type:varchar(128);not null"`
+	CreatedAt time.Time  `gorm:"column:created_at"`
+	UsedAt    *time.Time `gorm:"column:used_at"`
+}
+
+// TableName overrides the table name used by RecoveryCode to `recovery_codes`.
+func (RecoveryCode) TableName() string {
+	return "recovery_codes"
+}
+
+// TwoFactorEnrollment is returned when a user starts enrolling, to be added to an authenticator.
+type TwoFactorEnrollment struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+// TwoFactorToProto converts TwoFactor model to protobuf message.
+func TwoFactorToProto(t *TwoFactor) *pb.TwoFactor {
+	tf := &pb.TwoFactor{
+		UserId:    t.UserID,
+		Secret:    t.Secret,
+		Enabled:   t.Enabled,
+		LastStep:  t.LastStep,
+		CreatedAt: timestamppb.New(t.CreatedAt),
+	}
+	if t.EnabledAt != nil {
+		tf.EnabledAt = timestamppb.New(*t.EnabledAt)
+	}
+
+	return tf
+}
+
+// ProtoToTwoFactor converts protobuf message to TwoFactor model.
+func ProtoToTwoFactor(pbTwoFactor *pb.TwoFactor) (*TwoFactor, error) {
+	if pbTwoFactor == nil {
+		return nil, errors.New("twoFactorProto is nil")
+	}
+
+	tf := &TwoFactor{
+		UserID:    pbTwoFactor.GetUserId(),
+		Secret:    pbTwoFactor.GetSecret(),
+		Enabled:   pbTwoFactor.GetEnabled(),
+		LastStep:  pbTwoFactor.GetLastStep(),
+		CreatedAt: pbTwoFactor.GetCreatedAt().AsTime(),
+	}
+	if pbTwoFactor.GetEnabledAt() != nil {
+		enabledAt := pbTwoFactor.GetEnabledAt().AsTime()
+		tf.EnabledAt = &enabledAt
+	}
+
+	return tf, nil
+}
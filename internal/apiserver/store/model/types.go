@@ -2,9 +2,12 @@ package model
 
 import (
 	"encoding/json"
+	"fmt"
 	"time"
 
+	pbO "github.com/skeleton1231/gotal/internal/proto/options"
 	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 	"gorm.io/gorm"
 )
 
@@ -30,6 +33,9 @@ func (ext Extend) Merge(extendShadow string) Extend {
 
 type ListMeta struct {
 	TotalCount int64 `json:"totalCount,omitempty"`
+
+	// Continue is set when more items are available, pass it back to get the next page.
+	Continue string `json:"continue,omitempty"`
 }
 
 type ObjectMeta struct {
@@ -78,27 +84,90 @@ type ListOptions struct {
 	Offset *int64 `json:"offset,omitempty" form:"offset"`
 
 	Limit *int64 `json:"limit,omitempty" form:"limit"`
+
+	// Continue is the token returned with the previous page. It cannot be combined with Offset.
+	Continue string `json:"continue,omitempty" form:"continue"`
+
+	// SkipCount leaves TotalCount unset, which saves counting every matching row.
+	SkipCount bool `json:"skipCount,omitempty" form:"skipCount"`
 }
 
 type GetOptions struct {
 }
 
 type DeleteOptions struct {
-	Unscoped bool `json:"unscoped"`
+	Unscoped bool `json:"unscoped" form:"unscoped"`
+}
+
+// DeleteCollectionOptions selects the users removed by a batch delete, in
+// addition to an explicit list of identifiers.
+type DeleteCollectionOptions struct {
+	DeleteOptions
+
+	FieldSelector string `json:"fieldSelector,omitempty" form:"fieldSelector"`
+
+	// Limit caps the number of rows a single batch may delete, up to MaxDeleteCollectionLimit.
+	// When more rows match, nothing is deleted.
+	Limit *int64 `json:"limit,omitempty" form:"limit"`
+}
+
+// MaxDeleteCollectionLimit is the most rows a batch delete may remove at once.
+const MaxDeleteCollectionLimit = 500
+
+// DeleteCollectionLimit returns the effective cap of a batch delete.
+func (o DeleteCollectionOptions) DeleteCollectionLimit() int {
+	if o.Limit == nil || *o.Limit <= 0 || *o.Limit > MaxDeleteCollectionLimit {
+		return MaxDeleteCollectionLimit
+	}
+
+	return int(*o.Limit)
 }
 
 type CreateOptions struct {
-	DryRun []string `json:"dryRun,omitempty"`
+	DryRun []string `json:"dryRun,omitempty" form:"dryRun"`
 }
 
 type PatchOptions struct {
-	DryRun []string `json:"dryRun,omitempty"`
+	DryRun []string `json:"dryRun,omitempty" form:"dryRun"`
 
 	Force bool `json:"force,omitempty"`
 }
 
 type UpdateOptions struct {
-	DryRun []string `json:"dryRun,omitempty"`
+	DryRun []string `json:"dryRun,omitempty" form:"dryRun"`
+}
+
+// ChangePasswordOptions is the options of a password change.
+type ChangePasswordOptions struct {
+	// RememberToken, when set, changes the password only if it is still the remember token
+	// of the user. The token is cleared by the change, so it cannot be used twice.
+	RememberToken string `json:"rememberToken,omitempty"`
+}
+
+// DryRunAll runs every stage of a request, validation, uniqueness checks and hooks
+// included, without persisting the result.
+const DryRunAll = "All"
+
+// ValidateDryRun verifies the values of a dryRun option.
+func ValidateDryRun(dryRun []string) error {
+	for _, v := range dryRun {
+		if v != DryRunAll {
+			return fmt.Errorf("unsupported dryRun value `%s`, only `%s` is allowed", v, DryRunAll)
+		}
+	}
+
+	return nil
+}
+
+// IsDryRun reports whether a dryRun option asks not to persist anything.
+func IsDryRun(dryRun []string) bool {
+	for _, v := range dryRun {
+		if v == DryRunAll {
+			return true
+		}
+	}
+
+	return false
 }
 
 type TableOptions struct {
@@ -107,6 +176,40 @@ type TableOptions struct {
 
 const DefaultLimit = 1000
 
+// ListOptionsToProto converts ListOptions to protobuf message. Unset limit and offset stay unset.
+func ListOptionsToProto(opts ListOptions) *pbO.ListOptions {
+	pbOpts := &pbO.ListOptions{
+		LabelSelector: wrapperspb.String(opts.LabelSelector),
+		FieldSelector: wrapperspb.String(opts.FieldSelector),
+	}
+	if opts.Limit != nil {
+		pbOpts.Limit = wrapperspb.Int64(*opts.Limit)
+	}
+	if opts.Offset != nil {
+		pbOpts.Offset = wrapperspb.Int64(*opts.Offset)
+	}
+
+	return pbOpts
+}
+
+// ProtoToListOptions converts protobuf message to ListOptions. Unset wrappers are left nil.
+func ProtoToListOptions(pbOpts *pbO.ListOptions) ListOptions {
+	opts := ListOptions{
+		LabelSelector: pbOpts.GetLabelSelector().GetValue(),
+		FieldSelector: pbOpts.GetFieldSelector().GetValue(),
+	}
+	if pbOpts.GetLimit() != nil {
+		limit := pbOpts.GetLimit().GetValue()
+		opts.Limit = &limit
+	}
+	if pbOpts.GetOffset() != nil {
+		offset := pbOpts.GetOffset().GetValue()
+		opts.Offset = &offset
+	}
+
+	return opts
+}
+
 type LimitAndOffset struct {
 	Offset int
 	Limit  int
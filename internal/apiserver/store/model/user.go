@@ -14,7 +14,7 @@ import (
 
 type User struct {
 	ObjectMeta
-	Name string `json:"name,omitempty" gorm:"column:name;type:varchar(255);not null" validate:"required"`
+	Name string `json:"name,omitempty" gorm:"column:name;type:varchar(255);not null;uniqueIndex:idx_name" validate:"required"`
 	// Required: true
 	Email           string    `json:"email" gorm:"column:email" validate:"required,email,min=1,max=100"`
 	EmailVerifiedAt time.Time `gorm:"column:email_verified_at" json:"-"`
@@ -24,9 +24,10 @@ type User struct {
 	DiscordID       uint64    `gorm:"default:0" json:"discordId"`
 	PMType          string    `gorm:"size:255" json:"-"`
 	PMLastFour      string    `gorm:"size:4" json:"-"`
-	TrialEndsAt     time.Time `gorm:"column:trial_ends_at" json:"-"`
+	TrialEndsAt     time.Time `gorm:"column:trial_ends_at;index:idx_trial_ends_at" json:"-"`
 	TotalCredits    int       `gorm:"default:0" json:"totalCredits"`
 	Token           string    `json:"token,omitempty" gorm:"-"`
+	Roles           []string  `json:"roles,omitempty" gorm:"-"`
 }
 
 // TableName overrides the table name used by User to `users`.
@@ -54,6 +55,38 @@ func (u *User) Compare(pwd string) error {
 	return nil
 }
 
+// EmailUnverified is stored as the verification date of an address which was not verified.
+var EmailUnverified = time.Unix(0, 0).UTC()
+
+// EmailVerified reports whether the email address was verified by now. Accounts created
+// before verification existed carry a date in the future, which does not count.
+func (u *User) EmailVerified(now time.Time) bool {
+	return u.EmailVerifiedAt.Unix() > 0 && !u.EmailVerifiedAt.After(now)
+}
+
+// userFieldColumns maps the field mask paths which can be written by a patch to their columns.
+// Password and TotalCredits have their own RPCs and are deliberately missing.
+var userFieldColumns = map[string]string{
+	"name":            "name",
+	"email":           "email",
+	"emailVerifiedAt": "email_verified_at",
+	"rememberToken":   "remember_token",
+	"stripeId":        "stripe_id",
+	"discordId":       "discord_id",
+	"pmType":          "pm_type",
+	"pmLastFour":      "pm_last_four",
+	"trialEndsAt":     "trial_ends_at",
+	"meta.extend":     "extendShadow",
+	"meta.status":     "status",
+}
+
+// UserColumn returns the column written for a field mask path of the User message.
+func UserColumn(field string) (string, bool) {
+	column, ok := userFieldColumns[field]
+
+	return column, ok
+}
+
 // ToProto converts User model to protobuf message
 func UserToProto(u *User) *pb.User {
 
@@ -130,3 +163,58 @@ func ProtoToUser(pbUser *pb.User) (*User, error) {
 
 	return user, nil
 }
+
+// UserDeleteResult is the outcome of one user of a batch delete.
+type UserDeleteResult struct {
+	ID      uint64 `json:"id"`
+	Deleted bool   `json:"deleted"`
+	// Reason explains why the user was not deleted.
+	Reason string `json:"reason,omitempty"`
+}
+
+// UserDeleteResultsToProto converts batch delete results to their protobuf representation.
+func UserDeleteResultsToProto(results []*UserDeleteResult) []*pb.DeleteResult {
+	items := make([]*pb.DeleteResult, 0, len(results))
+	for _, r := range results {
+		items = append(items, &pb.DeleteResult{UserId: r.ID, Deleted: r.Deleted, Reason: r.Reason})
+	}
+
+	return items
+}
+
+// ProtoToUserDeleteResults converts protobuf batch delete results back to the model.
+func ProtoToUserDeleteResults(items []*pb.DeleteResult) []*UserDeleteResult {
+	results := make([]*UserDeleteResult, 0, len(items))
+	for _, item := range items {
+		results = append(results, &UserDeleteResult{ID: item.GetUserId(), Deleted: item.GetDeleted(), Reason: item.GetReason()})
+	}
+
+	return results
+}
+
+// UserListToProto converts UserList model to protobuf message.
+func UserListToProto(list *UserList) *pb.UserList {
+	items := make([]*pb.User, 0, len(list.Items))
+	for _, u := range list.Items {
+		items = append(items, UserToProto(u))
+	}
+
+	return &pb.UserList{Items: items, TotalCount: list.TotalCount, Continue: list.Continue}
+}
+
+// ProtoToUserList converts protobuf message to UserList model.
+func ProtoToUserList(pbList *pb.UserList) (*UserList, error) {
+	list := &UserList{
+		ListMeta: ListMeta{TotalCount: pbList.GetTotalCount(), Continue: pbList.GetContinue()},
+		Items:    make([]*User, 0, len(pbList.GetItems())),
+	}
+	for _, item := range pbList.GetItems() {
+		user, err := ProtoToUser(item)
+		if err != nil {
+			return nil, err
+		}
+		list.Items = append(list.Items, user)
+	}
+
+	return list, nil
+}
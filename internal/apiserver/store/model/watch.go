@@ -0,0 +1,77 @@
+package model
+
+import (
+	pb "github.com/skeleton1231/gotal/internal/proto/user"
+)
+
+// WatchEventType is the kind of change a watch event reports.
+type WatchEventType string
+
+const (
+	// WatchEventAdded reports a created user.
+	WatchEventAdded WatchEventType = "ADDED"
+
+	// WatchEventModified reports an updated user.
+	WatchEventModified WatchEventType = "MODIFIED"
+
+	// WatchEventDeleted reports a deleted user.
+	WatchEventDeleted WatchEventType = "DELETED"
+)
+
+// WatchOptions selects the changes delivered by a watch.
+type WatchOptions struct {
+	// FieldSelector filters the users like the one of ListOptions does.
+	FieldSelector string `json:"fieldSelector,omitempty" form:"fieldSelector"`
+
+	// ResourceVersion resumes the watch after the given version. Zero only delivers
+	// the changes made once the watch has started.
+	ResourceVersion uint64 `json:"resourceVersion,omitempty" form:"resourceVersion"`
+}
+
+// UserWatchEvent is a change of a user. Resource versions grow with every change
+// of any user, so a client resumes a watch from the last version it has seen.
+type UserWatchEvent struct {
+	Type            WatchEventType `json:"type"`
+	ResourceVersion uint64         `json:"resourceVersion"`
+	User            *User          `json:"user"`
+}
+
+// UserWatcher delivers user changes until it is stopped.
+type UserWatcher interface {
+	// ResultChan returns the events. It is closed when the watch ends.
+	ResultChan() <-chan *UserWatchEvent
+
+	// Err returns why the watch ended, nil when it was stopped by the caller.
+	Err() error
+
+	// Stop ends the watch and releases its resources.
+	Stop()
+}
+
+// UserWatchEventToProto converts UserWatchEvent model to protobuf message.
+// Secrets of the user are never sent along with an event.
+func UserWatchEventToProto(e *UserWatchEvent) *pb.WatchEvent {
+	user := *e.User
+	user.Password = ""
+	user.RememberToken = ""
+
+	return &pb.WatchEvent{
+		Type:            string(e.Type),
+		ResourceVersion: e.ResourceVersion,
+		User:            UserToProto(&user),
+	}
+}
+
+// ProtoToUserWatchEvent converts protobuf message to UserWatchEvent model.
+func ProtoToUserWatchEvent(pbEvent *pb.WatchEvent) (*UserWatchEvent, error) {
+	user, err := ProtoToUser(pbEvent.GetUser())
+	if err != nil {
+		return nil, err
+	}
+
+	return &UserWatchEvent{
+		Type:            WatchEventType(pbEvent.GetType()),
+		ResourceVersion: pbEvent.GetResourceVersion(),
+		User:            user,
+	}, nil
+}
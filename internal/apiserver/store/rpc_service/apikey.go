@@ -0,0 +1,72 @@
+package rpc_service
+
+import (
+	"context"
+
+	"github.com/skeleton1231/gotal/internal/apiserver/store"
+	"github.com/skeleton1231/gotal/internal/apiserver/store/model"
+	pb "github.com/skeleton1231/gotal/internal/proto/apikey"
+)
+
+// apiKeyGrpcServiceImpl implements the APIKeyStore interface over gRPC.
+type apiKeyGrpcServiceImpl struct {
+	client pb.APIKeyServiceClient
+}
+
+func newAPIKey(ds *datastore) store.APIKeyStore {
+	return &apiKeyGrpcServiceImpl{ds.apiKeyClient}
+}
+
+func (s *apiKeyGrpcServiceImpl) Create(ctx context.Context, key *model.APIKey) error {
+	resp, err := s.client.CreateAPIKey(ctx, &pb.CreateAPIKeyRequest{Key: model.APIKeyToProto(key)})
+	if err != nil {
+		return err
+	}
+
+	created, err := model.ProtoToAPIKey(resp.GetKey())
+	if err != nil {
+		return err
+	}
+	// The plaintext token never leaves the apiserver, keep it for the caller.
+	created.Token = key.Token
+	*key = *created
+
+	return nil
+}
+
+func (s *apiKeyGrpcServiceImpl) List(ctx context.Context, userId uint64) (*model.APIKeyList, error) {
+	resp, err := s.client.ListAPIKeys(ctx, &pb.ListAPIKeysRequest{UserId: userId})
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &model.APIKeyList{Items: make([]*model.APIKey, 0, len(resp.GetItems()))}
+	for _, item := range resp.GetItems() {
+		key, err := model.ProtoToAPIKey(item)
+		if err != nil {
+			return nil, err
+		}
+		ret.Items = append(ret.Items, key)
+	}
+	ret.TotalCount = int64(len(ret.Items))
+
+	return ret, nil
+}
+
+func (s *apiKeyGrpcServiceImpl) Delete(ctx context.Context, userId uint64, id uint64) (*model.APIKey, error) {
+	resp, err := s.client.RevokeAPIKey(ctx, &pb.RevokeAPIKeyRequest{UserId: userId, Id: id})
+	if err != nil {
+		return nil, err
+	}
+
+	return model.ProtoToAPIKey(resp.GetKey())
+}
+
+func (s *apiKeyGrpcServiceImpl) GetByHash(ctx context.Context, hash string) (*model.APIKey, error) {
+	resp, err := s.client.GetAPIKeyByHash(ctx, &pb.GetAPIKeyByHashRequest{Hash: hash})
+	if err != nil {
+		return nil, err
+	}
+
+	return model.ProtoToAPIKey(resp.GetKey())
+}
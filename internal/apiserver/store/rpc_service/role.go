@@ -0,0 +1,100 @@
+package rpc_service
+
+import (
+	"context"
+
+	"github.com/skeleton1231/gotal/internal/apiserver/store"
+	"github.com/skeleton1231/gotal/internal/apiserver/store/model"
+	pbO "github.com/skeleton1231/gotal/internal/proto/options"
+	pb "github.com/skeleton1231/gotal/internal/proto/role"
+	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// roleGrpcServiceImpl implements the RoleStore interface over gRPC.
+type roleGrpcServiceImpl struct {
+	client pb.RoleServiceClient
+}
+
+func newRole(ds *datastore) store.RoleStore {
+	return &roleGrpcServiceImpl{ds.roleClient}
+}
+
+func (s *roleGrpcServiceImpl) Create(ctx context.Context, role *model.Role, opts model.CreateOptions) error {
+	resp, err := s.client.CreateRole(ctx, &pb.CreateRoleRequest{
+		Role:    model.RoleToProto(role),
+		Options: &pbO.CreateOptions{DryRun: opts.DryRun},
+	})
+	if err != nil {
+		return err
+	}
+
+	created, err := model.ProtoToRole(resp.GetRole())
+	if err != nil {
+		return err
+	}
+	*role = *created
+
+	return nil
+}
+
+func (s *roleGrpcServiceImpl) Get(ctx context.Context, roleId uint64, opts model.GetOptions) (*model.Role, error) {
+	resp, err := s.client.GetRole(ctx, &pb.GetRoleRequest{
+		RoleId:  roleId,
+		Options: &pbO.GetOptions{},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return model.ProtoToRole(resp.GetRole())
+}
+
+func (s *roleGrpcServiceImpl) List(ctx context.Context, opts model.ListOptions) (*model.RoleList, error) {
+	pbOpts := &pbO.ListOptions{
+		LabelSelector: wrapperspb.String(opts.LabelSelector),
+		FieldSelector: wrapperspb.String(opts.FieldSelector),
+	}
+	if opts.Limit != nil {
+		pbOpts.Limit = wrapperspb.Int64(*opts.Limit)
+	}
+	if opts.Offset != nil {
+		pbOpts.Offset = wrapperspb.Int64(*opts.Offset)
+	}
+
+	resp, err := s.client.ListRoles(ctx, &pb.ListRolesRequest{Options: pbOpts})
+	if err != nil {
+		return nil, err
+	}
+
+	return model.ProtoToRoleList(resp.GetRoles()), nil
+}
+
+func (s *roleGrpcServiceImpl) Delete(ctx context.Context, roleId uint64, opts model.DeleteOptions) error {
+	_, err := s.client.DeleteRole(ctx, &pb.DeleteRoleRequest{
+		RoleId:  roleId,
+		Options: &pbO.DeleteOptions{Unscoped: opts.Unscoped},
+	})
+
+	return err
+}
+
+func (s *roleGrpcServiceImpl) Grant(ctx context.Context, userId uint64, roleId uint64) error {
+	_, err := s.client.GrantRole(ctx, &pb.GrantRoleRequest{UserId: userId, RoleId: roleId})
+
+	return err
+}
+
+func (s *roleGrpcServiceImpl) Revoke(ctx context.Context, userId uint64, roleId uint64) error {
+	_, err := s.client.RevokeRole(ctx, &pb.RevokeRoleRequest{UserId: userId, RoleId: roleId})
+
+	return err
+}
+
+func (s *roleGrpcServiceImpl) ListUserRoles(ctx context.Context, userId uint64) (*model.RoleList, error) {
+	resp, err := s.client.ListUserRoles(ctx, &pb.ListUserRolesRequest{UserId: userId})
+	if err != nil {
+		return nil, err
+	}
+
+	return model.ProtoToRoleList(resp.GetRoles()), nil
+}
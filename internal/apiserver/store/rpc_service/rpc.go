@@ -8,13 +8,22 @@ import (
 
 	"github.com/sirupsen/logrus"
 	"github.com/skeleton1231/gotal/internal/apiserver/store"
+	"github.com/skeleton1231/gotal/internal/pkg/grpcerror"
+	pbAPIKey "github.com/skeleton1231/gotal/internal/proto/apikey"
+	pbRole "github.com/skeleton1231/gotal/internal/proto/role"
+	pbSession "github.com/skeleton1231/gotal/internal/proto/session"
+	pbTwoFactor "github.com/skeleton1231/gotal/internal/proto/twofactor"
 	pb "github.com/skeleton1231/gotal/internal/proto/user"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 )
 
 type datastore struct {
-	client pb.UserServiceClient
+	client          pb.UserServiceClient
+	roleClient      pbRole.RoleServiceClient
+	apiKeyClient    pbAPIKey.APIKeyServiceClient
+	sessionClient   pbSession.SessionServiceClient
+	twoFactorClient pbTwoFactor.TwoFactorServiceClient
 }
 
 // Close implements store.Factory.
@@ -26,6 +35,32 @@ func (ds *datastore) Users() store.UserStore {
 	return newUser(ds)
 }
 
+func (ds *datastore) Roles() store.RoleStore {
+	return newRole(ds)
+}
+
+func (ds *datastore) APIKeys() store.APIKeyStore {
+	return newAPIKey(ds)
+}
+
+func (ds *datastore) Sessions() store.SessionStore {
+	return newSession(ds)
+}
+
+func (ds *datastore) TwoFactors() store.TwoFactorStore {
+	return newTwoFactor(ds)
+}
+
+// dialOptions returns the options used to connect to user_service. The interceptors
+// rebuild the coded errors returned by the server, so callers see the same business code.
+func dialOptions(creds credentials.TransportCredentials) []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithTransportCredentials(creds),
+		grpc.WithChainUnaryInterceptor(grpcerror.UnaryClientInterceptor()),
+		grpc.WithChainStreamInterceptor(grpcerror.StreamClientInterceptor()),
+	}
+}
+
 var (
 	rpcServerFactory store.Factory
 	once             sync.Once
@@ -44,15 +79,20 @@ func GetRPCServerFactory(address string, clientCA string) (store.Factory, error)
 			return
 		}
 
-		conn, err := grpc.Dial(address, grpc.WithTransportCredentials(creds))
+		conn, err := grpc.Dial(address, dialOptions(creds)...)
 		if err != nil {
 			logrus.Errorf("Connect to grpc server failed, error: %s", err)
 			initErr = err
 			return
 		}
 
-		client := pb.NewUserServiceClient(conn)
-		rpcServerFactory = &datastore{client: client}
+		rpcServerFactory = &datastore{
+			client:          pb.NewUserServiceClient(conn),
+			roleClient:      pbRole.NewRoleServiceClient(conn),
+			apiKeyClient:    pbAPIKey.NewAPIKeyServiceClient(conn),
+			sessionClient:   pbSession.NewSessionServiceClient(conn),
+			twoFactorClient: pbTwoFactor.NewTwoFactorServiceClient(conn),
+		}
 		logrus.Infof("Connected to grpc server, address: %s", address)
 	})
 
@@ -78,7 +118,7 @@ func GetRPCServerFactoryNoTLS(serverAddr string) (store.Factory, error) {
 		creds := credentials.NewTLS(&tls.Config{
 			InsecureSkipVerify: true,
 		})
-		conn, err := grpc.Dial(serverAddr, grpc.WithTransportCredentials(creds))
+		conn, err := grpc.Dial(serverAddr, dialOptions(creds)...)
 		if err != nil {
 			logrus.Errorf("Failed to dial: %v", err)
 			initErr = err
@@ -88,8 +128,13 @@ func GetRPCServerFactoryNoTLS(serverAddr string) (store.Factory, error) {
 		// Note: Do not close the connection here. It will be closed when the factory is closed.
 		// defer conn.Close()
 
-		client := pb.NewUserServiceClient(conn)
-		rpcServerFactory = &datastore{client: client}
+		rpcServerFactory = &datastore{
+			client:          pb.NewUserServiceClient(conn),
+			roleClient:      pbRole.NewRoleServiceClient(conn),
+			apiKeyClient:    pbAPIKey.NewAPIKeyServiceClient(conn),
+			sessionClient:   pbSession.NewSessionServiceClient(conn),
+			twoFactorClient: pbTwoFactor.NewTwoFactorServiceClient(conn),
+		}
 	})
 
 	if initErr != nil {
@@ -0,0 +1,77 @@
+package rpc_service
+
+import (
+	"context"
+	"time"
+
+	"github.com/skeleton1231/gotal/internal/apiserver/store"
+	"github.com/skeleton1231/gotal/internal/apiserver/store/model"
+	pb "github.com/skeleton1231/gotal/internal/proto/session"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// sessionGrpcServiceImpl implements the SessionStore interface over gRPC.
+type sessionGrpcServiceImpl struct {
+	client pb.SessionServiceClient
+}
+
+func newSession(ds *datastore) store.SessionStore {
+	return &sessionGrpcServiceImpl{ds.sessionClient}
+}
+
+func (s *sessionGrpcServiceImpl) Create(ctx context.Context, session *model.Session) error {
+	resp, err := s.client.CreateSession(ctx, &pb.CreateSessionRequest{Session: model.SessionToProto(session)})
+	if err != nil {
+		return err
+	}
+
+	created, err := model.ProtoToSession(resp.GetSession())
+	if err != nil {
+		return err
+	}
+	// The plaintext refresh token never leaves the apiserver, keep it for the caller.
+	created.Token = session.Token
+	*session = *created
+
+	return nil
+}
+
+func (s *sessionGrpcServiceImpl) Rotate(ctx context.Context, refreshHash, newRefreshHash string, expiresAt time.Time) (*model.Session, error) {
+	resp, err := s.client.RotateSession(ctx, &pb.RotateSessionRequest{
+		RefreshHash:    refreshHash,
+		NewRefreshHash: newRefreshHash,
+		ExpiresAt:      timestamppb.New(expiresAt),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return model.ProtoToSession(resp.GetSession())
+}
+
+func (s *sessionGrpcServiceImpl) List(ctx context.Context, userId uint64) (*model.SessionList, error) {
+	resp, err := s.client.ListSessions(ctx, &pb.ListSessionsRequest{UserId: userId})
+	if err != nil {
+		return nil, err
+	}
+
+	return model.ProtoToSessionList(resp.GetItems())
+}
+
+func (s *sessionGrpcServiceImpl) Revoke(ctx context.Context, userId uint64, id uint64) (*model.Session, error) {
+	resp, err := s.client.RevokeSession(ctx, &pb.RevokeSessionRequest{UserId: userId, Id: id})
+	if err != nil {
+		return nil, err
+	}
+
+	return model.ProtoToSession(resp.GetSession())
+}
+
+func (s *sessionGrpcServiceImpl) RevokeAll(ctx context.Context, userId uint64) (*model.SessionList, error) {
+	resp, err := s.client.RevokeUserSessions(ctx, &pb.RevokeUserSessionsRequest{UserId: userId})
+	if err != nil {
+		return nil, err
+	}
+
+	return model.ProtoToSessionList(resp.GetItems())
+}
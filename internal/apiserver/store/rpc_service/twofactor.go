@@ -0,0 +1,81 @@
+package rpc_service
+
+import (
+	"context"
+
+	"github.com/skeleton1231/gotal/internal/apiserver/store"
+	"github.com/skeleton1231/gotal/internal/apiserver/store/model"
+	"github.com/skeleton1231/gotal/internal/pkg/code"
+	"github.com/skeleton1231/gotal/internal/pkg/errors"
+	pb "github.com/skeleton1231/gotal/internal/proto/twofactor"
+)
+
+// twoFactorGrpcServiceImpl implements the TwoFactorStore interface over gRPC.
+type twoFactorGrpcServiceImpl struct {
+	client pb.TwoFactorServiceClient
+}
+
+func newTwoFactor(ds *datastore) store.TwoFactorStore {
+	return &twoFactorGrpcServiceImpl{ds.twoFactorClient}
+}
+
+func (s *twoFactorGrpcServiceImpl) Get(ctx context.Context, userId uint64) (*model.TwoFactor, error) {
+	resp, err := s.client.GetTwoFactor(ctx, &pb.GetTwoFactorRequest{UserId: userId})
+	if err != nil {
+		return nil, err
+	}
+	if resp.GetTwoFactor() == nil {
+		return nil, errors.WithCode(code.ErrTwoFactorNotFound, "two-factor authentication of user %d is not enrolled", userId)
+	}
+
+	return model.ProtoToTwoFactor(resp.GetTwoFactor())
+}
+
+func (s *twoFactorGrpcServiceImpl) Save(ctx context.Context, twoFactor *model.TwoFactor) error {
+	resp, err := s.client.SaveTwoFactor(ctx, &pb.SaveTwoFactorRequest{TwoFactor: model.TwoFactorToProto(twoFactor)})
+	if err != nil {
+		return err
+	}
+
+	saved, err := model.ProtoToTwoFactor(resp.GetTwoFactor())
+	if err != nil {
+		return err
+	}
+	*twoFactor = *saved
+
+	return nil
+}
+
+func (s *twoFactorGrpcServiceImpl) Enable(ctx context.Context, userId uint64, step int64, recoveryHashes []string) error {
+	_, err := s.client.EnableTwoFactor(ctx, &pb.EnableTwoFactorRequest{
+		UserId:         userId,
+		Step:           step,
+		RecoveryHashes: recoveryHashes,
+	})
+
+	return err
+}
+
+func (s *twoFactorGrpcServiceImpl) Delete(ctx context.Context, userId uint64) error {
+	_, err := s.client.DeleteTwoFactor(ctx, &pb.DeleteTwoFactorRequest{UserId: userId})
+
+	return err
+}
+
+func (s *twoFactorGrpcServiceImpl) UseStep(ctx context.Context, userId uint64, step int64) (bool, error) {
+	resp, err := s.client.UseTwoFactorStep(ctx, &pb.UseTwoFactorStepRequest{UserId: userId, Step: step})
+	if err != nil {
+		return false, err
+	}
+
+	return resp.GetAccepted(), nil
+}
+
+func (s *twoFactorGrpcServiceImpl) UseRecoveryCode(ctx context.Context, userId uint64, hash string) (bool, error) {
+	resp, err := s.client.UseRecoveryCode(ctx, &pb.UseRecoveryCodeRequest{UserId: userId, Hash: hash})
+	if err != nil {
+		return false, err
+	}
+
+	return resp.GetAccepted(), nil
+}
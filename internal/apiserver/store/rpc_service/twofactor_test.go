@@ -0,0 +1,26 @@
+package rpc_service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/skeleton1231/gotal/internal/apiserver/store/mocks"
+	"github.com/skeleton1231/gotal/internal/pkg/code"
+	"github.com/skeleton1231/gotal/internal/pkg/errors"
+	pb "github.com/skeleton1231/gotal/internal/proto/twofactor"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTwoFactorGrpcServiceImpl_GetNotEnrolled(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockClient := mocks.NewMockTwoFactorServiceClient(mockCtrl)
+	mockClient.EXPECT().
+		GetTwoFactor(gomock.Any(), &pb.GetTwoFactorRequest{UserId: 1}).
+		Return(&pb.GetTwoFactorResponse{}, nil)
+
+	_, err := newTwoFactor(&datastore{twoFactorClient: mockClient}).Get(context.Background(), 1)
+	assert.True(t, errors.IsCode(err, code.ErrTwoFactorNotFound), err)
+}
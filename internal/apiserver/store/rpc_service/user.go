@@ -4,12 +4,16 @@ package rpc_service
 import (
 	"context"
 	"errors"
+	"io"
+	"sync"
 
 	"github.com/skeleton1231/gotal/internal/apiserver/store"
 	"github.com/skeleton1231/gotal/internal/apiserver/store/model"
 	pbO "github.com/skeleton1231/gotal/internal/proto/options"
 	pb "github.com/skeleton1231/gotal/internal/proto/user"
-	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
 // userGrpcServiceImpl 实现 UserStore 接口
@@ -25,18 +29,27 @@ func newUser(ds *datastore) store.UserStore {
 func (s *userGrpcServiceImpl) Create(ctx context.Context, user *model.User, opts model.CreateOptions) error {
 
 	// 创建CreateOptions的实例
-	createOpts := &pbO.CreateOptions{}
+	createOpts := &pbO.CreateOptions{DryRun: opts.DryRun}
 
 	pbUser := model.UserToProto(user) // 转换为Protobuf格式
-	_, err := s.client.Create(ctx, &pb.CreateRequest{
+	resp, err := s.client.Create(ctx, &pb.CreateRequest{
 		User:    pbUser, // 使用转换后的用户信息
 		Options: createOpts,
 	})
-	return err
+	if err != nil {
+		return err
+	}
+
+	// Pick up the identifier assigned by the user service.
+	if id := resp.GetUser().GetMeta().GetId(); id != 0 {
+		user.ID = id
+	}
+
+	return nil
 }
 
 func (s *userGrpcServiceImpl) Update(ctx context.Context, user *model.User, opts model.UpdateOptions) error {
-	udpateOpts := &pbO.UpdateOptions{}
+	udpateOpts := &pbO.UpdateOptions{DryRun: opts.DryRun}
 	pbUser := model.UserToProto(user) // 转换为Protobuf格式
 	_, err := s.client.Update(ctx, &pb.UpdateRequest{
 		User:    pbUser, // 使用转换后的用户信息
@@ -45,11 +58,57 @@ func (s *userGrpcServiceImpl) Update(ctx context.Context, user *model.User, opts
 	return err
 }
 
+// Patch sends the user with a field mask, so only the listed fields are written.
+// The user is refreshed with the stored result.
+func (s *userGrpcServiceImpl) Patch(ctx context.Context, user *model.User, fields []string, opts model.PatchOptions) error {
+	resp, err := s.client.Update(ctx, &pb.UpdateRequest{
+		User:       model.UserToProto(user),
+		Options:    &pbO.UpdateOptions{DryRun: opts.DryRun},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: fields},
+	})
+	if err != nil {
+		return err
+	}
+
+	updated, err := model.ProtoToUser(resp.GetUser())
+	if err != nil {
+		return err
+	}
+	*user = *updated
+
+	return nil
+}
+
 func (s *userGrpcServiceImpl) Delete(ctx context.Context, userId uint64, opts model.DeleteOptions) error {
-	_, err := s.client.Delete(ctx, &pb.DeleteRequest{})
+	_, err := s.client.Delete(ctx, &pb.DeleteRequest{
+		UserId:  userId,
+		Options: &pbO.DeleteOptions{Unscoped: opts.Unscoped},
+	})
 	return err
 }
 
+func (s *userGrpcServiceImpl) DeleteCollection(
+	ctx context.Context,
+	userIds []uint64,
+	opts model.DeleteCollectionOptions,
+) ([]*model.UserDeleteResult, error) {
+	req := &pb.DeleteCollectionRequest{
+		UserIds:       userIds,
+		Options:       &pbO.DeleteOptions{Unscoped: opts.Unscoped},
+		FieldSelector: opts.FieldSelector,
+	}
+	if opts.Limit != nil {
+		req.Limit = wrapperspb.Int64(*opts.Limit)
+	}
+
+	resp, err := s.client.DeleteCollection(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.ProtoToUserDeleteResults(resp.GetResults()), nil
+}
+
 func (s *userGrpcServiceImpl) Get(ctx context.Context, userId uint64, opts model.GetOptions) (*model.User, error) {
 	var user *model.User
 	getOpts := &pbO.GetOptions{}
@@ -82,48 +141,246 @@ func (s *userGrpcServiceImpl) GetByUsername(ctx context.Context, username string
 	return user, err
 }
 
+func (s *userGrpcServiceImpl) GetByDiscordID(ctx context.Context, discordId uint64, opts model.GetOptions) (*model.User, error) {
+	resp, err := s.client.GetByDiscordID(ctx, &pb.GetByDiscordIDRequest{
+		DiscordId: discordId,
+		Options:   &pbO.GetOptions{},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return model.ProtoToUser(resp.GetUser())
+}
+
+func (s *userGrpcServiceImpl) GetByEmail(ctx context.Context, email string, opts model.GetOptions) (*model.User, error) {
+	resp, err := s.client.GetByEmail(ctx, &pb.GetByEmailRequest{
+		Email:   email,
+		Options: &pbO.GetOptions{},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return model.ProtoToUser(resp.GetUser())
+}
+
 func (s *userGrpcServiceImpl) List(ctx context.Context, opts model.ListOptions) (*model.UserList, error) {
-	// 创建 pb.ListOptions 实例
-	pbOpts := &pbO.ListOptions{
-		LabelSelector: wrapperspb.String(opts.LabelSelector),
-		FieldSelector: wrapperspb.String(opts.FieldSelector),
+	pbList, err := s.client.List(ctx, &pb.ListRequest{
+		Options:   model.ListOptionsToProto(opts),
+		Continue:  opts.Continue,
+		SkipCount: opts.SkipCount,
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	// 对于 Limit 和 Offset，只有在它们不为 nil 时才设置
-	if opts.Limit != nil {
-		pbOpts.Limit = wrapperspb.Int64(*opts.Limit)
+	return model.ProtoToUserList(pbList.GetUsers())
+}
+
+// Watch opens a Watch stream. Errors of the server, such as an expired resource
+// version, are reported by the Err method of the returned watcher.
+func (s *userGrpcServiceImpl) Watch(ctx context.Context, opts model.WatchOptions) (model.UserWatcher, error) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	stream, err := s.client.Watch(ctx, &pb.WatchRequest{
+		FieldSelector:   opts.FieldSelector,
+		ResourceVersion: opts.ResourceVersion,
+	})
+	if err != nil {
+		cancel()
+
+		return nil, err
 	}
-	if opts.Offset != nil {
-		pbOpts.Offset = wrapperspb.Int64(*opts.Offset)
+
+	w := &userStreamWatcher{cancel: cancel, result: make(chan *model.UserWatchEvent)}
+	go w.receive(ctx, stream)
+
+	return w, nil
+}
+
+// userStreamWatcher implements model.UserWatcher on top of a Watch stream.
+type userStreamWatcher struct {
+	cancel context.CancelFunc
+	result chan *model.UserWatchEvent
+
+	mu  sync.Mutex
+	err error
+}
+
+func (w *userStreamWatcher) ResultChan() <-chan *model.UserWatchEvent {
+	return w.result
+}
+
+func (w *userStreamWatcher) Err() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.err
+}
+
+func (w *userStreamWatcher) Stop() {
+	w.cancel()
+}
+
+func (w *userStreamWatcher) receive(ctx context.Context, stream pb.UserService_WatchClient) {
+	defer close(w.result)
+	defer w.cancel()
+
+	for {
+		pbEvent, err := stream.Recv()
+		if err != nil {
+			// The stream ends with the context when the watcher is stopped.
+			if !errors.Is(err, io.EOF) && ctx.Err() == nil {
+				w.fail(err)
+			}
+
+			return
+		}
+
+		event, err := model.ProtoToUserWatchEvent(pbEvent)
+		if err != nil {
+			w.fail(err)
+
+			return
+		}
+
+		select {
+		case w.result <- event:
+		case <-ctx.Done():
+			return
+		}
 	}
+}
 
-	// 构造 ListRequest，包括转换后的 ListOptions
-	pbReq := &pb.ListRequest{
-		Options: pbOpts,
+func (w *userStreamWatcher) fail(err error) {
+	w.mu.Lock()
+	w.err = err
+	w.mu.Unlock()
+}
+
+// Import streams the users to the user service, which imports them in batches.
+func (s *userGrpcServiceImpl) Import(
+	ctx context.Context,
+	users []*model.User,
+	opts model.ImportOptions,
+) (*model.UserImportSummary, error) {
+	if opts.OnConflict != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, model.ImportOnConflictMetadataKey, opts.OnConflict)
 	}
 
-	// 调用 List 方法
-	pbList, err := s.client.List(ctx, pbReq)
+	stream, err := s.client.Import(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	// 将 protobuf 返回的 UserList 转换为 model.UserList
-	userList := &model.UserList{
-		Items: []*model.User{},
+	for _, user := range users {
+		// io.EOF means the server ended the stream, its status is returned by CloseAndRecv.
+		if err := stream.Send(model.UserToProto(user)); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			return nil, err
+		}
+	}
+
+	summary, err := stream.CloseAndRecv()
+	if err != nil {
+		return nil, err
 	}
-	for _, pbUser := range pbList.GetUsers().Items {
+
+	return model.ProtoToUserImportSummary(summary), nil
+}
+
+// Export calls fn with every user streamed by the user service. Returning an error
+// from fn cancels the stream.
+func (s *userGrpcServiceImpl) Export(ctx context.Context, opts model.ListOptions, fn func(user *model.User) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := s.client.Export(ctx, &pb.ListRequest{Options: model.ListOptionsToProto(opts)})
+	if err != nil {
+		return err
+	}
+
+	for {
+		pbUser, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
 		user, err := model.ProtoToUser(pbUser)
 		if err != nil {
-			// 处理转换错误
-			continue
+			return err
+		}
+		if err := fn(user); err != nil {
+			return err
 		}
-		userList.Items = append(userList.Items, user)
 	}
+}
+
+// ChangePassword sends the new password in plain text, the user service encrypts it.
+func (s *userGrpcServiceImpl) ChangePassword(ctx context.Context, userId uint64, password string, opts model.ChangePasswordOptions) error {
+	_, err := s.client.ChangePassword(ctx, &pb.ChangePasswordRequest{
+		UserId:        userId,
+		NewPassword:   password,
+		RememberToken: opts.RememberToken,
+	})
+
+	return err
+}
+
+// ChangeCredits calls Credit for positive amounts and Debit for negative ones.
+func (s *userGrpcServiceImpl) ChangeCredits(ctx context.Context, transaction *model.CreditTransaction) error {
+	req := &pb.CreditRequest{
+		UserId:         transaction.UserID,
+		Amount:         transaction.Amount,
+		IdempotencyKey: transaction.IdempotencyKey,
+		Reason:         transaction.Reason,
+	}
+
+	change := s.client.Credit
+	if transaction.Amount < 0 {
+		req.Amount = -transaction.Amount
+		change = s.client.Debit
+	}
+
+	resp, err := change(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	recorded, err := model.ProtoToCreditTransaction(resp.GetTransaction())
+	if err != nil {
+		return err
+	}
+	*transaction = *recorded
+	transaction.Replayed = resp.GetReplayed()
 
-	return userList, nil
+	return nil
 }
 
-func (s *userGrpcServiceImpl) ChangePassword(ctx context.Context, req *pb.ChangePasswordRequest) (*pb.ChangePasswordResponse, error) {
-	return s.client.ChangePassword(ctx, req)
+func (s *userGrpcServiceImpl) ListTransactions(ctx context.Context, userId uint64, opts model.ListOptions) (*model.CreditTransactionList, error) {
+	resp, err := s.client.ListTransactions(ctx, &pb.ListTransactionsRequest{
+		UserId:  userId,
+		Options: model.ListOptionsToProto(opts),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	list := &model.CreditTransactionList{ListMeta: model.ListMeta{TotalCount: resp.GetTotalCount()}}
+	for _, item := range resp.GetItems() {
+		transaction, err := model.ProtoToCreditTransaction(item)
+		if err != nil {
+			return nil, err
+		}
+		list.Items = append(list.Items, transaction)
+	}
+
+	return list, nil
 }
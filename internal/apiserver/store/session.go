@@ -0,0 +1,19 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/skeleton1231/gotal/internal/apiserver/store/model"
+)
+
+// SessionStore defines the login session storage interface.
+type SessionStore interface {
+	Create(ctx context.Context, session *model.Session) error
+	// Rotate replaces the refresh token of the session owning refreshHash. Presenting a
+	// rotated token revokes the session and fails with code.ErrRefreshTokenReused.
+	Rotate(ctx context.Context, refreshHash, newRefreshHash string, expiresAt time.Time) (*model.Session, error)
+	List(ctx context.Context, userId uint64) (*model.SessionList, error)
+	Revoke(ctx context.Context, userId uint64, id uint64) (*model.Session, error)
+	RevokeAll(ctx context.Context, userId uint64) (*model.SessionList, error)
+}
@@ -6,8 +6,12 @@ var client Factory
 // Factory is an interface that abstracts the creation of different stores.
 // It provides methods to access different data stores and to close them.
 type Factory interface {
-	Users() UserStore // Users returns an instance of UserStore for user-related data operations.
-	Close() error     // Close is responsible for closing any resources used by the factory, e.g., database connections.
+	Users() UserStore           // Users returns an instance of UserStore for user-related data operations.
+	Roles() RoleStore           // Roles returns an instance of RoleStore for role-related data operations.
+	APIKeys() APIKeyStore       // APIKeys returns an instance of APIKeyStore for API key data operations.
+	Sessions() SessionStore     // Sessions returns an instance of SessionStore for login session data operations.
+	TwoFactors() TwoFactorStore // TwoFactors returns an instance of TwoFactorStore for two-factor authentication data operations.
+	Close() error               // Close is responsible for closing any resources used by the factory, e.g., database connections.
 }
 
 // Client is a function that returns the current instance of Factory.
@@ -0,0 +1,23 @@
+package store
+
+import (
+	"context"
+
+	"github.com/skeleton1231/gotal/internal/apiserver/store/model"
+)
+
+// TwoFactorStore defines the two-factor authentication storage interface.
+type TwoFactorStore interface {
+	Get(ctx context.Context, userId uint64) (*model.TwoFactor, error)
+	// Save stores a pending second factor, replacing a pending one. It fails with
+	// code.ErrTwoFactorEnabled when the user already has 2FA enabled.
+	Save(ctx context.Context, twoFactor *model.TwoFactor) error
+	// Enable enables the pending second factor and replaces the recovery codes of the user.
+	Enable(ctx context.Context, userId uint64, step int64, recoveryHashes []string) error
+	Delete(ctx context.Context, userId uint64) error
+	// UseStep records the time step of an accepted code. It reports false when a code of
+	// the step, or of a later one, has already been accepted.
+	UseStep(ctx context.Context, userId uint64, step int64) (bool, error)
+	// UseRecoveryCode consumes an unused recovery code. It reports false when there is none.
+	UseRecoveryCode(ctx context.Context, userId uint64, hash string) (bool, error)
+}
@@ -10,9 +10,20 @@ import (
 type UserStore interface {
 	Create(ctx context.Context, user *model.User, opts model.CreateOptions) error
 	Update(ctx context.Context, user *model.User, opts model.UpdateOptions) error
+	Patch(ctx context.Context, user *model.User, fields []string, opts model.PatchOptions) error
 	Delete(ctx context.Context, userId uint64, opts model.DeleteOptions) error
-	// DeleteCollection(ctx context.Context, userId []uint64, opts model.DeleteOptions) error
+	DeleteCollection(ctx context.Context, userIds []uint64, opts model.DeleteCollectionOptions) ([]*model.UserDeleteResult, error)
 	Get(ctx context.Context, userId uint64, opts model.GetOptions) (*model.User, error)
 	GetByUsername(ctx context.Context, username string, opts model.GetOptions) (*model.User, error)
+	GetByDiscordID(ctx context.Context, discordId uint64, opts model.GetOptions) (*model.User, error)
+	GetByEmail(ctx context.Context, email string, opts model.GetOptions) (*model.User, error)
+	ChangePassword(ctx context.Context, userId uint64, password string, opts model.ChangePasswordOptions) error
 	List(ctx context.Context, opts model.ListOptions) (*model.UserList, error)
+	Watch(ctx context.Context, opts model.WatchOptions) (model.UserWatcher, error)
+	Import(ctx context.Context, users []*model.User, opts model.ImportOptions) (*model.UserImportSummary, error)
+	Export(ctx context.Context, opts model.ListOptions, fn func(user *model.User) error) error
+	// ChangeCredits records the transaction in the ledger and adds its amount to the balance
+	// of the user, in one database transaction. The balance never goes below zero.
+	ChangeCredits(ctx context.Context, transaction *model.CreditTransaction) error
+	ListTransactions(ctx context.Context, userId uint64, opts model.ListOptions) (*model.CreditTransactionList, error)
 }
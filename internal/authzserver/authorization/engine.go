@@ -0,0 +1,189 @@
+// Copyright 2023 Talhuang<talhuang1231@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package authorization
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+)
+
+// Engine evaluates authorization requests against the currently loaded policy set.
+// It is safe for concurrent use; loading a new policy set swaps it atomically.
+type Engine struct {
+	policies atomic.Pointer[compiledPolicySet]
+}
+
+type compiledPolicySet struct {
+	version      int64
+	roles        map[string]Role
+	bindings     map[string][]string
+	defaultRoles []string
+}
+
+// NewEngine creates an engine with an empty policy set which denies everything.
+func NewEngine() *Engine {
+	e := &Engine{}
+	e.policies.Store(compile(&PolicySet{}))
+
+	return e
+}
+
+// Load validates the policy set and replaces the one used for evaluation.
+func (e *Engine) Load(ps *PolicySet) error {
+	if ps == nil {
+		return fmt.Errorf("policy set cannot be nil")
+	}
+
+	if err := ps.Validate(); err != nil {
+		return err
+	}
+
+	e.policies.Store(compile(ps))
+
+	return nil
+}
+
+// Version returns the version of the loaded policy set.
+func (e *Engine) Version() int64 {
+	return e.policies.Load().version
+}
+
+func compile(ps *PolicySet) *compiledPolicySet {
+	cps := &compiledPolicySet{
+		version:      ps.Version,
+		roles:        make(map[string]Role, len(ps.Roles)),
+		bindings:     make(map[string][]string, len(ps.Bindings)),
+		defaultRoles: append([]string(nil), ps.DefaultRoles...),
+	}
+
+	for _, role := range ps.Roles {
+		cps.roles[role.Name] = role
+	}
+
+	for subject, roles := range ps.Bindings {
+		cps.bindings[subject] = append([]string(nil), roles...)
+	}
+
+	return cps
+}
+
+// Authorize evaluates the request. A matching deny rule always wins, otherwise
+// the first matching allow rule grants access. Requests matching no rule are denied.
+func (e *Engine) Authorize(req *Request) *Decision {
+	cps := e.policies.Load()
+	action := normalizeAction(req.Action)
+
+	roles := make([]string, 0, len(cps.defaultRoles)+len(cps.bindings[req.Subject]))
+	roles = append(roles, cps.defaultRoles...)
+	roles = append(roles, cps.bindings[req.Subject]...)
+	// Roles granted through the role service travel with the request.
+	if granted := req.Context[ContextRoles]; granted != "" {
+		roles = append(roles, strings.Split(granted, ",")...)
+	}
+
+	var allowedBy string
+	for _, name := range roles {
+		role := cps.roles[name]
+		for i, rule := range role.Rules {
+			if !rule.matches(req, action) {
+				continue
+			}
+
+			if rule.Effect == EffectDeny {
+				return &Decision{
+					Allowed: false,
+					Reason:  fmt.Sprintf("denied by role `%s` rule %d", role.Name, i),
+				}
+			}
+
+			if allowedBy == "" {
+				allowedBy = fmt.Sprintf("allowed by role `%s` rule %d", role.Name, i)
+			}
+		}
+	}
+
+	if allowedBy != "" {
+		return &Decision{Allowed: true, Reason: allowedBy}
+	}
+
+	return &Decision{
+		Allowed: false,
+		Reason:  fmt.Sprintf("no rule allows `%s` to `%s` on `%s`", req.Subject, action, req.Resource),
+	}
+}
+
+func (r Rule) matches(req *Request, action string) bool {
+	actionMatched := false
+	for _, pattern := range r.Actions {
+		if matchAction(pattern, action) {
+			actionMatched = true
+			break
+		}
+	}
+	if !actionMatched {
+		return false
+	}
+
+	for _, pattern := range r.Resources {
+		params, ok := matchResource(pattern, req.Resource)
+		if !ok {
+			continue
+		}
+
+		if conditionsHold(r.Conditions, req, params) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func conditionsHold(conds []Condition, req *Request, params map[string]string) bool {
+	for _, cond := range conds {
+		if !cond.holds(req, params) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (c Condition) holds(req *Request, params map[string]string) bool {
+	switch c.Type {
+	case ConditionOwner:
+		value, ok := params[c.Param]
+		if !ok {
+			return false
+		}
+
+		// Without an attribute the resource parameter is compared with the subject itself.
+		if c.Attribute == "" {
+			return value == req.Subject
+		}
+
+		owner, ok := req.Context[c.Attribute]
+
+		return ok && owner != "" && value == owner
+	case ConditionEquals:
+		value, ok := req.Context[c.Attribute]
+
+		return ok && value == c.Value
+	case ConditionIn:
+		value, ok := req.Context[c.Attribute]
+		if !ok {
+			return false
+		}
+		for _, v := range c.Values {
+			if v == value {
+				return true
+			}
+		}
+
+		return false
+	default:
+		return false
+	}
+}
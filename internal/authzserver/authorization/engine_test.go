@@ -0,0 +1,202 @@
+package authorization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testPolicySet() *PolicySet {
+	return &PolicySet{
+		Version: 1,
+		Roles: []Role{
+			{
+				Name: "admin",
+				Rules: []Rule{
+					{Effect: EffectAllow, Actions: []string{"*"}, Resources: []string{"/v1/**"}},
+				},
+			},
+			{
+				Name: "user",
+				Rules: []Rule{
+					{
+						Effect:    EffectAllow,
+						Actions:   []string{"GET", "PUT"},
+						Resources: []string{"/v1/users/:id"},
+						Conditions: []Condition{
+							{Type: ConditionOwner, Param: "id", Attribute: "userID"},
+						},
+					},
+					{Effect: EffectAllow, Actions: []string{"/gotal.user.UserService/Get*"}, Resources: []string{"*"}},
+				},
+			},
+			{
+				Name: "readonly",
+				Rules: []Rule{
+					{Effect: EffectDeny, Actions: []string{"POST", "PUT", "DELETE"}, Resources: []string{"**"}},
+				},
+			},
+		},
+		Bindings: map[string][]string{
+			"root":   {"admin"},
+			"alice":  {"user"},
+			"viewer": {"admin", "readonly"},
+		},
+	}
+}
+
+func TestEngine_Authorize(t *testing.T) {
+	e := NewEngine()
+	assert.NoError(t, e.Load(testPolicySet()))
+	assert.Equal(t, int64(1), e.Version())
+
+	tests := []struct {
+		name    string
+		req     *Request
+		allowed bool
+	}{
+		{
+			name:    "admin wildcard",
+			req:     &Request{Subject: "root", Action: "delete", Resource: "/v1/users/7"},
+			allowed: true,
+		},
+		{
+			name:    "admin outside of prefix",
+			req:     &Request{Subject: "root", Action: "GET", Resource: "/healthz"},
+			allowed: false,
+		},
+		{
+			name:    "owner of resource",
+			req:     &Request{Subject: "alice", Action: "GET", Resource: "/v1/users/42", Context: map[string]string{"userID": "42"}},
+			allowed: true,
+		},
+		{
+			name:    "not owner of resource",
+			req:     &Request{Subject: "alice", Action: "PUT", Resource: "/v1/users/43", Context: map[string]string{"userID": "42"}},
+			allowed: false,
+		},
+		{
+			name:    "owner without attribute",
+			req:     &Request{Subject: "alice", Action: "GET", Resource: "/v1/users/42"},
+			allowed: false,
+		},
+		{
+			name:    "action not granted",
+			req:     &Request{Subject: "alice", Action: "DELETE", Resource: "/v1/users/42", Context: map[string]string{"userID": "42"}},
+			allowed: false,
+		},
+		{
+			name:    "grpc method wildcard",
+			req:     &Request{Subject: "alice", Action: "/gotal.user.UserService/GetByUsername", Resource: "alice"},
+			allowed: true,
+		},
+		{
+			name:    "deny overrides allow",
+			req:     &Request{Subject: "viewer", Action: "POST", Resource: "/v1/users"},
+			allowed: false,
+		},
+		{
+			name:    "allow when deny does not match",
+			req:     &Request{Subject: "viewer", Action: "GET", Resource: "/v1/users"},
+			allowed: true,
+		},
+		{
+			name:    "role from request context",
+			req:     &Request{Subject: "mallory", Action: "GET", Resource: "/v1/users", Context: map[string]string{ContextRoles: "support,admin"}},
+			allowed: true,
+		},
+		{
+			name:    "unknown role from request context",
+			req:     &Request{Subject: "mallory", Action: "GET", Resource: "/v1/users", Context: map[string]string{ContextRoles: "support"}},
+			allowed: false,
+		},
+		{
+			name:    "unknown subject",
+			req:     &Request{Subject: "mallory", Action: "GET", Resource: "/v1/users/1"},
+			allowed: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := e.Authorize(tt.req)
+			assert.Equal(t, tt.allowed, got.Allowed, got.Reason)
+			assert.NotEmpty(t, got.Reason)
+		})
+	}
+}
+
+func TestEngine_DefaultRoles(t *testing.T) {
+	ps := testPolicySet()
+	ps.DefaultRoles = []string{"user"}
+
+	e := NewEngine()
+	assert.NoError(t, e.Load(ps))
+
+	got := e.Authorize(&Request{Subject: "bob", Action: "GET", Resource: "/v1/users/5", Context: map[string]string{"userID": "5"}})
+	assert.True(t, got.Allowed)
+}
+
+func TestEngine_EmptyPolicyDeniesAll(t *testing.T) {
+	got := NewEngine().Authorize(&Request{Subject: "root", Action: "GET", Resource: "/v1/users"})
+	assert.False(t, got.Allowed)
+}
+
+func TestPolicySet_Validate(t *testing.T) {
+	tests := []struct {
+		name string
+		ps   *PolicySet
+	}{
+		{
+			name: "unknown role binding",
+			ps:   &PolicySet{Bindings: map[string][]string{"alice": {"missing"}}},
+		},
+		{
+			name: "invalid effect",
+			ps: &PolicySet{Roles: []Role{{Name: "r", Rules: []Rule{
+				{Effect: "maybe", Actions: []string{"*"}, Resources: []string{"*"}},
+			}}}},
+		},
+		{
+			name: "unknown condition",
+			ps: &PolicySet{Roles: []Role{{Name: "r", Rules: []Rule{
+				{Effect: EffectAllow, Actions: []string{"*"}, Resources: []string{"*"}, Conditions: []Condition{{Type: "time"}}},
+			}}}},
+		},
+		{
+			name: "duplicate role",
+			ps:   &PolicySet{Roles: []Role{{Name: "r"}, {Name: "r"}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Error(t, tt.ps.Validate())
+			assert.Error(t, NewEngine().Load(tt.ps))
+		})
+	}
+}
+
+func TestMatchResource(t *testing.T) {
+	tests := []struct {
+		pattern, resource string
+		match             bool
+		params            map[string]string
+	}{
+		{"/v1/users/*", "/v1/users/1", true, nil},
+		{"/v1/users/*", "/v1/users/1/secrets", false, nil},
+		{"/v1/users/**", "/v1/users/1/secrets", true, nil},
+		{"/v1/users/:id", "/v1/users/9", true, map[string]string{"id": "9"}},
+		{"/v1/users/:id", "/v1/users", false, nil},
+		{"user-*", "user-1", false, nil},
+		{"*", "anything/at/all", true, nil},
+	}
+
+	for _, tt := range tests {
+		params, ok := matchResource(tt.pattern, tt.resource)
+		assert.Equal(t, tt.match, ok, "%s ~ %s", tt.pattern, tt.resource)
+		if tt.match {
+			assert.Equal(t, tt.params, params)
+		}
+	}
+}
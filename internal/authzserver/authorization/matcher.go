@@ -0,0 +1,88 @@
+// Copyright 2023 Talhuang<talhuang1231@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package authorization
+
+import "strings"
+
+// matchAction reports whether the action matches the pattern.
+// `*` in the pattern matches any sequence of characters.
+func matchAction(pattern, action string) bool {
+	return matchGlob(normalizeAction(pattern), action)
+}
+
+func matchGlob(pattern, s string) bool {
+	if pattern == "*" {
+		return true
+	}
+
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == s
+	}
+
+	if !strings.HasPrefix(s, parts[0]) {
+		return false
+	}
+	s = s[len(parts[0]):]
+
+	for i := 1; i < len(parts)-1; i++ {
+		idx := strings.Index(s, parts[i])
+		if idx < 0 {
+			return false
+		}
+		s = s[idx+len(parts[i]):]
+	}
+
+	return strings.HasSuffix(s, parts[len(parts)-1])
+}
+
+// matchResource reports whether the resource matches the pattern and returns
+// the parameters captured by `:name` segments.
+func matchResource(pattern, resource string) (map[string]string, bool) {
+	if pattern == "*" || pattern == "**" {
+		return nil, true
+	}
+
+	patternSegs := splitPath(pattern)
+	resourceSegs := splitPath(resource)
+
+	var params map[string]string
+
+	for i, seg := range patternSegs {
+		if seg == "**" && i == len(patternSegs)-1 {
+			return params, true
+		}
+
+		if i >= len(resourceSegs) {
+			return nil, false
+		}
+
+		switch {
+		case seg == "*":
+		case strings.HasPrefix(seg, ":"):
+			if params == nil {
+				params = make(map[string]string)
+			}
+			params[seg[1:]] = resourceSegs[i]
+		case seg != resourceSegs[i]:
+			return nil, false
+		}
+	}
+
+	if len(patternSegs) != len(resourceSegs) {
+		return nil, false
+	}
+
+	return params, true
+}
+
+func splitPath(p string) []string {
+	p = strings.Trim(p, "/")
+	if p == "" {
+		return nil
+	}
+
+	return strings.Split(p, "/")
+}
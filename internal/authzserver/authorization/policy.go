@@ -0,0 +1,181 @@
+// Copyright 2023 Talhuang<talhuang1231@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+// Package authorization implements the RBAC/ABAC policy decision engine used by the authz server.
+package authorization
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Effect defines whether a matching rule allows or denies the request.
+type Effect string
+
+const (
+	// EffectAllow grants access when the rule matches.
+	EffectAllow Effect = "allow"
+	// EffectDeny rejects access when the rule matches. Deny always overrides allow.
+	EffectDeny Effect = "deny"
+)
+
+// Supported condition types.
+const (
+	// ConditionOwner matches when the subject owns the resource. The resource
+	// parameter named by Param is compared with the request context attribute named by Attribute.
+	ConditionOwner = "owner"
+	// ConditionEquals matches when the request context attribute named by Attribute equals Value.
+	ConditionEquals = "equals"
+	// ConditionIn matches when the request context attribute named by Attribute is one of Values.
+	ConditionIn = "in"
+)
+
+// ContextRoles is the request context attribute holding a comma separated list of
+// role names granted to the subject in addition to the bindings of the policy set.
+const ContextRoles = "roles"
+
+// Condition is an attribute based constraint evaluated against the request.
+type Condition struct {
+	Type      string   `json:"type"`
+	Param     string   `json:"param,omitempty"`
+	Attribute string   `json:"attribute,omitempty"`
+	Value     string   `json:"value,omitempty"`
+	Values    []string `json:"values,omitempty"`
+}
+
+// Rule grants or denies a set of actions on a set of resources.
+// Resources use `/`-separated patterns: `*` matches a single segment,
+// `:name` matches a single segment and captures it as a parameter and
+// a trailing `**` matches any remaining segments.
+// Actions are HTTP methods or gRPC full method names; `*` matches any sequence of characters.
+type Rule struct {
+	Effect     Effect      `json:"effect"`
+	Actions    []string    `json:"actions"`
+	Resources  []string    `json:"resources"`
+	Conditions []Condition `json:"conditions,omitempty"`
+}
+
+// Role is a named set of rules.
+type Role struct {
+	Name  string `json:"name"`
+	Rules []Rule `json:"rules"`
+}
+
+// PolicySet is the complete authorization policy evaluated by the engine.
+type PolicySet struct {
+	// Version increases every time the policy set changes.
+	Version int64 `json:"version"`
+	// Roles lists all the roles known to the engine.
+	Roles []Role `json:"roles"`
+	// Bindings maps a subject to the names of the roles granted to it.
+	Bindings map[string][]string `json:"bindings"`
+	// DefaultRoles are granted to every subject, including unknown ones.
+	DefaultRoles []string `json:"defaultRoles,omitempty"`
+}
+
+// ParsePolicySet decodes and validates a JSON encoded policy set.
+func ParsePolicySet(data []byte) (*PolicySet, error) {
+	var ps PolicySet
+	if err := json.Unmarshal(data, &ps); err != nil {
+		return nil, fmt.Errorf("decode policy set: %w", err)
+	}
+
+	if err := ps.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &ps, nil
+}
+
+// Validate checks that every role and binding in the policy set is well formed.
+func (ps *PolicySet) Validate() error {
+	roles := make(map[string]struct{}, len(ps.Roles))
+	for _, role := range ps.Roles {
+		if role.Name == "" {
+			return fmt.Errorf("role name cannot be empty")
+		}
+		if _, ok := roles[role.Name]; ok {
+			return fmt.Errorf("duplicate role %q", role.Name)
+		}
+		roles[role.Name] = struct{}{}
+
+		for i, rule := range role.Rules {
+			if err := rule.validate(); err != nil {
+				return fmt.Errorf("role %q rule %d: %w", role.Name, i, err)
+			}
+		}
+	}
+
+	for subject, names := range ps.Bindings {
+		for _, name := range names {
+			if _, ok := roles[name]; !ok {
+				return fmt.Errorf("subject %q is bound to unknown role %q", subject, name)
+			}
+		}
+	}
+
+	for _, name := range ps.DefaultRoles {
+		if _, ok := roles[name]; !ok {
+			return fmt.Errorf("unknown default role %q", name)
+		}
+	}
+
+	return nil
+}
+
+func (r Rule) validate() error {
+	if r.Effect != EffectAllow && r.Effect != EffectDeny {
+		return fmt.Errorf("invalid effect %q", r.Effect)
+	}
+	if len(r.Actions) == 0 {
+		return fmt.Errorf("actions cannot be empty")
+	}
+	if len(r.Resources) == 0 {
+		return fmt.Errorf("resources cannot be empty")
+	}
+
+	for _, cond := range r.Conditions {
+		switch cond.Type {
+		case ConditionOwner:
+			if cond.Param == "" {
+				return fmt.Errorf("owner condition requires a param")
+			}
+		case ConditionEquals, ConditionIn:
+			if cond.Attribute == "" {
+				return fmt.Errorf("%s condition requires an attribute", cond.Type)
+			}
+		default:
+			return fmt.Errorf("unknown condition type %q", cond.Type)
+		}
+	}
+
+	return nil
+}
+
+// Request describes the access being checked.
+type Request struct {
+	// Subject is the username asking for access.
+	Subject string `json:"subject" binding:"required"`
+	// Action is an HTTP method or a gRPC full method name.
+	Action string `json:"action" binding:"required"`
+	// Resource is a request path or a resource identifier.
+	Resource string `json:"resource" binding:"required"`
+	// Context carries extra attributes about the subject or the request, e.g. `userID` or `roles`.
+	Context map[string]string `json:"context,omitempty"`
+}
+
+// Decision is the result of evaluating a request.
+type Decision struct {
+	Allowed bool   `json:"allowed"`
+	Reason  string `json:"reason"`
+}
+
+func normalizeAction(action string) string {
+	if strings.HasPrefix(action, "/") {
+		return action
+	}
+
+	return strings.ToUpper(action)
+}
@@ -0,0 +1,41 @@
+// Copyright 2023 Talhuang<talhuang1231@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package authorize
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/skeleton1231/gotal/internal/authzserver/authorization"
+	"github.com/skeleton1231/gotal/internal/pkg/code"
+	"github.com/skeleton1231/gotal/internal/pkg/errors"
+	"github.com/skeleton1231/gotal/internal/pkg/response"
+	"github.com/skeleton1231/gotal/pkg/log"
+)
+
+// AuthzController create an authorize handler used to handle policy decision requests.
+type AuthzController struct {
+	engine *authorization.Engine
+}
+
+// NewAuthzController creates an authorize handler.
+func NewAuthzController(engine *authorization.Engine) *AuthzController {
+	return &AuthzController{
+		engine: engine,
+	}
+}
+
+// Authorize returns whether the subject is allowed to perform the action on the resource.
+func (a *AuthzController) Authorize(c *gin.Context) {
+	var r authorization.Request
+	if err := c.ShouldBindJSON(&r); err != nil {
+		response.WriteResponse(c, errors.WithCode(code.ErrBind, err.Error()), nil)
+
+		return
+	}
+
+	decision := a.engine.Authorize(&r)
+	log.Record(c).Debugf("authorize `%s` %s %s: allowed=%t, %s", r.Subject, r.Action, r.Resource, decision.Allowed, decision.Reason)
+
+	response.WriteResponse(c, nil, decision)
+}
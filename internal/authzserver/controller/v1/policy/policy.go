@@ -0,0 +1,55 @@
+// Copyright 2023 Talhuang<talhuang1231@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package policy
+
+import (
+	"os"
+
+	"github.com/gin-gonic/gin"
+	"github.com/skeleton1231/gotal/internal/authzserver/authorization"
+	"github.com/skeleton1231/gotal/internal/authzserver/store"
+	"github.com/skeleton1231/gotal/internal/pkg/response"
+	"github.com/skeleton1231/gotal/pkg/log"
+)
+
+// PolicyController create a policy handler used to inspect the loaded policies.
+type PolicyController struct {
+	engine   *authorization.Engine
+	notifier store.PolicyNotifier
+}
+
+// NewPolicyController creates a policy handler.
+func NewPolicyController(engine *authorization.Engine, notifier store.PolicyNotifier) *PolicyController {
+	return &PolicyController{
+		engine:   engine,
+		notifier: notifier,
+	}
+}
+
+// VersionResponse describes the policy version served by a replica.
+type VersionResponse struct {
+	// Hostname identifies the replica which answered.
+	Hostname string `json:"hostname"`
+	// Version is the version of the policy set loaded by the replica.
+	Version int64 `json:"version"`
+	// Published is the latest version announced to all replicas, zero when unknown.
+	Published int64 `json:"published"`
+}
+
+// Version returns the policy version loaded by this replica together with the latest published one.
+func (p *PolicyController) Version(c *gin.Context) {
+	hostname, _ := os.Hostname()
+
+	published, err := p.notifier.Version(c)
+	if err != nil {
+		log.Record(c).Warnf("get published policy version failed: %s", err.Error())
+	}
+
+	response.WriteResponse(c, nil, &VersionResponse{
+		Hostname:  hostname,
+		Version:   p.engine.Version(),
+		Published: published,
+	})
+}
@@ -0,0 +1,164 @@
+// Copyright 2023 Talhuang<talhuang1231@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+// Package load keeps the policy set of the authz engine in sync with the policy store.
+package load
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/skeleton1231/gotal/internal/authzserver/authorization"
+	"github.com/skeleton1231/gotal/internal/authzserver/store"
+	"github.com/skeleton1231/gotal/pkg/log"
+)
+
+// resubscribeDelay is how long to wait before subscribing again after the subscription ended.
+const resubscribeDelay = time.Second
+
+// Loader reloads the engine whenever a newer policy version is published.
+// Notifications only trigger a reload: the policy set itself is always read from
+// the durable store, and a periodic resync covers notifications lost while disconnected.
+type Loader struct {
+	engine   *authorization.Engine
+	store    store.PolicyStore
+	notifier store.PolicyNotifier
+	interval time.Duration
+	reloadCh chan struct{}
+}
+
+// NewLoader creates a loader which resyncs the engine at least once per interval.
+func NewLoader(engine *authorization.Engine, s store.PolicyStore, n store.PolicyNotifier, interval time.Duration) *Loader {
+	return &Loader{
+		engine:   engine,
+		store:    s,
+		notifier: n,
+		interval: interval,
+		reloadCh: make(chan struct{}, 1),
+	}
+}
+
+// Reload loads the latest policy set from the store and swaps it into the engine
+// when it is newer than the loaded one.
+func (l *Loader) Reload(ctx context.Context) error {
+	ps, err := l.store.Latest(ctx)
+	if err != nil {
+		if errors.Is(err, store.ErrPolicyNotFound) {
+			return nil
+		}
+
+		return err
+	}
+
+	current := l.engine.Version()
+	if ps.Version <= current {
+		return nil
+	}
+
+	if err := l.engine.Load(ps); err != nil {
+		return err
+	}
+	log.Infof("policy set reloaded from version %d to version %d", current, ps.Version)
+
+	return nil
+}
+
+// Publish stores the policy set as a new revision, unless it is identical to the latest
+// stored one, loads it into the engine and notifies the other replicas.
+func (l *Loader) Publish(ctx context.Context, ps *authorization.PolicySet) (int64, error) {
+	latest, err := l.store.Latest(ctx)
+	if err != nil && !errors.Is(err, store.ErrPolicyNotFound) {
+		return 0, err
+	}
+
+	if latest != nil && samePolicies(latest, ps) {
+		return latest.Version, l.Reload(ctx)
+	}
+
+	created, err := l.store.Create(ctx, ps)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := l.Reload(ctx); err != nil {
+		return 0, err
+	}
+
+	if err := l.notifier.Publish(ctx, created.Version); err != nil {
+		// Other replicas still pick the new version up on their next resync.
+		log.Warnf("notify policy version %d failed: %s", created.Version, err.Error())
+	}
+
+	return created.Version, nil
+}
+
+// Start runs the loader until ctx is canceled.
+func (l *Loader) Start(ctx context.Context) {
+	go l.subscribe(ctx)
+	go l.run(ctx)
+}
+
+func (l *Loader) subscribe(ctx context.Context) {
+	for {
+		err := l.notifier.Subscribe(ctx, func(version int64) {
+			if version > l.engine.Version() {
+				l.trigger()
+			}
+		})
+		if err != nil {
+			log.Debugf("policy change subscription ended: %s", err.Error())
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(resubscribeDelay):
+		}
+	}
+}
+
+func (l *Loader) trigger() {
+	select {
+	case l.reloadCh <- struct{}{}:
+	default:
+		// A reload is already queued and will pick the newest version up.
+	}
+}
+
+func (l *Loader) run(ctx context.Context) {
+	ticker := time.NewTicker(l.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-l.reloadCh:
+		case <-ticker.C:
+		}
+
+		if err := l.Reload(ctx); err != nil {
+			log.Errorf("reload policy set failed: %s", err.Error())
+		}
+	}
+}
+
+func samePolicies(a, b *authorization.PolicySet) bool {
+	ac, bc := *a, *b
+	ac.Version, bc.Version = 0, 0
+
+	aj, err := json.Marshal(&ac)
+	if err != nil {
+		return false
+	}
+	bj, err := json.Marshal(&bc)
+	if err != nil {
+		return false
+	}
+
+	return bytes.Equal(aj, bj)
+}
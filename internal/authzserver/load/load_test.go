@@ -0,0 +1,148 @@
+package load
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/skeleton1231/gotal/internal/authzserver/authorization"
+	"github.com/skeleton1231/gotal/internal/authzserver/store"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeStore struct {
+	mu        sync.Mutex
+	revisions []*authorization.PolicySet
+}
+
+func (f *fakeStore) Latest(ctx context.Context) (*authorization.PolicySet, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if len(f.revisions) == 0 {
+		return nil, store.ErrPolicyNotFound
+	}
+
+	return f.revisions[len(f.revisions)-1], nil
+}
+
+func (f *fakeStore) Create(ctx context.Context, ps *authorization.PolicySet) (*authorization.PolicySet, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	stored := *ps
+	stored.Version = int64(len(f.revisions) + 1)
+	f.revisions = append(f.revisions, &stored)
+
+	return &stored, nil
+}
+
+type fakeNotifier struct {
+	mu        sync.Mutex
+	version   int64
+	listeners []func(int64)
+}
+
+func (f *fakeNotifier) Version(ctx context.Context) (int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.version, nil
+}
+
+func (f *fakeNotifier) Publish(ctx context.Context, version int64) error {
+	f.mu.Lock()
+	f.version = version
+	listeners := append([]func(int64){}, f.listeners...)
+	f.mu.Unlock()
+
+	for _, l := range listeners {
+		l(version)
+	}
+
+	return nil
+}
+
+func (f *fakeNotifier) Subscribe(ctx context.Context, onChange func(version int64)) error {
+	f.mu.Lock()
+	f.listeners = append(f.listeners, onChange)
+	f.mu.Unlock()
+
+	<-ctx.Done()
+
+	return ctx.Err()
+}
+
+func policySet(role string) *authorization.PolicySet {
+	return &authorization.PolicySet{
+		Roles: []authorization.Role{{
+			Name: role,
+			Rules: []authorization.Rule{
+				{Effect: authorization.EffectAllow, Actions: []string{"GET"}, Resources: []string{"/v1/**"}},
+			},
+		}},
+		Bindings: map[string][]string{"alice": {role}},
+	}
+}
+
+func TestLoader_PublishIsIdempotent(t *testing.T) {
+	s, n := &fakeStore{}, &fakeNotifier{}
+	l := NewLoader(authorization.NewEngine(), s, n, time.Hour)
+
+	v1, err := l.Publish(context.Background(), policySet("reader"))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), v1)
+
+	again, err := l.Publish(context.Background(), policySet("reader"))
+	assert.NoError(t, err)
+	assert.Equal(t, v1, again)
+	assert.Len(t, s.revisions, 1)
+
+	v2, err := l.Publish(context.Background(), policySet("viewer"))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), v2)
+	assert.Equal(t, int64(2), n.version)
+	assert.Equal(t, int64(2), l.engine.Version())
+}
+
+func TestLoader_ReplicasReloadOnNotification(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s, n := &fakeStore{}, &fakeNotifier{}
+	writer := NewLoader(authorization.NewEngine(), s, n, time.Hour)
+	replica := NewLoader(authorization.NewEngine(), s, n, time.Hour)
+	replica.Start(ctx)
+
+	// Wait until the replica has subscribed.
+	assert.Eventually(t, func() bool {
+		n.mu.Lock()
+		defer n.mu.Unlock()
+
+		return len(n.listeners) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	_, err := writer.Publish(ctx, policySet("reader"))
+	assert.NoError(t, err)
+
+	assert.Eventually(t, func() bool {
+		return replica.engine.Version() == 1
+	}, time.Second, 10*time.Millisecond)
+	assert.True(t, replica.engine.Authorize(&authorization.Request{Subject: "alice", Action: "GET", Resource: "/v1/users"}).Allowed)
+}
+
+func TestLoader_ResyncWithoutNotification(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s := &fakeStore{}
+	_, _ = s.Create(ctx, policySet("reader"))
+
+	replica := NewLoader(authorization.NewEngine(), s, &fakeNotifier{}, 10*time.Millisecond)
+	replica.Start(ctx)
+
+	assert.Eventually(t, func() bool {
+		return replica.engine.Version() == 1
+	}, time.Second, 10*time.Millisecond)
+}
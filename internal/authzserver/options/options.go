@@ -2,6 +2,7 @@ package options
 
 import (
 	"encoding/json"
+	"time"
 
 	genericOptions "github.com/skeleton1231/gotal/internal/pkg/options"
 	"github.com/skeleton1231/gotal/internal/pkg/server"
@@ -17,6 +18,15 @@ type Options struct {
 	// ClientCA represents the file path of the client certificate authority.
 	ClientCA string `json:"client-ca-file" mapstructure:"client-cat-file"`
 
+	// PolicyFile is the path of a JSON policy set published to the policy store at startup.
+	PolicyFile string `json:"policy-file" mapstructure:"policy-file"`
+
+	// PolicyResyncInterval is how often the policy store is checked for a newer version.
+	PolicyResyncInterval time.Duration `json:"policy-resync-interval" mapstructure:"policy-resync-interval"`
+
+	// GRPCOptions specifies where the AuthzService gRPC server listens.
+	GRPCOptions *genericOptions.GRPCOptions `json:"grpc" mapstructure:"grpc"`
+
 	// GenericServerOptions holds the options for running a generic server.
 	GenericServerOptions *genericOptions.ServerRunOptions `json:"server" mapstructure:"server"`
 
@@ -26,6 +36,9 @@ type Options struct {
 	// SecureServing options for running the server with TLS.
 	SecureServing *genericOptions.SecureServingOptions `json:"secure" mapstructure:"secure"`
 
+	// MySQLOptions specifies the options for connecting to the policy store.
+	MySQLOptions *genericOptions.MySQLOptions `json:"mysql" mapstructure:"mysql"`
+
 	// RedisOptions specifies the options for connecting to Redis.
 	RedisOptions *genericOptions.RedisOptions `json:"redis" mapstructure:"redis"`
 
@@ -41,14 +54,21 @@ func NewOptions() *Options {
 	o := Options{
 		RPCServer:            "127.0.0.1:8081",
 		ClientCA:             "",
+		PolicyFile:           "",
+		PolicyResyncInterval: 30 * time.Second,
+		GRPCOptions:          genericOptions.NewGRPCOptions(),
 		GenericServerOptions: genericOptions.NewServerRunOptions(),
 		InsecureServing:      genericOptions.NewInsecureServingOptions(),
 		SecureServing:        genericOptions.NewSecureServingOptions(),
+		MySQLOptions:         genericOptions.NewMySQLOptions(),
 		RedisOptions:         genericOptions.NewRedisOptions(),
 		FeatureOptions:       genericOptions.NewFeatureOptions(),
 		Log:                  log.NewOptions(),
 	}
 
+	// The user service already listens on the default gRPC port.
+	o.GRPCOptions.BindPort = 8082
+
 	return &o
 }
 
@@ -62,16 +82,23 @@ func (o *Options) ApplyTo(c *server.Config) error {
 func (o *Options) Flags() (fss flag.NamedFlagSets) {
 	// Add flags for each option category.
 	o.GenericServerOptions.AddFlags(fss.FlagSet("generic"))
+	o.GRPCOptions.AddFlags(fss.FlagSet("grpc"))
 	o.InsecureServing.AddFlags(fss.FlagSet("Insecure serving"))
 	o.SecureServing.AddFlags(fss.FlagSet("Secure serving"))
 	o.FeatureOptions.AddFlags(fss.FlagSet("features"))
 	o.Log.AddFlags(fss.FlagSet("logs"))
+	o.MySQLOptions.AddFlags(fss.FlagSet("mysql"))
 	o.RedisOptions.AddFlags(fss.FlagSet("redis"))
 
 	// Add miscellaneous flags.
 	fs := fss.FlagSet("misc")
 	fs.StringVar(&o.RPCServer, "rpcserver", o.RPCServer, "authorization rpc server")
 	fs.StringVar(&o.ClientCA, "client-ca-file", o.ClientCA, "client certificate")
+	fs.StringVar(&o.PolicyFile, "policy-file", o.PolicyFile, ""+
+		"JSON policy set published to the policy store at startup when it differs from the latest stored one. "+
+		"When empty, the latest stored policy set is used.")
+	fs.DurationVar(&o.PolicyResyncInterval, "policy-resync-interval", o.PolicyResyncInterval, ""+
+		"How often the policy store is checked for a newer policy version, in addition to change notifications.")
 	return fss
 }
 
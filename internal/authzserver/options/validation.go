@@ -1,14 +1,22 @@
 package options
 
+import "fmt"
+
 func (o *Options) Validate() []error {
 	var errs []error
 
 	errs = append(errs, o.GenericServerOptions.Validate()...)
+	errs = append(errs, o.GRPCOptions.Validate()...)
 	errs = append(errs, o.InsecureServing.Validate()...)
 	errs = append(errs, o.SecureServing.Validate()...)
+	errs = append(errs, o.MySQLOptions.Validate()...)
 	errs = append(errs, o.RedisOptions.Validate()...)
 	errs = append(errs, o.FeatureOptions.Validate()...)
 	errs = append(errs, o.Log.Validate()...)
 
+	if o.PolicyResyncInterval <= 0 {
+		errs = append(errs, fmt.Errorf("--policy-resync-interval should be a positive duration"))
+	}
+
 	return errs
 }
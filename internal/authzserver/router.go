@@ -0,0 +1,39 @@
+package authzserver
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/skeleton1231/gotal/internal/authzserver/authorization"
+	"github.com/skeleton1231/gotal/internal/authzserver/controller/v1/authorize"
+	"github.com/skeleton1231/gotal/internal/authzserver/controller/v1/policy"
+	"github.com/skeleton1231/gotal/internal/authzserver/store"
+	"github.com/skeleton1231/gotal/internal/pkg/code"
+	"github.com/skeleton1231/gotal/internal/pkg/errors"
+	"github.com/skeleton1231/gotal/internal/pkg/middleware"
+	"github.com/skeleton1231/gotal/internal/pkg/response"
+)
+
+func initRouter(g *gin.Engine, engine *authorization.Engine, notifier store.PolicyNotifier) {
+	installMiddleware(g)
+	installController(g, engine, notifier)
+}
+
+func installMiddleware(g *gin.Engine) {
+	g.Use(middleware.ResponseLogger())
+}
+
+func installController(g *gin.Engine, engine *authorization.Engine, notifier store.PolicyNotifier) *gin.Engine {
+	g.NoRoute(func(c *gin.Context) {
+		response.WriteResponse(c, errors.WithCode(code.ErrPageNotFound, "Page not found."), nil)
+	})
+
+	authzController := authorize.NewAuthzController(engine)
+	policyController := policy.NewPolicyController(engine, notifier)
+
+	v1 := g.Group("/v1")
+	{
+		v1.POST("/authz", authzController.Authorize)
+		v1.GET("/policies/version", policyController.Version)
+	}
+
+	return g
+}
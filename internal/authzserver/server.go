@@ -2,14 +2,23 @@ package authzserver
 
 import (
 	"context"
-	"log"
+	"fmt"
+	"os"
 
+	"github.com/skeleton1231/gotal/internal/authzserver/authorization"
 	"github.com/skeleton1231/gotal/internal/authzserver/config"
+	"github.com/skeleton1231/gotal/internal/authzserver/load"
+	ssv1 "github.com/skeleton1231/gotal/internal/authzserver/service/server"
+	"github.com/skeleton1231/gotal/internal/authzserver/store"
 	genericOptions "github.com/skeleton1231/gotal/internal/pkg/options"
 	genericApiServer "github.com/skeleton1231/gotal/internal/pkg/server"
+	pbAuthz "github.com/skeleton1231/gotal/internal/proto/authz"
 	"github.com/skeleton1231/gotal/pkg/cache"
+	"github.com/skeleton1231/gotal/pkg/log"
 	"github.com/skeleton1231/gotal/pkg/shutdown"
 	posixsignal "github.com/skeleton1231/gotal/pkg/shutdown/managers"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 // authzServer struct holds all the necessary fields for the authorization server.
@@ -19,13 +28,23 @@ type authzServer struct {
 	clientCA         string                       // Client Certificate Authority
 	redisOptions     *genericOptions.RedisOptions // Configuration options for Redis
 	genericApiServer *genericApiServer.APIServer  // Generic API server
+	gRPCAPIServer    *grpcAPIServer               // AuthzService gRPC server
 	redisCancelFunc  context.CancelFunc           // Function to cancel Redis context
+	policyFile       string                       // Path of the JSON policy set published at startup
+	policyNotifier   store.PolicyNotifier         // Redis backed policy version counter and change channel
+	policyLoader     *load.Loader                 // Keeps the engine in sync with the policy store
+	engine           *authorization.Engine        // Policy decision engine
 }
 
 // PrepareRun initializes the server and returns a preparedAuthzServer ready to be run.
 func (s *authzServer) PrepareRun() preparedAuthzServer {
-	_ = s.initializes()
-	// Router Initialization (not shown here)
+	if err := s.initializes(); err != nil {
+		log.Fatalf("initialize authz server failed: %s", err.Error())
+	}
+
+	// Router Initialization
+	initRouter(s.genericApiServer.Engine, s.engine, s.policyNotifier)
+
 	return preparedAuthzServer{s}
 }
 
@@ -56,6 +75,37 @@ func (s *authzServer) initializes() error {
 	// Start connecting to Redis with the configuration provided.
 	go cache.ConnectToRedisV2(ctx, s.buildCacheConfig())
 
+	// Serve the latest stored policy set from the start.
+	if err := s.policyLoader.Reload(ctx); err != nil {
+		return err
+	}
+
+	// Publish the policy file as a new version when it differs from the stored one.
+	if s.policyFile != "" {
+		data, err := os.ReadFile(s.policyFile)
+		if err != nil {
+			return err
+		}
+
+		ps, err := authorization.ParsePolicySet(data)
+		if err != nil {
+			return err
+		}
+
+		version, err := s.policyLoader.Publish(ctx, ps)
+		if err != nil {
+			return err
+		}
+		log.Infof("policy set from %s is version %d", s.policyFile, version)
+	}
+
+	if s.engine.Version() == 0 {
+		log.Warn("no policy set found in the policy store, all requests will be denied")
+	}
+
+	// Reload whenever a newer version is published.
+	s.policyLoader.Start(ctx)
+
 	return nil
 }
 
@@ -76,9 +126,13 @@ func (s preparedAuthzServer) Run() error {
 	// Start the generic API server in a separate goroutine.
 	go s.genericApiServer.Run()
 
+	// Start the AuthzService gRPC server.
+	s.gRPCAPIServer.Run()
+
 	// Register a shutdown callback for clean up.
 	s.gs.AddShutdownCallback(shutdown.ShutdownFunc(func(string) error {
-		// Close API server and cancel Redis context on shutdown.
+		// Close API servers and cancel Redis context on shutdown.
+		s.gRPCAPIServer.Close()
 		s.genericApiServer.Close()
 		s.redisCancelFunc()
 		return nil
@@ -129,6 +183,19 @@ func createAuthzServer(cfg *config.Config) (*authzServer, error) {
 		return nil, err
 	}
 
+	policyStore, err := store.NewMySQLPolicyStore(cfg.MySQLOptions)
+	if err != nil {
+		return nil, err
+	}
+	policyNotifier := store.NewRedisPolicyNotifier()
+
+	engine := authorization.NewEngine()
+
+	grpcServer, err := newGRPCServer(cfg, engine)
+	if err != nil {
+		return nil, err
+	}
+
 	// Construct the authorization server with the necessary components.
 	server := &authzServer{
 		// Assign the relevant fields from the configuration and initialized components.
@@ -137,7 +204,31 @@ func createAuthzServer(cfg *config.Config) (*authzServer, error) {
 		rpcServer:        cfg.RPCServer,
 		clientCA:         cfg.ClientCA,
 		genericApiServer: genericServer,
+		gRPCAPIServer:    grpcServer,
+		policyFile:       cfg.PolicyFile,
+		policyNotifier:   policyNotifier,
+		policyLoader:     load.NewLoader(engine, policyStore, policyNotifier, cfg.PolicyResyncInterval),
+		engine:           engine,
 	}
 
 	return server, nil
 }
+
+// newGRPCServer creates the AuthzService gRPC server. TLS is used when a server certificate is configured.
+func newGRPCServer(cfg *config.Config, engine *authorization.Engine) (*grpcAPIServer, error) {
+	opts := []grpc.ServerOption{grpc.MaxRecvMsgSize(cfg.GRPCOptions.MaxMsgSize)}
+
+	certKey := cfg.SecureServing.ServerCert.CertKey
+	if certKey.CertFile != "" && certKey.KeyFile != "" {
+		creds, err := credentials.NewServerTLSFromFile(certKey.CertFile, certKey.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, grpc.Creds(creds))
+	}
+
+	grpcServer := grpc.NewServer(opts...)
+	pbAuthz.RegisterAuthzServiceServer(grpcServer, ssv1.NewAuthzServiceServer(engine))
+
+	return &grpcAPIServer{grpcServer, fmt.Sprintf("%s:%d", cfg.GRPCOptions.BindAddress, cfg.GRPCOptions.BindPort)}, nil
+}
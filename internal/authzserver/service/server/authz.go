@@ -0,0 +1,34 @@
+package service
+
+import (
+	"context"
+
+	"github.com/skeleton1231/gotal/internal/authzserver/authorization"
+	pb "github.com/skeleton1231/gotal/internal/proto/authz"
+)
+
+// AuthzServiceServer implements the AuthzService gRPC interface on top of the policy engine.
+type AuthzServiceServer struct {
+	engine *authorization.Engine
+	pb.UnimplementedAuthzServiceServer
+}
+
+// NewAuthzServiceServer creates an AuthzServiceServer with the given engine.
+func NewAuthzServiceServer(engine *authorization.Engine) *AuthzServiceServer {
+	return &AuthzServiceServer{engine: engine}
+}
+
+// Authorize returns whether the subject is allowed to perform the action on the resource.
+func (s *AuthzServiceServer) Authorize(ctx context.Context, req *pb.AuthorizeRequest) (*pb.AuthorizeResponse, error) {
+	decision := s.engine.Authorize(&authorization.Request{
+		Subject:  req.GetSubject(),
+		Action:   req.GetAction(),
+		Resource: req.GetResource(),
+		Context:  req.GetContext(),
+	})
+
+	return &pb.AuthorizeResponse{
+		Allowed: decision.Allowed,
+		Reason:  decision.Reason,
+	}, nil
+}
@@ -0,0 +1,104 @@
+// Copyright 2023 Talhuang<talhuang1231@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/skeleton1231/gotal/internal/authzserver/authorization"
+	"github.com/skeleton1231/gotal/internal/pkg/logger"
+	"github.com/skeleton1231/gotal/internal/pkg/options"
+	"github.com/skeleton1231/gotal/pkg/db"
+	"gorm.io/gorm"
+)
+
+// policyRevision is a stored policy set. The auto increment primary key is the policy version.
+type policyRevision struct {
+	Version   int64     `gorm:"column:version;primaryKey;autoIncrement"`
+	Document  string    `gorm:"column:document;type:mediumtext;not null"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+}
+
+// TableName maps to mysql table name.
+func (policyRevision) TableName() string {
+	return "authz_policies"
+}
+
+type mysqlPolicyStore struct {
+	db *gorm.DB
+}
+
+var _ PolicyStore = (*mysqlPolicyStore)(nil)
+
+// NewMySQLPolicyStore returns a PolicyStore keeping every policy revision in mysql.
+func NewMySQLPolicyStore(opts *options.MySQLOptions) (PolicyStore, error) {
+	dbIns, err := db.New(&db.Options{
+		Host:                  opts.Host,
+		Username:              opts.Username,
+		Password:              opts.Password,
+		Database:              opts.Database,
+		MaxIdleConnections:    opts.MaxIdleConnections,
+		MaxOpenConnections:    opts.MaxOpenConnections,
+		MaxConnectionLifeTime: opts.MaxConnectionLifeTime,
+		LogLevel:              opts.LogLevel,
+		Logger:                logger.New(opts.LogLevel),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := dbIns.AutoMigrate(&policyRevision{}); err != nil {
+		return nil, err
+	}
+
+	return &mysqlPolicyStore{db: dbIns}, nil
+}
+
+// Latest implements PolicyStore.
+func (m *mysqlPolicyStore) Latest(ctx context.Context) (*authorization.PolicySet, error) {
+	var rev policyRevision
+	err := m.db.WithContext(ctx).Order("version desc").Take(&rev).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPolicyNotFound
+		}
+
+		return nil, err
+	}
+
+	ps, err := authorization.ParsePolicySet([]byte(rev.Document))
+	if err != nil {
+		return nil, err
+	}
+	ps.Version = rev.Version
+
+	return ps, nil
+}
+
+// Create implements PolicyStore.
+func (m *mysqlPolicyStore) Create(ctx context.Context, ps *authorization.PolicySet) (*authorization.PolicySet, error) {
+	if err := ps.Validate(); err != nil {
+		return nil, err
+	}
+
+	stored := *ps
+	stored.Version = 0
+
+	data, err := json.Marshal(&stored)
+	if err != nil {
+		return nil, err
+	}
+
+	rev := policyRevision{Document: string(data)}
+	if err := m.db.WithContext(ctx).Create(&rev).Error; err != nil {
+		return nil, err
+	}
+	stored.Version = rev.Version
+
+	return &stored, nil
+}
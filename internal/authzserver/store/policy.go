@@ -0,0 +1,36 @@
+// Copyright 2023 Talhuang<talhuang1231@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+// Package store defines the policy storage used by the authz server.
+package store
+
+import (
+	"context"
+	"errors"
+
+	"github.com/skeleton1231/gotal/internal/authzserver/authorization"
+)
+
+// ErrPolicyNotFound is returned when no policy set has been stored yet.
+var ErrPolicyNotFound = errors.New("policy set not found")
+
+// PolicyStore defines the durable policy storage. Every stored policy set is kept
+// as a revision whose version is assigned by the store and only ever increases.
+type PolicyStore interface {
+	// Latest returns the policy set with the highest version. It returns ErrPolicyNotFound when nothing is stored.
+	Latest(ctx context.Context) (*authorization.PolicySet, error)
+	// Create stores the policy set as a new revision and returns it with its assigned version.
+	Create(ctx context.Context, ps *authorization.PolicySet) (*authorization.PolicySet, error)
+}
+
+// PolicyNotifier distributes policy changes between authz server replicas.
+type PolicyNotifier interface {
+	// Version returns the latest published policy version, zero when nothing has been published.
+	Version(ctx context.Context) (int64, error)
+	// Publish records the version as the latest one and notifies every subscribed replica.
+	// A version not newer than the latest published one is ignored.
+	Publish(ctx context.Context, version int64) error
+	// Subscribe calls onChange with every version published afterwards. It blocks until the subscription ends.
+	Subscribe(ctx context.Context, onChange func(version int64)) error
+}
@@ -0,0 +1,93 @@
+// Copyright 2023 Talhuang<talhuang1231@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package store
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/skeleton1231/gotal/pkg/cache"
+	"github.com/skeleton1231/gotal/pkg/log"
+)
+
+const (
+	policyKeyPrefix     = "gotal-authz-"
+	policyVersionKey    = "policy-version"
+	policyChangeChannel = "gotal-authz-policy-changed"
+)
+
+// publishVersionScript records the version only when it is newer than the stored one, so a
+// replica publishing late can not move the latest version back. Only recorded versions are announced.
+var publishVersionScript = redis.NewScript(`
+local current = tonumber(redis.call('GET', KEYS[1]) or '0')
+if tonumber(ARGV[1]) <= current then
+	return 0
+end
+redis.call('SET', KEYS[1], ARGV[1])
+redis.call('PUBLISH', ARGV[2], ARGV[1])
+return 1
+`)
+
+type redisPolicyNotifier struct {
+	cli *cache.RedisClusterV2
+}
+
+var _ PolicyNotifier = (*redisPolicyNotifier)(nil)
+
+// NewRedisPolicyNotifier returns a PolicyNotifier backed by the shared redis connection.
+// The latest version is kept in a counter key and changes are announced on a pub/sub channel.
+func NewRedisPolicyNotifier() PolicyNotifier {
+	return &redisPolicyNotifier{cli: &cache.RedisClusterV2{KeyPrefix: policyKeyPrefix}}
+}
+
+// Version implements PolicyNotifier.
+func (r *redisPolicyNotifier) Version(ctx context.Context) (int64, error) {
+	data, err := r.cli.GetKey(ctx, policyVersionKey)
+	if err != nil {
+		if errors.Is(err, cache.ErrKeyNotFound) {
+			return 0, nil
+		}
+
+		return 0, err
+	}
+
+	return strconv.ParseInt(data, 10, 64)
+}
+
+// Publish implements PolicyNotifier. Versions older than the published one are ignored.
+func (r *redisPolicyNotifier) Publish(ctx context.Context, version int64) error {
+	recorded, err := r.cli.RunScript(ctx, publishVersionScript, []string{policyVersionKey},
+		strconv.FormatInt(version, 10), policyChangeChannel)
+	if err != nil {
+		return err
+	}
+
+	if n, _ := recorded.(int64); n == 0 {
+		log.Infof("skip publishing policy version %d, a newer version has been published", version)
+	}
+
+	return nil
+}
+
+// Subscribe implements PolicyNotifier.
+func (r *redisPolicyNotifier) Subscribe(ctx context.Context, onChange func(version int64)) error {
+	return r.cli.StartPubSubHandler(ctx, policyChangeChannel, func(v interface{}) {
+		msg, ok := v.(*redis.Message)
+		if !ok {
+			return
+		}
+
+		version, err := strconv.ParseInt(msg.Payload, 10, 64)
+		if err != nil {
+			log.Warnf("ignore malformed policy change notification `%s`", msg.Payload)
+
+			return
+		}
+
+		onChange(version)
+	})
+}
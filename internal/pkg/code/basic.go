@@ -88,4 +88,100 @@ const (
 
 	// ErrUserAlreadyExist - 400: User already exist.
 	ErrUserAlreadyExist
+
+	// ErrUserWatchExpired - 400: Resource version is too old to resume the watch from.
+	ErrUserWatchExpired
+
+	// ErrUserWatchClosed - 500: Watch was closed by the server.
+	ErrUserWatchClosed
+)
+
+const (
+	// ErrRoleNotFound - 404: Role not found.
+	ErrRoleNotFound int = iota + 110101
+
+	// ErrRoleAlreadyExist - 400: Role already exist.
+	ErrRoleAlreadyExist
+)
+
+const (
+	// ErrAPIKeyNotFound - 404: API key not found.
+	ErrAPIKeyNotFound int = iota + 110201
+)
+
+const (
+	// ErrSessionNotFound - 404: Session not found.
+	ErrSessionNotFound int = iota + 110301
+
+	// ErrRefreshTokenReused - 401: Refresh token has already been used.
+	ErrRefreshTokenReused
+)
+
+const (
+	// ErrOAuthStateInvalid - 400: OAuth state is invalid or has expired.
+	ErrOAuthStateInvalid int = iota + 110401
+
+	// ErrOAuthFailed - 401: OAuth provider did not authorize the login.
+	ErrOAuthFailed
+
+	// ErrOAuthAccountLinked - 400: OAuth account is already linked to another user.
+	ErrOAuthAccountLinked
+)
+
+const (
+	// ErrTwoFactorNotFound - 404: Two-factor authentication is not enrolled.
+	ErrTwoFactorNotFound int = iota + 110501
+
+	// ErrTwoFactorEnabled - 400: Two-factor authentication is already enabled.
+	ErrTwoFactorEnabled
+
+	// ErrTwoFactorCodeInvalid - 401: Two-factor code is invalid.
+	ErrTwoFactorCodeInvalid
+
+	// ErrTwoFactorChallengeInvalid - 401: Two-factor challenge is invalid or has expired.
+	ErrTwoFactorChallengeInvalid
+
+	// ErrTwoFactorRequired - 401: Two-factor authentication is required, log in with a password and a code.
+	ErrTwoFactorRequired
+)
+
+const (
+	// ErrEmailNotVerified - 401: Email address has not been verified.
+	ErrEmailNotVerified int = iota + 110601
+
+	// ErrEmailAlreadyVerified - 400: Email address is already verified.
+	ErrEmailAlreadyVerified
+
+	// ErrVerificationTokenInvalid - 400: Verification token is invalid or has expired.
+	ErrVerificationTokenInvalid
+
+	// ErrSendMail - 500: Email could not be sent.
+	ErrSendMail
+)
+
+const (
+	// ErrPasswordResetTokenInvalid - 400: Password reset token is invalid or has expired.
+	ErrPasswordResetTokenInvalid int = iota + 110701
+
+	// ErrPasswordResetLimited - 403: Too many password reset attempts.
+	ErrPasswordResetLimited
+)
+
+const (
+	// ErrInsufficientCredits - 400: Not enough credits.
+	ErrInsufficientCredits int = iota + 110801
+
+	// ErrIdempotencyKeyReused - 400: Idempotency key was used for a different request.
+	ErrIdempotencyKeyReused
+)
+
+const (
+	// ErrWebhookSignatureInvalid - 400: Webhook signature is invalid or has expired.
+	ErrWebhookSignatureInvalid int = iota + 110901
+
+	// ErrBillingProvider - 500: Billing provider request failed.
+	ErrBillingProvider
+
+	// ErrWebhookEventProcessing - 409: Webhook event is still being processed, deliver it again later.
+	ErrWebhookEventProcessing
 )
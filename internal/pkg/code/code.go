@@ -41,7 +41,7 @@ func (coder ErrCode) HTTPStatus() int {
 }
 
 func isValidHTTPStatus(code int) bool {
-	validStatusCodes := []int{http.StatusOK, http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound, http.StatusInternalServerError}
+	validStatusCodes := []int{http.StatusOK, http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound, http.StatusConflict, http.StatusInternalServerError}
 	for _, v := range validStatusCodes {
 		if code == v {
 			return true
@@ -52,7 +52,7 @@ func isValidHTTPStatus(code int) bool {
 
 func register(code int, httpStatus int, message string, refs ...string) {
 	if !isValidHTTPStatus(httpStatus) {
-		panic("http code not in `200, 400, 401, 403, 404, 500`")
+		panic("http code not in `200, 400, 401, 403, 404, 409, 500`")
 	}
 
 	coder := &ErrCode{
@@ -7,6 +7,32 @@ package code
 func init() {
 	register(ErrUserNotFound, 404, "User not found")
 	register(ErrUserAlreadyExist, 400, "User already exist")
+	register(ErrUserWatchExpired, 400, "Resource version is too old to resume the watch from")
+	register(ErrUserWatchClosed, 500, "Watch was closed by the server")
+	register(ErrRoleNotFound, 404, "Role not found")
+	register(ErrRoleAlreadyExist, 400, "Role already exist")
+	register(ErrAPIKeyNotFound, 404, "API key not found")
+	register(ErrSessionNotFound, 404, "Session not found")
+	register(ErrRefreshTokenReused, 401, "Refresh token has already been used")
+	register(ErrOAuthStateInvalid, 400, "OAuth state is invalid or has expired")
+	register(ErrOAuthFailed, 401, "OAuth provider did not authorize the login")
+	register(ErrOAuthAccountLinked, 400, "OAuth account is already linked to another user")
+	register(ErrTwoFactorNotFound, 404, "Two-factor authentication is not enrolled")
+	register(ErrTwoFactorEnabled, 400, "Two-factor authentication is already enabled")
+	register(ErrTwoFactorCodeInvalid, 401, "Two-factor code is invalid")
+	register(ErrTwoFactorChallengeInvalid, 401, "Two-factor challenge is invalid or has expired")
+	register(ErrTwoFactorRequired, 401, "Two-factor authentication is required, log in with a password and a code")
+	register(ErrEmailNotVerified, 401, "Email address has not been verified")
+	register(ErrEmailAlreadyVerified, 400, "Email address is already verified")
+	register(ErrVerificationTokenInvalid, 400, "Verification token is invalid or has expired")
+	register(ErrSendMail, 500, "Email could not be sent")
+	register(ErrPasswordResetTokenInvalid, 400, "Password reset token is invalid or has expired")
+	register(ErrPasswordResetLimited, 403, "Too many password reset attempts, try again later")
+	register(ErrInsufficientCredits, 400, "Not enough credits")
+	register(ErrIdempotencyKeyReused, 400, "Idempotency key was used for a different request")
+	register(ErrWebhookSignatureInvalid, 400, "Webhook signature is invalid or has expired")
+	register(ErrBillingProvider, 500, "Billing provider request failed")
+	register(ErrWebhookEventProcessing, 409, "Webhook event is still being processed, deliver it again later")
 	register(ErrSuccess, 200, "OK")
 	register(ErrUnknown, 500, "Internal server error")
 	register(ErrBind, 400, "Error occurred while binding the request body to the struct")
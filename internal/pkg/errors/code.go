@@ -123,6 +123,16 @@ func IsCode(err error, code int) bool {
 	return false
 }
 
+// Message returns the internal message of err. For a coded error this is the message
+// it was created with, which Error() replaces with the externally-safe text of the code.
+func Message(err error) string {
+	if v, ok := err.(*withCode); ok {
+		return v.err.Error()
+	}
+
+	return err.Error()
+}
+
 func init() {
 	codes[unknownCoder.Code()] = unknownCoder
 }
@@ -205,9 +205,10 @@ func (w *withMessage) Format(s fmt.State, verb rune) {
 }
 
 type withCode struct {
-	err   error
-	code  int
-	cause error
+	err        error
+	code       int
+	cause      error
+	violations []FieldViolation
 	*stack
 }
 
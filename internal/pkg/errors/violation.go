@@ -0,0 +1,42 @@
+package errors
+
+// FieldViolation describes a single invalid field of a request.
+type FieldViolation struct {
+	Field       string `json:"field"`
+	Description string `json:"description"`
+}
+
+// WithFieldViolations attaches field violations to a coded error.
+// Errors created without a code are returned unchanged.
+func WithFieldViolations(err error, violations ...FieldViolation) error {
+	e, ok := err.(*withCode)
+	if !ok {
+		return err
+	}
+
+	return &withCode{
+		err:        e.err,
+		code:       e.code,
+		cause:      e.cause,
+		violations: append(append([]FieldViolation(nil), e.violations...), violations...),
+		stack:      e.stack,
+	}
+}
+
+// FieldViolations returns the field violations of the first coded error in err's chain
+// which carries any.
+func FieldViolations(err error) []FieldViolation {
+	for err != nil {
+		if e, ok := err.(*withCode); ok && len(e.violations) > 0 {
+			return e.violations
+		}
+
+		cause, ok := err.(interface{ Cause() error })
+		if !ok {
+			return nil
+		}
+		err = cause.Cause()
+	}
+
+	return nil
+}
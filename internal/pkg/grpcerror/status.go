@@ -0,0 +1,103 @@
+// Copyright 2023 Talhuang<talhuang1231@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+// Package grpcerror carries coded errors across gRPC calls without losing the business code.
+// The server side turns an error built with errors.WithCode into a status with an
+// errdetail.Error detail, the client side turns such a status back into a coded error.
+package grpcerror
+
+import (
+	"net/http"
+
+	"github.com/skeleton1231/gotal/internal/pkg/errors"
+	"github.com/skeleton1231/gotal/internal/proto/errdetail"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ToStatus converts err into a gRPC status. Coded errors keep their code, message and
+// field violations in an errdetail.Error detail. Errors which already are a status are kept.
+func ToStatus(err error) *status.Status {
+	if err == nil {
+		return nil
+	}
+
+	if st, ok := status.FromError(err); ok {
+		return st
+	}
+
+	coder := errors.ParseCoder(err)
+	detail := &errdetail.Error{
+		Code:    int32(coder.Code()),
+		Message: errors.Message(err),
+	}
+	for _, v := range errors.FieldViolations(err) {
+		detail.FieldViolations = append(detail.FieldViolations, &errdetail.FieldViolation{
+			Field:       v.Field,
+			Description: v.Description,
+		})
+	}
+
+	st := status.New(grpcCode(coder.HTTPStatus()), errors.Message(err))
+	if withDetail, derr := st.WithDetails(detail); derr == nil {
+		st = withDetail
+	}
+
+	return st
+}
+
+// FromStatus rebuilds the coded error carried by st. A status without an errdetail.Error
+// detail is returned as a plain status error.
+func FromStatus(st *status.Status) error {
+	if st == nil || st.Code() == codes.OK {
+		return nil
+	}
+
+	for _, d := range st.Details() {
+		detail, ok := d.(*errdetail.Error)
+		if !ok {
+			continue
+		}
+
+		violations := make([]errors.FieldViolation, 0, len(detail.GetFieldViolations()))
+		for _, v := range detail.GetFieldViolations() {
+			violations = append(violations, errors.FieldViolation{
+				Field:       v.GetField(),
+				Description: v.GetDescription(),
+			})
+		}
+
+		return errors.WithFieldViolations(errors.WithCode(int(detail.GetCode()), "%s", detail.GetMessage()), violations...)
+	}
+
+	return st.Err()
+}
+
+// FromError rebuilds the coded error carried by a gRPC call error.
+// Errors which are not a status are returned unchanged.
+func FromError(err error) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	return FromStatus(st)
+}
+
+func grpcCode(httpStatus int) codes.Code {
+	switch httpStatus {
+	case http.StatusBadRequest:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusInternalServerError:
+		return codes.Internal
+	default:
+		return codes.Unknown
+	}
+}
@@ -0,0 +1,94 @@
+package grpcerror
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/skeleton1231/gotal/internal/pkg/code"
+	"github.com/skeleton1231/gotal/internal/pkg/errors"
+	pb "github.com/skeleton1231/gotal/internal/proto/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestRoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		grpcCode codes.Code
+	}{
+		{name: "not found", err: errors.WithCode(code.ErrUserNotFound, "user 7 not found"), grpcCode: codes.NotFound},
+		{name: "validation", err: errors.WithCode(code.ErrValidation, "bad request"), grpcCode: codes.InvalidArgument},
+		{name: "unauthorized", err: errors.WithCode(code.ErrPasswordIncorrect, "wrong password"), grpcCode: codes.Unauthenticated},
+		{name: "database", err: errors.WithCode(code.ErrDatabase, "connection refused"), grpcCode: codes.Internal},
+		{name: "wrapped", err: errors.Wrap(errors.WithCode(code.ErrUserNotFound, "inner"), "outer"), grpcCode: codes.NotFound},
+		{name: "without code", err: errors.New("boom"), grpcCode: codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := ToStatus(tt.err)
+			assert.Equal(t, tt.grpcCode, st.Code())
+
+			got := FromError(st.Err())
+			want := errors.ParseCoder(tt.err)
+			assert.Equal(t, want.Code(), errors.ParseCoder(got).Code())
+			assert.Equal(t, want.HTTPStatus(), errors.ParseCoder(got).HTTPStatus())
+			assert.Equal(t, errors.Message(tt.err), errors.Message(got))
+		})
+	}
+}
+
+func TestRoundTrip_FieldViolations(t *testing.T) {
+	violations := []errors.FieldViolation{
+		{Field: "email", Description: "must be a valid email"},
+		{Field: "name", Description: "is required"},
+	}
+	err := errors.WithFieldViolations(errors.WithCode(code.ErrValidation, "invalid user"), violations...)
+
+	got := FromError(ToStatus(err).Err())
+	assert.True(t, errors.IsCode(got, code.ErrValidation))
+	assert.Equal(t, violations, errors.FieldViolations(got))
+}
+
+func TestFromError_PlainStatus(t *testing.T) {
+	err := status.Error(codes.Unavailable, "connection refused")
+
+	assert.Equal(t, err, FromError(err))
+	assert.Nil(t, FromError(nil))
+}
+
+type userServer struct {
+	pb.UnimplementedUserServiceServer
+}
+
+func (userServer) Get(context.Context, *pb.GetRequest) (*pb.GetResponse, error) {
+	return nil, errors.WithCode(code.ErrUserNotFound, "user not found")
+}
+
+func TestInterceptors(t *testing.T) {
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer(grpc.ChainUnaryInterceptor(UnaryServerInterceptor()))
+	pb.RegisterUserServiceServer(srv, userServer{})
+	go func() { _ = srv.Serve(lis) }()
+	defer srv.Stop()
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(UnaryClientInterceptor()),
+	)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	_, err = pb.NewUserServiceClient(conn).Get(context.Background(), &pb.GetRequest{UserId: 7})
+	assert.True(t, errors.IsCode(err, code.ErrUserNotFound))
+	assert.Equal(t, 404, errors.ParseCoder(err).HTTPStatus())
+	assert.Equal(t, "user not found", errors.Message(err))
+}
@@ -0,0 +1,75 @@
+// Copyright 2023 Talhuang<talhuang1231@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package auth
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/skeleton1231/gotal/internal/pkg/code"
+	"github.com/skeleton1231/gotal/internal/pkg/errors"
+	"github.com/skeleton1231/gotal/internal/pkg/middleware"
+	"github.com/skeleton1231/gotal/internal/pkg/response"
+)
+
+// APIKeyHeader is the header carrying an API key.
+const APIKeyHeader = "X-API-Key"
+
+// APIKeyScheme is the `Authorization` scheme carrying an API key.
+const APIKeyScheme = "ApiKey"
+
+// APIKeyStrategy defines API key authentication strategy.
+type APIKeyStrategy struct {
+	validate func(c *gin.Context, key string) (string, error)
+}
+
+var _ middleware.AuthStrategy = &APIKeyStrategy{}
+
+// NewAPIKeyStrategy create API key strategy with validate function.
+// The validate function returns the username owning the key.
+func NewAPIKeyStrategy(validate func(c *gin.Context, key string) (string, error)) APIKeyStrategy {
+	return APIKeyStrategy{
+		validate: validate,
+	}
+}
+
+// AuthFunc defines API key strategy as the gin authentication middleware.
+func (a APIKeyStrategy) AuthFunc() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := apiKeyFromRequest(c)
+		if key == "" {
+			response.WriteResponse(c, errors.WithCode(code.ErrInvalidAuthHeader, "API key is missing."), nil)
+			c.Abort()
+
+			return
+		}
+
+		username, err := a.validate(c, key)
+		if err != nil {
+			response.WriteResponse(c, err, nil)
+			c.Abort()
+
+			return
+		}
+
+		c.Set(middleware.UsernameKey, username)
+
+		c.Next()
+	}
+}
+
+// apiKeyFromRequest returns the API key from the `X-API-Key` header or the `ApiKey` Authorization scheme.
+func apiKeyFromRequest(c *gin.Context) string {
+	if key := c.Request.Header.Get(APIKeyHeader); key != "" {
+		return key
+	}
+
+	auth := strings.SplitN(c.Request.Header.Get("Authorization"), " ", authHeaderCount)
+	if len(auth) == authHeaderCount && auth[0] == APIKeyScheme {
+		return strings.TrimSpace(auth[1])
+	}
+
+	return ""
+}
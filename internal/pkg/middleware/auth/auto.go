@@ -16,20 +16,22 @@ import (
 
 const authHeaderCount = 2
 
-// AutoStrategy defines authentication strategy which can automatically choose between Basic and Bearer
-// according `Authorization` header.
+// AutoStrategy defines authentication strategy which can automatically choose between Basic, Bearer
+// and API key according `Authorization` and `X-API-Key` headers.
 type AutoStrategy struct {
-	basic middleware.AuthStrategy
-	jwt   middleware.AuthStrategy
+	basic  middleware.AuthStrategy
+	jwt    middleware.AuthStrategy
+	apiKey middleware.AuthStrategy
 }
 
 var _ middleware.AuthStrategy = &AutoStrategy{}
 
-// NewAutoStrategy create auto strategy with basic strategy and jwt strategy.
-func NewAutoStrategy(basic, jwt middleware.AuthStrategy) AutoStrategy {
+// NewAutoStrategy create auto strategy with basic strategy, jwt strategy and API key strategy.
+func NewAutoStrategy(basic, jwt, apiKey middleware.AuthStrategy) AutoStrategy {
 	return AutoStrategy{
-		basic: basic,
-		jwt:   jwt,
+		basic:  basic,
+		jwt:    jwt,
+		apiKey: apiKey,
 	}
 }
 
@@ -37,6 +39,15 @@ func NewAutoStrategy(basic, jwt middleware.AuthStrategy) AutoStrategy {
 func (a AutoStrategy) AuthFunc() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		operator := middleware.AuthOperator{}
+		if c.Request.Header.Get(APIKeyHeader) != "" {
+			operator.SetStrategy(a.apiKey)
+			operator.AuthFunc()(c)
+
+			c.Next()
+
+			return
+		}
+
 		authHeader := strings.SplitN(c.Request.Header.Get("Authorization"), " ", 2)
 
 		if len(authHeader) != authHeaderCount {
@@ -55,6 +66,8 @@ func (a AutoStrategy) AuthFunc() gin.HandlerFunc {
 			operator.SetStrategy(a.basic)
 		case "Bearer":
 			operator.SetStrategy(a.jwt)
+		case APIKeyScheme:
+			operator.SetStrategy(a.apiKey)
 		default:
 			response.WriteResponse(c, errors.WithCode(code.ErrSignatureInvalid, "unrecognized Authorization header."), nil)
 			c.Abort()
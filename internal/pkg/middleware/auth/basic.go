@@ -17,15 +17,16 @@ import (
 
 // BasicStrategy defines Basic authentication strategy.
 type BasicStrategy struct {
-	compare func(username string, password string) bool
+	validate func(c *gin.Context, username string, password string) error
 }
 
 var _ middleware.AuthStrategy = &BasicStrategy{}
 
-// NewBasicStrategy create basic strategy with compare function.
-func NewBasicStrategy(compare func(username string, password string) bool) BasicStrategy {
+// NewBasicStrategy create basic strategy with validate function.
+// The validate function returns the error responded when the user is not let in.
+func NewBasicStrategy(validate func(c *gin.Context, username string, password string) error) BasicStrategy {
 	return BasicStrategy{
-		compare: compare,
+		validate: validate,
 	}
 }
 
@@ -48,7 +49,7 @@ func (b BasicStrategy) AuthFunc() gin.HandlerFunc {
 		payload, _ := base64.StdEncoding.DecodeString(auth[1])
 		pair := strings.SplitN(string(payload), ":", 2)
 
-		if len(pair) != 2 || !b.compare(pair[0], pair[1]) {
+		if len(pair) != 2 {
 			response.WriteResponse(
 				c,
 				errors.WithCode(code.ErrSignatureInvalid, "Authorization header format is wrong."),
@@ -59,6 +60,13 @@ func (b BasicStrategy) AuthFunc() gin.HandlerFunc {
 			return
 		}
 
+		if err := b.validate(c, pair[0], pair[1]); err != nil {
+			response.WriteResponse(c, err, nil)
+			c.Abort()
+
+			return
+		}
+
 		c.Set(middleware.UsernameKey, pair[0])
 
 		c.Next()
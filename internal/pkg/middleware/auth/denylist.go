@@ -0,0 +1,85 @@
+// Copyright 2023 Talhuang<talhuang1231@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/skeleton1231/gotal/pkg/cache"
+)
+
+// TokenDenylist records revoked tokens until they can no longer be used.
+type TokenDenylist interface {
+	// Revoke revokes the token with the given `jti` claim until the given time.
+	Revoke(ctx context.Context, jti string, until time.Time) error
+	// RevokeUser revokes every token the user authenticated for before now, until the given time.
+	RevokeUser(ctx context.Context, username string, until time.Time) error
+	// IsRevoked reports whether the token with the given id, owner and authentication time is revoked.
+	IsRevoked(ctx context.Context, jti, username string, authTime time.Time) (bool, error)
+}
+
+type redisTokenDenylist struct {
+	cache *cache.RedisClusterV2
+	now   func() time.Time
+}
+
+// NewRedisTokenDenylist create a token denylist stored in redis. Entries expire on their own
+// once the revoked tokens are expired, so the denylist does not grow unbounded.
+func NewRedisTokenDenylist() TokenDenylist {
+	return &redisTokenDenylist{
+		cache: &cache.RedisClusterV2{KeyPrefix: "gotal-jwt-denylist-"},
+		now:   time.Now,
+	}
+}
+
+func (d *redisTokenDenylist) Revoke(ctx context.Context, jti string, until time.Time) error {
+	ttl := until.Sub(d.now())
+	if jti == "" || ttl <= 0 {
+		return nil
+	}
+
+	return d.cache.SetKey(ctx, "jti-"+jti, "1", ttl)
+}
+
+func (d *redisTokenDenylist) RevokeUser(ctx context.Context, username string, until time.Time) error {
+	now := d.now()
+	ttl := until.Sub(now)
+	if ttl <= 0 {
+		return nil
+	}
+
+	return d.cache.SetKey(ctx, "user-"+username, strconv.FormatInt(now.UnixMilli(), 10), ttl)
+}
+
+func (d *redisTokenDenylist) IsRevoked(ctx context.Context, jti, username string, authTime time.Time) (bool, error) {
+	if jti != "" {
+		_, err := d.cache.GetKey(ctx, "jti-"+jti)
+		if err == nil {
+			return true, nil
+		}
+		if !errors.Is(err, cache.ErrKeyNotFound) {
+			return false, err
+		}
+	}
+
+	value, err := d.cache.GetKey(ctx, "user-"+username)
+	if err != nil {
+		if errors.Is(err, cache.ErrKeyNotFound) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	revokedAt, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return false, err
+	}
+
+	return authTime.Before(time.UnixMilli(revokedAt)), nil
+}
@@ -5,24 +5,157 @@
 package auth
 
 import (
+	"errors"
+	"math"
+	"time"
+
 	ginjwt "github.com/appleboy/gin-jwt/v2"
 	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/skeleton1231/gotal/internal/pkg/code"
+	pkgerrors "github.com/skeleton1231/gotal/internal/pkg/errors"
 	"github.com/skeleton1231/gotal/internal/pkg/middleware"
+	"github.com/skeleton1231/gotal/internal/pkg/response"
+	"github.com/skeleton1231/gotal/pkg/log"
+)
+
+// Claims used to revoke tokens.
+const (
+	// ClaimTokenID identifies a token. It is kept when the token is refreshed.
+	ClaimTokenID = "jti"
+	// ClaimAuthTime is the time the user authenticated, in seconds. It is kept when the token is refreshed.
+	ClaimAuthTime = "auth_time"
 )
 
 // JWTStrategy defines jwt bearer authentication strategy.
 type JWTStrategy struct {
 	ginjwt.GinJWTMiddleware
+	denylist TokenDenylist
 }
 
 var _ middleware.AuthStrategy = &JWTStrategy{}
 
 // NewJWTStrategy create jwt bearer strategy with GinJWTMiddleware.
-func NewJWTStrategy(gjwt ginjwt.GinJWTMiddleware) JWTStrategy {
-	return JWTStrategy{gjwt}
+// Revoked tokens are rejected when denylist is not nil.
+func NewJWTStrategy(gjwt ginjwt.GinJWTMiddleware, denylist TokenDenylist) JWTStrategy {
+	return JWTStrategy{GinJWTMiddleware: gjwt, denylist: denylist}
 }
 
 // AuthFunc defines jwt bearer strategy as the gin authentication middleware.
 func (j JWTStrategy) AuthFunc() gin.HandlerFunc {
-	return j.MiddlewareFunc()
+	next := j.MiddlewareFunc()
+
+	return func(c *gin.Context) {
+		// Invalid tokens are reported by the gin-jwt middleware itself.
+		if claims, err := j.GetClaimsFromJWT(c); err == nil && j.revoked(c, claims) {
+			response.WriteResponse(c, pkgerrors.WithCode(code.ErrTokenInvalid, "token has been revoked"), nil)
+			c.Abort()
+
+			return
+		}
+
+		next(c)
+	}
+}
+
+// LogoutHandler revokes the token of the request and clears the auth cookie.
+func (j JWTStrategy) LogoutHandler(c *gin.Context) {
+	if claims, err := j.refreshableClaims(c); err == nil && j.denylist != nil {
+		jti, _ := claims[ClaimTokenID].(string)
+		if err := j.denylist.Revoke(c, jti, j.usableUntil(claims)); err != nil {
+			log.Record(c).Errorf("revoke token `%s` failed: %s", jti, err.Error())
+		}
+	}
+
+	j.GinJWTMiddleware.LogoutHandler(c)
+}
+
+// IssueToken creates a token for data, the same way LoginHandler does, and sets the auth cookie.
+func (j JWTStrategy) IssueToken(c *gin.Context, data interface{}) (string, time.Time, error) {
+	token, expire, err := j.TokenGenerator(data)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	if j.SendCookie {
+		if j.CookieSameSite != 0 {
+			c.SetSameSite(j.CookieSameSite)
+		}
+
+		maxage := int(j.TimeFunc().Add(j.CookieMaxAge).Unix() - j.TimeFunc().Unix())
+		c.SetCookie(j.CookieName, token, maxage, "/", j.CookieDomain, j.SecureCookie, j.CookieHTTPOnly)
+	}
+
+	return token, expire, nil
+}
+
+// Identity returns the identity of the valid and unrevoked token the request carries, if any.
+func (j JWTStrategy) Identity(c *gin.Context) (string, bool) {
+	claims, err := j.GetClaimsFromJWT(c)
+	if err != nil || j.revoked(c, claims) {
+		return "", false
+	}
+
+	identity, ok := claims[j.IdentityKey].(string)
+
+	return identity, ok && identity != ""
+}
+
+// revoked reports whether the token is revoked. The request is let through when the
+// denylist cannot be reached, since the token is still signed and expires shortly.
+func (j JWTStrategy) revoked(c *gin.Context, claims ginjwt.MapClaims) bool {
+	if j.denylist == nil {
+		return false
+	}
+
+	jti, _ := claims[ClaimTokenID].(string)
+	username, _ := claims[j.IdentityKey].(string)
+	revoked, err := j.denylist.IsRevoked(c, jti, username, claimTime(claims, ClaimAuthTime))
+	if err != nil {
+		log.Record(c).Warnf("check revocation of token `%s` failed: %s", jti, err.Error())
+
+		return false
+	}
+
+	return revoked
+}
+
+// refreshableClaims returns the claims of a token which is valid or expired but still refreshable.
+func (j JWTStrategy) refreshableClaims(c *gin.Context) (ginjwt.MapClaims, error) {
+	token, err := j.ParseToken(c)
+	if err != nil {
+		var ve *jwt.ValidationError
+		if !errors.As(err, &ve) || ve.Errors != jwt.ValidationErrorExpired {
+			return nil, err
+		}
+	}
+
+	claims := ginjwt.MapClaims{}
+	for key, value := range token.Claims.(jwt.MapClaims) {
+		claims[key] = value
+	}
+
+	return claims, nil
+}
+
+// usableUntil returns the time after which the token can neither be used nor refreshed.
+func (j JWTStrategy) usableUntil(claims ginjwt.MapClaims) time.Time {
+	until := claimTime(claims, "exp")
+	if refresh := claimTime(claims, "orig_iat").Add(j.MaxRefresh); refresh.After(until) {
+		until = refresh
+	}
+
+	return until
+}
+
+// claimTime returns a NumericDate claim, which is decoded from JSON as float64.
+func claimTime(claims ginjwt.MapClaims, key string) time.Time {
+	v, ok := claims[key].(float64)
+	if !ok {
+		return time.Time{}
+	}
+
+	sec, frac := math.Modf(v)
+
+	return time.Unix(int64(sec), int64(frac*float64(time.Second)))
 }
@@ -0,0 +1,149 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	ginjwt "github.com/appleboy/gin-jwt/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/skeleton1231/gotal/internal/pkg/code"
+	"github.com/skeleton1231/gotal/internal/pkg/middleware"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeDenylist struct {
+	tokens map[string]time.Time
+	users  map[string]time.Time
+}
+
+func newFakeDenylist() *fakeDenylist {
+	return &fakeDenylist{tokens: map[string]time.Time{}, users: map[string]time.Time{}}
+}
+
+func (d *fakeDenylist) Revoke(ctx context.Context, jti string, until time.Time) error {
+	d.tokens[jti] = until
+
+	return nil
+}
+
+func (d *fakeDenylist) RevokeUser(ctx context.Context, username string, until time.Time) error {
+	d.users[username] = time.Now()
+
+	return nil
+}
+
+func (d *fakeDenylist) IsRevoked(ctx context.Context, jti, username string, authTime time.Time) (bool, error) {
+	if _, ok := d.tokens[jti]; ok {
+		return true, nil
+	}
+	revokedAt, ok := d.users[username]
+
+	return ok && authTime.Before(revokedAt), nil
+}
+
+func newTestJWTStrategy(t *testing.T, denylist TokenDenylist) JWTStrategy {
+	mw, err := ginjwt.New(&ginjwt.GinJWTMiddleware{
+		Realm:       "test",
+		Key:         []byte("0123456789abcdef0123456789abcdef"),
+		Timeout:     time.Hour,
+		MaxRefresh:  2 * time.Hour,
+		IdentityKey: middleware.UsernameKey,
+		PayloadFunc: func(data interface{}) ginjwt.MapClaims {
+			return ginjwt.MapClaims{
+				middleware.UsernameKey: data,
+				ClaimTokenID:           "token-1",
+				ClaimAuthTime:          float64(time.Now().Add(-time.Minute).UnixMilli()) / 1000,
+			}
+		},
+		IdentityHandler: func(c *gin.Context) interface{} {
+			return ginjwt.ExtractClaims(c)[middleware.UsernameKey]
+		},
+		LogoutResponse: func(c *gin.Context, code int) {
+			c.Status(code)
+		},
+		TimeFunc: time.Now,
+	})
+	assert.NoError(t, err)
+
+	return NewJWTStrategy(*mw, denylist)
+}
+
+func serve(j JWTStrategy, method, path, token string) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/secret", j.AuthFunc(), func(c *gin.Context) {
+		c.String(http.StatusOK, c.GetString(middleware.UsernameKey))
+	})
+	r.POST("/logout", j.LogoutHandler)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(method, path, nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	r.ServeHTTP(w, req)
+
+	return w
+}
+
+func TestJWTStrategy_Logout(t *testing.T) {
+	denylist := newFakeDenylist()
+	j := newTestJWTStrategy(t, denylist)
+	token, expire, err := j.TokenGenerator("alice")
+	assert.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, serve(j, http.MethodGet, "/secret", token).Code)
+	assert.Equal(t, http.StatusOK, serve(j, http.MethodPost, "/logout", token).Code)
+
+	// The denylist entry lives as long as the token can be refreshed.
+	assert.Contains(t, denylist.tokens, "token-1")
+	assert.True(t, denylist.tokens["token-1"].After(expire))
+
+	w := serve(j, http.MethodGet, "/secret", token)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Contains(t, w.Body.String(), strconv.Itoa(code.ErrTokenInvalid))
+}
+
+func TestJWTStrategy_RevokedUser(t *testing.T) {
+	denylist := newFakeDenylist()
+	j := newTestJWTStrategy(t, denylist)
+	token, _, err := j.TokenGenerator("alice")
+	assert.NoError(t, err)
+
+	assert.NoError(t, denylist.RevokeUser(context.Background(), "alice", time.Now().Add(time.Hour)))
+	assert.Equal(t, http.StatusUnauthorized, serve(j, http.MethodGet, "/secret", token).Code)
+
+	other, _, err := j.TokenGenerator("bob")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, serve(j, http.MethodGet, "/secret", other).Code)
+}
+
+func TestJWTStrategy_Identity(t *testing.T) {
+	denylist := newFakeDenylist()
+	j := newTestJWTStrategy(t, denylist)
+	token, _, err := j.TokenGenerator("alice")
+	assert.NoError(t, err)
+
+	identity := func(token string) (string, bool) {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+		if token != "" {
+			c.Request.Header.Set("Authorization", "Bearer "+token)
+		}
+
+		return j.Identity(c)
+	}
+
+	name, ok := identity(token)
+	assert.True(t, ok)
+	assert.Equal(t, "alice", name)
+
+	_, ok = identity("")
+	assert.False(t, ok)
+
+	assert.NoError(t, denylist.Revoke(context.Background(), "token-1", time.Now().Add(time.Hour)))
+	_, ok = identity(token)
+	assert.False(t, ok)
+}
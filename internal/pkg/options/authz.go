@@ -0,0 +1,82 @@
+// Copyright 2023 Talhuang<talhuang1231@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package options
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// Supported protocols used to talk to the authz service.
+const (
+	AuthzProtocolHTTP = "http"
+	AuthzProtocolGRPC = "grpc"
+)
+
+// AuthzOptions defines options for calling the authz service.
+type AuthzOptions struct {
+	Protocol string        `json:"protocol"       mapstructure:"protocol"`
+	Address  string        `json:"address"        mapstructure:"address"`
+	ClientCA string        `json:"client-ca-file" mapstructure:"client-ca-file"`
+	Timeout  time.Duration `json:"timeout"        mapstructure:"timeout"`
+	CacheTTL time.Duration `json:"cache-ttl"      mapstructure:"cache-ttl"`
+	FailOpen bool          `json:"fail-open"      mapstructure:"fail-open"`
+}
+
+// NewAuthzOptions create a `zero` value instance.
+func NewAuthzOptions() *AuthzOptions {
+	return &AuthzOptions{
+		Protocol: AuthzProtocolHTTP,
+		Address:  "http://127.0.0.1:9090",
+		ClientCA: "",
+		Timeout:  2 * time.Second,
+		CacheTTL: 5 * time.Second,
+		FailOpen: false,
+	}
+}
+
+// Validate verifies flags passed to AuthzOptions.
+func (o *AuthzOptions) Validate() []error {
+	var errs []error
+
+	if o.Protocol != AuthzProtocolHTTP && o.Protocol != AuthzProtocolGRPC {
+		errs = append(errs, fmt.Errorf("authz protocol must be one of `%s` or `%s`", AuthzProtocolHTTP, AuthzProtocolGRPC))
+	}
+
+	if o.Address == "" {
+		errs = append(errs, fmt.Errorf("authz address cannot be empty"))
+	}
+
+	if o.Timeout <= 0 {
+		errs = append(errs, fmt.Errorf("authz timeout should be a positive duration"))
+	}
+
+	if o.CacheTTL < 0 {
+		errs = append(errs, fmt.Errorf("authz cache-ttl should be non-negative"))
+	}
+
+	return errs
+}
+
+// AddFlags adds flags related to the authz service to the specified FlagSet.
+func (o *AuthzOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.Protocol, "authz.protocol", o.Protocol, "Protocol used to call the authz service, `http` or `grpc`.")
+
+	fs.StringVar(&o.Address, "authz.address", o.Address, ""+
+		"Address of the authz service. A base URL such as http://127.0.0.1:9090 for http, host:port for grpc.")
+
+	fs.StringVar(&o.ClientCA, "authz.client-ca-file", o.ClientCA, ""+
+		"CA file used to verify the authz grpc server. Plaintext is used when empty.")
+
+	fs.DurationVar(&o.Timeout, "authz.timeout", o.Timeout, "Timeout of a single authorization call.")
+
+	fs.DurationVar(&o.CacheTTL, "authz.cache-ttl", o.CacheTTL, ""+
+		"How long authorization decisions are cached locally. Set to zero to disable the cache.")
+
+	fs.BoolVar(&o.FailOpen, "authz.fail-open", o.FailOpen, ""+
+		"Allow requests when the authz service cannot be reached. Requests are denied by default.")
+}
@@ -0,0 +1,104 @@
+// Copyright 2023 Talhuang<talhuang1231@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package options
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// DiscordOptions defines options for logging in with Discord through OAuth2.
+// The login is disabled when the client id is empty.
+type DiscordOptions struct {
+	ClientID     string        `json:"client-id"     mapstructure:"client-id"`
+	ClientSecret string        `json:"-"             mapstructure:"client-secret"`
+	RedirectURL  string        `json:"redirect-url"  mapstructure:"redirect-url"`
+	AuthURL      string        `json:"auth-url"      mapstructure:"auth-url"`
+	TokenURL     string        `json:"token-url"     mapstructure:"token-url"`
+	UserURL      string        `json:"user-url"      mapstructure:"user-url"`
+	Scopes       []string      `json:"scopes"        mapstructure:"scopes"`
+	StateTTL     time.Duration `json:"state-ttl"     mapstructure:"state-ttl"`
+	Timeout      time.Duration `json:"timeout"       mapstructure:"timeout"`
+}
+
+// NewDiscordOptions create a `zero` value instance.
+func NewDiscordOptions() *DiscordOptions {
+	return &DiscordOptions{
+		ClientID:     "",
+		ClientSecret: "",
+		RedirectURL:  "",
+		AuthURL:      "https://discord.com/oauth2/authorize",
+		TokenURL:     "https://discord.com/api/oauth2/token",
+		UserURL:      "https://discord.com/api/users/@me",
+		Scopes:       []string{"identify", "email"},
+		StateTTL:     10 * time.Minute,
+		Timeout:      10 * time.Second,
+	}
+}
+
+// Enabled reports whether the Discord login is configured.
+func (o *DiscordOptions) Enabled() bool {
+	return o.ClientID != ""
+}
+
+// Validate verifies flags passed to DiscordOptions.
+func (o *DiscordOptions) Validate() []error {
+	var errs []error
+
+	if !o.Enabled() {
+		return errs
+	}
+
+	if o.ClientSecret == "" {
+		errs = append(errs, fmt.Errorf("discord client-secret cannot be empty when client-id is set"))
+	}
+
+	for name, value := range map[string]string{
+		"redirect-url": o.RedirectURL,
+		"auth-url":     o.AuthURL,
+		"token-url":    o.TokenURL,
+		"user-url":     o.UserURL,
+	} {
+		if u, err := url.Parse(value); err != nil || u.Scheme == "" || u.Host == "" {
+			errs = append(errs, fmt.Errorf("discord %s must be an absolute url, got `%s`", name, value))
+		}
+	}
+
+	if o.StateTTL <= 0 {
+		errs = append(errs, fmt.Errorf("discord state-ttl should be a positive duration"))
+	}
+
+	if o.Timeout <= 0 {
+		errs = append(errs, fmt.Errorf("discord timeout should be a positive duration"))
+	}
+
+	return errs
+}
+
+// AddFlags adds flags related to the Discord login to the specified FlagSet.
+func (o *DiscordOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.ClientID, "discord.client-id", o.ClientID, ""+
+		"Client id of the Discord application. Login with Discord is disabled when empty.")
+
+	fs.StringVar(&o.ClientSecret, "discord.client-secret", o.ClientSecret, "Client secret of the Discord application.")
+
+	fs.StringVar(&o.RedirectURL, "discord.redirect-url", o.RedirectURL, ""+
+		"Absolute url of /v1/oauth/discord/callback registered in the Discord application.")
+
+	fs.StringVar(&o.AuthURL, "discord.auth-url", o.AuthURL, "Authorization endpoint of the provider.")
+
+	fs.StringVar(&o.TokenURL, "discord.token-url", o.TokenURL, "Token endpoint of the provider.")
+
+	fs.StringVar(&o.UserURL, "discord.user-url", o.UserURL, "Endpoint returning the authorized user.")
+
+	fs.StringSliceVar(&o.Scopes, "discord.scopes", o.Scopes, "Scopes requested from the provider.")
+
+	fs.DurationVar(&o.StateTTL, "discord.state-ttl", o.StateTTL, "How long a started login stays valid.")
+
+	fs.DurationVar(&o.Timeout, "discord.timeout", o.Timeout, "Timeout of a single call to the provider.")
+}
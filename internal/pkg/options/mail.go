@@ -0,0 +1,124 @@
+// Copyright 2023 Talhuang<talhuang1231@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package options
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/skeleton1231/gotal/pkg/mail"
+	"github.com/spf13/pflag"
+)
+
+// Mail drivers.
+const (
+	MailDriverSMTP   = "smtp"
+	MailDriverOutbox = "outbox"
+)
+
+// MailOptions defines options for sending emails. Nothing is sent when the driver is empty.
+type MailOptions struct {
+	Driver       string        `json:"driver"        mapstructure:"driver"`
+	From         string        `json:"from"          mapstructure:"from"`
+	SMTPHost     string        `json:"smtp-host"     mapstructure:"smtp-host"`
+	SMTPPort     int           `json:"smtp-port"     mapstructure:"smtp-port"`
+	SMTPUsername string        `json:"smtp-username" mapstructure:"smtp-username"`
+	SMTPPassword string        `json:"-"             mapstructure:"smtp-password"`
+	OutboxDir    string        `json:"outbox-dir"    mapstructure:"outbox-dir"`
+	Timeout      time.Duration `json:"timeout"       mapstructure:"timeout"`
+}
+
+// NewMailOptions create a `zero` value instance.
+func NewMailOptions() *MailOptions {
+	return &MailOptions{
+		Driver:    "",
+		From:      "",
+		SMTPHost:  "127.0.0.1",
+		SMTPPort:  587,
+		OutboxDir: "outbox",
+		Timeout:   10 * time.Second,
+	}
+}
+
+// Enabled reports whether emails are sent.
+func (o *MailOptions) Enabled() bool {
+	return o.Driver != ""
+}
+
+// NewMailer creates the mailer of the configured driver, nil when mail is disabled.
+func (o *MailOptions) NewMailer() (mail.Mailer, error) {
+	switch o.Driver {
+	case "":
+		return nil, nil
+	case MailDriverSMTP:
+		return &mail.SMTP{
+			Addr:     net.JoinHostPort(o.SMTPHost, strconv.Itoa(o.SMTPPort)),
+			Username: o.SMTPUsername,
+			Password: o.SMTPPassword,
+			Timeout:  o.Timeout,
+		}, nil
+	case MailDriverOutbox:
+		return mail.NewOutbox(o.OutboxDir)
+	default:
+		return nil, fmt.Errorf("unknown mail driver `%s`", o.Driver)
+	}
+}
+
+// Validate verifies flags passed to MailOptions.
+func (o *MailOptions) Validate() []error {
+	var errs []error
+
+	if !o.Enabled() {
+		return errs
+	}
+
+	switch o.Driver {
+	case MailDriverSMTP:
+		if o.SMTPHost == "" {
+			errs = append(errs, fmt.Errorf("mail smtp-host cannot be empty with the smtp driver"))
+		}
+		if o.SMTPPort <= 0 || o.SMTPPort > 65535 {
+			errs = append(errs, fmt.Errorf("mail smtp-port %d is out of range", o.SMTPPort))
+		}
+	case MailDriverOutbox:
+		if o.OutboxDir == "" {
+			errs = append(errs, fmt.Errorf("mail outbox-dir cannot be empty with the outbox driver"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("mail driver must be `%s` or `%s`, got `%s`", MailDriverSMTP, MailDriverOutbox, o.Driver))
+	}
+
+	if o.From == "" {
+		errs = append(errs, fmt.Errorf("mail from cannot be empty when a driver is set"))
+	}
+
+	if o.Timeout <= 0 {
+		errs = append(errs, fmt.Errorf("mail timeout should be a positive duration"))
+	}
+
+	return errs
+}
+
+// AddFlags adds flags related to sending emails to the specified FlagSet.
+func (o *MailOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.Driver, "mail.driver", o.Driver, ""+
+		"How emails are sent, `smtp` or `outbox` which writes them to a directory. Nothing is sent when empty.")
+
+	fs.StringVar(&o.From, "mail.from", o.From, "Sender address of the emails.")
+
+	fs.StringVar(&o.SMTPHost, "mail.smtp-host", o.SMTPHost, "Host of the SMTP server.")
+
+	fs.IntVar(&o.SMTPPort, "mail.smtp-port", o.SMTPPort, "Port of the SMTP server, STARTTLS is used when offered.")
+
+	fs.StringVar(&o.SMTPUsername, "mail.smtp-username", o.SMTPUsername, "Username of the SMTP server, if it requires authentication.")
+
+	fs.StringVar(&o.SMTPPassword, "mail.smtp-password", o.SMTPPassword, "Password of the SMTP server.")
+
+	fs.StringVar(&o.OutboxDir, "mail.outbox-dir", o.OutboxDir, "Directory the outbox driver writes the emails to.")
+
+	fs.DurationVar(&o.Timeout, "mail.timeout", o.Timeout, "Timeout of sending a single email.")
+}
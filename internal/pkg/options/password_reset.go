@@ -0,0 +1,73 @@
+// Copyright 2023 Talhuang<talhuang1231@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package options
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// PasswordResetOptions defines options for resetting forgotten passwords.
+type PasswordResetOptions struct {
+	TokenTTL    time.Duration `json:"token-ttl"     mapstructure:"token-ttl"`
+	URL         string        `json:"url"           mapstructure:"url"`
+	MaxPerEmail int           `json:"max-per-email" mapstructure:"max-per-email"`
+	MaxPerIP    int           `json:"max-per-ip"    mapstructure:"max-per-ip"`
+	Window      time.Duration `json:"window"        mapstructure:"window"`
+}
+
+// NewPasswordResetOptions create a `zero` value instance.
+func NewPasswordResetOptions() *PasswordResetOptions {
+	return &PasswordResetOptions{
+		TokenTTL:    time.Hour,
+		URL:         "http://127.0.0.1:8080/password/reset",
+		MaxPerEmail: 3,
+		MaxPerIP:    20,
+		Window:      time.Hour,
+	}
+}
+
+// Validate verifies flags passed to PasswordResetOptions.
+func (o *PasswordResetOptions) Validate() []error {
+	var errs []error
+
+	if o.TokenTTL <= 0 {
+		errs = append(errs, fmt.Errorf("password-reset token-ttl should be a positive duration"))
+	}
+
+	if u, err := url.Parse(o.URL); err != nil || u.Scheme == "" || u.Host == "" {
+		errs = append(errs, fmt.Errorf("password-reset url must be an absolute url, got `%s`", o.URL))
+	}
+
+	if o.MaxPerEmail <= 0 || o.MaxPerIP <= 0 {
+		errs = append(errs, fmt.Errorf("password-reset max-per-email and max-per-ip should be positive"))
+	}
+
+	if o.Window < time.Second {
+		errs = append(errs, fmt.Errorf("password-reset window should be at least one second"))
+	}
+
+	return errs
+}
+
+// AddFlags adds flags related to password reset to the specified FlagSet.
+func (o *PasswordResetOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.DurationVar(&o.TokenTTL, "password-reset.token-ttl", o.TokenTTL, "How long a password reset link stays valid.")
+
+	fs.StringVar(&o.URL, "password-reset.url", o.URL, ""+
+		"Absolute url of the page which posts the token and the new password to /v1/password/reset, "+
+		"sent in the password reset emails.")
+
+	fs.IntVar(&o.MaxPerEmail, "password-reset.max-per-email", o.MaxPerEmail, ""+
+		"Number of reset emails an address may receive per window.")
+
+	fs.IntVar(&o.MaxPerIP, "password-reset.max-per-ip", o.MaxPerIP, ""+
+		"Number of password reset requests a client address may make per window.")
+
+	fs.DurationVar(&o.Window, "password-reset.window", o.Window, "Window of the password reset rate limits.")
+}
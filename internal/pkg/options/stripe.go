@@ -0,0 +1,91 @@
+// Copyright 2023 Talhuang<talhuang1231@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package options
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// StripeOptions defines options for the Stripe billing integration.
+// The webhook endpoint is disabled when the webhook secret is empty.
+type StripeOptions struct {
+	SecretKey     string        `json:"-"              mapstructure:"secret-key"`
+	WebhookSecret string        `json:"-"              mapstructure:"webhook-secret"`
+	APIURL        string        `json:"api-url"        mapstructure:"api-url"`
+	Tolerance     time.Duration `json:"tolerance"      mapstructure:"tolerance"`
+	EventTTL      time.Duration `json:"event-ttl"      mapstructure:"event-ttl"`
+	Timeout       time.Duration `json:"timeout"        mapstructure:"timeout"`
+}
+
+// NewStripeOptions create a `zero` value instance.
+func NewStripeOptions() *StripeOptions {
+	return &StripeOptions{
+		SecretKey:     "",
+		WebhookSecret: "",
+		APIURL:        "https://api.stripe.com",
+		Tolerance:     5 * time.Minute,
+		EventTTL:      7 * 24 * time.Hour,
+		Timeout:       10 * time.Second,
+	}
+}
+
+// Enabled reports whether the Stripe webhook endpoint is configured.
+func (o *StripeOptions) Enabled() bool {
+	return o.WebhookSecret != ""
+}
+
+// Validate verifies flags passed to StripeOptions.
+func (o *StripeOptions) Validate() []error {
+	var errs []error
+
+	if !o.Enabled() {
+		return errs
+	}
+
+	if o.SecretKey == "" {
+		errs = append(errs, fmt.Errorf("stripe secret-key cannot be empty when webhook-secret is set"))
+	}
+
+	if u, err := url.Parse(o.APIURL); err != nil || u.Scheme == "" || u.Host == "" {
+		errs = append(errs, fmt.Errorf("stripe api-url must be an absolute url, got `%s`", o.APIURL))
+	}
+
+	if o.Tolerance <= 0 {
+		errs = append(errs, fmt.Errorf("stripe tolerance should be a positive duration"))
+	}
+
+	// Stripe retries a failed delivery for up to three days.
+	if o.EventTTL < 72*time.Hour {
+		errs = append(errs, fmt.Errorf("stripe event-ttl should be at least 72h, got %s", o.EventTTL))
+	}
+
+	if o.Timeout <= 0 {
+		errs = append(errs, fmt.Errorf("stripe timeout should be a positive duration"))
+	}
+
+	return errs
+}
+
+// AddFlags adds flags related to Stripe to the specified FlagSet.
+func (o *StripeOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.SecretKey, "stripe.secret-key", o.SecretKey, "Secret API key of the Stripe account.")
+
+	fs.StringVar(&o.WebhookSecret, "stripe.webhook-secret", o.WebhookSecret, ""+
+		"Signing secret of the /webhooks/stripe endpoint. The endpoint is disabled when empty.")
+
+	fs.StringVar(&o.APIURL, "stripe.api-url", o.APIURL, "Base url of the Stripe API.")
+
+	fs.DurationVar(&o.Tolerance, "stripe.tolerance", o.Tolerance, ""+
+		"How far the timestamp of a webhook signature may be from the current time.")
+
+	fs.DurationVar(&o.EventTTL, "stripe.event-ttl", o.EventTTL, ""+
+		"How long processed event ids are remembered, so that redelivered events are skipped.")
+
+	fs.DurationVar(&o.Timeout, "stripe.timeout", o.Timeout, "Timeout of a single call to the Stripe API.")
+}
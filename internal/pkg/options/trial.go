@@ -0,0 +1,72 @@
+// Copyright 2023 Talhuang<talhuang1231@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package options
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// TrialOptions defines options for processing the end of the trials of users.
+type TrialOptions struct {
+	Enabled         bool          `json:"enabled"           mapstructure:"enabled"`
+	Interval        time.Duration `json:"interval"          mapstructure:"interval"`
+	RemindDays      int           `json:"remind-days"       mapstructure:"remind-days"`
+	PostTrialStatus int           `json:"post-trial-status" mapstructure:"post-trial-status"`
+	BatchSize       int           `json:"batch-size"        mapstructure:"batch-size"`
+}
+
+// NewTrialOptions create a `zero` value instance.
+func NewTrialOptions() *TrialOptions {
+	return &TrialOptions{
+		Enabled:         true,
+		Interval:        time.Minute,
+		RemindDays:      3,
+		PostTrialStatus: 2,
+		BatchSize:       100,
+	}
+}
+
+// Validate verifies flags passed to TrialOptions.
+func (o *TrialOptions) Validate() []error {
+	var errs []error
+
+	if o.Interval < time.Second {
+		errs = append(errs, fmt.Errorf("trial interval should be at least one second"))
+	}
+
+	if o.RemindDays < 0 {
+		errs = append(errs, fmt.Errorf("trial remind-days cannot be negative"))
+	}
+
+	// Status 1 is the one of active users, expired trials would be processed forever.
+	if o.PostTrialStatus == 1 {
+		errs = append(errs, fmt.Errorf("trial post-trial-status cannot be the active status 1"))
+	}
+
+	if o.BatchSize <= 0 {
+		errs = append(errs, fmt.Errorf("trial batch-size should be positive"))
+	}
+
+	return errs
+}
+
+// AddFlags adds flags related to trials to the specified FlagSet.
+func (o *TrialOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.BoolVar(&o.Enabled, "trial.enabled", o.Enabled, "Remind users of the end of their trial and expire ended trials.")
+
+	fs.DurationVar(&o.Interval, "trial.interval", o.Interval, "How often the trials are checked.")
+
+	fs.IntVar(&o.RemindDays, "trial.remind-days", o.RemindDays, ""+
+		"Number of days before the end of a trial the user is reminded of it. Zero disables the reminders.")
+
+	fs.IntVar(&o.PostTrialStatus, "trial.post-trial-status", o.PostTrialStatus, ""+
+		"Status given to the users whose trial ended without a payment method. "+
+		"Only users with status 1 can sign in.")
+
+	fs.IntVar(&o.BatchSize, "trial.batch-size", o.BatchSize, "Number of users processed per query.")
+}
@@ -0,0 +1,55 @@
+// Copyright 2023 Talhuang<talhuang1231@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package options
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// VerificationOptions defines options for verifying the email address of users.
+type VerificationOptions struct {
+	Required bool          `json:"required"  mapstructure:"required"`
+	TokenTTL time.Duration `json:"token-ttl" mapstructure:"token-ttl"`
+	URL      string        `json:"url"       mapstructure:"url"`
+}
+
+// NewVerificationOptions create a `zero` value instance.
+func NewVerificationOptions() *VerificationOptions {
+	return &VerificationOptions{
+		Required: false,
+		TokenTTL: 24 * time.Hour,
+		URL:      "http://127.0.0.1:8080/v1/verify",
+	}
+}
+
+// Validate verifies flags passed to VerificationOptions.
+func (o *VerificationOptions) Validate() []error {
+	var errs []error
+
+	if o.TokenTTL <= 0 {
+		errs = append(errs, fmt.Errorf("verification token-ttl should be a positive duration"))
+	}
+
+	if u, err := url.Parse(o.URL); err != nil || u.Scheme == "" || u.Host == "" {
+		errs = append(errs, fmt.Errorf("verification url must be an absolute url, got `%s`", o.URL))
+	}
+
+	return errs
+}
+
+// AddFlags adds flags related to email verification to the specified FlagSet.
+func (o *VerificationOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.BoolVar(&o.Required, "verification.required", o.Required, ""+
+		"Refuse to log in users whose email address has not been verified. Requires --mail.driver.")
+
+	fs.DurationVar(&o.TokenTTL, "verification.token-ttl", o.TokenTTL, "How long a verification link stays valid.")
+
+	fs.StringVar(&o.URL, "verification.url", o.URL, ""+
+		"Absolute url of /v1/verify, or of a page forwarding the token to it, sent in the verification emails.")
+}
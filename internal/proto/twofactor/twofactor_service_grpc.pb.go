@@ -0,0 +1,294 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v4.25.2
+// source: twofactor/twofactor_service.proto
+
+package twofactor
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	TwoFactorService_GetTwoFactor_FullMethodName     = "/gotal.twofactor.TwoFactorService/GetTwoFactor"
+	TwoFactorService_SaveTwoFactor_FullMethodName    = "/gotal.twofactor.TwoFactorService/SaveTwoFactor"
+	TwoFactorService_EnableTwoFactor_FullMethodName  = "/gotal.twofactor.TwoFactorService/EnableTwoFactor"
+	TwoFactorService_DeleteTwoFactor_FullMethodName  = "/gotal.twofactor.TwoFactorService/DeleteTwoFactor"
+	TwoFactorService_UseTwoFactorStep_FullMethodName = "/gotal.twofactor.TwoFactorService/UseTwoFactorStep"
+	TwoFactorService_UseRecoveryCode_FullMethodName  = "/gotal.twofactor.TwoFactorService/UseRecoveryCode"
+)
+
+// TwoFactorServiceClient is the client API for TwoFactorService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type TwoFactorServiceClient interface {
+	GetTwoFactor(ctx context.Context, in *GetTwoFactorRequest, opts ...grpc.CallOption) (*GetTwoFactorResponse, error)
+	SaveTwoFactor(ctx context.Context, in *SaveTwoFactorRequest, opts ...grpc.CallOption) (*SaveTwoFactorResponse, error)
+	EnableTwoFactor(ctx context.Context, in *EnableTwoFactorRequest, opts ...grpc.CallOption) (*EnableTwoFactorResponse, error)
+	DeleteTwoFactor(ctx context.Context, in *DeleteTwoFactorRequest, opts ...grpc.CallOption) (*DeleteTwoFactorResponse, error)
+	UseTwoFactorStep(ctx context.Context, in *UseTwoFactorStepRequest, opts ...grpc.CallOption) (*UseTwoFactorStepResponse, error)
+	UseRecoveryCode(ctx context.Context, in *UseRecoveryCodeRequest, opts ...grpc.CallOption) (*UseRecoveryCodeResponse, error)
+}
+
+type twoFactorServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTwoFactorServiceClient(cc grpc.ClientConnInterface) TwoFactorServiceClient {
+	return &twoFactorServiceClient{cc}
+}
+
+func (c *twoFactorServiceClient) GetTwoFactor(ctx context.Context, in *GetTwoFactorRequest, opts ...grpc.CallOption) (*GetTwoFactorResponse, error) {
+	out := new(GetTwoFactorResponse)
+	err := c.cc.Invoke(ctx, TwoFactorService_GetTwoFactor_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *twoFactorServiceClient) SaveTwoFactor(ctx context.Context, in *SaveTwoFactorRequest, opts ...grpc.CallOption) (*SaveTwoFactorResponse, error) {
+	out := new(SaveTwoFactorResponse)
+	err := c.cc.Invoke(ctx, TwoFactorService_SaveTwoFactor_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *twoFactorServiceClient) EnableTwoFactor(ctx context.Context, in *EnableTwoFactorRequest, opts ...grpc.CallOption) (*EnableTwoFactorResponse, error) {
+	out := new(EnableTwoFactorResponse)
+	err := c.cc.Invoke(ctx, TwoFactorService_EnableTwoFactor_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *twoFactorServiceClient) DeleteTwoFactor(ctx context.Context, in *DeleteTwoFactorRequest, opts ...grpc.CallOption) (*DeleteTwoFactorResponse, error) {
+	out := new(DeleteTwoFactorResponse)
+	err := c.cc.Invoke(ctx, TwoFactorService_DeleteTwoFactor_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *twoFactorServiceClient) UseTwoFactorStep(ctx context.Context, in *UseTwoFactorStepRequest, opts ...grpc.CallOption) (*UseTwoFactorStepResponse, error) {
+	out := new(UseTwoFactorStepResponse)
+	err := c.cc.Invoke(ctx, TwoFactorService_UseTwoFactorStep_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *twoFactorServiceClient) UseRecoveryCode(ctx context.Context, in *UseRecoveryCodeRequest, opts ...grpc.CallOption) (*UseRecoveryCodeResponse, error) {
+	out := new(UseRecoveryCodeResponse)
+	err := c.cc.Invoke(ctx, TwoFactorService_UseRecoveryCode_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TwoFactorServiceServer is the server API for TwoFactorService service.
+// All implementations must embed UnimplementedTwoFactorServiceServer
+// for forward compatibility
+type TwoFactorServiceServer interface {
+	GetTwoFactor(context.Context, *GetTwoFactorRequest) (*GetTwoFactorResponse, error)
+	SaveTwoFactor(context.Context, *SaveTwoFactorRequest) (*SaveTwoFactorResponse, error)
+	EnableTwoFactor(context.Context, *EnableTwoFactorRequest) (*EnableTwoFactorResponse, error)
+	DeleteTwoFactor(context.Context, *DeleteTwoFactorRequest) (*DeleteTwoFactorResponse, error)
+	UseTwoFactorStep(context.Context, *UseTwoFactorStepRequest) (*UseTwoFactorStepResponse, error)
+	UseRecoveryCode(context.Context, *UseRecoveryCodeRequest) (*UseRecoveryCodeResponse, error)
+	mustEmbedUnimplementedTwoFactorServiceServer()
+}
+
+// UnimplementedTwoFactorServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedTwoFactorServiceServer struct {
+}
+
+func (UnimplementedTwoFactorServiceServer) GetTwoFactor(context.Context, *GetTwoFactorRequest) (*GetTwoFactorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTwoFactor not implemented")
+}
+func (UnimplementedTwoFactorServiceServer) SaveTwoFactor(context.Context, *SaveTwoFactorRequest) (*SaveTwoFactorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SaveTwoFactor not implemented")
+}
+func (UnimplementedTwoFactorServiceServer) EnableTwoFactor(context.Context, *EnableTwoFactorRequest) (*EnableTwoFactorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnableTwoFactor not implemented")
+}
+func (UnimplementedTwoFactorServiceServer) DeleteTwoFactor(context.Context, *DeleteTwoFactorRequest) (*DeleteTwoFactorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTwoFactor not implemented")
+}
+func (UnimplementedTwoFactorServiceServer) UseTwoFactorStep(context.Context, *UseTwoFactorStepRequest) (*UseTwoFactorStepResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UseTwoFactorStep not implemented")
+}
+func (UnimplementedTwoFactorServiceServer) UseRecoveryCode(context.Context, *UseRecoveryCodeRequest) (*UseRecoveryCodeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UseRecoveryCode not implemented")
+}
+func (UnimplementedTwoFactorServiceServer) mustEmbedUnimplementedTwoFactorServiceServer() {}
+
+// UnsafeTwoFactorServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TwoFactorServiceServer will
+// result in compilation errors.
+type UnsafeTwoFactorServiceServer interface {
+	mustEmbedUnimplementedTwoFactorServiceServer()
+}
+
+func RegisterTwoFactorServiceServer(s grpc.ServiceRegistrar, srv TwoFactorServiceServer) {
+	s.RegisterService(&TwoFactorService_ServiceDesc, srv)
+}
+
+func _TwoFactorService_GetTwoFactor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTwoFactorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TwoFactorServiceServer).GetTwoFactor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TwoFactorService_GetTwoFactor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TwoFactorServiceServer).GetTwoFactor(ctx, req.(*GetTwoFactorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TwoFactorService_SaveTwoFactor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveTwoFactorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TwoFactorServiceServer).SaveTwoFactor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TwoFactorService_SaveTwoFactor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TwoFactorServiceServer).SaveTwoFactor(ctx, req.(*SaveTwoFactorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TwoFactorService_EnableTwoFactor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnableTwoFactorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TwoFactorServiceServer).EnableTwoFactor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TwoFactorService_EnableTwoFactor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TwoFactorServiceServer).EnableTwoFactor(ctx, req.(*EnableTwoFactorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TwoFactorService_DeleteTwoFactor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTwoFactorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TwoFactorServiceServer).DeleteTwoFactor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TwoFactorService_DeleteTwoFactor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TwoFactorServiceServer).DeleteTwoFactor(ctx, req.(*DeleteTwoFactorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TwoFactorService_UseTwoFactorStep_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UseTwoFactorStepRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TwoFactorServiceServer).UseTwoFactorStep(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TwoFactorService_UseTwoFactorStep_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TwoFactorServiceServer).UseTwoFactorStep(ctx, req.(*UseTwoFactorStepRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TwoFactorService_UseRecoveryCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UseRecoveryCodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TwoFactorServiceServer).UseRecoveryCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TwoFactorService_UseRecoveryCode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TwoFactorServiceServer).UseRecoveryCode(ctx, req.(*UseRecoveryCodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// TwoFactorService_ServiceDesc is the grpc.ServiceDesc for TwoFactorService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TwoFactorService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "gotal.twofactor.TwoFactorService",
+	HandlerType: (*TwoFactorServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetTwoFactor",
+			Handler:    _TwoFactorService_GetTwoFactor_Handler,
+		},
+		{
+			MethodName: "SaveTwoFactor",
+			Handler:    _TwoFactorService_SaveTwoFactor_Handler,
+		},
+		{
+			MethodName: "EnableTwoFactor",
+			Handler:    _TwoFactorService_EnableTwoFactor_Handler,
+		},
+		{
+			MethodName: "DeleteTwoFactor",
+			Handler:    _TwoFactorService_DeleteTwoFactor_Handler,
+		},
+		{
+			MethodName: "UseTwoFactorStep",
+			Handler:    _TwoFactorService_UseTwoFactorStep_Handler,
+		},
+		{
+			MethodName: "UseRecoveryCode",
+			Handler:    _TwoFactorService_UseRecoveryCode_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "twofactor/twofactor_service.proto",
+}
@@ -25,6 +25,8 @@ func NewApp(basename string) *app.App {
 		app.WithDefaultValidArgs(),
 		app.WithRunFunc(run(opts)),
 	)
+	application.AddCommand(newReconcileCreditsCommand(opts))
+	application.AddCommand(newMigrateCommand(opts))
 
 	return application
 }
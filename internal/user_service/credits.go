@@ -0,0 +1,67 @@
+// Copyright 2023 Talhuang<talhuang1231@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package userservice
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/skeleton1231/gotal/internal/user_service/options"
+	"github.com/skeleton1231/gotal/internal/user_service/store/database"
+	"github.com/skeleton1231/gotal/pkg/app"
+	"github.com/skeleton1231/gotal/pkg/log"
+	"github.com/skeleton1231/gotal/pkg/util/flag"
+)
+
+const reconcileCreditsDesc = `Compare the cached credits of every user with the sum of the credits ledger.
+With --fix, the cached credits of the users that differ are recomputed from the ledger.`
+
+// reconcileOptions are the options of the reconcile-credits command.
+type reconcileOptions struct {
+	*options.Options `mapstructure:",squash"`
+
+	Fix bool `json:"fix" mapstructure:"fix"`
+}
+
+// Flags returns the flags of the service along with the reconcile-credits ones.
+func (o *reconcileOptions) Flags() (fss flag.NamedFlagSets) {
+	fss = o.Options.Flags()
+	fss.FlagSet("reconcile").BoolVar(&o.Fix, "fix", o.Fix, "Overwrite the cached credits that differ from the ledger.")
+
+	return fss
+}
+
+// newReconcileCreditsCommand creates the maintenance command that reconciles TotalCredits
+// with the credit_transactions ledger.
+func newReconcileCreditsCommand(opts *options.Options) *app.Command {
+	ropts := &reconcileOptions{Options: opts}
+
+	return app.NewCommand("reconcile-credits", reconcileCreditsDesc,
+		app.WithCommandOptions(ropts),
+		app.WithCommandRunFunc(func(args []string) error {
+			log.Init(ropts.Log)
+			defer log.Flush()
+
+			drifts, err := database.ReconcileCredits(context.Background(), ropts.MySQLOptions, ropts.Fix)
+			if err != nil {
+				return err
+			}
+
+			for _, drift := range drifts {
+				fmt.Printf("user %d: cached %d, ledger %d\n", drift.UserID, drift.Cached, drift.Ledger)
+			}
+			switch {
+			case len(drifts) == 0:
+				fmt.Println("cached credits match the ledger")
+			case ropts.Fix:
+				fmt.Printf("fixed the cached credits of %d users\n", len(drifts))
+			default:
+				fmt.Printf("%d users differ, run again with --fix to repair them\n", len(drifts))
+			}
+
+			return nil
+		}),
+	)
+}
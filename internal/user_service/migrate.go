@@ -0,0 +1,122 @@
+// Copyright 2023 Talhuang<talhuang1231@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package userservice
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/skeleton1231/gotal/internal/user_service/options"
+	"github.com/skeleton1231/gotal/internal/user_service/store/database"
+	"github.com/skeleton1231/gotal/pkg/app"
+	"github.com/skeleton1231/gotal/pkg/log"
+	"github.com/skeleton1231/gotal/pkg/migrate"
+)
+
+const migrateDesc = `Manage the versions of the database schema.
+
+The service refuses to start until the pending migrations are applied with migrate up.`
+
+// newMigrateCommand creates the migrate command and its up, down, status and to subcommands.
+func newMigrateCommand(opts *options.Options) *app.Command {
+	cmd := app.NewCommand("migrate", migrateDesc)
+	cmd.AddCommands(
+		newMigrateSubcommand(opts, "up", "Apply the pending migrations.",
+			func(ctx context.Context, m *migrate.Migrator, _ []string) error {
+				applied, err := m.Up(ctx)
+				printMigrations("applied", applied, err, "the schema is up to date")
+
+				return err
+			}),
+		newMigrateSubcommand(opts, "down", "Revert the latest applied migration.",
+			func(ctx context.Context, m *migrate.Migrator, _ []string) error {
+				reverted, err := m.Down(ctx)
+				if reverted != nil {
+					printMigrations("reverted", []*migrate.Migration{reverted}, err, "")
+				} else if err == nil {
+					fmt.Println("no migration is applied")
+				}
+
+				return err
+			}),
+		newMigrateSubcommand(opts, "status", "List the migrations and whether they are applied.",
+			func(ctx context.Context, m *migrate.Migrator, _ []string) error {
+				statuses, err := m.Status(ctx)
+				if err != nil {
+					return err
+				}
+				printStatuses(statuses)
+
+				return nil
+			}),
+		newMigrateSubcommand(opts, "to <version>", "Apply or revert migrations until the version is the latest applied, 0 reverts them all.",
+			func(ctx context.Context, m *migrate.Migrator, args []string) error {
+				if len(args) != 1 {
+					return fmt.Errorf("to expects a version, got %d arguments", len(args))
+				}
+				version, err := strconv.ParseUint(args[0], 10, 64)
+				if err != nil {
+					return fmt.Errorf("invalid version `%s`", args[0])
+				}
+
+				changed, err := m.To(ctx, version)
+				printMigrations("migrated", changed, err, "the schema is already at version "+args[0])
+
+				return err
+			}),
+	)
+
+	return cmd
+}
+
+func newMigrateSubcommand(opts *options.Options, usage, desc string,
+	run func(ctx context.Context, m *migrate.Migrator, args []string) error,
+) *app.Command {
+	return app.NewCommand(usage, desc,
+		app.WithCommandOptions(opts),
+		app.WithCommandRunFunc(func(args []string) error {
+			log.Init(opts.Log)
+			defer log.Flush()
+
+			migrator, err := database.NewMigrator(opts.MySQLOptions)
+			if err != nil {
+				return err
+			}
+
+			return run(context.Background(), migrator, args)
+		}),
+	)
+}
+
+// printMigrations prints the migrations changed by the command, or none when it succeeded
+// without changes.
+func printMigrations(action string, migrations []*migrate.Migration, err error, none string) {
+	for _, m := range migrations {
+		fmt.Printf("%s %d_%s\n", action, m.Version, m.Name)
+	}
+	if len(migrations) == 0 && err == nil && none != "" {
+		fmt.Println(none)
+	}
+}
+
+func printStatuses(statuses []*migrate.Status) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+	for _, s := range statuses {
+		appliedAt := "pending"
+		if s.AppliedAt != nil {
+			appliedAt = s.AppliedAt.Format("2006-01-02 15:04:05")
+		}
+		name := s.Name
+		if s.Unknown {
+			name = "(applied by a newer release)"
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\n", s.Version, name, appliedAt)
+	}
+	_ = w.Flush()
+}
@@ -26,6 +26,8 @@ type Options struct {
 	JwtOptions              *options.JwtOptions             `json:"jwt"      mapstructure:"jwt"`
 	FeatureOptions          *options.FeatureOptions         `json:"feature"  mapstructure:"feature"`
 	RateLimitOptions        *options.RateLimitOptions       `json:"ratelimit"  mapstructure:"ratelimit"`
+	MailOptions             *options.MailOptions            `json:"mail"     mapstructure:"mail"`
+	TrialOptions            *options.TrialOptions           `json:"trial"    mapstructure:"trial"`
 	Log                     *log.Options                    `json:"log"      mapstructure:"log"`
 }
 
@@ -41,6 +43,8 @@ func NewOptions() *Options {
 		JwtOptions:              options.NewJwtOptions(),
 		FeatureOptions:          options.NewFeatureOptions(),
 		RateLimitOptions:        options.NewRateLimitOptions(),
+		MailOptions:             options.NewMailOptions(),
+		TrialOptions:            options.NewTrialOptions(),
 	}
 }
 
@@ -57,6 +61,8 @@ func (o *Options) Flags() (fss flag.NamedFlagSets) {
 	o.FeatureOptions.AddFlags(fss.FlagSet("features"))
 	o.InsecureServing.AddFlags(fss.FlagSet("insecure serving"))
 	o.SecureServing.AddFlags(fss.FlagSet("secure serving"))
+	o.MailOptions.AddFlags(fss.FlagSet("mail"))
+	o.TrialOptions.AddFlags(fss.FlagSet("trial"))
 	// o.RateLimitOptions.AddFlags(fss.FlagSet("ratelimit"))
 	return fss
 }
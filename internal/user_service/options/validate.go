@@ -20,6 +20,8 @@ func (o *Options) Validate() []error {
 		o.JwtOptions,
 		o.FeatureOptions,
 		o.RateLimitOptions,
+		o.MailOptions,
+		o.TrialOptions,
 	}
 
 	for _, validator := range validators {
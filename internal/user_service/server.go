@@ -12,11 +12,17 @@ import (
 	"github.com/skeleton1231/gotal/internal/user_service/config"
 	"github.com/skeleton1231/gotal/internal/user_service/store"
 
+	"github.com/skeleton1231/gotal/internal/pkg/grpcerror"
 	"github.com/skeleton1231/gotal/internal/pkg/options"
 	"github.com/skeleton1231/gotal/internal/pkg/server"
+	pbAPIKey "github.com/skeleton1231/gotal/internal/proto/apikey"
+	pbRole "github.com/skeleton1231/gotal/internal/proto/role"
+	pbSession "github.com/skeleton1231/gotal/internal/proto/session"
+	pbTwoFactor "github.com/skeleton1231/gotal/internal/proto/twofactor"
 	pbUser "github.com/skeleton1231/gotal/internal/proto/user"
 	ssv1 "github.com/skeleton1231/gotal/internal/user_service/service/server"
 	"github.com/skeleton1231/gotal/internal/user_service/store/database"
+	"github.com/skeleton1231/gotal/internal/user_service/trial"
 	"github.com/skeleton1231/gotal/pkg/cache"
 	"github.com/skeleton1231/gotal/pkg/log"
 	"github.com/skeleton1231/gotal/pkg/shutdown"
@@ -29,6 +35,8 @@ import (
 type apiServer struct {
 	gs            *shutdown.GracefulShutdown
 	redisOptions  *options.RedisOptions
+	mailOptions   *options.MailOptions
+	trialOptions  *options.TrialOptions
 	httpAPIServer *server.APIServer // embedding internal/pkg/server
 	gRPCAPIServer *grpcAPIServer    // embedding grpcAPIServer
 }
@@ -48,6 +56,11 @@ func NewAPIServer(cfg *config.Config) (*apiServer, error) {
 	gs := shutdown.New()
 	gs.AddShutdownManager(posix.NewPosixSignalManager())
 
+	// refuse to run against a database schema older than the code
+	if err := database.CheckSchema(context.Background(), cfg.MySQLOptions); err != nil {
+		return nil, err
+	}
+
 	genericConfig, err := buildGenericConfig(cfg)
 	if err != nil {
 		return nil, err
@@ -73,6 +86,8 @@ func NewAPIServer(cfg *config.Config) (*apiServer, error) {
 	server := &apiServer{
 		gs:            gs,
 		redisOptions:  cfg.RedisOptions,
+		mailOptions:   cfg.MailOptions,
+		trialOptions:  cfg.TrialOptions,
 		httpAPIServer: genericServer,
 		gRPCAPIServer: extraServer,
 	}
@@ -88,6 +103,12 @@ func (s *apiServer) PrepareRun() preparedAPIServer {
 	// initialize redis
 	s.initRedisStore()
 
+	// deliver user events to the watches served by this replica
+	s.initUserWatch()
+
+	// remind users of the end of their trial and expire ended trials
+	s.initTrials()
+
 	s.gs.AddShutdownCallback(shutdown.ShutdownFunc(func(string) error {
 
 		mysqlStore, _ := database.GetMySQLFactoryOr(nil)
@@ -135,7 +156,12 @@ func (c *completedExtraConfig) New() (*grpcAPIServer, error) {
 	if err != nil {
 		log.Fatalf("Failed to generate credentials %s", err.Error())
 	}
-	opts := []grpc.ServerOption{grpc.MaxRecvMsgSize(c.MaxMsgSize), grpc.Creds(creds)}
+	opts := []grpc.ServerOption{
+		grpc.MaxRecvMsgSize(c.MaxMsgSize),
+		grpc.Creds(creds),
+		grpc.ChainUnaryInterceptor(grpcerror.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(grpcerror.StreamServerInterceptor()),
+	}
 	grpcServer := grpc.NewServer(opts...)
 
 	storeIns, _ := database.GetMySQLFactoryOr(c.mysqlOptions)
@@ -144,8 +170,16 @@ func (c *completedExtraConfig) New() (*grpcAPIServer, error) {
 	store.SetClient(storeIns)
 
 	userService, _ := ssv1.GetUserInsOr(storeIns)
+	roleService, _ := ssv1.GetRoleInsOr(storeIns)
+	apiKeyService, _ := ssv1.GetAPIKeyInsOr(storeIns)
+	sessionService, _ := ssv1.GetSessionInsOr(storeIns)
+	twoFactorService, _ := ssv1.GetTwoFactorInsOr(storeIns)
 	// Register GRPC Server
 	pbUser.RegisterUserServiceServer(grpcServer, userService)
+	pbRole.RegisterRoleServiceServer(grpcServer, roleService)
+	pbAPIKey.RegisterAPIKeyServiceServer(grpcServer, apiKeyService)
+	pbSession.RegisterSessionServiceServer(grpcServer, sessionService)
+	pbTwoFactor.RegisterTwoFactorServiceServer(grpcServer, twoFactorService)
 	reflection.Register(grpcServer)
 
 	return &grpcAPIServer{grpcServer, c.Addr}, nil
@@ -186,6 +220,48 @@ func buildExtraConfig(cfg *config.Config) (*ExtraConfig, error) {
 	}, nil
 }
 
+func (s *apiServer) initUserWatch() {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	s.gs.AddShutdownCallback(shutdown.ShutdownFunc(func(string) error {
+		cancel()
+
+		return nil
+	}))
+
+	database.StartUserWatch(ctx)
+}
+
+func (s *apiServer) initTrials() {
+	if !s.trialOptions.Enabled {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	s.gs.AddShutdownCallback(shutdown.ShutdownFunc(func(string) error {
+		cancel()
+
+		return nil
+	}))
+
+	var notifier trial.Notifier = trial.LogNotifier{}
+	mailer, err := s.mailOptions.NewMailer()
+	if err != nil {
+		log.Errorf("create mailer failed, trial reminders are only logged: %s", err.Error())
+	} else if mailer != nil {
+		notifier = trial.NewMailNotifier(mailer, s.mailOptions.From)
+	}
+
+	storeIns, err := database.GetMySQLFactoryOr(nil)
+	if err != nil || storeIns == nil {
+		log.Errorf("trials are not processed, no mysql store: %v", err)
+
+		return
+	}
+	go trial.NewProcessor(storeIns.Trials(), notifier, s.trialOptions).Run(ctx)
+}
+
 func (s *apiServer) initRedisStore() {
 	ctx, cancel := context.WithCancel(context.Background())
 
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/skeleton1231/gotal/internal/apiserver/store/model"
+	pb "github.com/skeleton1231/gotal/internal/proto/apikey"
+	"github.com/skeleton1231/gotal/internal/user_service/store"
+	"github.com/skeleton1231/gotal/pkg/log"
+)
+
+// APIKeyServiceServer is the implementation of the APIKeyServiceServer interface.
+type APIKeyServiceServer struct {
+	store store.Factory
+	pb.UnimplementedAPIKeyServiceServer
+}
+
+var (
+	apiKeyServer *APIKeyServiceServer
+	apiKeyOnce   sync.Once
+)
+
+// GetAPIKeyInsOr return API key server instance with given factory.
+func GetAPIKeyInsOr(store store.Factory) (*APIKeyServiceServer, error) {
+	if store != nil {
+		apiKeyOnce.Do(func() {
+			apiKeyServer = &APIKeyServiceServer{store: store}
+		})
+	}
+
+	if apiKeyServer == nil {
+		return nil, fmt.Errorf("got nil api key server")
+	}
+
+	return apiKeyServer, nil
+}
+
+// CreateAPIKey stores a new API key. Only the hash of the key is received.
+func (s *APIKeyServiceServer) CreateAPIKey(ctx context.Context, req *pb.CreateAPIKeyRequest) (*pb.CreateAPIKeyResponse, error) {
+	key, err := model.ProtoToAPIKey(req.GetKey())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.store.APIKeys().Create(ctx, key); err != nil {
+		log.Errorf("APIKey Create fail: %+v", err)
+
+		return nil, err
+	}
+
+	return &pb.CreateAPIKeyResponse{Key: model.APIKeyToProto(key)}, nil
+}
+
+// ListAPIKeys returns the API keys of a user.
+func (s *APIKeyServiceServer) ListAPIKeys(ctx context.Context, req *pb.ListAPIKeysRequest) (*pb.ListAPIKeysResponse, error) {
+	keys, err := s.store.APIKeys().List(ctx, req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*pb.APIKey, 0, len(keys.Items))
+	for _, key := range keys.Items {
+		items = append(items, model.APIKeyToProto(key))
+	}
+
+	return &pb.ListAPIKeysResponse{Items: items}, nil
+}
+
+// RevokeAPIKey deletes an API key of a user.
+func (s *APIKeyServiceServer) RevokeAPIKey(ctx context.Context, req *pb.RevokeAPIKeyRequest) (*pb.RevokeAPIKeyResponse, error) {
+	key, err := s.store.APIKeys().Delete(ctx, req.GetUserId(), req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.RevokeAPIKeyResponse{Key: model.APIKeyToProto(key)}, nil
+}
+
+// GetAPIKeyByHash returns the API key with the given hash.
+func (s *APIKeyServiceServer) GetAPIKeyByHash(ctx context.Context, req *pb.GetAPIKeyByHashRequest) (*pb.GetAPIKeyByHashResponse, error) {
+	key, err := s.store.APIKeys().GetByHash(ctx, req.GetHash())
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetAPIKeyByHashResponse{Key: model.APIKeyToProto(key)}, nil
+}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+
+	"github.com/skeleton1231/gotal/internal/apiserver/store/model"
+	"github.com/skeleton1231/gotal/internal/pkg/code"
+	"github.com/skeleton1231/gotal/internal/pkg/errors"
+	pb "github.com/skeleton1231/gotal/internal/proto/user"
+	"github.com/skeleton1231/gotal/pkg/log"
+)
+
+// Credit 为用户增加积分
+func (s *UserServiceServer) Credit(ctx context.Context, req *pb.CreditRequest) (*pb.CreditResponse, error) {
+	return s.changeCredits(ctx, req, 1)
+}
+
+// Debit 扣减用户积分，余额不足时返回 ErrInsufficientCredits，余额不会变为负数
+func (s *UserServiceServer) Debit(ctx context.Context, req *pb.CreditRequest) (*pb.CreditResponse, error) {
+	return s.changeCredits(ctx, req, -1)
+}
+
+// changeCredits 校验请求后在一个事务中记账并更新余额，sign 决定增加还是扣减
+func (s *UserServiceServer) changeCredits(ctx context.Context, req *pb.CreditRequest, sign int64) (*pb.CreditResponse, error) {
+	var violations []errors.FieldViolation
+	if req.GetAmount() <= 0 {
+		violations = append(violations, errors.FieldViolation{Field: "amount", Description: "must be positive"})
+	}
+	if key := req.GetIdempotencyKey(); key == "" || len(key) > model.MaxIdempotencyKeyLength {
+		violations = append(violations, errors.FieldViolation{
+			Field:       "idempotencyKey",
+			Description: "must be between 1 and 64 characters",
+		})
+	}
+	if len(violations) > 0 {
+		return nil, errors.WithFieldViolations(errors.WithCode(code.ErrValidation, "invalid credit request"), violations...)
+	}
+
+	transaction := &model.CreditTransaction{
+		UserID:         req.GetUserId(),
+		Amount:         sign * req.GetAmount(),
+		IdempotencyKey: req.GetIdempotencyKey(),
+		Reason:         req.GetReason(),
+	}
+	if err := s.store.Users().ChangeCredits(ctx, transaction); err != nil {
+		return nil, err
+	}
+	if !transaction.Replayed {
+		log.Infof("credits of user %d changed by %d to %d", transaction.UserID, transaction.Amount, transaction.Balance)
+	}
+
+	return &pb.CreditResponse{
+		Transaction: model.CreditTransactionToProto(transaction),
+		Replayed:    transaction.Replayed,
+	}, nil
+}
+
+// ListTransactions 按时间倒序返回用户的积分流水
+func (s *UserServiceServer) ListTransactions(ctx context.Context, req *pb.ListTransactionsRequest) (*pb.ListTransactionsResponse, error) {
+	list, err := s.store.Users().ListTransactions(ctx, req.GetUserId(), model.ProtoToListOptions(req.GetOptions()))
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.ListTransactionsResponse{TotalCount: list.TotalCount}
+	for _, transaction := range list.Items {
+		resp.Items = append(resp.Items, model.CreditTransactionToProto(transaction))
+	}
+
+	return resp, nil
+}
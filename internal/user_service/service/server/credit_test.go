@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/skeleton1231/gotal/internal/apiserver/store/model"
+	"github.com/skeleton1231/gotal/internal/pkg/code"
+	"github.com/skeleton1231/gotal/internal/pkg/errors"
+	pb "github.com/skeleton1231/gotal/internal/proto/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserServiceServer_Debit(t *testing.T) {
+	t.Run("records a negative amount", func(t *testing.T) {
+		s, users := newTestUserServer(t)
+		users.EXPECT().ChangeCredits(gomock.Any(), &model.CreditTransaction{UserID: 7, Amount: -30, IdempotencyKey: "order-1", Reason: "purchase"}).
+			DoAndReturn(func(_ context.Context, transaction *model.CreditTransaction) error {
+				transaction.ID = 3
+				transaction.Balance = 70
+
+				return nil
+			})
+
+		resp, err := s.Debit(context.Background(), &pb.CreditRequest{UserId: 7, Amount: 30, IdempotencyKey: "order-1", Reason: "purchase"})
+		require.NoError(t, err)
+		assert.Equal(t, int64(-30), resp.GetTransaction().GetAmount())
+		assert.Equal(t, int64(70), resp.GetTransaction().GetBalance())
+		assert.False(t, resp.GetReplayed())
+	})
+
+	t.Run("insufficient credits", func(t *testing.T) {
+		s, users := newTestUserServer(t)
+		users.EXPECT().ChangeCredits(gomock.Any(), gomock.Any()).
+			Return(errors.WithCode(code.ErrInsufficientCredits, "not enough"))
+
+		_, err := s.Debit(context.Background(), &pb.CreditRequest{UserId: 7, Amount: 500, IdempotencyKey: "order-2"})
+		assert.True(t, errors.IsCode(err, code.ErrInsufficientCredits))
+	})
+}
+
+func TestUserServiceServer_Credit_Validation(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *pb.CreditRequest
+	}{
+		{name: "zero amount", req: &pb.CreditRequest{UserId: 7, IdempotencyKey: "order-1"}},
+		{name: "negative amount", req: &pb.CreditRequest{UserId: 7, Amount: -5, IdempotencyKey: "order-1"}},
+		{name: "no idempotency key", req: &pb.CreditRequest{UserId: 7, Amount: 5}},
+		{name: "long idempotency key", req: &pb.CreditRequest{UserId: 7, Amount: 5, IdempotencyKey: strings.Repeat("k", 65)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _ := newTestUserServer(t)
+
+			_, err := s.Credit(context.Background(), tt.req)
+			assert.True(t, errors.IsCode(err, code.ErrValidation), err)
+		})
+	}
+}
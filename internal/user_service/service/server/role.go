@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/skeleton1231/gotal/internal/apiserver/store/model"
+	pb "github.com/skeleton1231/gotal/internal/proto/role"
+	"github.com/skeleton1231/gotal/internal/user_service/store"
+	"github.com/skeleton1231/gotal/pkg/log"
+)
+
+// RoleServiceServer is the implementation of the RoleServiceServer interface.
+type RoleServiceServer struct {
+	store store.Factory
+	pb.UnimplementedRoleServiceServer
+}
+
+var (
+	roleServer *RoleServiceServer
+	roleOnce   sync.Once
+)
+
+// GetRoleInsOr return role server instance with given factory.
+func GetRoleInsOr(store store.Factory) (*RoleServiceServer, error) {
+	if store != nil {
+		roleOnce.Do(func() {
+			roleServer = &RoleServiceServer{store: store}
+		})
+	}
+
+	if roleServer == nil {
+		return nil, fmt.Errorf("got nil role server")
+	}
+
+	return roleServer, nil
+}
+
+// CreateRole creates a new role.
+func (s *RoleServiceServer) CreateRole(ctx context.Context, req *pb.CreateRoleRequest) (*pb.CreateRoleResponse, error) {
+	role, err := model.ProtoToRole(req.GetRole())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.store.Roles().Create(ctx, role, model.CreateOptions{}); err != nil {
+		log.Errorf("Role Create fail: %+v", err)
+
+		return nil, err
+	}
+
+	return &pb.CreateRoleResponse{Role: model.RoleToProto(role)}, nil
+}
+
+// GetRole returns a role by the role identifier.
+func (s *RoleServiceServer) GetRole(ctx context.Context, req *pb.GetRoleRequest) (*pb.GetRoleResponse, error) {
+	role, err := s.store.Roles().Get(ctx, req.GetRoleId(), model.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetRoleResponse{Role: model.RoleToProto(role)}, nil
+}
+
+// ListRoles returns the roles matching the list options.
+func (s *RoleServiceServer) ListRoles(ctx context.Context, req *pb.ListRolesRequest) (*pb.ListRolesResponse, error) {
+	opts := model.ListOptions{
+		LabelSelector: req.GetOptions().GetLabelSelector().GetValue(),
+		FieldSelector: req.GetOptions().GetFieldSelector().GetValue(),
+	}
+	if limit := req.GetOptions().GetLimit(); limit != nil {
+		opts.Limit = &limit.Value
+	}
+	if offset := req.GetOptions().GetOffset(); offset != nil {
+		opts.Offset = &offset.Value
+	}
+
+	roles, err := s.store.Roles().List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ListRolesResponse{Roles: model.RoleListToProto(roles)}, nil
+}
+
+// DeleteRole deletes a role and revokes it from every user.
+func (s *RoleServiceServer) DeleteRole(ctx context.Context, req *pb.DeleteRoleRequest) (*pb.DeleteRoleResponse, error) {
+	opts := model.DeleteOptions{Unscoped: req.GetOptions().GetUnscoped()}
+	if err := s.store.Roles().Delete(ctx, req.GetRoleId(), opts); err != nil {
+		return nil, err
+	}
+
+	return &pb.DeleteRoleResponse{}, nil
+}
+
+// GrantRole grants a role to a user.
+func (s *RoleServiceServer) GrantRole(ctx context.Context, req *pb.GrantRoleRequest) (*pb.GrantRoleResponse, error) {
+	if err := s.store.Roles().Grant(ctx, req.GetUserId(), req.GetRoleId()); err != nil {
+		return nil, err
+	}
+
+	return &pb.GrantRoleResponse{}, nil
+}
+
+// RevokeRole revokes a role from a user.
+func (s *RoleServiceServer) RevokeRole(ctx context.Context, req *pb.RevokeRoleRequest) (*pb.RevokeRoleResponse, error) {
+	if err := s.store.Roles().Revoke(ctx, req.GetUserId(), req.GetRoleId()); err != nil {
+		return nil, err
+	}
+
+	return &pb.RevokeRoleResponse{}, nil
+}
+
+// ListUserRoles returns the roles granted to a user.
+func (s *RoleServiceServer) ListUserRoles(ctx context.Context, req *pb.ListUserRolesRequest) (*pb.ListUserRolesResponse, error) {
+	roles, err := s.store.Roles().ListUserRoles(ctx, req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ListUserRolesResponse{Roles: model.RoleListToProto(roles)}, nil
+}
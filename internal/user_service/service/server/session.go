@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/skeleton1231/gotal/internal/apiserver/store/model"
+	pb "github.com/skeleton1231/gotal/internal/proto/session"
+	"github.com/skeleton1231/gotal/internal/user_service/store"
+	"github.com/skeleton1231/gotal/pkg/log"
+)
+
+// SessionServiceServer is the implementation of the SessionServiceServer interface.
+type SessionServiceServer struct {
+	store store.Factory
+	pb.UnimplementedSessionServiceServer
+}
+
+var (
+	sessionServer *SessionServiceServer
+	sessionOnce   sync.Once
+)
+
+// GetSessionInsOr return session server instance with given factory.
+func GetSessionInsOr(store store.Factory) (*SessionServiceServer, error) {
+	if store != nil {
+		sessionOnce.Do(func() {
+			sessionServer = &SessionServiceServer{store: store}
+		})
+	}
+
+	if sessionServer == nil {
+		return nil, fmt.Errorf("got nil session server")
+	}
+
+	return sessionServer, nil
+}
+
+// CreateSession stores a new session. Only the hash of the refresh token is received.
+func (s *SessionServiceServer) CreateSession(ctx context.Context, req *pb.CreateSessionRequest) (*pb.CreateSessionResponse, error) {
+	session, err := model.ProtoToSession(req.GetSession())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.store.Sessions().Create(ctx, session); err != nil {
+		log.Errorf("Session Create fail: %+v", err)
+
+		return nil, err
+	}
+
+	return &pb.CreateSessionResponse{Session: model.SessionToProto(session)}, nil
+}
+
+// RotateSession replaces the refresh token of a session.
+func (s *SessionServiceServer) RotateSession(ctx context.Context, req *pb.RotateSessionRequest) (*pb.RotateSessionResponse, error) {
+	session, err := s.store.Sessions().Rotate(ctx, req.GetRefreshHash(), req.GetNewRefreshHash(), req.GetExpiresAt().AsTime())
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.RotateSessionResponse{Session: model.SessionToProto(session)}, nil
+}
+
+// ListSessions returns the active sessions of a user.
+func (s *SessionServiceServer) ListSessions(ctx context.Context, req *pb.ListSessionsRequest) (*pb.ListSessionsResponse, error) {
+	sessions, err := s.store.Sessions().List(ctx, req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ListSessionsResponse{Items: model.SessionListToProto(sessions)}, nil
+}
+
+// RevokeSession revokes a session of a user.
+func (s *SessionServiceServer) RevokeSession(ctx context.Context, req *pb.RevokeSessionRequest) (*pb.RevokeSessionResponse, error) {
+	session, err := s.store.Sessions().Revoke(ctx, req.GetUserId(), req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.RevokeSessionResponse{Session: model.SessionToProto(session)}, nil
+}
+
+// RevokeUserSessions revokes every active session of a user.
+func (s *SessionServiceServer) RevokeUserSessions(ctx context.Context, req *pb.RevokeUserSessionsRequest) (*pb.RevokeUserSessionsResponse, error) {
+	sessions, err := s.store.Sessions().RevokeAll(ctx, req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.RevokeUserSessionsResponse{Items: model.SessionListToProto(sessions)}, nil
+}
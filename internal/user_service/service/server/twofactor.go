@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/skeleton1231/gotal/internal/apiserver/store/model"
+	"github.com/skeleton1231/gotal/internal/pkg/code"
+	"github.com/skeleton1231/gotal/internal/pkg/errors"
+	pb "github.com/skeleton1231/gotal/internal/proto/twofactor"
+	"github.com/skeleton1231/gotal/internal/user_service/store"
+	"github.com/skeleton1231/gotal/pkg/log"
+)
+
+// TwoFactorServiceServer is the implementation of the TwoFactorServiceServer interface.
+type TwoFactorServiceServer struct {
+	store store.Factory
+	pb.UnimplementedTwoFactorServiceServer
+}
+
+var (
+	twoFactorServer *TwoFactorServiceServer
+	twoFactorOnce   sync.Once
+)
+
+// GetTwoFactorInsOr return two-factor server instance with given factory.
+func GetTwoFactorInsOr(store store.Factory) (*TwoFactorServiceServer, error) {
+	if store != nil {
+		twoFactorOnce.Do(func() {
+			twoFactorServer = &TwoFactorServiceServer{store: store}
+		})
+	}
+
+	if twoFactorServer == nil {
+		return nil, fmt.Errorf("got nil two-factor server")
+	}
+
+	return twoFactorServer, nil
+}
+
+// GetTwoFactor returns the second factor of a user. Most users have none, which is answered
+// with an empty response rather than an error.
+func (s *TwoFactorServiceServer) GetTwoFactor(ctx context.Context, req *pb.GetTwoFactorRequest) (*pb.GetTwoFactorResponse, error) {
+	tf, err := s.store.TwoFactors().Get(ctx, req.GetUserId())
+	if errors.IsCode(err, code.ErrTwoFactorNotFound) {
+		return &pb.GetTwoFactorResponse{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetTwoFactorResponse{TwoFactor: model.TwoFactorToProto(tf)}, nil
+}
+
+// SaveTwoFactor stores a pending second factor.
+func (s *TwoFactorServiceServer) SaveTwoFactor(ctx context.Context, req *pb.SaveTwoFactorRequest) (*pb.SaveTwoFactorResponse, error) {
+	tf, err := model.ProtoToTwoFactor(req.GetTwoFactor())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.store.TwoFactors().Save(ctx, tf); err != nil {
+		log.Errorf("TwoFactor Save fail: %+v", err)
+
+		return nil, err
+	}
+
+	return &pb.SaveTwoFactorResponse{TwoFactor: model.TwoFactorToProto(tf)}, nil
+}
+
+// EnableTwoFactor enables the pending second factor of a user with new recovery codes.
+func (s *TwoFactorServiceServer) EnableTwoFactor(ctx context.Context, req *pb.EnableTwoFactorRequest) (*pb.EnableTwoFactorResponse, error) {
+	if err := s.store.TwoFactors().Enable(ctx, req.GetUserId(), req.GetStep(), req.GetRecoveryHashes()); err != nil {
+		return nil, err
+	}
+
+	return &pb.EnableTwoFactorResponse{}, nil
+}
+
+// DeleteTwoFactor removes the second factor of a user.
+func (s *TwoFactorServiceServer) DeleteTwoFactor(ctx context.Context, req *pb.DeleteTwoFactorRequest) (*pb.DeleteTwoFactorResponse, error) {
+	if err := s.store.TwoFactors().Delete(ctx, req.GetUserId()); err != nil {
+		return nil, err
+	}
+
+	return &pb.DeleteTwoFactorResponse{}, nil
+}
+
+// UseTwoFactorStep records the time step of an accepted code.
+func (s *TwoFactorServiceServer) UseTwoFactorStep(ctx context.Context, req *pb.UseTwoFactorStepRequest) (*pb.UseTwoFactorStepResponse, error) {
+	accepted, err := s.store.TwoFactors().UseStep(ctx, req.GetUserId(), req.GetStep())
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.UseTwoFactorStepResponse{Accepted: accepted}, nil
+}
+
+// UseRecoveryCode consumes a recovery code of a user.
+func (s *TwoFactorServiceServer) UseRecoveryCode(ctx context.Context, req *pb.UseRecoveryCodeRequest) (*pb.UseRecoveryCodeResponse, error) {
+	accepted, err := s.store.TwoFactors().UseRecoveryCode(ctx, req.GetUserId(), req.GetHash())
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.UseRecoveryCodeResponse{Accepted: accepted}, nil
+}
@@ -3,14 +3,22 @@ package service
 import (
 	"context"
 	"fmt"
+	"io"
 	"sync"
 
 	"github.com/skeleton1231/gotal/internal/apiserver/store/model"
+	"github.com/skeleton1231/gotal/internal/pkg/code"
+	"github.com/skeleton1231/gotal/internal/pkg/errors"
+	"github.com/skeleton1231/gotal/internal/pkg/validation"
 	pb "github.com/skeleton1231/gotal/internal/proto/user"
 	"github.com/skeleton1231/gotal/internal/user_service/store"
 	"github.com/skeleton1231/gotal/pkg/log"
+	"google.golang.org/grpc/metadata"
 )
 
+// importBatchSize 是 Import 每次交给存储层写入的用户数
+const importBatchSize = 100
+
 // userServiceServer 是 UserServiceServer 接口的实现
 type UserServiceServer struct {
 	// 这里可以包含服务器需要的任何状态或依赖
@@ -56,19 +64,24 @@ func (s *UserServiceServer) Create(ctx context.Context, req *pb.CreateRequest) (
 	// log.Infof("TrialEndsAt: %+v\n", obj.GetTrialEndsAt().AsTime())
 
 	// 然后调用 store 方法来创建用户
-	err = s.store.Users().Create(ctx, user, model.CreateOptions{})
+	err = s.store.Users().Create(ctx, user, model.CreateOptions{DryRun: req.GetOptions().GetDryRun()})
 	if err != nil {
 		// 处理创建过程中可能发生的错误
 		log.Errorf("User Create fail: %+v\n", err)
 		return nil, err
 	}
 	// 如果创建成功，返回创建的用户信息
-	return &pb.CreateResponse{User: obj}, nil
+	return &pb.CreateResponse{User: model.UserToProto(user)}, nil
 }
 
 // 实现 Update 方法
 // Update 方法更新一个用户
 func (s *UserServiceServer) Update(ctx context.Context, req *pb.UpdateRequest) (*pb.UpdateResponse, error) {
+	// 带 updateMask 的请求只更新列出的字段
+	if len(req.GetUpdateMask().GetPaths()) > 0 {
+		return s.patch(ctx, req)
+	}
+
 	// 从请求中提取用户ID
 	userID := req.GetUser().GetMeta().GetId()
 
@@ -94,10 +107,13 @@ func (s *UserServiceServer) Update(ctx context.Context, req *pb.UpdateRequest) (
 	if updatedUser.Email != "" {
 		existingUser.Email = updatedUser.Email
 	}
+	if updatedUser.DiscordID != 0 {
+		existingUser.DiscordID = updatedUser.DiscordID
+	}
 	// 更新其他需要更新的字段...
 
 	// 使用更新后的用户信息进行更新操作
-	err = s.store.Users().Update(ctx, existingUser, model.UpdateOptions{})
+	err = s.store.Users().Update(ctx, existingUser, model.UpdateOptions{DryRun: req.GetOptions().GetDryRun()})
 	if err != nil {
 		return nil, err
 	}
@@ -109,12 +125,191 @@ func (s *UserServiceServer) Update(ctx context.Context, req *pb.UpdateRequest) (
 	}, nil
 }
 
+// patch 方法按 updateMask 写入字段，空值同样会被写入，因此可以清空字段
+func (s *UserServiceServer) patch(ctx context.Context, req *pb.UpdateRequest) (*pb.UpdateResponse, error) {
+	mask := req.GetUpdateMask()
+	if !mask.IsValid(req.GetUser()) {
+		return nil, errors.WithCode(code.ErrValidation, "invalid update mask %v", mask.GetPaths())
+	}
+	mask.Normalize()
+
+	var violations []errors.FieldViolation
+	for _, field := range mask.GetPaths() {
+		if _, ok := model.UserColumn(field); !ok {
+			violations = append(violations, errors.FieldViolation{Field: field, Description: "cannot be patched"})
+		}
+	}
+	if len(violations) > 0 {
+		return nil, errors.WithFieldViolations(errors.WithCode(code.ErrValidation, "update mask contains protected fields"), violations...)
+	}
+
+	user, err := model.ProtoToUser(req.GetUser())
+	if err != nil {
+		return nil, errors.WithCode(code.ErrBind, err.Error())
+	}
+
+	opts := model.PatchOptions{DryRun: req.GetOptions().GetDryRun()}
+	if err := s.store.Users().Patch(ctx, user, mask.GetPaths(), opts); err != nil {
+		log.Errorf("User Patch fail: %+v", err)
+
+		return nil, err
+	}
+
+	// dry run 没有写入数据库，直接返回将要保存的用户
+	if model.IsDryRun(opts.DryRun) {
+		return &pb.UpdateResponse{User: model.UserToProto(user)}, nil
+	}
+
+	patched, err := s.store.Users().Get(ctx, user.ID, model.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.UpdateResponse{User: model.UserToProto(patched)}, nil
+}
+
 // 实现 Delete 方法
+// Delete 方法删除一个用户，默认软删除，unscoped 为 true 时从数据库中彻底删除
 func (s *UserServiceServer) Delete(ctx context.Context, req *pb.DeleteRequest) (*pb.DeleteResponse, error) {
-	// 在这里编写 Delete 方法的具体实现
+	opts := model.DeleteOptions{Unscoped: req.GetOptions().GetUnscoped()}
+	if err := s.store.Users().Delete(ctx, req.GetUserId(), opts); err != nil {
+		log.Errorf("User Delete fail: %+v", err)
+
+		return nil, err
+	}
+
 	return &pb.DeleteResponse{}, nil
 }
 
+// DeleteCollection 在一个事务中批量删除用户，并返回每个用户的删除结果
+func (s *UserServiceServer) DeleteCollection(
+	ctx context.Context,
+	req *pb.DeleteCollectionRequest,
+) (*pb.DeleteCollectionResponse, error) {
+	opts := model.DeleteCollectionOptions{
+		DeleteOptions: model.DeleteOptions{Unscoped: req.GetOptions().GetUnscoped()},
+		FieldSelector: req.GetFieldSelector(),
+	}
+	if req.GetLimit() != nil {
+		limit := req.GetLimit().GetValue()
+		opts.Limit = &limit
+	}
+
+	results, err := s.store.Users().DeleteCollection(ctx, req.GetUserIds(), opts)
+	if err != nil {
+		log.Errorf("User DeleteCollection fail: %+v", err)
+
+		return nil, err
+	}
+
+	return &pb.DeleteCollectionResponse{Results: model.UserDeleteResultsToProto(results)}, nil
+}
+
+// Watch 推送用户的变更，直到客户端断开或者订阅被服务端关闭
+func (s *UserServiceServer) Watch(req *pb.WatchRequest, stream pb.UserService_WatchServer) error {
+	w, err := s.store.Users().Watch(stream.Context(), model.WatchOptions{
+		FieldSelector:   req.GetFieldSelector(),
+		ResourceVersion: req.GetResourceVersion(),
+	})
+	if err != nil {
+		return err
+	}
+	defer w.Stop()
+
+	for event := range w.ResultChan() {
+		if err := stream.Send(model.UserWatchEventToProto(event)); err != nil {
+			return err
+		}
+	}
+
+	return w.Err()
+}
+
+// Import 接收用户流并分批写入，校验或写入失败的行记录在汇总中，不会中断整个导入
+func (s *UserServiceServer) Import(stream pb.UserService_ImportServer) error {
+	ctx := stream.Context()
+
+	var opts model.ImportOptions
+	if values := metadata.ValueFromIncomingContext(ctx, model.ImportOnConflictMetadataKey); len(values) > 0 {
+		opts.OnConflict = values[0]
+	}
+	if err := opts.Validate(); err != nil {
+		return errors.WithCode(code.ErrValidation, err.Error())
+	}
+
+	summary := &model.UserImportSummary{}
+	batch := make([]*model.User, 0, importBatchSize)
+	rows := make([]int, 0, importBatchSize)
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+
+		part, err := s.store.Users().Import(ctx, batch, opts)
+		if err != nil {
+			log.Errorf("User Import fail: %+v", err)
+
+			return err
+		}
+		summary.Merge(part, rows)
+
+		batch = batch[:0]
+		rows = rows[:0]
+
+		return nil
+	}
+
+	for row := 1; ; row++ {
+		pbUser, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		user, err := model.ProtoToUser(pbUser)
+		if err != nil {
+			summary.Fail(row, pbUser.GetName(), err.Error())
+
+			continue
+		}
+		if _, err := validation.CheckModel(user); err != nil {
+			summary.Fail(row, user.Name, err.Error())
+
+			continue
+		}
+
+		batch = append(batch, user)
+		rows = append(rows, row)
+		if len(batch) == importBatchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := flush(); err != nil {
+		return err
+	}
+	summary.SortErrors()
+
+	return stream.SendAndClose(model.UserImportSummaryToProto(summary))
+}
+
+// Export 按 List 的字段选择器和标签选择器推送全部匹配的用户，密码等敏感字段不会被导出
+func (s *UserServiceServer) Export(req *pb.ListRequest, stream pb.UserService_ExportServer) error {
+	opts := model.ProtoToListOptions(req.GetOptions())
+
+	return s.store.Users().Export(stream.Context(), opts, func(user *model.User) error {
+		user.Password = ""
+		user.RememberToken = ""
+
+		return stream.Send(model.UserToProto(user))
+	})
+}
+
 // 实现 Get 方法
 func (s *UserServiceServer) Get(ctx context.Context, req *pb.GetRequest) (*pb.GetResponse, error) {
 	// 从请求中获取用户的标识（假设是用户的 ID）
@@ -137,9 +332,20 @@ func (s *UserServiceServer) Get(ctx context.Context, req *pb.GetRequest) (*pb.Ge
 }
 
 // 实现 List 方法
+// List 方法按字段选择器、标签选择器和分页参数返回用户列表
 func (s *UserServiceServer) List(ctx context.Context, req *pb.ListRequest) (*pb.ListResponse, error) {
-	// 在这里编写 List 方法的具体实现
-	return &pb.ListResponse{}, nil
+	opts := model.ProtoToListOptions(req.GetOptions())
+	opts.Continue = req.GetContinue()
+	opts.SkipCount = req.GetSkipCount()
+
+	users, err := s.store.Users().List(ctx, opts)
+	if err != nil {
+		log.Errorf("User List fail: %+v", err)
+
+		return nil, err
+	}
+
+	return &pb.ListResponse{Users: model.UserListToProto(users)}, nil
 }
 
 func (s *UserServiceServer) GetByUsername(ctx context.Context, req *pb.GetByUsernameRequest) (*pb.GetByUsernameResponse, error) {
@@ -157,8 +363,43 @@ func (s *UserServiceServer) GetByUsername(ctx context.Context, req *pb.GetByUser
 	}, nil
 }
 
+// GetByDiscordID returns the user linked to a Discord account.
+func (s *UserServiceServer) GetByDiscordID(ctx context.Context, req *pb.GetByDiscordIDRequest) (*pb.GetByDiscordIDResponse, error) {
+	user, err := s.store.Users().GetByDiscordID(ctx, req.GetDiscordId(), model.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetByDiscordIDResponse{User: model.UserToProto(user)}, nil
+}
+
+// GetByEmail returns the first user with the email address.
+func (s *UserServiceServer) GetByEmail(ctx context.Context, req *pb.GetByEmailRequest) (*pb.GetByEmailResponse, error) {
+	user, err := s.store.Users().GetByEmail(ctx, req.GetEmail(), model.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetByEmailResponse{User: model.UserToProto(user)}, nil
+}
+
 // 实现 ChangePassword 方法
+// ChangePassword 方法接收明文的新密码，由存储层加密后保存
+// 请求带有 RememberToken 时，只有令牌仍然有效才会修改密码，且令牌只能使用一次
 func (s *UserServiceServer) ChangePassword(ctx context.Context, req *pb.ChangePasswordRequest) (*pb.ChangePasswordResponse, error) {
-	// 在这里编写 ChangePassword 方法的具体实现
+	if req.GetNewPassword() == "" {
+		return nil, errors.WithFieldViolations(
+			errors.WithCode(code.ErrValidation, "new password cannot be empty"),
+			errors.FieldViolation{Field: "newPassword", Description: "cannot be empty"},
+		)
+	}
+
+	opts := model.ChangePasswordOptions{RememberToken: req.GetRememberToken()}
+	if err := s.store.Users().ChangePassword(ctx, req.GetUserId(), req.GetNewPassword(), opts); err != nil {
+		log.Errorf("User ChangePassword fail: %+v", err)
+
+		return nil, err
+	}
+
 	return &pb.ChangePasswordResponse{}, nil
 }
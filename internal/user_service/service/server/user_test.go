@@ -0,0 +1,325 @@
+package service
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/skeleton1231/gotal/internal/apiserver/store/model"
+	"github.com/skeleton1231/gotal/internal/pkg/code"
+	"github.com/skeleton1231/gotal/internal/pkg/errors"
+	pbO "github.com/skeleton1231/gotal/internal/proto/options"
+	pb "github.com/skeleton1231/gotal/internal/proto/user"
+	"github.com/skeleton1231/gotal/internal/user_service/store"
+	"github.com/skeleton1231/gotal/internal/user_service/store/mock_store"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// fakeFactory only serves the user store, which is all UserServiceServer touches.
+type fakeFactory struct {
+	store.Factory
+	users *mock_store.MockUserStore
+}
+
+func (f *fakeFactory) Users() store.UserStore {
+	return f.users
+}
+
+func newTestUserServer(t *testing.T) (*UserServiceServer, *mock_store.MockUserStore) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	users := mock_store.NewMockUserStore(ctrl)
+
+	return &UserServiceServer{store: &fakeFactory{users: users}}, users
+}
+
+func TestUserServiceServer_Delete(t *testing.T) {
+	tests := []struct {
+		name     string
+		req      *pb.DeleteRequest
+		unscoped bool
+		err      error
+	}{
+		{
+			name: "soft delete",
+			req:  &pb.DeleteRequest{UserId: 1},
+		},
+		{
+			name:     "hard delete",
+			req:      &pb.DeleteRequest{UserId: 1, Options: &pbO.DeleteOptions{Unscoped: true}},
+			unscoped: true,
+		},
+		{
+			name: "store error",
+			req:  &pb.DeleteRequest{UserId: 1},
+			err:  errors.WithCode(code.ErrDatabase, "boom"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, users := newTestUserServer(t)
+			users.EXPECT().
+				Delete(gomock.Any(), uint64(1), model.DeleteOptions{Unscoped: tt.unscoped}).
+				Return(tt.err)
+
+			_, err := s.Delete(context.Background(), tt.req)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestUserServiceServer_DeleteCollection(t *testing.T) {
+	s, users := newTestUserServer(t)
+	limit := int64(10)
+	users.EXPECT().
+		DeleteCollection(gomock.Any(), []uint64{1, 2}, model.DeleteCollectionOptions{
+			DeleteOptions: model.DeleteOptions{Unscoped: true},
+			FieldSelector: "status=1",
+			Limit:         &limit,
+		}).
+		Return([]*model.UserDeleteResult{{ID: 1, Deleted: true}, {ID: 2, Reason: "not found"}}, nil)
+
+	resp, err := s.DeleteCollection(context.Background(), &pb.DeleteCollectionRequest{
+		UserIds:       []uint64{1, 2},
+		Options:       &pbO.DeleteOptions{Unscoped: true},
+		FieldSelector: "status=1",
+		Limit:         wrapperspb.Int64(limit),
+	})
+	assert.NoError(t, err)
+	assert.Len(t, resp.GetResults(), 2)
+	assert.True(t, resp.GetResults()[0].GetDeleted())
+	assert.Equal(t, "not found", resp.GetResults()[1].GetReason())
+}
+
+func TestUserServiceServer_List(t *testing.T) {
+	limit, offset := int64(10), int64(20)
+
+	tests := []struct {
+		name string
+		req  *pb.ListRequest
+		opts model.ListOptions
+	}{
+		{
+			name: "without options",
+			req:  &pb.ListRequest{},
+			opts: model.ListOptions{},
+		},
+		{
+			name: "with options",
+			req: &pb.ListRequest{Options: &pbO.ListOptions{
+				FieldSelector: wrapperspb.String("name=alice"),
+				Limit:         wrapperspb.Int64(limit),
+				Offset:        wrapperspb.Int64(offset),
+			}},
+			opts: model.ListOptions{FieldSelector: "name=alice", Limit: &limit, Offset: &offset},
+		},
+		{
+			name: "with continue token",
+			req: &pb.ListRequest{
+				Options:   &pbO.ListOptions{Limit: wrapperspb.Int64(limit)},
+				Continue:  "eyJsYXN0SWQiOjh9",
+				SkipCount: true,
+			},
+			opts: model.ListOptions{Limit: &limit, Continue: "eyJsYXN0SWQiOjh9", SkipCount: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, users := newTestUserServer(t)
+			users.EXPECT().List(gomock.Any(), tt.opts).Return(&model.UserList{
+				ListMeta: model.ListMeta{TotalCount: 42, Continue: "next"},
+				Items:    []*model.User{{ObjectMeta: model.ObjectMeta{ID: 7}, Name: "alice"}},
+			}, nil)
+
+			resp, err := s.List(context.Background(), tt.req)
+			assert.NoError(t, err)
+			assert.Equal(t, int64(42), resp.GetUsers().GetTotalCount())
+			assert.Equal(t, "next", resp.GetUsers().GetContinue())
+			assert.Len(t, resp.GetUsers().GetItems(), 1)
+			assert.Equal(t, "alice", resp.GetUsers().GetItems()[0].GetName())
+		})
+	}
+}
+
+func TestUserServiceServer_ChangePassword(t *testing.T) {
+	t.Run("passes the new password to the store", func(t *testing.T) {
+		s, users := newTestUserServer(t)
+		users.EXPECT().ChangePassword(gomock.Any(), uint64(7), "Secret123!", model.ChangePasswordOptions{RememberToken: "hash.1700000000"}).
+			Return(nil)
+
+		_, err := s.ChangePassword(context.Background(), &pb.ChangePasswordRequest{
+			UserId:        7,
+			NewPassword:   "Secret123!",
+			RememberToken: "hash.1700000000",
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("empty password", func(t *testing.T) {
+		s, _ := newTestUserServer(t)
+
+		_, err := s.ChangePassword(context.Background(), &pb.ChangePasswordRequest{UserId: 7})
+		assert.True(t, errors.IsCode(err, code.ErrValidation))
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		s, users := newTestUserServer(t)
+		users.EXPECT().ChangePassword(gomock.Any(), uint64(7), "Secret123!", model.ChangePasswordOptions{}).
+			Return(errors.WithCode(code.ErrUserNotFound, "not found"))
+
+		_, err := s.ChangePassword(context.Background(), &pb.ChangePasswordRequest{UserId: 7, NewPassword: "Secret123!"})
+		assert.True(t, errors.IsCode(err, code.ErrUserNotFound))
+	})
+}
+
+func TestUserServiceServer_Update_Mask(t *testing.T) {
+	user := &pb.User{Meta: &pb.ObjectMeta{Id: 7}, StripeId: "", DiscordId: 9}
+
+	tests := []struct {
+		name  string
+		paths []string
+		store bool
+		code  int
+	}{
+		{name: "allowed fields", paths: []string{"stripeId", "discordId"}, store: true},
+		{name: "protected field", paths: []string{"discordId", "password"}, code: code.ErrValidation},
+		{name: "credits", paths: []string{"totalCredits"}, code: code.ErrValidation},
+		{name: "unknown field", paths: []string{"nickname"}, code: code.ErrValidation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, users := newTestUserServer(t)
+			if tt.store {
+				users.EXPECT().Patch(gomock.Any(), gomock.Any(), []string{"discordId", "stripeId"}, model.PatchOptions{}).
+					DoAndReturn(func(_ context.Context, u *model.User, _ []string, _ model.PatchOptions) error {
+						assert.Equal(t, uint64(7), u.ID)
+						assert.Equal(t, uint64(9), u.DiscordID)
+
+						return nil
+					})
+				users.EXPECT().Get(gomock.Any(), uint64(7), model.GetOptions{}).
+					Return(&model.User{ObjectMeta: model.ObjectMeta{ID: 7}, Name: "alice", DiscordID: 9}, nil)
+			}
+
+			resp, err := s.Update(context.Background(), &pb.UpdateRequest{
+				User:       user,
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: tt.paths},
+			})
+			if tt.code != 0 {
+				assert.True(t, errors.IsCode(err, tt.code), "%v", err)
+
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "alice", resp.GetUser().GetName())
+		})
+	}
+}
+
+func TestUserServiceServer_DryRun(t *testing.T) {
+	dryRun := []string{model.DryRunAll}
+
+	t.Run("create", func(t *testing.T) {
+		s, users := newTestUserServer(t)
+		users.EXPECT().Create(gomock.Any(), gomock.Any(), model.CreateOptions{DryRun: dryRun}).Return(nil)
+
+		resp, err := s.Create(context.Background(), &pb.CreateRequest{
+			User:    &pb.User{Meta: &pb.ObjectMeta{}, Name: "alice"},
+			Options: &pbO.CreateOptions{DryRun: dryRun},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "alice", resp.GetUser().GetName())
+	})
+
+	t.Run("patch returns the would-be user", func(t *testing.T) {
+		s, users := newTestUserServer(t)
+		users.EXPECT().Patch(gomock.Any(), gomock.Any(), []string{"email"}, model.PatchOptions{DryRun: dryRun}).Return(nil)
+
+		resp, err := s.Update(context.Background(), &pb.UpdateRequest{
+			User:       &pb.User{Meta: &pb.ObjectMeta{Id: 7}, Email: "new@example.com"},
+			Options:    &pbO.UpdateOptions{DryRun: dryRun},
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"email"}},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "new@example.com", resp.GetUser().GetEmail())
+	})
+}
+
+// fakeImportStream feeds the users to Import and keeps the summary it answers with.
+type fakeImportStream struct {
+	pb.UserService_ImportServer
+	ctx     context.Context
+	users   []*pb.User
+	summary *pb.ImportSummary
+}
+
+func (f *fakeImportStream) Context() context.Context {
+	return f.ctx
+}
+
+func (f *fakeImportStream) Recv() (*pb.User, error) {
+	if len(f.users) == 0 {
+		return nil, io.EOF
+	}
+	user := f.users[0]
+	f.users = f.users[1:]
+
+	return user, nil
+}
+
+func (f *fakeImportStream) SendAndClose(summary *pb.ImportSummary) error {
+	f.summary = summary
+
+	return nil
+}
+
+func TestUserServiceServer_Import(t *testing.T) {
+	s, users := newTestUserServer(t)
+
+	valid := func(name string) *pb.User {
+		return &pb.User{Name: name, Email: name + "@example.com", Password: "hash"}
+	}
+
+	ctx := metadata.NewIncomingContext(context.Background(),
+		metadata.Pairs(model.ImportOnConflictMetadataKey, model.ImportOnConflictUpsert))
+	stream := &fakeImportStream{ctx: ctx, users: []*pb.User{
+		valid("alice"),
+		{Name: "bob", Email: "not an email", Password: "hash"},
+		valid("carol"),
+		valid("dave"),
+	}}
+
+	// The invalid row never reaches the store, whose rows are renumbered in the summary.
+	users.EXPECT().
+		Import(gomock.Any(), gomock.Len(3), model.ImportOptions{OnConflict: model.ImportOnConflictUpsert}).
+		Return(&model.UserImportSummary{
+			Created: 1,
+			Updated: 1,
+			Failed:  1,
+			Errors:  []*model.UserImportError{{Row: 2, Name: "carol", Reason: "duplicate email"}},
+		}, nil)
+
+	assert.NoError(t, s.Import(stream))
+	assert.Equal(t, int32(1), stream.summary.GetCreated())
+	assert.Equal(t, int32(1), stream.summary.GetUpdated())
+	assert.Equal(t, int32(2), stream.summary.GetFailed())
+	if assert.Len(t, stream.summary.GetErrors(), 2) {
+		assert.Equal(t, int32(2), stream.summary.GetErrors()[0].GetRow())
+		assert.Equal(t, "bob", stream.summary.GetErrors()[0].GetName())
+		assert.Equal(t, int32(3), stream.summary.GetErrors()[1].GetRow())
+		assert.Equal(t, "duplicate email", stream.summary.GetErrors()[1].GetReason())
+	}
+
+	stream = &fakeImportStream{
+		ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs(model.ImportOnConflictMetadataKey, "replace")),
+	}
+	assert.True(t, errors.IsCode(s.Import(stream), code.ErrValidation))
+}
@@ -0,0 +1,80 @@
+package database
+
+import (
+	"context"
+
+	"github.com/skeleton1231/gotal/internal/apiserver/store/model"
+	"github.com/skeleton1231/gotal/internal/pkg/code"
+	"github.com/skeleton1231/gotal/internal/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type apiKeys struct {
+	db *gorm.DB
+}
+
+func newAPIKeys(ds *datastore) *apiKeys {
+	return &apiKeys{ds.db}
+}
+
+// Create creates a new API key.
+func (a *apiKeys) Create(ctx context.Context, key *model.APIKey) error {
+	if err := a.db.WithContext(ctx).Create(key).Error; err != nil {
+		return errors.WithCode(code.ErrDatabase, err.Error())
+	}
+
+	return nil
+}
+
+// List return all API keys of the user.
+func (a *apiKeys) List(ctx context.Context, userId uint64) (*model.APIKeyList, error) {
+	ret := &model.APIKeyList{}
+
+	err := a.db.WithContext(ctx).Where("user_id = ?", userId).Order("id desc").Find(&ret.Items).Error
+	if err != nil {
+		return nil, errors.WithCode(code.ErrDatabase, err.Error())
+	}
+	ret.TotalCount = int64(len(ret.Items))
+
+	return ret, nil
+}
+
+// Delete deletes the API key owned by the user and returns the deleted key.
+func (a *apiKeys) Delete(ctx context.Context, userId uint64, id uint64) (*model.APIKey, error) {
+	key := &model.APIKey{}
+	err := a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("id = ? AND user_id = ?", id, userId).First(key).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.WithCode(code.ErrAPIKeyNotFound, err.Error())
+			}
+
+			return errors.WithCode(code.ErrDatabase, err.Error())
+		}
+
+		if err := tx.Delete(key).Error; err != nil {
+			return errors.WithCode(code.ErrDatabase, err.Error())
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// GetByHash return the API key with the given hash.
+func (a *apiKeys) GetByHash(ctx context.Context, hash string) (*model.APIKey, error) {
+	key := &model.APIKey{}
+	err := a.db.WithContext(ctx).Where("hash = ?", hash).First(key).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.WithCode(code.ErrAPIKeyNotFound, err.Error())
+		}
+
+		return nil, errors.WithCode(code.ErrDatabase, err.Error())
+	}
+
+	return key, nil
+}
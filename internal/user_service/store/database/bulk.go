@@ -0,0 +1,121 @@
+package database
+
+import (
+	"context"
+
+	"github.com/skeleton1231/gotal/internal/apiserver/store/model"
+	"github.com/skeleton1231/gotal/internal/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// exportPageSize is how many users Export reads from the database at once.
+const exportPageSize = 500
+
+// importUserColumns are the columns an upsert overwrites. The password, credits and trial
+// of an existing user are kept, they are only changed by their own flows.
+var importUserColumns = []string{"email", "stripe_id", "discord_id", "extendShadow", "status"}
+
+type importAction int
+
+const (
+	importCreated importAction = iota
+	importUpdated
+	importSkipped
+)
+
+// Import creates the users in their own transaction each, so that a failing row does not
+// abort the others. A user whose name is taken is skipped, or overwritten with opts.Upsert.
+// The rows of the summary are the positions of the users in the slice, starting from 1.
+func (u *users) Import(ctx context.Context, users []*model.User, opts model.ImportOptions) (*model.UserImportSummary, error) {
+	summary := &model.UserImportSummary{}
+	for i, user := range users {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		action, err := u.importUser(ctx, user, opts)
+		if err != nil {
+			summary.Fail(i+1, user.Name, err.Error())
+
+			continue
+		}
+
+		switch action {
+		case importCreated:
+			summary.Created++
+		case importUpdated:
+			summary.Updated++
+		case importSkipped:
+			summary.Skipped++
+		}
+	}
+
+	return summary, nil
+}
+
+func (u *users) importUser(ctx context.Context, user *model.User, opts model.ImportOptions) (importAction, error) {
+	ctx, changes := withUserChanges(ctx)
+
+	var action importAction
+	err := u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Lock the user with the same name, so that concurrent imports do not overwrite each other.
+		var existing model.User
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("name = ?", user.Name).Take(&existing).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			action = importCreated
+			// Credits are only granted through the ledger.
+			user.TotalCredits = 0
+
+			return tx.Create(user).Error
+		}
+		if err != nil {
+			return err
+		}
+
+		user.ID = existing.ID
+		if !opts.Upsert() {
+			action = importSkipped
+
+			return nil
+		}
+
+		action = importUpdated
+
+		return tx.Model(user).Select(importUserColumns).Updates(user).Error
+	})
+	if err != nil {
+		return action, err
+	}
+	userWatch.publish(ctx, u.db.WithContext(ctx), changes.changes...)
+
+	return action, nil
+}
+
+// Export calls fn with every user matching the selectors of opts, newest first.
+// Offset and limit are ignored, the users are read page by page until none is left.
+func (u *users) Export(ctx context.Context, opts model.ListOptions, fn func(user *model.User) error) error {
+	limit := int64(exportPageSize)
+	opts.Offset = nil
+	opts.Limit = &limit
+	opts.Continue = ""
+	opts.SkipCount = true
+
+	for {
+		list, err := u.List(ctx, opts)
+		if err != nil {
+			return err
+		}
+
+		for _, user := range list.Items {
+			if err := fn(user); err != nil {
+				return err
+			}
+		}
+
+		if list.Continue == "" {
+			return nil
+		}
+		opts.Continue = list.Continue
+	}
+}
@@ -0,0 +1,116 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/skeleton1231/gotal/internal/apiserver/store/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImport(t *testing.T) {
+	selectByName := regexp.QuoteMeta("SELECT * FROM `users` WHERE name = ? AND `users`.`deleted_at` IS NULL LIMIT 1 FOR UPDATE")
+	columns := []string{"id", "name", "extendShadow"}
+
+	tests := []struct {
+		name   string
+		opts   model.ImportOptions
+		expect func(mock sqlmock.Sqlmock)
+		want   *model.UserImportSummary
+	}{
+		{
+			name: "create and skip",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectByName).WithArgs("alice").WillReturnRows(sqlmock.NewRows(columns))
+				mock.ExpectExec("INSERT INTO `users`").WithArgs(anyArgs(16)...).WillReturnResult(sqlmock.NewResult(7, 1))
+				mock.ExpectCommit()
+
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectByName).WithArgs("bob").WillReturnRows(sqlmock.NewRows(columns).AddRow(3, "bob", "{}"))
+				mock.ExpectCommit()
+			},
+			want: &model.UserImportSummary{Created: 1, Skipped: 1},
+		},
+		{
+			name: "upsert and failure",
+			opts: model.ImportOptions{OnConflict: model.ImportOnConflictUpsert},
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectByName).WithArgs("alice").WillReturnRows(sqlmock.NewRows(columns))
+				mock.ExpectExec("INSERT INTO `users`").WithArgs(anyArgs(16)...).
+					WillReturnError(fmt.Errorf("Duplicate entry 'alice@example.com' for key 'idx_email'"))
+				mock.ExpectRollback()
+
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectByName).WithArgs("bob").WillReturnRows(sqlmock.NewRows(columns).AddRow(3, "bob", "{}"))
+				mock.ExpectExec(regexp.QuoteMeta(
+					"UPDATE `users` SET `extendShadow`=?,`updated_at`=?,`status`=?,`email`=?,`stripe_id`=?,`discord_id`=? WHERE",
+				)).WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), 1, "bob@example.com", "", 0, 3).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			want: &model.UserImportSummary{
+				Updated: 1,
+				Failed:  1,
+				Errors: []*model.UserImportError{
+					{Row: 1, Name: "alice", Reason: "Duplicate entry 'alice@example.com' for key 'idx_email'"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := setupMockDB()
+			require.NoError(t, err)
+			tt.expect(mock)
+
+			users := []*model.User{
+				{ObjectMeta: model.ObjectMeta{Status: 1}, Name: "alice", Email: "alice@example.com", Password: "hash"},
+				{ObjectMeta: model.ObjectMeta{Status: 1}, Name: "bob", Email: "bob@example.com", Password: "hash"},
+			}
+
+			summary, err := newUsers(&datastore{db}).Import(context.Background(), users, tt.opts)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, summary)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestExport(t *testing.T) {
+	db, mock, err := setupMockDB()
+	require.NoError(t, err)
+
+	columns := []string{"id", "name", "extendShadow"}
+	page := make([]string, 0, exportPageSize+1)
+	rows := sqlmock.NewRows(columns)
+	for id := exportPageSize + 10; id >= 10; id-- {
+		rows.AddRow(id, fmt.Sprintf("user%d", id), "{}")
+		page = append(page, fmt.Sprintf("user%d", id))
+	}
+
+	// A full page and its extra row, then the rest from the continue token.
+	where := "SELECT * FROM `users` WHERE (status = 1 and deleted_at IS NULL) "
+	mock.ExpectQuery(regexp.QuoteMeta(where + "AND `users`.`deleted_at` IS NULL ORDER BY id desc LIMIT 501")).
+		WillReturnRows(rows)
+	mock.ExpectQuery(regexp.QuoteMeta(where + "AND id < ? AND `users`.`deleted_at` IS NULL ORDER BY id desc LIMIT 501")).
+		WithArgs(11).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(10, "user10", "{}"))
+
+	var exported []string
+	limit := int64(1)
+	err = newUsers(&datastore{db}).Export(context.Background(), model.ListOptions{Limit: &limit}, func(user *model.User) error {
+		exported = append(exported, user.Name)
+
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, page, exported)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,35 @@
+package database
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+)
+
+// continueToken is the position a list stopped at. It is handed to clients as
+// an opaque string, the same way Kubernetes uses ListMeta.Continue.
+type continueToken struct {
+	// LastID is the sort key of the last item of the previous page, lists are ordered by id desc.
+	LastID uint64 `json:"lastId"`
+}
+
+func encodeContinue(token continueToken) string {
+	data, _ := json.Marshal(token)
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeContinue(value string) (continueToken, error) {
+	var token continueToken
+
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return token, fmt.Errorf("invalid continue token")
+	}
+
+	if err := json.Unmarshal(data, &token); err != nil || token.LastID == 0 {
+		return token, fmt.Errorf("invalid continue token")
+	}
+
+	return token, nil
+}
@@ -0,0 +1,168 @@
+package database
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/skeleton1231/gotal/internal/apiserver/store/model"
+	"github.com/skeleton1231/gotal/internal/pkg/code"
+	"github.com/skeleton1231/gotal/internal/pkg/errors"
+	"github.com/skeleton1231/gotal/internal/pkg/options"
+	"gorm.io/gorm"
+)
+
+// errDuplicateTransaction reports that a concurrent request recorded the idempotency key first.
+var errDuplicateTransaction = errors.New("duplicate credit transaction")
+
+// ChangeCredits records the transaction and adds its amount to the cached balance in one
+// database transaction. The conditional update of the balance takes the row lock of the user,
+// so concurrent changes are applied one after the other and none is lost.
+func (u *users) ChangeCredits(ctx context.Context, transaction *model.CreditTransaction) error {
+	ctx, changes := withUserChanges(ctx)
+
+	err := u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return changeCredits(tx, transaction)
+	})
+	if errors.Is(err, errDuplicateTransaction) {
+		// The balance change was rolled back along with the insert.
+		return replayCredits(u.db.WithContext(ctx), transaction)
+	}
+	if err != nil {
+		return err
+	}
+	userWatch.publish(ctx, u.db.WithContext(ctx), changes.changes...)
+
+	return nil
+}
+
+func changeCredits(tx *gorm.DB, transaction *model.CreditTransaction) error {
+	if err := replayCredits(tx, transaction); !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	result := tx.Model(&model.User{ObjectMeta: model.ObjectMeta{ID: transaction.UserID}}).
+		Where("status = 1 and deleted_at IS NULL and total_credits + ? >= 0", transaction.Amount).
+		Update("total_credits", gorm.Expr("total_credits + ?", transaction.Amount))
+	if result.Error != nil {
+		return errors.WithCode(code.ErrDatabase, result.Error.Error())
+	}
+	if result.RowsAffected == 0 {
+		var count int64
+		if err := tx.Model(&model.User{}).Where("id = ? and status = 1 and deleted_at IS NULL", transaction.UserID).
+			Count(&count).Error; err != nil {
+			return errors.WithCode(code.ErrDatabase, err.Error())
+		}
+		if count == 0 {
+			return errors.WithCode(code.ErrUserNotFound, "user %d not found", transaction.UserID)
+		}
+
+		return errors.WithCode(code.ErrInsufficientCredits, "user %d has fewer than %d credits", transaction.UserID, -transaction.Amount)
+	}
+
+	// The transaction reads its own write, the row stays locked until the commit.
+	if err := tx.Model(&model.User{}).Select("total_credits").Where("id = ?", transaction.UserID).
+		Row().Scan(&transaction.Balance); err != nil {
+		return errors.WithCode(code.ErrDatabase, err.Error())
+	}
+
+	transaction.ID = 0
+	transaction.CreatedAt = time.Now()
+	if err := tx.Create(transaction).Error; err != nil {
+		if strings.Contains(err.Error(), "Duplicate entry") {
+			return errDuplicateTransaction
+		}
+
+		return errors.WithCode(code.ErrDatabase, err.Error())
+	}
+
+	return nil
+}
+
+// replayCredits fills the transaction with the one recorded earlier with the same
+// idempotency key. It returns gorm.ErrRecordNotFound when the key is new.
+func replayCredits(db *gorm.DB, transaction *model.CreditTransaction) error {
+	existing := &model.CreditTransaction{}
+	err := db.Where("user_id = ? and idempotency_key = ?", transaction.UserID, transaction.IdempotencyKey).
+		First(existing).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	if err != nil {
+		return errors.WithCode(code.ErrDatabase, err.Error())
+	}
+
+	if existing.Amount != transaction.Amount {
+		return errors.WithCode(code.ErrIdempotencyKeyReused,
+			"idempotency key `%s` was used for an amount of %d", transaction.IdempotencyKey, existing.Amount)
+	}
+
+	*transaction = *existing
+	transaction.Replayed = true
+
+	return nil
+}
+
+// ListTransactions returns the ledger of the user, newest first.
+func (u *users) ListTransactions(ctx context.Context, userId uint64, opts model.ListOptions) (*model.CreditTransactionList, error) {
+	ret := &model.CreditTransactionList{}
+	ol := model.Unpointer(opts.Offset, opts.Limit)
+
+	query := u.db.WithContext(ctx).Model(&model.CreditTransaction{}).Where("user_id = ?", userId).Session(&gorm.Session{})
+
+	if err := query.Offset(ol.Offset).Limit(ol.Limit).Order("id desc").Find(&ret.Items).Error; err != nil {
+		return nil, errors.WithCode(code.ErrDatabase, err.Error())
+	}
+
+	if err := query.Count(&ret.TotalCount).Error; err != nil {
+		return nil, errors.WithCode(code.ErrDatabase, err.Error())
+	}
+
+	return ret, nil
+}
+
+// ReconcileCredits compares the cached balance of every user with the sum of their ledger,
+// and returns the users whose balances differ. With fix, the cached balances of those users
+// are overwritten with the ledger sums.
+func ReconcileCredits(ctx context.Context, opts *options.MySQLOptions, fix bool) ([]*model.CreditDrift, error) {
+	factory, err := GetMySQLFactoryOr(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return reconcileCredits(ctx, factory.(*datastore).db, fix)
+}
+
+func reconcileCredits(ctx context.Context, db *gorm.DB, fix bool) ([]*model.CreditDrift, error) {
+	var drifts []*model.CreditDrift
+	err := db.WithContext(ctx).Raw("SELECT u.id AS user_id, u.total_credits AS cached, COALESCE(SUM(t.amount), 0) AS ledger " +
+		"FROM users u LEFT JOIN credit_transactions t ON t.user_id = u.id " +
+		"GROUP BY u.id, u.total_credits HAVING cached <> ledger ORDER BY u.id").
+		Scan(&drifts).Error
+	if err != nil {
+		return nil, errors.WithCode(code.ErrDatabase, err.Error())
+	}
+
+	if !fix {
+		return drifts, nil
+	}
+
+	for _, drift := range drifts {
+		// The balance is recomputed under the row lock, so changes made since the report
+		// are not overwritten with a stale sum.
+		err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec("SELECT id FROM users WHERE id = ? FOR UPDATE", drift.UserID).Error; err != nil {
+				return err
+			}
+
+			return tx.Exec("UPDATE users SET total_credits = "+
+				"(SELECT COALESCE(SUM(amount), 0) FROM credit_transactions WHERE user_id = ?) WHERE id = ?",
+				drift.UserID, drift.UserID).Error
+		})
+		if err != nil {
+			return drifts, errors.WithCode(code.ErrDatabase, err.Error())
+		}
+	}
+
+	return drifts, nil
+}
@@ -0,0 +1,139 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/skeleton1231/gotal/internal/apiserver/store/model"
+	"github.com/skeleton1231/gotal/internal/pkg/code"
+	"github.com/skeleton1231/gotal/internal/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	selectTransaction = regexp.QuoteMeta("SELECT * FROM `credit_transactions` WHERE user_id = ? and idempotency_key = ?")
+	updateCredits     = regexp.QuoteMeta("UPDATE `users` SET `total_credits`=total_credits + ?")
+	transactionRows   = []string{"id", "user_id", "amount", "balance", "idempotency_key", "reason"}
+)
+
+func TestChangeCredits(t *testing.T) {
+	db, mock, err := setupMockDB()
+	require.NoError(t, err)
+
+	u := newUsers(&datastore{db})
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(selectTransaction).WithArgs(7, "order-1").WillReturnRows(sqlmock.NewRows(transactionRows))
+	mock.ExpectExec(updateCredits).WithArgs(-30, sqlmock.AnyArg(), -30, 7).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("SELECT `total_credits` FROM `users`").WithArgs(7).
+		WillReturnRows(sqlmock.NewRows([]string{"total_credits"}).AddRow(70))
+	mock.ExpectExec("INSERT INTO `credit_transactions`").WithArgs(7, -30, 70, "order-1", "purchase", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(3, 1))
+	mock.ExpectCommit()
+
+	transaction := &model.CreditTransaction{UserID: 7, Amount: -30, IdempotencyKey: "order-1", Reason: "purchase"}
+	require.NoError(t, u.ChangeCredits(context.Background(), transaction))
+	assert.Equal(t, uint64(3), transaction.ID)
+	assert.Equal(t, int64(70), transaction.Balance)
+	assert.False(t, transaction.Replayed)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestChangeCredits_Insufficient(t *testing.T) {
+	db, mock, err := setupMockDB()
+	require.NoError(t, err)
+
+	u := newUsers(&datastore{db})
+	count := regexp.QuoteMeta("SELECT count(*) FROM `users` WHERE (id = ? and status = 1 and deleted_at IS NULL)")
+
+	for _, tt := range []struct {
+		name  string
+		count int
+		code  int
+	}{
+		{name: "insufficient", count: 1, code: code.ErrInsufficientCredits},
+		{name: "no user", count: 0, code: code.ErrUserNotFound},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			mock.ExpectBegin()
+			mock.ExpectQuery(selectTransaction).WithArgs(7, "order-2").WillReturnRows(sqlmock.NewRows(transactionRows))
+			mock.ExpectExec(updateCredits).WithArgs(-500, sqlmock.AnyArg(), -500, 7).WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectQuery(count).WithArgs(7).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(tt.count))
+			mock.ExpectRollback()
+
+			err := u.ChangeCredits(context.Background(), &model.CreditTransaction{UserID: 7, Amount: -500, IdempotencyKey: "order-2"})
+			assert.True(t, errors.IsCode(err, tt.code), err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestChangeCredits_Replay(t *testing.T) {
+	db, mock, err := setupMockDB()
+	require.NoError(t, err)
+
+	u := newUsers(&datastore{db})
+
+	// The key was recorded by an earlier request: nothing changes.
+	mock.ExpectBegin()
+	mock.ExpectQuery(selectTransaction).WithArgs(7, "order-1").
+		WillReturnRows(sqlmock.NewRows(transactionRows).AddRow(3, 7, -30, 70, "order-1", "purchase"))
+	mock.ExpectCommit()
+
+	transaction := &model.CreditTransaction{UserID: 7, Amount: -30, IdempotencyKey: "order-1"}
+	require.NoError(t, u.ChangeCredits(context.Background(), transaction))
+	assert.True(t, transaction.Replayed)
+	assert.Equal(t, uint64(3), transaction.ID)
+	assert.Equal(t, int64(70), transaction.Balance)
+
+	// The same key with another amount is a client bug.
+	mock.ExpectBegin()
+	mock.ExpectQuery(selectTransaction).WithArgs(7, "order-1").
+		WillReturnRows(sqlmock.NewRows(transactionRows).AddRow(3, 7, -30, 70, "order-1", "purchase"))
+	mock.ExpectRollback()
+
+	err = u.ChangeCredits(context.Background(), &model.CreditTransaction{UserID: 7, Amount: -40, IdempotencyKey: "order-1"})
+	assert.True(t, errors.IsCode(err, code.ErrIdempotencyKeyReused), err)
+
+	// A concurrent request recorded the key first: the balance change is rolled back and
+	// the recorded transaction is returned.
+	mock.ExpectBegin()
+	mock.ExpectQuery(selectTransaction).WithArgs(7, "order-3").WillReturnRows(sqlmock.NewRows(transactionRows))
+	mock.ExpectExec(updateCredits).WithArgs(20, sqlmock.AnyArg(), 20, 7).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("SELECT `total_credits` FROM `users`").WithArgs(7).
+		WillReturnRows(sqlmock.NewRows([]string{"total_credits"}).AddRow(110))
+	mock.ExpectExec("INSERT INTO `credit_transactions`").WithArgs(anyArgs(6)...).
+		WillReturnError(fmt.Errorf("Error 1062 (23000): Duplicate entry '7-order-3' for key 'idx_user_idempotency_key'"))
+	mock.ExpectRollback()
+	mock.ExpectQuery(selectTransaction).WithArgs(7, "order-3").
+		WillReturnRows(sqlmock.NewRows(transactionRows).AddRow(4, 7, 20, 90, "order-3", ""))
+
+	transaction = &model.CreditTransaction{UserID: 7, Amount: 20, IdempotencyKey: "order-3"}
+	require.NoError(t, u.ChangeCredits(context.Background(), transaction))
+	assert.True(t, transaction.Replayed)
+	assert.Equal(t, int64(90), transaction.Balance)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReconcileCredits(t *testing.T) {
+	db, mock, err := setupMockDB()
+	require.NoError(t, err)
+
+	mock.ExpectQuery(regexp.QuoteMeta("FROM users u LEFT JOIN credit_transactions t ON t.user_id = u.id")).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "cached", "ledger"}).AddRow(7, 100, 70))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("SELECT id FROM users WHERE id = ? FOR UPDATE")).WithArgs(7).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE users SET total_credits = (SELECT COALESCE(SUM(amount), 0)")).WithArgs(7, 7).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	drifts, err := reconcileCredits(context.Background(), db, true)
+	require.NoError(t, err)
+	assert.Equal(t, []*model.CreditDrift{{UserID: 7, Cached: 100, Ledger: 70}}, drifts)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
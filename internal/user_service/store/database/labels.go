@@ -0,0 +1,123 @@
+package database
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Operators of a label selector requirement, as in Kubernetes label selectors.
+const (
+	labelEquals       = "="
+	labelDoubleEquals = "=="
+	labelNotEquals    = "!="
+	labelIn           = "in"
+	labelNotIn        = "notin"
+	labelExists       = "exists"
+	labelDoesNotExist = "!"
+)
+
+var (
+	// labelKeyRegexp accepts an optional DNS-like prefix followed by a name, e.g. `example.com/tier`.
+	labelKeyRegexp   = regexp.MustCompile(`^([a-zA-Z0-9]([-a-zA-Z0-9_.]*[a-zA-Z0-9])?/)?[a-zA-Z0-9]([-a-zA-Z0-9_.]*[a-zA-Z0-9])?$`)
+	labelValueRegexp = regexp.MustCompile(`^([a-zA-Z0-9]([-a-zA-Z0-9_.]*[a-zA-Z0-9])?)?$`)
+	labelSetRegexp   = regexp.MustCompile(`^(\S+)\s+(in|notin)\s*\((.*)\)$`)
+)
+
+// labelRequirement is a single requirement of a label selector.
+type labelRequirement struct {
+	key      string
+	operator string
+	values   []string
+}
+
+// parseLabelSelector parses a Kubernetes-style label selector such as
+// `tier=gold,env in (prod,staging),!banned,beta`.
+func parseLabelSelector(selector string) ([]labelRequirement, error) {
+	var requirements []labelRequirement
+
+	for _, term := range splitLabelSelector(selector) {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+
+		req, err := parseLabelRequirement(term)
+		if err != nil {
+			return nil, err
+		}
+		requirements = append(requirements, req)
+	}
+
+	return requirements, nil
+}
+
+// splitLabelSelector splits the selector on the commas which are not inside a value set.
+func splitLabelSelector(selector string) []string {
+	var (
+		terms []string
+		depth int
+		start int
+	)
+
+	for i, r := range selector {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				terms = append(terms, selector[start:i])
+				start = i + 1
+			}
+		}
+	}
+
+	return append(terms, selector[start:])
+}
+
+func parseLabelRequirement(term string) (labelRequirement, error) {
+	if strings.HasPrefix(term, labelDoesNotExist) && !strings.Contains(term, "=") {
+		return newLabelRequirement(strings.TrimSpace(term[1:]), labelDoesNotExist)
+	}
+
+	if m := labelSetRegexp.FindStringSubmatch(term); m != nil {
+		values := strings.Split(m[3], ",")
+		for i := range values {
+			values[i] = strings.TrimSpace(values[i])
+		}
+
+		return newLabelRequirement(m[1], m[2], values...)
+	}
+
+	for _, op := range []string{labelNotEquals, labelDoubleEquals, labelEquals} {
+		if i := strings.Index(term, op); i > 0 {
+			return newLabelRequirement(strings.TrimSpace(term[:i]), op, strings.TrimSpace(term[i+len(op):]))
+		}
+	}
+
+	if !labelKeyRegexp.MatchString(term) {
+		return labelRequirement{}, fmt.Errorf("unknown operator in label selector requirement `%s`", term)
+	}
+
+	return newLabelRequirement(term, labelExists)
+}
+
+func newLabelRequirement(key, operator string, values ...string) (labelRequirement, error) {
+	if !labelKeyRegexp.MatchString(key) {
+		return labelRequirement{}, fmt.Errorf("invalid label key `%s`", key)
+	}
+
+	for _, v := range values {
+		if !labelValueRegexp.MatchString(v) {
+			return labelRequirement{}, fmt.Errorf("invalid value `%s` for label key `%s`", v, key)
+		}
+	}
+
+	if (operator == labelIn || operator == labelNotIn) && len(values) == 0 {
+		return labelRequirement{}, fmt.Errorf("label key `%s` requires at least one value for `%s`", key, operator)
+	}
+
+	return labelRequirement{key: key, operator: operator, values: values}, nil
+}
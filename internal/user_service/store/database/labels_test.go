@@ -0,0 +1,54 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLabelSelector(t *testing.T) {
+	tests := []struct {
+		selector string
+		want     []labelRequirement
+	}{
+		{selector: "", want: nil},
+		{selector: "tier=gold", want: []labelRequirement{{key: "tier", operator: labelEquals, values: []string{"gold"}}}},
+		{selector: "tier==gold", want: []labelRequirement{{key: "tier", operator: labelDoubleEquals, values: []string{"gold"}}}},
+		{selector: "tier != gold", want: []labelRequirement{{key: "tier", operator: labelNotEquals, values: []string{"gold"}}}},
+		{
+			selector: "env in (prod, staging),example.com/team notin (ops)",
+			want: []labelRequirement{
+				{key: "env", operator: labelIn, values: []string{"prod", "staging"}},
+				{key: "example.com/team", operator: labelNotIn, values: []string{"ops"}},
+			},
+		},
+		{
+			selector: "beta,!banned",
+			want: []labelRequirement{
+				{key: "beta", operator: labelExists},
+				{key: "banned", operator: labelDoesNotExist},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := parseLabelSelector(tt.selector)
+		assert.NoError(t, err, tt.selector)
+		assert.Equal(t, tt.want, got, tt.selector)
+	}
+}
+
+func TestParseLabelSelector_Invalid(t *testing.T) {
+	for _, selector := range []string{
+		"tier>1",
+		"tier<1",
+		"tier~=gold",
+		"env in prod",
+		"!tier=gold",
+		`tier="gold"`,
+		"ti'er=gold",
+	} {
+		_, err := parseLabelSelector(selector)
+		assert.Error(t, err, selector)
+	}
+}
@@ -0,0 +1,67 @@
+package database
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+
+	"github.com/skeleton1231/gotal/internal/pkg/errors"
+	"github.com/skeleton1231/gotal/internal/pkg/options"
+	"github.com/skeleton1231/gotal/pkg/migrate"
+)
+
+// migrationFiles holds the schema of the tables of the service, in versioned up and down files.
+//
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// Migrations returns the migrations of the schema of the service.
+func Migrations() ([]*migrate.Migration, error) {
+	files, err := fs.Sub(migrationFiles, "migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	return migrate.Load(files)
+}
+
+// NewMigrator returns the migrator of the schema of the service.
+func NewMigrator(opts *options.MySQLOptions) (*migrate.Migrator, error) {
+	factory, err := GetMySQLFactoryOr(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	sqlDB, err := factory.(*datastore).db.DB()
+	if err != nil {
+		return nil, errors.Wrap(err, "get sql db instance failed")
+	}
+
+	migrations, err := Migrations()
+	if err != nil {
+		return nil, err
+	}
+
+	return migrate.New(sqlDB, migrations), nil
+}
+
+// CheckSchema returns an error when migrations of the schema are pending, the service does
+// not run against tables it does not know.
+func CheckSchema(ctx context.Context, opts *options.MySQLOptions) error {
+	migrator, err := NewMigrator(opts)
+	if err != nil {
+		return err
+	}
+
+	pending, err := migrator.Pending(ctx)
+	if err != nil {
+		return errors.Wrap(err, "read the schema version failed")
+	}
+	if len(pending) > 0 {
+		return fmt.Errorf("the database schema is behind, %d migrations are pending from version %d: run `migrate up` first",
+			len(pending), pending[0].Version)
+	}
+
+	return nil
+}
@@ -0,0 +1,33 @@
+package database
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/skeleton1231/gotal/internal/apiserver/store/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrations(t *testing.T) {
+	migrations, err := Migrations()
+	require.NoError(t, err)
+
+	var up strings.Builder
+	for i, m := range migrations {
+		assert.Equal(t, uint64(i+1), m.Version, "versions follow each other")
+		assert.NotEmpty(t, strings.TrimSpace(m.Down), "migration %d_%s can be reverted", m.Version, m.Name)
+		up.WriteString(m.Up)
+	}
+
+	// Every table of the service is created by a migration.
+	for _, table := range []interface{ TableName() string }{
+		model.User{}, model.Role{}, model.UserRole{}, model.APIKey{}, model.Session{}, model.RetiredRefreshToken{},
+		model.TwoFactor{}, model.RecoveryCode{}, model.CreditTransaction{}, model.TrialTransition{},
+	} {
+		assert.Contains(t, up.String(), "CREATE TABLE IF NOT EXISTS `"+table.TableName()+"`")
+	}
+	for _, index := range []string{"idx_name", "idx_trial_ends_at", "idx_user_idempotency_key", "idx_user_kind_trial"} {
+		assert.Contains(t, up.String(), "`"+index+"`")
+	}
+}
@@ -0,0 +1,27 @@
+-- The tables created before migrations existed are only created when missing, so databases
+-- set up by hand adopt the migrations without changes.
+CREATE TABLE IF NOT EXISTS `users` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `extendShadow` longtext,
+  `created_at` datetime(3) DEFAULT NULL,
+  `updated_at` datetime(3) DEFAULT NULL,
+  `deleted_at` datetime(3) DEFAULT NULL,
+  `status` bigint NOT NULL DEFAULT 0,
+  `name` varchar(255) NOT NULL,
+  `email` varchar(255) NOT NULL DEFAULT '',
+  `email_verified_at` datetime(3) DEFAULT NULL,
+  `password` varchar(255) NOT NULL DEFAULT '',
+  `remember_token` varchar(100) NOT NULL DEFAULT '',
+  `stripe_id` varchar(255) NOT NULL DEFAULT '',
+  `discord_id` bigint unsigned NOT NULL DEFAULT 0,
+  `pm_type` varchar(255) NOT NULL DEFAULT '',
+  `pm_last_four` varchar(4) NOT NULL DEFAULT '',
+  `trial_ends_at` datetime(3) DEFAULT NULL,
+  `total_credits` bigint NOT NULL DEFAULT 0,
+  PRIMARY KEY (`id`),
+  -- userService.Create reports duplicates of this index as ErrUserAlreadyExist.
+  UNIQUE KEY `idx_name` (`name`),
+  KEY `idx_email` (`email`),
+  KEY `idx_stripe_id` (`stripe_id`),
+  KEY `idx_deleted_at` (`deleted_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
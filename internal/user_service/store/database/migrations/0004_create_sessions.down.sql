@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS `retired_refresh_tokens`;
+DROP TABLE IF EXISTS `sessions`;
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS `two_factors` (
+  `user_id` bigint unsigned NOT NULL,
+  `secret` varchar(64) NOT NULL,
+  `enabled` tinyint(1) NOT NULL DEFAULT 0,
+  `last_step` bigint NOT NULL DEFAULT 0,
+  `created_at` datetime(3) DEFAULT NULL,
+  `enabled_at` datetime(3) DEFAULT NULL,
+  PRIMARY KEY (`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `recovery_codes` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `user_id` bigint unsigned NOT NULL,
+  `hash` varchar(128) NOT NULL,
+  `created_at` datetime(3) DEFAULT NULL,
+  `used_at` datetime(3) DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `idx_user_id` (`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
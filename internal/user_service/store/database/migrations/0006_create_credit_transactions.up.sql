@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS `credit_transactions` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `user_id` bigint unsigned NOT NULL,
+  `amount` bigint NOT NULL,
+  `balance` bigint NOT NULL,
+  `idempotency_key` varchar(64) NOT NULL,
+  `reason` varchar(255) NOT NULL DEFAULT '',
+  `created_at` datetime(3) DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_user_idempotency_key` (`user_id`, `idempotency_key`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
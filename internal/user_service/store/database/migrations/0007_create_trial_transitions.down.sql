@@ -0,0 +1,2 @@
+ALTER TABLE `users` DROP INDEX `idx_trial_ends_at`;
+DROP TABLE IF EXISTS `trial_transitions`;
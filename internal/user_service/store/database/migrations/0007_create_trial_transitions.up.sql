@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS `trial_transitions` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `user_id` bigint unsigned NOT NULL,
+  `kind` varchar(16) NOT NULL,
+  `trial_ends_at` datetime(3) NOT NULL,
+  `created_at` datetime(3) DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_user_kind_trial` (`user_id`, `kind`, `trial_ends_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- The trial processor scans the users by the end of their trial. Users tables created from
+-- model.User before the migrations already have the index.
+SET @add_idx_trial_ends_at = IF(
+  (SELECT COUNT(*) FROM information_schema.statistics
+    WHERE table_schema = DATABASE() AND table_name = 'users' AND index_name = 'idx_trial_ends_at') = 0,
+  'ALTER TABLE `users` ADD INDEX `idx_trial_ends_at` (`trial_ends_at`)',
+  'DO 0');
+PREPARE add_idx_trial_ends_at FROM @add_idx_trial_ends_at;
+EXECUTE add_idx_trial_ends_at;
+DEALLOCATE PREPARE add_idx_trial_ends_at;
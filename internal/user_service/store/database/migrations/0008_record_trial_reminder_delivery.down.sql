@@ -0,0 +1,3 @@
+ALTER TABLE `trial_transitions`
+  DROP COLUMN `notified_at`,
+  DROP COLUMN `claimed_until`;
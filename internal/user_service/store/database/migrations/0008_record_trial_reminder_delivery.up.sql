@@ -0,0 +1,8 @@
+-- Reminders are claimed for a while and sent outside of the transaction recording them,
+-- their delivery is recorded once sent.
+ALTER TABLE `trial_transitions`
+  ADD COLUMN `claimed_until` datetime(3) DEFAULT NULL,
+  ADD COLUMN `notified_at` datetime(3) DEFAULT NULL;
+
+-- Reminders recorded so far were sent in the same transaction.
+UPDATE `trial_transitions` SET `notified_at` = `created_at` WHERE `kind` = 'reminded';
@@ -0,0 +1,2 @@
+-- idx_name belongs to the users table of 0001_create_users, it is left in place.
+DO 0;
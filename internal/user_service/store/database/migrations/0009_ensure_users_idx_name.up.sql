@@ -0,0 +1,13 @@
+-- 0001_create_users leaves the users tables of databases set up by hand as they are, which
+-- may lack idx_name. userService.Create relies on it to report duplicate names, so it is
+-- added when missing. Adding it fails, and so does the migration, when names are duplicated
+-- or another index is named idx_name; those have to be fixed by hand.
+SET @add_idx_name = IF(
+  (SELECT COUNT(*) FROM information_schema.statistics
+    WHERE table_schema = DATABASE() AND table_name = 'users' AND index_name = 'idx_name'
+      AND column_name = 'name' AND non_unique = 0) = 0,
+  'ALTER TABLE `users` ADD UNIQUE INDEX `idx_name` (`name`)',
+  'DO 0');
+PREPARE add_idx_name FROM @add_idx_name;
+EXECUTE add_idx_name;
+DEALLOCATE PREPARE add_idx_name;
@@ -0,0 +1,3 @@
+ALTER TABLE `users`
+  DROP INDEX `idx_discord_id`,
+  DROP COLUMN `discord_link`;
@@ -0,0 +1,6 @@
+-- A Discord account logs in as the user it is linked to, so it is linked to one user at most.
+-- Users without one have a zero discord_id, which the index leaves out as NULL. Adding the
+-- index fails when an account is linked to several users; those have to be fixed by hand.
+ALTER TABLE `users`
+  ADD COLUMN `discord_link` bigint unsigned GENERATED ALWAYS AS (NULLIF(`discord_id`, 0)) STORED,
+  ADD UNIQUE INDEX `idx_discord_id` (`discord_link`);
@@ -23,6 +23,31 @@ func (ds *datastore) Users() store.UserStore {
 	return newUsers(ds)
 }
 
+// Roles implements store.Factory.
+func (ds *datastore) Roles() store.RoleStore {
+	return newRoles(ds)
+}
+
+// APIKeys implements store.Factory.
+func (ds *datastore) APIKeys() store.APIKeyStore {
+	return newAPIKeys(ds)
+}
+
+// Sessions implements store.Factory.
+func (ds *datastore) Sessions() store.SessionStore {
+	return newSessions(ds)
+}
+
+// TwoFactors implements store.Factory.
+func (ds *datastore) TwoFactors() store.TwoFactorStore {
+	return newTwoFactors(ds)
+}
+
+// Trials implements store.Factory.
+func (ds *datastore) Trials() store.TrialStore {
+	return newTrials(ds)
+}
+
 func (ds *datastore) Close() error {
 	db, err := ds.db.DB()
 	if err != nil {
@@ -31,6 +56,18 @@ func (ds *datastore) Close() error {
 	return db.Close()
 }
 
+// dryRun runs fn in a transaction which is always rolled back. Constraints, uniqueness
+// checks and GORM hooks take effect as usual, but nothing is persisted.
+func dryRun(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+	tx := db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+	defer tx.Rollback()
+
+	return fn(tx)
+}
+
 var (
 	mysqlFactory store.Factory
 	once         sync.Once
@@ -56,6 +93,13 @@ func GetMySQLFactoryOr(opts *options.MySQLOptions) (store.Factory, error) {
 			Logger:                logger.New(opts.LogLevel),
 		}
 		dbIns, err = db.New(options)
+		if err != nil {
+			return
+		}
+
+		if err = registerUserWatchCallbacks(dbIns); err != nil {
+			return
+		}
 		mysqlFactory = &datastore{dbIns}
 	})
 
@@ -0,0 +1,158 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/skeleton1231/gotal/internal/apiserver/store/model"
+	"github.com/skeleton1231/gotal/internal/pkg/code"
+	"github.com/skeleton1231/gotal/internal/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"k8s.io/apimachinery/pkg/fields"
+)
+
+type roles struct {
+	db *gorm.DB
+}
+
+func newRoles(ds *datastore) *roles {
+	return &roles{ds.db}
+}
+
+// Create creates a new role.
+func (r *roles) Create(ctx context.Context, role *model.Role, opts model.CreateOptions) error {
+	return r.db.WithContext(ctx).Create(role).Error
+}
+
+// Get return a role by the role identifier.
+func (r *roles) Get(ctx context.Context, roleId uint64, opts model.GetOptions) (*model.Role, error) {
+	role := &model.Role{}
+	err := r.db.WithContext(ctx).Where("id = ?", roleId).First(role).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.WithCode(code.ErrRoleNotFound, err.Error())
+		}
+
+		return nil, errors.WithCode(code.ErrDatabase, err.Error())
+	}
+
+	return role, nil
+}
+
+// List return all roles.
+func (r *roles) List(ctx context.Context, opts model.ListOptions) (*model.RoleList, error) {
+	ret := &model.RoleList{}
+	ol := model.Unpointer(opts.Offset, opts.Limit)
+
+	query, err := roleApplyFieldSelectors(r.db.WithContext(ctx), opts.FieldSelector)
+	if err != nil {
+		return nil, errors.WithCode(code.ErrValidation, err.Error())
+	}
+
+	d := query.
+		Offset(ol.Offset).
+		Limit(ol.Limit).
+		Order("id desc").
+		Find(&ret.Items).
+		Offset(-1).
+		Limit(-1).
+		Count(&ret.TotalCount)
+	if d.Error != nil {
+		return nil, errors.WithCode(code.ErrDatabase, d.Error.Error())
+	}
+
+	return ret, nil
+}
+
+// Delete deletes the role and all of its grants.
+func (r *roles) Delete(ctx context.Context, roleId uint64, opts model.DeleteOptions) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("role_id = ?", roleId).Delete(&model.UserRole{}).Error; err != nil {
+			return errors.WithCode(code.ErrDatabase, err.Error())
+		}
+
+		if opts.Unscoped {
+			tx = tx.Unscoped()
+		}
+		if err := tx.Where("id = ?", roleId).Delete(&model.Role{}).Error; err != nil {
+			return errors.WithCode(code.ErrDatabase, err.Error())
+		}
+
+		return nil
+	})
+}
+
+// Grant grants the role to the user. Granting a role twice is not an error.
+func (r *roles) Grant(ctx context.Context, userId uint64, roleId uint64) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&model.User{}).Where("id = ?", userId).Count(&count).Error; err != nil {
+			return errors.WithCode(code.ErrDatabase, err.Error())
+		}
+		if count == 0 {
+			return errors.WithCode(code.ErrUserNotFound, "user %d not found", userId)
+		}
+
+		if err := tx.Model(&model.Role{}).Where("id = ?", roleId).Count(&count).Error; err != nil {
+			return errors.WithCode(code.ErrDatabase, err.Error())
+		}
+		if count == 0 {
+			return errors.WithCode(code.ErrRoleNotFound, "role %d not found", roleId)
+		}
+
+		err := tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&model.UserRole{UserID: userId, RoleID: roleId}).Error
+		if err != nil {
+			return errors.WithCode(code.ErrDatabase, err.Error())
+		}
+
+		return nil
+	})
+}
+
+// Revoke revokes the role from the user. Revoking a role which is not granted is not an error.
+func (r *roles) Revoke(ctx context.Context, userId uint64, roleId uint64) error {
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? and role_id = ?", userId, roleId).
+		Delete(&model.UserRole{}).Error
+	if err != nil {
+		return errors.WithCode(code.ErrDatabase, err.Error())
+	}
+
+	return nil
+}
+
+// ListUserRoles return the roles granted to the user.
+func (r *roles) ListUserRoles(ctx context.Context, userId uint64) (*model.RoleList, error) {
+	ret := &model.RoleList{}
+	err := r.db.WithContext(ctx).
+		Joins("JOIN user_roles ON user_roles.role_id = roles.id").
+		Where("user_roles.user_id = ?", userId).
+		Order("roles.name").
+		Find(&ret.Items).Error
+	if err != nil {
+		return nil, errors.WithCode(code.ErrDatabase, err.Error())
+	}
+	ret.TotalCount = int64(len(ret.Items))
+
+	return ret, nil
+}
+
+func roleApplyFieldSelectors(query *gorm.DB, fieldSelector string) (*gorm.DB, error) {
+	selector, err := fields.ParseSelector(fieldSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, req := range selector.Requirements() {
+		switch req.Field {
+		case "name":
+			query = query.Where("name like ?", fmt.Sprintf("%%%s%%", req.Value))
+		case "status":
+			query = query.Where("status = ?", req.Value)
+		}
+	}
+
+	return query, nil
+}
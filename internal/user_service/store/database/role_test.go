@@ -0,0 +1,71 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/skeleton1231/gotal/internal/pkg/code"
+	"github.com/skeleton1231/gotal/internal/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGrantRole(t *testing.T) {
+	db, mock, err := setupMockDB()
+	assert.NoError(t, err)
+
+	r := newRoles(&datastore{db})
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT count\\(\\*\\) FROM `users`").
+		WithArgs(uint64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery("SELECT count\\(\\*\\) FROM `roles`").
+		WithArgs(uint64(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectExec("INSERT INTO `user_roles`.*ON DUPLICATE KEY UPDATE").
+		WithArgs(uint64(1), uint64(2), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	assert.NoError(t, r.Grant(context.Background(), 1, 2))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGrantRoleUnknownRole(t *testing.T) {
+	db, mock, err := setupMockDB()
+	assert.NoError(t, err)
+
+	r := newRoles(&datastore{db})
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT count\\(\\*\\) FROM `users`").
+		WithArgs(uint64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery("SELECT count\\(\\*\\) FROM `roles`").
+		WithArgs(uint64(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectRollback()
+
+	err = r.Grant(context.Background(), 1, 2)
+	assert.True(t, errors.IsCode(err, code.ErrRoleNotFound), err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListUserRoles(t *testing.T) {
+	db, mock, err := setupMockDB()
+	assert.NoError(t, err)
+
+	r := newRoles(&datastore{db})
+
+	mock.ExpectQuery("SELECT `roles`.`id`.* FROM `roles` JOIN user_roles ON user_roles.role_id = roles.id WHERE user_roles.user_id = \\?").
+		WithArgs(uint64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "extendShadow"}).
+			AddRow(1, "admin", "{}").
+			AddRow(2, "support", "{}"))
+
+	roles, err := r.ListUserRoles(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"admin", "support"}, roles.RoleNames())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
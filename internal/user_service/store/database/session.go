@@ -0,0 +1,183 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/skeleton1231/gotal/internal/apiserver/store/model"
+	"github.com/skeleton1231/gotal/internal/pkg/code"
+	"github.com/skeleton1231/gotal/internal/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type sessions struct {
+	db *gorm.DB
+}
+
+func newSessions(ds *datastore) *sessions {
+	return &sessions{ds.db}
+}
+
+// Create creates a new session.
+func (s *sessions) Create(ctx context.Context, session *model.Session) error {
+	if err := s.db.WithContext(ctx).Create(session).Error; err != nil {
+		return errors.WithCode(code.ErrDatabase, err.Error())
+	}
+
+	return nil
+}
+
+// Rotate replaces the refresh token of the session owning refreshHash and retires the old one.
+// When refreshHash is a retired token the session is revoked and returned with RevokedAt set.
+func (s *sessions) Rotate(ctx context.Context, refreshHash, newRefreshHash string, expiresAt time.Time) (*model.Session, error) {
+	session := &model.Session{}
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("refresh_hash = ?", refreshHash).First(session).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return revokeReusedSession(tx, refreshHash, session, now)
+		}
+		if err != nil {
+			return errors.WithCode(code.ErrDatabase, err.Error())
+		}
+
+		if !session.Active(now) {
+			return errors.WithCode(code.ErrTokenInvalid, "session %d is revoked or expired", session.ID)
+		}
+
+		retired := &model.RetiredRefreshToken{Hash: refreshHash, SessionID: session.ID, CreatedAt: now}
+		if err := tx.Create(retired).Error; err != nil {
+			return errors.WithCode(code.ErrDatabase, err.Error())
+		}
+
+		session.RefreshHash = newRefreshHash
+		session.LastUsedAt = now
+		session.ExpiresAt = expiresAt
+		err = tx.Model(session).Updates(map[string]interface{}{
+			"refresh_hash": newRefreshHash,
+			"last_used_at": now,
+			"expires_at":   expiresAt,
+		}).Error
+		if err != nil {
+			return errors.WithCode(code.ErrDatabase, err.Error())
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+// revokeReusedSession revokes the session which issued the retired refreshHash.
+func revokeReusedSession(tx *gorm.DB, refreshHash string, session *model.Session, now time.Time) error {
+	retired := &model.RetiredRefreshToken{}
+	if err := tx.Where("hash = ?", refreshHash).First(retired).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.WithCode(code.ErrTokenInvalid, "unknown refresh token")
+		}
+
+		return errors.WithCode(code.ErrDatabase, err.Error())
+	}
+
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", retired.SessionID).First(session).Error
+	if err != nil {
+		return errors.WithCode(code.ErrDatabase, err.Error())
+	}
+
+	if session.RevokedAt == nil {
+		session.RevokedAt = &now
+		if err := tx.Model(session).Update("revoked_at", now).Error; err != nil {
+			return errors.WithCode(code.ErrDatabase, err.Error())
+		}
+	}
+
+	return nil
+}
+
+// List return the active sessions of the user.
+func (s *sessions) List(ctx context.Context, userId uint64) (*model.SessionList, error) {
+	ret := &model.SessionList{}
+
+	err := s.db.WithContext(ctx).
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userId, time.Now()).
+		Order("last_used_at desc").
+		Find(&ret.Items).Error
+	if err != nil {
+		return nil, errors.WithCode(code.ErrDatabase, err.Error())
+	}
+	ret.TotalCount = int64(len(ret.Items))
+
+	return ret, nil
+}
+
+// Revoke revokes the session of the user and returns it.
+func (s *sessions) Revoke(ctx context.Context, userId uint64, id uint64) (*model.Session, error) {
+	session := &model.Session{}
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("id = ? AND user_id = ?", id, userId).First(session).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.WithCode(code.ErrSessionNotFound, err.Error())
+			}
+
+			return errors.WithCode(code.ErrDatabase, err.Error())
+		}
+
+		if session.RevokedAt != nil {
+			return nil
+		}
+
+		now := time.Now()
+		session.RevokedAt = &now
+		if err := tx.Model(session).Update("revoked_at", now).Error; err != nil {
+			return errors.WithCode(code.ErrDatabase, err.Error())
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+// RevokeAll revokes every active session of the user and returns them.
+func (s *sessions) RevokeAll(ctx context.Context, userId uint64) (*model.SessionList, error) {
+	ret := &model.SessionList{}
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("user_id = ? AND revoked_at IS NULL", userId).
+			Find(&ret.Items).Error
+		if err != nil {
+			return errors.WithCode(code.ErrDatabase, err.Error())
+		}
+
+		if len(ret.Items) == 0 {
+			return nil
+		}
+
+		ids := make([]uint64, 0, len(ret.Items))
+		for _, session := range ret.Items {
+			session.RevokedAt = &now
+			ids = append(ids, session.ID)
+		}
+
+		if err := tx.Model(&model.Session{}).Where("id IN ?", ids).Update("revoked_at", now).Error; err != nil {
+			return errors.WithCode(code.ErrDatabase, err.Error())
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	ret.TotalCount = int64(len(ret.Items))
+
+	return ret, nil
+}
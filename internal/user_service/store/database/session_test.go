@@ -0,0 +1,90 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/skeleton1231/gotal/internal/pkg/code"
+	"github.com/skeleton1231/gotal/internal/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+var sessionColumns = []string{"id", "user_id", "refresh_hash", "expires_at", "revoked_at"}
+
+func TestRotateSession(t *testing.T) {
+	db, mock, err := setupMockDB()
+	assert.NoError(t, err)
+
+	s := newSessions(&datastore{db})
+	expiresAt := time.Now().Add(time.Hour)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT \\* FROM `sessions` WHERE refresh_hash = \\? .* FOR UPDATE").
+		WithArgs("old").
+		WillReturnRows(sqlmock.NewRows(sessionColumns).AddRow(7, 1, "old", expiresAt, nil))
+	mock.ExpectExec("INSERT INTO `retired_refresh_tokens`").
+		WithArgs("old", uint64(7), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE `sessions` SET").
+		WithArgs(expiresAt, sqlmock.AnyArg(), "new", uint64(7)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	session, err := s.Rotate(context.Background(), "old", "new", expiresAt)
+	assert.NoError(t, err)
+	assert.Equal(t, "new", session.RefreshHash)
+	assert.Nil(t, session.RevokedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRotateSessionReusedToken(t *testing.T) {
+	db, mock, err := setupMockDB()
+	assert.NoError(t, err)
+
+	s := newSessions(&datastore{db})
+	expiresAt := time.Now().Add(time.Hour)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT \\* FROM `sessions` WHERE refresh_hash = \\?").
+		WithArgs("old").
+		WillReturnRows(sqlmock.NewRows(sessionColumns))
+	mock.ExpectQuery("SELECT \\* FROM `retired_refresh_tokens` WHERE hash = \\?").
+		WithArgs("old").
+		WillReturnRows(sqlmock.NewRows([]string{"hash", "session_id"}).AddRow("old", 7))
+	mock.ExpectQuery("SELECT \\* FROM `sessions` WHERE id = \\?").
+		WithArgs(uint64(7)).
+		WillReturnRows(sqlmock.NewRows(sessionColumns).AddRow(7, 1, "current", expiresAt, nil))
+	mock.ExpectExec("UPDATE `sessions` SET `revoked_at`=\\?").
+		WithArgs(sqlmock.AnyArg(), uint64(7)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	// The revocation is committed, the caller sees the revoked session.
+	session, err := s.Rotate(context.Background(), "old", "new", expiresAt)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(7), session.ID)
+	assert.NotNil(t, session.RevokedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRotateSessionUnknownToken(t *testing.T) {
+	db, mock, err := setupMockDB()
+	assert.NoError(t, err)
+
+	s := newSessions(&datastore{db})
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT \\* FROM `sessions` WHERE refresh_hash = \\?").
+		WithArgs("forged").
+		WillReturnRows(sqlmock.NewRows(sessionColumns))
+	mock.ExpectQuery("SELECT \\* FROM `retired_refresh_tokens` WHERE hash = \\?").
+		WithArgs("forged").
+		WillReturnRows(sqlmock.NewRows([]string{"hash", "session_id"}))
+	mock.ExpectRollback()
+
+	_, err = s.Rotate(context.Background(), "forged", "new", time.Now().Add(time.Hour))
+	assert.True(t, errors.IsCode(err, code.ErrTokenInvalid), err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
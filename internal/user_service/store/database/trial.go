@@ -0,0 +1,187 @@
+package database
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/skeleton1231/gotal/internal/apiserver/store/model"
+	"github.com/skeleton1231/gotal/internal/pkg/code"
+	"github.com/skeleton1231/gotal/internal/pkg/errors"
+	"github.com/skeleton1231/gotal/pkg/log"
+	"gorm.io/gorm"
+)
+
+// errTransitionRecorded reports that the transition was recorded by another replica first.
+var errTransitionRecorded = errors.New("trial transition already recorded")
+
+// reminderLease is how long a replica holds a reminder it is sending. A reminder still not
+// delivered by then, because its replica died, is claimed and sent again.
+const reminderLease = 10 * time.Minute
+
+type trials struct {
+	db *gorm.DB
+}
+
+func newTrials(ds *datastore) *trials {
+	return &trials{ds.db}
+}
+
+// notTransitioned filters out the users whose current trial already went through the transition.
+const notTransitioned = "NOT EXISTS (SELECT 1 FROM trial_transitions t " +
+	"WHERE t.user_id = users.id AND t.kind = ? AND t.trial_ends_at = users.trial_ends_at)"
+
+// notReminded filters out the users whose current trial has a reminder delivered, or held by a replica.
+const notReminded = "NOT EXISTS (SELECT 1 FROM trial_transitions t " +
+	"WHERE t.user_id = users.id AND t.kind = ? AND t.trial_ends_at = users.trial_ends_at " +
+	"AND (t.notified_at IS NOT NULL OR t.claimed_until > ?))"
+
+// Ending returns the active users whose trial ends in (from, to], in the order they end.
+// The range is served by idx_trial_ends_at, the transitions by idx_user_kind_trial.
+func (t *trials) Ending(ctx context.Context, from, to time.Time, limit int) ([]*model.User, error) {
+	var users []*model.User
+	err := t.db.WithContext(ctx).
+		Where("status = 1 and trial_ends_at > ? and trial_ends_at <= ?", from, to).
+		Where(notReminded, model.TrialReminded, time.Now()).
+		Order("trial_ends_at, id").Limit(limit).Find(&users).Error
+	if err != nil {
+		return nil, errors.WithCode(code.ErrDatabase, err.Error())
+	}
+
+	return users, nil
+}
+
+// Expired returns the active users whose trial ended by now and who have no payment method.
+// Users without a trial have a zero end, before the epoch, and are left out.
+func (t *trials) Expired(ctx context.Context, now time.Time, limit int) ([]*model.User, error) {
+	var users []*model.User
+	err := t.db.WithContext(ctx).
+		Where("status = 1 and pm_type = '' and trial_ends_at > ? and trial_ends_at <= ?", time.Unix(0, 0), now).
+		Where(notTransitioned, model.TrialExpired).
+		Order("trial_ends_at, id").Limit(limit).Find(&users).Error
+	if err != nil {
+		return nil, errors.WithCode(code.ErrDatabase, err.Error())
+	}
+
+	return users, nil
+}
+
+// Remind claims the reminder and notifies once the claim is committed, so that no transaction
+// waits on the notification. The delivery is recorded afterwards. A failed reminder is released
+// right away, one whose replica died is claimed again when its lease ends.
+func (t *trials) Remind(ctx context.Context, user *model.User, notify func(ctx context.Context) error) (bool, error) {
+	ok, err := transitioned(t.claimReminder(ctx, user))
+	if !ok {
+		return false, err
+	}
+
+	if err := notify(ctx); err != nil {
+		if release := t.reminder(ctx, user).Update("claimed_until", nil).Error; release != nil {
+			log.Errorf("release the trial reminder of user %d failed: %s", user.ID, release.Error())
+		}
+
+		return false, err
+	}
+
+	if err := t.reminder(ctx, user).Update("notified_at", time.Now()).Error; err != nil {
+		return false, errors.WithCode(code.ErrDatabase, err.Error())
+	}
+
+	return true, nil
+}
+
+// reminder selects the reminder of the current trial of the user.
+func (t *trials) reminder(ctx context.Context, user *model.User) *gorm.DB {
+	return t.db.WithContext(ctx).Model(&model.TrialTransition{}).
+		Where("user_id = ? and kind = ? and trial_ends_at = ?", user.ID, model.TrialReminded, user.TrialEndsAt)
+}
+
+// claimReminder records the reminder held by this replica for the lease. A reminder recorded
+// before is taken over when it was neither delivered nor is held anymore.
+func (t *trials) claimReminder(ctx context.Context, user *model.User) error {
+	now := time.Now()
+	until := now.Add(reminderLease)
+
+	transition := newTransition(user, model.TrialReminded, now)
+	transition.ClaimedUntil = &until
+	err := recordTransition(t.db.WithContext(ctx), transition)
+	if !errors.Is(err, errTransitionRecorded) {
+		return err
+	}
+
+	result := t.reminder(ctx, user).
+		Where("notified_at is null and (claimed_until is null or claimed_until <= ?)", now).
+		Update("claimed_until", until)
+	if result.Error != nil {
+		return errors.WithCode(code.ErrDatabase, result.Error.Error())
+	}
+	if result.RowsAffected == 0 {
+		return errTransitionRecorded
+	}
+
+	return nil
+}
+
+// Expire changes the status only while the user is active with the same trial, a trial
+// extended meanwhile is left alone.
+func (t *trials) Expire(ctx context.Context, user *model.User, status int) (bool, error) {
+	ctx, changes := withUserChanges(ctx)
+
+	err := t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := recordTransition(tx, newTransition(user, model.TrialExpired, time.Now())); err != nil {
+			return err
+		}
+
+		result := tx.Model(&model.User{ObjectMeta: model.ObjectMeta{ID: user.ID}}).
+			Where("status = 1 and trial_ends_at = ?", user.TrialEndsAt).
+			Update("status", status)
+		if result.Error != nil {
+			return errors.WithCode(code.ErrDatabase, result.Error.Error())
+		}
+		if result.RowsAffected == 0 {
+			return errTransitionRecorded
+		}
+
+		return nil
+	})
+	ok, err := transitioned(err)
+	if ok {
+		user.Status = status
+		userWatch.publish(ctx, t.db.WithContext(ctx), changes.changes...)
+	}
+
+	return ok, err
+}
+
+func newTransition(user *model.User, kind string, now time.Time) *model.TrialTransition {
+	return &model.TrialTransition{
+		UserID:      user.ID,
+		Kind:        kind,
+		TrialEndsAt: user.TrialEndsAt,
+		CreatedAt:   now,
+	}
+}
+
+func recordTransition(tx *gorm.DB, transition *model.TrialTransition) error {
+	if err := tx.Create(transition).Error; err != nil {
+		if strings.Contains(err.Error(), "Duplicate entry") {
+			return errTransitionRecorded
+		}
+
+		return errors.WithCode(code.ErrDatabase, err.Error())
+	}
+
+	return nil
+}
+
+// transitioned tells a transition made by this call from one made elsewhere and from errors.
+func transitioned(err error) (bool, error) {
+	if errors.Is(err, errTransitionRecorded) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
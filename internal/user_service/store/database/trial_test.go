@@ -0,0 +1,128 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/skeleton1231/gotal/internal/apiserver/store/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var insertTransition = regexp.QuoteMeta("INSERT INTO `trial_transitions`")
+
+func TestTrials_Remind(t *testing.T) {
+	db, mock, err := setupMockDB()
+	require.NoError(t, err)
+
+	trials := newTrials(&datastore{db})
+	endsAt := time.Now().Add(time.Hour)
+	user := &model.User{TrialEndsAt: endsAt}
+	user.ID = 7
+
+	claim := regexp.QuoteMeta("UPDATE `trial_transitions` SET `claimed_until`=? WHERE (user_id = ? and kind = ? and trial_ends_at = ?) " +
+		"AND (notified_at is null and (claimed_until is null or claimed_until <= ?))")
+	release := regexp.QuoteMeta("UPDATE `trial_transitions` SET `claimed_until`=? WHERE user_id = ? and kind = ? and trial_ends_at = ?")
+	delivered := regexp.QuoteMeta("UPDATE `trial_transitions` SET `notified_at`=? WHERE user_id = ? and kind = ? and trial_ends_at = ?")
+	duplicate := errors.New("Error 1062: Duplicate entry '7-reminded' for key 'idx_user_kind_trial'")
+
+	// The claim is committed before notifying, and the delivery recorded after.
+	notified := 0
+	mock.ExpectBegin()
+	mock.ExpectExec(insertTransition).
+		WithArgs(7, model.TrialReminded, endsAt, sqlmock.AnyArg(), sqlmock.AnyArg(), nil).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	notify := func(context.Context) error {
+		assert.NoError(t, mock.ExpectationsWereMet(), "the claim is committed")
+		notified++
+
+		mock.ExpectBegin()
+		mock.ExpectExec(delivered).WithArgs(sqlmock.AnyArg(), 7, model.TrialReminded, endsAt).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		return nil
+	}
+
+	ok, err := trials.Remind(context.Background(), user, notify)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, 1, notified)
+
+	// Another replica reminded the user, or holds the reminder.
+	mock.ExpectBegin()
+	mock.ExpectExec(insertTransition).
+		WithArgs(7, model.TrialReminded, endsAt, sqlmock.AnyArg(), sqlmock.AnyArg(), nil).
+		WillReturnError(duplicate)
+	mock.ExpectRollback()
+	mock.ExpectBegin()
+	mock.ExpectExec(claim).WithArgs(sqlmock.AnyArg(), 7, model.TrialReminded, endsAt, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	ok, err = trials.Remind(context.Background(), user, notify)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, 1, notified)
+
+	// A failed notification releases the claim, so it is tried again.
+	mock.ExpectBegin()
+	mock.ExpectExec(insertTransition).
+		WithArgs(7, model.TrialReminded, endsAt, sqlmock.AnyArg(), sqlmock.AnyArg(), nil).
+		WillReturnError(duplicate)
+	mock.ExpectRollback()
+	mock.ExpectBegin()
+	mock.ExpectExec(claim).WithArgs(sqlmock.AnyArg(), 7, model.TrialReminded, endsAt, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec(release).WithArgs(nil, 7, model.TrialReminded, endsAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	_, err = trials.Remind(context.Background(), user, func(context.Context) error { return errors.New("mail server unavailable") })
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTrials_Expire(t *testing.T) {
+	db, mock, err := setupMockDB()
+	require.NoError(t, err)
+
+	trials := newTrials(&datastore{db})
+	endsAt := time.Now().Add(-time.Hour)
+	updateStatus := regexp.QuoteMeta("UPDATE `users` SET `status`=?,`updated_at`=? WHERE (status = 1 and trial_ends_at = ?)")
+
+	mock.ExpectBegin()
+	mock.ExpectExec(insertTransition).WithArgs(7, model.TrialExpired, endsAt, sqlmock.AnyArg(), nil, nil).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(updateStatus).WithArgs(2, sqlmock.AnyArg(), endsAt, 7).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	user := &model.User{TrialEndsAt: endsAt}
+	user.ID = 7
+	user.Status = 1
+	ok, err := trials.Expire(context.Background(), user, 2)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, 2, user.Status)
+
+	// The trial was extended since the user was listed.
+	mock.ExpectBegin()
+	mock.ExpectExec(insertTransition).WithArgs(7, model.TrialExpired, endsAt, sqlmock.AnyArg(), nil, nil).
+		WillReturnResult(sqlmock.NewResult(2, 1))
+	mock.ExpectExec(updateStatus).WithArgs(2, sqlmock.AnyArg(), endsAt, 7).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	user.Status = 1
+	ok, err = trials.Expire(context.Background(), user, 2)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, 1, user.Status)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
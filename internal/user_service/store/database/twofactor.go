@@ -0,0 +1,135 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/skeleton1231/gotal/internal/apiserver/store/model"
+	"github.com/skeleton1231/gotal/internal/pkg/code"
+	"github.com/skeleton1231/gotal/internal/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type twoFactors struct {
+	db *gorm.DB
+}
+
+func newTwoFactors(ds *datastore) *twoFactors {
+	return &twoFactors{ds.db}
+}
+
+// Get return the second factor of the user.
+func (t *twoFactors) Get(ctx context.Context, userId uint64) (*model.TwoFactor, error) {
+	tf := &model.TwoFactor{}
+	if err := t.db.WithContext(ctx).Where("user_id = ?", userId).First(tf).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.WithCode(code.ErrTwoFactorNotFound, err.Error())
+		}
+
+		return nil, errors.WithCode(code.ErrDatabase, err.Error())
+	}
+
+	return tf, nil
+}
+
+// Save stores a pending second factor, replacing the pending one of the user.
+func (t *twoFactors) Save(ctx context.Context, twoFactor *model.TwoFactor) error {
+	return t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		existing := &model.TwoFactor{}
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("user_id = ?", twoFactor.UserID).First(existing).Error
+		switch {
+		case err == nil:
+			if existing.Enabled {
+				return errors.WithCode(code.ErrTwoFactorEnabled, "two-factor authentication of user %d is already enabled", twoFactor.UserID)
+			}
+			if err := tx.Delete(existing).Error; err != nil {
+				return errors.WithCode(code.ErrDatabase, err.Error())
+			}
+		case !errors.Is(err, gorm.ErrRecordNotFound):
+			return errors.WithCode(code.ErrDatabase, err.Error())
+		}
+
+		twoFactor.Enabled = false
+		twoFactor.EnabledAt = nil
+		if err := tx.Create(twoFactor).Error; err != nil {
+			return errors.WithCode(code.ErrDatabase, err.Error())
+		}
+
+		return nil
+	})
+}
+
+// Enable enables the pending second factor and replaces the recovery codes of the user.
+func (t *twoFactors) Enable(ctx context.Context, userId uint64, step int64, recoveryHashes []string) error {
+	return t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		result := tx.Model(&model.TwoFactor{}).
+			Where("user_id = ? AND enabled = ?", userId, false).
+			Updates(map[string]interface{}{"enabled": true, "enabled_at": now, "last_step": step})
+		if result.Error != nil {
+			return errors.WithCode(code.ErrDatabase, result.Error.Error())
+		}
+		if result.RowsAffected == 0 {
+			return errors.WithCode(code.ErrTwoFactorNotFound, "no pending two-factor authentication of user %d", userId)
+		}
+
+		if err := tx.Where("user_id = ?", userId).Delete(&model.RecoveryCode{}).Error; err != nil {
+			return errors.WithCode(code.ErrDatabase, err.Error())
+		}
+
+		if len(recoveryHashes) == 0 {
+			return nil
+		}
+
+		codes := make([]*model.RecoveryCode, 0, len(recoveryHashes))
+		for _, hash := range recoveryHashes {
+			codes = append(codes, &model.RecoveryCode{UserID: userId, Hash: hash, CreatedAt: now})
+		}
+		if err := tx.Create(&codes).Error; err != nil {
+			return errors.WithCode(code.ErrDatabase, err.Error())
+		}
+
+		return nil
+	})
+}
+
+// Delete removes the second factor and the recovery codes of the user.
+func (t *twoFactors) Delete(ctx context.Context, userId uint64) error {
+	return t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userId).Delete(&model.RecoveryCode{}).Error; err != nil {
+			return errors.WithCode(code.ErrDatabase, err.Error())
+		}
+
+		if err := tx.Where("user_id = ?", userId).Delete(&model.TwoFactor{}).Error; err != nil {
+			return errors.WithCode(code.ErrDatabase, err.Error())
+		}
+
+		return nil
+	})
+}
+
+// UseStep records the time step of an accepted code, unless a code of the step or a later one was accepted.
+func (t *twoFactors) UseStep(ctx context.Context, userId uint64, step int64) (bool, error) {
+	result := t.db.WithContext(ctx).Model(&model.TwoFactor{}).
+		Where("user_id = ? AND enabled = ? AND last_step < ?", userId, true, step).
+		Update("last_step", step)
+	if result.Error != nil {
+		return false, errors.WithCode(code.ErrDatabase, result.Error.Error())
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+// UseRecoveryCode marks an unused recovery code of the user as used. The codes of a user are
+// random, the hash finds one code at most.
+func (t *twoFactors) UseRecoveryCode(ctx context.Context, userId uint64, hash string) (bool, error) {
+	result := t.db.WithContext(ctx).Model(&model.RecoveryCode{}).
+		Where("user_id = ? AND hash = ? AND used_at IS NULL", userId, hash).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return false, errors.WithCode(code.ErrDatabase, result.Error.Error())
+	}
+
+	return result.RowsAffected > 0, nil
+}
@@ -0,0 +1,107 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/skeleton1231/gotal/internal/apiserver/store/model"
+	"github.com/skeleton1231/gotal/internal/pkg/code"
+	"github.com/skeleton1231/gotal/internal/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSaveTwoFactorAlreadyEnabled(t *testing.T) {
+	db, mock, err := setupMockDB()
+	assert.NoError(t, err)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT \\* FROM `two_factors` WHERE user_id = \\? .* FOR UPDATE").
+		WithArgs(uint64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "secret", "enabled"}).AddRow(1, "SECRET", true))
+	mock.ExpectRollback()
+
+	err = newTwoFactors(&datastore{db}).Save(context.Background(), &model.TwoFactor{UserID: 1, Secret: "OTHER"})
+	assert.True(t, errors.IsCode(err, code.ErrTwoFactorEnabled), err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestEnableTwoFactor(t *testing.T) {
+	db, mock, err := setupMockDB()
+	assert.NoError(t, err)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE `two_factors` SET").
+		WithArgs(true, sqlmock.AnyArg(), int64(100), uint64(1), false).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM `recovery_codes` WHERE user_id = \\?").
+		WithArgs(uint64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 10))
+	mock.ExpectExec("INSERT INTO `recovery_codes`").
+		WithArgs(uint64(1), "h1", sqlmock.AnyArg(), nil, uint64(1), "h2", sqlmock.AnyArg(), nil).
+		WillReturnResult(sqlmock.NewResult(1, 2))
+	mock.ExpectCommit()
+
+	err = newTwoFactors(&datastore{db}).Enable(context.Background(), 1, 100, []string{"h1", "h2"})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestEnableTwoFactorNotPending(t *testing.T) {
+	db, mock, err := setupMockDB()
+	assert.NoError(t, err)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE `two_factors` SET").
+		WithArgs(true, sqlmock.AnyArg(), int64(100), uint64(1), false).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	err = newTwoFactors(&datastore{db}).Enable(context.Background(), 1, 100, []string{"h1"})
+	assert.True(t, errors.IsCode(err, code.ErrTwoFactorNotFound), err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUseTwoFactorStep(t *testing.T) {
+	db, mock, err := setupMockDB()
+	assert.NoError(t, err)
+
+	tf := newTwoFactors(&datastore{db})
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE `two_factors` SET `last_step`=\\? WHERE user_id = \\? AND enabled = \\? AND last_step < \\?").
+		WithArgs(int64(100), uint64(1), true, int64(100)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE `two_factors` SET `last_step`=\\?").
+		WithArgs(int64(100), uint64(1), true, int64(100)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	accepted, err := tf.UseStep(context.Background(), 1, 100)
+	assert.NoError(t, err)
+	assert.True(t, accepted)
+
+	// The same code is rejected the second time.
+	accepted, err = tf.UseStep(context.Background(), 1, 100)
+	assert.NoError(t, err)
+	assert.False(t, accepted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUseRecoveryCode(t *testing.T) {
+	db, mock, err := setupMockDB()
+	assert.NoError(t, err)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE `recovery_codes` SET `used_at`=\\? WHERE user_id = \\? AND hash = \\? AND used_at IS NULL$").
+		WithArgs(sqlmock.AnyArg(), uint64(1), "h1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	accepted, err := newTwoFactors(&datastore{db}).UseRecoveryCode(context.Background(), 1, "h1")
+	assert.NoError(t, err)
+	assert.True(t, accepted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
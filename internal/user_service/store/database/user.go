@@ -8,7 +8,9 @@ import (
 	"github.com/skeleton1231/gotal/internal/pkg/code"
 	"github.com/skeleton1231/gotal/internal/pkg/errors"
 	"github.com/skeleton1231/gotal/pkg/log"
+	"github.com/skeleton1231/gotal/pkg/util/common"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"k8s.io/apimachinery/pkg/fields"
 )
 
@@ -20,20 +22,78 @@ func newUsers(ds *datastore) *users {
 	return &users{ds.db}
 }
 
-// Create creates a new user account.
+// Create creates a new user account. Credits are only granted through the ledger, so
+// every account starts without any.
 func (u *users) Create(ctx context.Context, user *model.User, opts model.CreateOptions) error {
+	user.TotalCredits = 0
+
+	if model.IsDryRun(opts.DryRun) {
+		return dryRun(u.db.WithContext(ctx), func(tx *gorm.DB) error {
+			if err := tx.Create(user).Error; err != nil {
+				return err
+			}
+			// The insert is rolled back, so the identifier it got belongs to nobody.
+			user.ID = 0
+
+			return nil
+		})
+	}
+
 	return u.db.Create(&user).Error
 }
 
-// Update updates an user account information.
+// Update updates an user account information. The balance is only changed through the
+// credits ledger, so total_credits is left as it is.
 func (u *users) Update(ctx context.Context, user *model.User, opts model.UpdateOptions) error {
-	return u.db.Save(user).Error
+	if model.IsDryRun(opts.DryRun) {
+		return dryRun(u.db.WithContext(ctx), func(tx *gorm.DB) error {
+			return tx.Omit("total_credits").Save(user).Error
+		})
+	}
+
+	return u.db.Omit("total_credits").Save(user).Error
+}
+
+// Patch updates exactly the columns of the given field mask paths, zero values included.
+func (u *users) Patch(ctx context.Context, user *model.User, fields []string, opts model.PatchOptions) error {
+	columns := make([]string, 0, len(fields))
+	for _, field := range fields {
+		column, ok := model.UserColumn(field)
+		if !ok {
+			return errors.WithCode(code.ErrValidation, "field `%s` cannot be patched", field)
+		}
+		columns = append(columns, column)
+	}
+
+	patch := func(tx *gorm.DB) error {
+		result := tx.Model(user).Select(columns).Updates(user)
+		if result.Error != nil {
+			return errors.WithCode(code.ErrDatabase, result.Error.Error())
+		}
+		if result.RowsAffected == 0 {
+			return errors.WithCode(code.ErrUserNotFound, "user %d not found", user.ID)
+		}
+
+		return nil
+	}
+
+	if model.IsDryRun(opts.DryRun) {
+		return dryRun(u.db.WithContext(ctx), patch)
+	}
+
+	return patch(u.db.WithContext(ctx))
 }
 
 // Delete deletes the user by the user identifier.
+// The user is soft deleted unless opts.Unscoped is set.
 func (u *users) Delete(ctx context.Context, userId uint64, opts model.DeleteOptions) error {
+	db := u.db.WithContext(ctx)
+	if opts.Unscoped {
+		db = db.Unscoped()
+	}
 
-	err := u.db.Where("id = ?", userId).Delete(&model.User{}).Error
+	// The identifier is set on the model so that watchers learn which user is gone.
+	err := db.Delete(&model.User{ObjectMeta: model.ObjectMeta{ID: userId}}).Error
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 		return errors.WithCode(code.ErrDatabase, err.Error())
 	}
@@ -41,11 +101,111 @@ func (u *users) Delete(ctx context.Context, userId uint64, opts model.DeleteOpti
 	return nil
 }
 
-// DeleteCollection batch deletes the users.
-// func (u *users) DeleteCollection(ctx context.Context, userIds []uint64, opts model.DeleteOptions) error {
+// DeleteCollection batch deletes the users matching the identifiers and the field selector
+// in one transaction. The users are soft deleted unless opts.Unscoped is set.
+func (u *users) DeleteCollection(
+	ctx context.Context,
+	userIds []uint64,
+	opts model.DeleteCollectionOptions,
+) ([]*model.UserDeleteResult, error) {
+	if len(userIds) == 0 && opts.FieldSelector == "" {
+		return nil, errors.WithCode(code.ErrValidation, "either user ids or a field selector is required")
+	}
+
+	limit := opts.DeleteCollectionLimit()
+	if len(userIds) > limit {
+		return nil, errors.WithCode(code.ErrValidation, "cannot delete more than %d users at once", limit)
+	}
+
+	ctx, changes := withUserChanges(ctx)
+
+	var matched []*model.User
+	err := u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if opts.Unscoped {
+			tx = tx.Unscoped().Session(&gorm.Session{})
+		}
+
+		query := tx.Model(&model.User{})
+		if len(userIds) > 0 {
+			query = query.Where("id IN ?", userIds)
+		}
+
+		query, err := userApplyFieldSelectors(query, opts.FieldSelector)
+		if err != nil {
+			return errors.WithCode(code.ErrValidation, err.Error())
+		}
+
+		// Lock the matched rows so the results describe exactly what is removed.
+		// One extra row tells whether the selection goes over the cap.
+		if err := query.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Order("id").
+			Limit(limit + 1).
+			Find(&matched).Error; err != nil {
+			return errors.WithCode(code.ErrDatabase, err.Error())
+		}
+
+		if len(matched) > limit {
+			return errors.WithCode(code.ErrValidation, "more than %d users match, nothing was deleted", limit)
+		}
+
+		if len(matched) == 0 {
+			return nil
+		}
+
+		// Deleting the models themselves lets watchers learn which users are gone.
+		if err := tx.Delete(&matched).Error; err != nil {
+			return errors.WithCode(code.ErrDatabase, err.Error())
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	userWatch.publish(ctx, u.db.WithContext(ctx), changes.changes...)
+
+	deleted := make([]uint64, 0, len(matched))
+	for _, user := range matched {
+		deleted = append(deleted, user.ID)
+	}
+
+	return userDeleteResults(userIds, deleted), nil
+}
+
+// userDeleteResults reports one result per requested identifier, in request order.
+// Without identifiers, every deleted user is reported.
+func userDeleteResults(requested, deleted []uint64) []*model.UserDeleteResult {
+	if len(requested) == 0 {
+		results := make([]*model.UserDeleteResult, 0, len(deleted))
+		for _, id := range deleted {
+			results = append(results, &model.UserDeleteResult{ID: id, Deleted: true})
+		}
+
+		return results
+	}
+
+	removed := make(map[uint64]bool, len(deleted))
+	for _, id := range deleted {
+		removed[id] = true
+	}
+
+	results := make([]*model.UserDeleteResult, 0, len(requested))
+	seen := make(map[uint64]bool, len(requested))
+	for _, id := range requested {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		result := &model.UserDeleteResult{ID: id, Deleted: removed[id]}
+		if !result.Deleted {
+			result.Reason = "user not found or not matched by the field selector"
+		}
+		results = append(results, result)
+	}
 
-// 	return u.db.Where("id in (?)", userIds).Delete(&model.User{}).Error
-// }
+	return results
+}
 
 // Get return an user by the user identifier.
 func (u *users) Get(ctx context.Context, userId uint64, opts model.GetOptions) (*model.User, error) {
@@ -77,6 +237,68 @@ func (u *users) GetByUsername(ctx context.Context, username string, opts model.G
 	return user, nil
 }
 
+// GetByDiscordID return the user linked to the Discord account. discord_link is the indexed
+// copy of discord_id, unique across the users.
+func (u *users) GetByDiscordID(ctx context.Context, discordId uint64, opts model.GetOptions) (*model.User, error) {
+	user := &model.User{}
+	err := u.db.WithContext(ctx).Where("discord_link = ? and status = 1 and deleted_at IS NULL", discordId).First(&user).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.WithCode(code.ErrUserNotFound, err.Error())
+		}
+
+		return nil, errors.WithCode(code.ErrDatabase, err.Error())
+	}
+
+	return user, nil
+}
+
+// GetByEmail return the first user with the email address.
+func (u *users) GetByEmail(ctx context.Context, email string, opts model.GetOptions) (*model.User, error) {
+	user := &model.User{}
+	err := u.db.WithContext(ctx).Where("email = ? and status = 1 and deleted_at IS NULL", email).First(&user).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.WithCode(code.ErrUserNotFound, err.Error())
+		}
+
+		return nil, errors.WithCode(code.ErrDatabase, err.Error())
+	}
+
+	return user, nil
+}
+
+// ChangePassword encrypts the password in plain text and saves it. With opts.RememberToken,
+// the check of the token and its removal happen in the same statement, so it is used once.
+func (u *users) ChangePassword(ctx context.Context, userId uint64, password string, opts model.ChangePasswordOptions) error {
+	encrypted, err := common.Encrypt(password)
+	if err != nil {
+		return errors.WithCode(code.ErrEncrypt, err.Error())
+	}
+
+	columns := map[string]interface{}{"password": encrypted}
+	query := u.db.WithContext(ctx).Model(&model.User{ObjectMeta: model.ObjectMeta{ID: userId}}).
+		Where("status = 1 and deleted_at IS NULL")
+	if opts.RememberToken != "" {
+		columns["remember_token"] = ""
+		query = query.Where("remember_token = ?", opts.RememberToken)
+	}
+
+	result := query.Updates(columns)
+	if result.Error != nil {
+		return errors.WithCode(code.ErrDatabase, result.Error.Error())
+	}
+	if result.RowsAffected == 0 {
+		if opts.RememberToken != "" {
+			return errors.WithCode(code.ErrPasswordResetTokenInvalid, "remember token of user %d does not match", userId)
+		}
+
+		return errors.WithCode(code.ErrUserNotFound, "user %d not found", userId)
+	}
+
+	return nil
+}
+
 // List return all users.
 func (u *users) List(ctx context.Context, opts model.ListOptions) (*model.UserList, error) {
 	ret := &model.UserList{}
@@ -86,20 +308,57 @@ func (u *users) List(ctx context.Context, opts model.ListOptions) (*model.UserLi
 	query, err := userApplyFieldSelectors(u.db.Where("status = 1 and deleted_at IS NULL"), opts.FieldSelector)
 	if err != nil {
 		log.Errorf("user query error: %v", err)
-		return nil, err // Return immediately if there's an error
+		return nil, errors.WithCode(code.ErrValidation, err.Error()) // Return immediately if there's an error
 	}
 
-	// Apply pagination and execute the query
-	d := query.
-		Offset(ol.Offset).
-		Limit(ol.Limit).
-		Order("id desc").
-		Find(&ret.Items).
-		Offset(-1).
-		Limit(-1).
-		Count(&ret.TotalCount)
+	// Apply label selectors against the keys of Extend
+	query, err = userApplyLabelSelectors(query, opts.LabelSelector)
+	if err != nil {
+		log.Errorf("user query error: %v", err)
+		return nil, errors.WithCode(code.ErrValidation, err.Error())
+	}
+
+	// The count must not see the cursor, so keep a reusable copy of the filtered query.
+	query = query.Session(&gorm.Session{})
+
+	page := query
+	if opts.Continue != "" {
+		if opts.Offset != nil {
+			return nil, errors.WithCode(code.ErrValidation, "continue cannot be used together with offset")
+		}
+
+		token, err := decodeContinue(opts.Continue)
+		if err != nil {
+			return nil, errors.WithCode(code.ErrValidation, err.Error())
+		}
+
+		page = page.Where("id < ?", token.LastID)
+	}
 
-	return ret, d.Error
+	// Fetch one extra row to find out whether another page follows.
+	limit := ol.Limit
+	if limit > 0 {
+		limit++
+	}
+
+	if err := page.Offset(ol.Offset).Limit(limit).Order("id desc").Find(&ret.Items).Error; err != nil {
+		return nil, err
+	}
+
+	if ol.Limit > 0 && len(ret.Items) > ol.Limit {
+		ret.Items = ret.Items[:ol.Limit]
+		ret.Continue = encodeContinue(continueToken{LastID: ret.Items[ol.Limit-1].ID})
+	}
+
+	if opts.SkipCount {
+		return ret, nil
+	}
+
+	if err := query.Model(&model.User{}).Count(&ret.TotalCount).Error; err != nil {
+		return nil, err
+	}
+
+	return ret, nil
 }
 
 func userApplyFieldSelectors(query *gorm.DB, fieldSelector string) (*gorm.DB, error) {
@@ -126,3 +385,40 @@ func userApplyFieldSelectors(query *gorm.DB, fieldSelector string) (*gorm.DB, er
 
 	return query, nil
 }
+
+// userApplyLabelSelectors filters users by Kubernetes-style label selectors, evaluated against
+// the keys of Extend which is persisted as JSON in the extendShadow column.
+// As in Kubernetes, `!=` and `notin` also match users without the key.
+func userApplyLabelSelectors(query *gorm.DB, labelSelector string) (*gorm.DB, error) {
+	requirements, err := parseLabelSelector(labelSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, req := range requirements {
+		// Label keys cannot contain quotes, so quoting the member is enough to address
+		// keys with dots or slashes.
+		path := fmt.Sprintf(`$."%s"`, req.key)
+
+		switch req.operator {
+		case labelEquals, labelDoubleEquals:
+			query = query.Where("JSON_UNQUOTE(JSON_EXTRACT(extendShadow, ?)) = ?", path, req.values[0])
+		case labelNotEquals:
+			query = query.Where("JSON_EXTRACT(extendShadow, ?) IS NULL OR JSON_UNQUOTE(JSON_EXTRACT(extendShadow, ?)) <> ?",
+				path, path, req.values[0])
+		case labelIn:
+			query = query.Where("JSON_UNQUOTE(JSON_EXTRACT(extendShadow, ?)) IN ?", path, req.values)
+		case labelNotIn:
+			query = query.Where("JSON_EXTRACT(extendShadow, ?) IS NULL OR JSON_UNQUOTE(JSON_EXTRACT(extendShadow, ?)) NOT IN ?",
+				path, path, req.values)
+		case labelExists:
+			query = query.Where("JSON_CONTAINS_PATH(extendShadow, 'one', ?)", path)
+		case labelDoesNotExist:
+			query = query.Where("NOT JSON_CONTAINS_PATH(extendShadow, 'one', ?)", path)
+		default:
+			return nil, fmt.Errorf("unsupported label selector operator `%s` for key `%s`", req.operator, req.key)
+		}
+	}
+
+	return query, nil
+}
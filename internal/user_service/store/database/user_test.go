@@ -3,11 +3,17 @@ package database
 
 import (
 	"context"
+	"database/sql/driver"
+	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/skeleton1231/gotal/internal/apiserver/store/model"
+	"github.com/skeleton1231/gotal/internal/pkg/code"
+	"github.com/skeleton1231/gotal/internal/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 )
@@ -64,4 +70,367 @@ func TestCreateUser(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestDeleteUser(t *testing.T) {
+	tests := []struct {
+		name     string
+		unscoped bool
+		query    string
+		args     []driver.Value
+	}{
+		{name: "soft delete", unscoped: false, query: "UPDATE `users` SET `deleted_at`=\\? WHERE `users`.`id` = \\? AND `users`.`deleted_at` IS NULL", args: []driver.Value{sqlmock.AnyArg(), 1}},
+		{name: "hard delete", unscoped: true, query: "DELETE FROM `users` WHERE `users`.`id` = \\?", args: []driver.Value{1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := setupMockDB()
+			assert.NoError(t, err)
+
+			u := newUsers(&datastore{db})
+
+			mock.ExpectBegin()
+			mock.ExpectExec(tt.query).WithArgs(tt.args...).WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+
+			err = u.Delete(context.Background(), 1, model.DeleteOptions{Unscoped: tt.unscoped})
+			assert.NoError(t, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestDeleteCollection(t *testing.T) {
+	t.Run("reports each requested id", func(t *testing.T) {
+		db, mock, err := setupMockDB()
+		require.NoError(t, err)
+
+		u := newUsers(&datastore{db})
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE id IN (?,?,?) AND `users`.`deleted_at` IS NULL ORDER BY id LIMIT 501 FOR UPDATE")).
+			WithArgs(3, 1, 2).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "extendShadow"}).AddRow(1, "{}").AddRow(3, "{}"))
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE `users` SET `deleted_at`=? WHERE `users`.`id` IN (?,?) AND `users`.`deleted_at` IS NULL")).
+			WithArgs(sqlmock.AnyArg(), 1, 3).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		results, err := u.DeleteCollection(context.Background(), []uint64{3, 1, 2}, model.DeleteCollectionOptions{})
+		require.NoError(t, err)
+		require.Len(t, results, 3)
+		assert.Equal(t, &model.UserDeleteResult{ID: 3, Deleted: true}, results[0])
+		assert.Equal(t, &model.UserDeleteResult{ID: 1, Deleted: true}, results[1])
+		assert.Equal(t, uint64(2), results[2].ID)
+		assert.False(t, results[2].Deleted)
+		assert.NotEmpty(t, results[2].Reason)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("hard deletes by field selector", func(t *testing.T) {
+		db, mock, err := setupMockDB()
+		require.NoError(t, err)
+
+		u := newUsers(&datastore{db})
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE stripe_id = ? ORDER BY id LIMIT 501 FOR UPDATE")).
+			WithArgs("cus_1").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "extendShadow"}).AddRow(4, "{}"))
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `users` WHERE `users`.`id` = ?")).
+			WithArgs(4).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		results, err := u.DeleteCollection(context.Background(), nil, model.DeleteCollectionOptions{
+			DeleteOptions: model.DeleteOptions{Unscoped: true},
+			FieldSelector: "stripeId=cus_1",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []*model.UserDeleteResult{{ID: 4, Deleted: true}}, results)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("rolls back over the cap", func(t *testing.T) {
+		db, mock, err := setupMockDB()
+		require.NoError(t, err)
+
+		u := newUsers(&datastore{db})
+		limit := int64(1)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE stripe_id = ? AND `users`.`deleted_at` IS NULL ORDER BY id LIMIT 2 FOR UPDATE")).
+			WithArgs("cus_1").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "extendShadow"}).AddRow(4, "{}").AddRow(5, "{}"))
+		mock.ExpectRollback()
+
+		_, err = u.DeleteCollection(context.Background(), nil, model.DeleteCollectionOptions{
+			FieldSelector: "stripeId=cus_1",
+			Limit:         &limit,
+		})
+		assert.True(t, errors.IsCode(err, code.ErrValidation))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("requires a selection", func(t *testing.T) {
+		db, mock, err := setupMockDB()
+		require.NoError(t, err)
+
+		u := newUsers(&datastore{db})
+
+		_, err = u.DeleteCollection(context.Background(), nil, model.DeleteCollectionOptions{})
+		assert.True(t, errors.IsCode(err, code.ErrValidation))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPatchUser(t *testing.T) {
+	db, mock, err := setupMockDB()
+	assert.NoError(t, err)
+
+	u := newUsers(&datastore{db})
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE `users` SET `updated_at`=\\?,`stripe_id`=\\?,`discord_id`=\\? WHERE `users`.`deleted_at` IS NULL AND `id` = \\?").
+		WithArgs(sqlmock.AnyArg(), "", 7, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	user := &model.User{ObjectMeta: model.ObjectMeta{ID: 1}, Name: "ignored", DiscordID: 7}
+	err = u.Patch(context.Background(), user, []string{"stripeId", "discordId"}, model.PatchOptions{})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE `users` SET `extendShadow`=\\?,`updated_at`=\\? WHERE `users`.`deleted_at` IS NULL AND `id` = \\?").
+		WithArgs(`{"tz":"UTC"}`, sqlmock.AnyArg(), 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	user.Extend = model.Extend{"tz": "UTC"}
+	err = u.Patch(context.Background(), user, []string{"meta.extend"}, model.PatchOptions{})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	err = u.Patch(context.Background(), user, []string{"password"}, model.PatchOptions{})
+	assert.True(t, errors.IsCode(err, code.ErrValidation))
+}
+
+func TestChangePassword(t *testing.T) {
+	db, mock, err := setupMockDB()
+	assert.NoError(t, err)
+
+	u := newUsers(&datastore{db})
+	update := regexp.QuoteMeta("UPDATE `users` SET `password`=?,`remember_token`=?,`updated_at`=? " +
+		"WHERE (status = 1 and deleted_at IS NULL) AND remember_token = ? AND `users`.`deleted_at` IS NULL AND `id` = ?")
+
+	mock.ExpectBegin()
+	mock.ExpectExec(update).
+		WithArgs(sqlmock.AnyArg(), "", sqlmock.AnyArg(), "hash.1700000000", 7).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err = u.ChangePassword(context.Background(), 7, "Secret123!", model.ChangePasswordOptions{RememberToken: "hash.1700000000"})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// The token was already used, or replaced by a newer one.
+	mock.ExpectBegin()
+	mock.ExpectExec(update).
+		WithArgs(sqlmock.AnyArg(), "", sqlmock.AnyArg(), "hash.1700000000", 7).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	err = u.ChangePassword(context.Background(), 7, "Secret123!", model.ChangePasswordOptions{RememberToken: "hash.1700000000"})
+	assert.True(t, errors.IsCode(err, code.ErrPasswordResetTokenInvalid), err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func anyArgs(n int) []driver.Value {
+	args := make([]driver.Value, n)
+	for i := range args {
+		args[i] = sqlmock.AnyArg()
+	}
+
+	return args
+}
+
+func TestDryRun(t *testing.T) {
+	db, mock, err := setupMockDB()
+	assert.NoError(t, err)
+
+	u := newUsers(&datastore{db})
+	dryRun := []string{model.DryRunAll}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `users`").WithArgs(anyArgs(16)...).WillReturnResult(sqlmock.NewResult(5, 1))
+	mock.ExpectRollback()
+
+	user := &model.User{Name: "John Doe", Email: "johndoe@example.com"}
+	err = u.Create(context.Background(), user, model.CreateOptions{DryRun: dryRun})
+	assert.NoError(t, err)
+	assert.Zero(t, user.ID)
+	assert.False(t, user.CreatedAt.IsZero(), "hooks and defaults still apply")
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE `users` SET").WithArgs(anyArgs(3)...).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectRollback()
+
+	user.ID = 1
+	err = u.Patch(context.Background(), user, []string{"email"}, model.PatchOptions{DryRun: dryRun})
+	assert.NoError(t, err)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `users`").WithArgs(anyArgs(16)...).WillReturnError(fmt.Errorf("Error 1062 (23000): Duplicate entry 'John Doe' for key 'idx_name'"))
+	mock.ExpectRollback()
+
+	err = u.Create(context.Background(), &model.User{Name: "John Doe"}, model.CreateOptions{DryRun: dryRun})
+	assert.ErrorContains(t, err, "Duplicate entry")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListUsers_LabelSelector(t *testing.T) {
+	tests := []struct {
+		name     string
+		selector string
+		where    string
+		args     []driver.Value
+	}{
+		{
+			name:     "equals",
+			selector: "tier=gold",
+			where:    "JSON_UNQUOTE(JSON_EXTRACT(extendShadow, ?)) = ?",
+			args:     []driver.Value{`$."tier"`, "gold"},
+		},
+		{
+			name:     "not equals",
+			selector: "tier!=gold",
+			where:    "(JSON_EXTRACT(extendShadow, ?) IS NULL OR JSON_UNQUOTE(JSON_EXTRACT(extendShadow, ?)) <> ?)",
+			args:     []driver.Value{`$."tier"`, `$."tier"`, "gold"},
+		},
+		{
+			name:     "in",
+			selector: "env in (prod,staging)",
+			where:    "JSON_UNQUOTE(JSON_EXTRACT(extendShadow, ?)) IN (?,?)",
+			args:     []driver.Value{`$."env"`, "prod", "staging"},
+		},
+		{
+			name:     "notin",
+			selector: "env notin (dev)",
+			where:    "(JSON_EXTRACT(extendShadow, ?) IS NULL OR JSON_UNQUOTE(JSON_EXTRACT(extendShadow, ?)) NOT IN (?))",
+			args:     []driver.Value{`$."env"`, `$."env"`, "dev"},
+		},
+		{
+			name:     "exists",
+			selector: "example.com/beta",
+			where:    "JSON_CONTAINS_PATH(extendShadow, 'one', ?)",
+			args:     []driver.Value{`$."example.com/beta"`},
+		},
+		{
+			name:     "does not exist",
+			selector: "!banned",
+			where:    "NOT JSON_CONTAINS_PATH(extendShadow, 'one', ?)",
+			args:     []driver.Value{`$."banned"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := setupMockDB()
+			assert.NoError(t, err)
+
+			u := newUsers(&datastore{db})
+
+			mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE (status = 1 and deleted_at IS NULL) AND " + tt.where)).
+				WithArgs(tt.args...).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "name", "extendShadow"}).AddRow(1, "john", `{"tier":"gold"}`))
+			mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `users` WHERE (status = 1 and deleted_at IS NULL) AND " + tt.where)).
+				WithArgs(tt.args...).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+			users, err := u.List(context.Background(), model.ListOptions{LabelSelector: tt.selector})
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), users.TotalCount)
+			assert.Equal(t, "gold", users.Items[0].Extend["tier"])
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestListUsers_InvalidLabelSelector(t *testing.T) {
+	db, mock, err := setupMockDB()
+	assert.NoError(t, err)
+
+	u := newUsers(&datastore{db})
+
+	for _, selector := range []string{"tier>1", "tier in gold", "!tier=gold"} {
+		_, err := u.List(context.Background(), model.ListOptions{LabelSelector: selector})
+		assert.True(t, errors.IsCode(err, code.ErrValidation), selector)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListUsers_Continue(t *testing.T) {
+	limit := int64(2)
+	columns := []string{"id", "name", "extendShadow"}
+
+	t.Run("first page", func(t *testing.T) {
+		db, mock, err := setupMockDB()
+		require.NoError(t, err)
+
+		u := newUsers(&datastore{db})
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE (status = 1 and deleted_at IS NULL) AND `users`.`deleted_at` IS NULL ORDER BY id desc LIMIT 3")).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(9, "a", "{}").AddRow(8, "b", "{}").AddRow(7, "c", "{}"))
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `users` WHERE (status = 1 and deleted_at IS NULL) AND `users`.`deleted_at` IS NULL")).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(5))
+
+		users, err := u.List(context.Background(), model.ListOptions{Limit: &limit})
+		require.NoError(t, err)
+		assert.Len(t, users.Items, 2)
+		assert.Equal(t, int64(5), users.TotalCount)
+		assert.Equal(t, encodeContinue(continueToken{LastID: 8}), users.Continue)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("last page without count", func(t *testing.T) {
+		db, mock, err := setupMockDB()
+		require.NoError(t, err)
+
+		u := newUsers(&datastore{db})
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE (status = 1 and deleted_at IS NULL) AND id < ? AND `users`.`deleted_at` IS NULL ORDER BY id desc LIMIT 3")).
+			WithArgs(8).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(7, "c", "{}").AddRow(6, "d", "{}"))
+
+		users, err := u.List(context.Background(), model.ListOptions{
+			Limit:     &limit,
+			Continue:  encodeContinue(continueToken{LastID: 8}),
+			SkipCount: true,
+		})
+		require.NoError(t, err)
+		assert.Len(t, users.Items, 2)
+		assert.Zero(t, users.TotalCount)
+		assert.Empty(t, users.Continue)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		db, mock, err := setupMockDB()
+		require.NoError(t, err)
+
+		u := newUsers(&datastore{db})
+		offset := int64(10)
+
+		for _, opts := range []model.ListOptions{
+			{Continue: "not a token"},
+			{Continue: encodeContinue(continueToken{})},
+			{Continue: encodeContinue(continueToken{LastID: 8}), Offset: &offset},
+		} {
+			_, err := u.List(context.Background(), opts)
+			assert.True(t, errors.IsCode(err, code.ErrValidation), opts.Continue)
+		}
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
 // 更多测试函数...
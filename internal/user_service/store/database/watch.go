@@ -0,0 +1,381 @@
+package database
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/skeleton1231/gotal/internal/apiserver/store/model"
+	"github.com/skeleton1231/gotal/internal/pkg/code"
+	"github.com/skeleton1231/gotal/internal/pkg/errors"
+	"github.com/skeleton1231/gotal/pkg/log"
+	"gorm.io/gorm"
+	"k8s.io/apimachinery/pkg/fields"
+)
+
+const (
+	// userWatchBuffer is how many events a watcher may fall behind before it is closed.
+	userWatchBuffer = 100
+
+	// resubscribeDelay is how long to wait before subscribing again after the subscription ended.
+	resubscribeDelay = time.Second
+)
+
+// userWatch serves the watches of this replica. Events of every replica reach it through the log.
+var userWatch = newUserWatchHub(newRedisUserEventLog())
+
+// StartUserWatch delivers user events to the watches of this replica until ctx is canceled.
+func StartUserWatch(ctx context.Context) {
+	go userWatch.run(ctx)
+}
+
+// Watch returns the changes of the users matching the field selector.
+func (u *users) Watch(ctx context.Context, opts model.WatchOptions) (model.UserWatcher, error) {
+	return userWatch.watch(ctx, opts)
+}
+
+// userWatchHub fans the events of the log out to the local watchers.
+type userWatchHub struct {
+	log userEventLog
+
+	mu       sync.Mutex
+	watchers map[*userWatcher]struct{}
+}
+
+func newUserWatchHub(log userEventLog) *userWatchHub {
+	return &userWatchHub{log: log, watchers: map[*userWatcher]struct{}{}}
+}
+
+func (h *userWatchHub) run(ctx context.Context) {
+	for {
+		err := h.log.Subscribe(ctx, h.dispatch)
+		// Events published while unsubscribed are lost to the watchers, they resume from the history.
+		h.closeAll(errors.WithCode(code.ErrUserWatchClosed, "user event subscription ended: %v", err))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(resubscribeDelay):
+		}
+	}
+}
+
+func (h *userWatchHub) watch(ctx context.Context, opts model.WatchOptions) (*userWatcher, error) {
+	selector, err := fields.ParseSelector(opts.FieldSelector)
+	if err != nil {
+		return nil, errors.WithCode(code.ErrValidation, err.Error())
+	}
+
+	w := &userWatcher{
+		hub:      h,
+		selector: selector,
+		incoming: make(chan *model.UserWatchEvent, userWatchBuffer),
+		result:   make(chan *model.UserWatchEvent),
+		done:     make(chan struct{}),
+	}
+
+	// Register before reading the history so that nothing falls between the two.
+	h.mu.Lock()
+	h.watchers[w] = struct{}{}
+	h.mu.Unlock()
+
+	var history []*model.UserWatchEvent
+	version := opts.ResourceVersion
+	if version == 0 {
+		version, err = h.log.Version(ctx)
+	} else {
+		history, err = h.log.Since(ctx, version)
+	}
+	if err != nil {
+		h.remove(w)
+		if errors.IsCode(err, code.ErrUserWatchExpired) {
+			return nil, err
+		}
+
+		return nil, errors.WithCode(code.ErrUserWatchClosed, err.Error())
+	}
+
+	go w.run(ctx, version, history)
+
+	return w, nil
+}
+
+func (h *userWatchHub) dispatch(event *model.UserWatchEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for w := range h.watchers {
+		select {
+		case w.incoming <- event:
+		default:
+			w.close(errors.WithCode(code.ErrUserWatchClosed, "watcher fell more than %d events behind", userWatchBuffer))
+			delete(h.watchers, w)
+		}
+	}
+}
+
+func (h *userWatchHub) remove(w *userWatcher) {
+	h.mu.Lock()
+	delete(h.watchers, w)
+	h.mu.Unlock()
+}
+
+func (h *userWatchHub) closeAll(err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for w := range h.watchers {
+		w.close(err)
+		delete(h.watchers, w)
+	}
+}
+
+// userWatcher implements model.UserWatcher.
+type userWatcher struct {
+	hub      *userWatchHub
+	selector fields.Selector
+	incoming chan *model.UserWatchEvent
+	result   chan *model.UserWatchEvent
+
+	once sync.Once
+	done chan struct{}
+	mu   sync.Mutex
+	err  error
+}
+
+var _ model.UserWatcher = (*userWatcher)(nil)
+
+// ResultChan implements model.UserWatcher.
+func (w *userWatcher) ResultChan() <-chan *model.UserWatchEvent {
+	return w.result
+}
+
+// Err implements model.UserWatcher.
+func (w *userWatcher) Err() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.err
+}
+
+// Stop implements model.UserWatcher.
+func (w *userWatcher) Stop() {
+	w.close(nil)
+	w.hub.remove(w)
+}
+
+func (w *userWatcher) close(err error) {
+	w.once.Do(func() {
+		w.mu.Lock()
+		w.err = err
+		w.mu.Unlock()
+
+		close(w.done)
+	})
+}
+
+// run delivers the history first, then the live events newer than the last delivered one.
+func (w *userWatcher) run(ctx context.Context, version uint64, history []*model.UserWatchEvent) {
+	defer close(w.result)
+	defer w.hub.remove(w)
+
+	deliver := func(event *model.UserWatchEvent) bool {
+		if event.ResourceVersion <= version {
+			return true
+		}
+		version = event.ResourceVersion
+
+		if !userMatchesFieldSelector(event.User, w.selector) {
+			return true
+		}
+
+		select {
+		case w.result <- event:
+			return true
+		case <-ctx.Done():
+			return false
+		case <-w.done:
+			return false
+		}
+	}
+
+	for _, event := range history {
+		if !deliver(event) {
+			return
+		}
+	}
+
+	for {
+		select {
+		case event := <-w.incoming:
+			if !deliver(event) {
+				return
+			}
+		case <-ctx.Done():
+			return
+		case <-w.done:
+			return
+		}
+	}
+}
+
+// userMatchesFieldSelector tells whether userApplyFieldSelectors would select the user.
+func userMatchesFieldSelector(user *model.User, selector fields.Selector) bool {
+	for _, req := range selector.Requirements() {
+		switch req.Field {
+		case "name":
+			// LIKE compares case insensitively with the default collation.
+			if !strings.Contains(strings.ToLower(user.Name), strings.ToLower(req.Value)) {
+				return false
+			}
+		case "email":
+			if !strings.Contains(strings.ToLower(user.Email), strings.ToLower(req.Value)) {
+				return false
+			}
+		case "discordId":
+			if strconv.FormatUint(user.DiscordID, 10) != req.Value {
+				return false
+			}
+		case "stripeId":
+			if user.StripeID != req.Value {
+				return false
+			}
+		case "status":
+			if strconv.Itoa(user.Status) != req.Value {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// userChange is a committed or pending change of some users.
+type userChange struct {
+	typ   model.WatchEventType
+	users []*model.User
+}
+
+type userChangesKey struct{}
+
+// userChanges collects the changes made in a transaction, to publish them once it commits.
+type userChanges struct {
+	mu      sync.Mutex
+	changes []userChange
+}
+
+func withUserChanges(ctx context.Context) (context.Context, *userChanges) {
+	changes := &userChanges{}
+
+	return context.WithValue(ctx, userChangesKey{}, changes), changes
+}
+
+func (c *userChanges) add(change userChange) {
+	c.mu.Lock()
+	c.changes = append(c.changes, change)
+	c.mu.Unlock()
+}
+
+// registerUserWatchCallbacks records the changes of users once they are committed.
+func registerUserWatchCallbacks(db *gorm.DB) error {
+	const after = "gorm:commit_or_rollback_transaction"
+
+	cb := db.Callback()
+	if err := cb.Create().After(after).Register("gotal:watch_users", recordUserChange(model.WatchEventAdded)); err != nil {
+		return err
+	}
+	if err := cb.Update().After(after).Register("gotal:watch_users", recordUserChange(model.WatchEventModified)); err != nil {
+		return err
+	}
+
+	return cb.Delete().After(after).Register("gotal:watch_users", recordUserChange(model.WatchEventDeleted))
+}
+
+func recordUserChange(typ model.WatchEventType) func(db *gorm.DB) {
+	return func(db *gorm.DB) {
+		if db.Error != nil || db.RowsAffected == 0 || db.Statement.Table != (model.User{}).TableName() {
+			return
+		}
+
+		// Statements without the primary keys of the users, like bulk updates, are not reported.
+		users := changedUsers(db.Statement.ReflectValue)
+		if len(users) == 0 {
+			return
+		}
+		change := userChange{typ: typ, users: users}
+
+		// The statement ran in a transaction of the caller, which may still be rolled back.
+		if _, ok := db.Statement.ConnPool.(gorm.TxCommitter); ok {
+			if changes, ok := db.Statement.Context.Value(userChangesKey{}).(*userChanges); ok {
+				changes.add(change)
+			}
+
+			return
+		}
+
+		ctx := db.Statement.Context
+		userWatch.publish(ctx, db.Session(&gorm.Session{NewDB: true, Context: ctx}), change)
+	}
+}
+
+func changedUsers(rv reflect.Value) []*model.User {
+	switch rv.Kind() {
+	case reflect.Struct:
+		if user, ok := rv.Interface().(model.User); ok && user.ID != 0 {
+			return []*model.User{&user}
+		}
+	case reflect.Slice, reflect.Array:
+		var users []*model.User
+		for i := 0; i < rv.Len(); i++ {
+			users = append(users, changedUsers(reflect.Indirect(rv.Index(i)))...)
+		}
+
+		return users
+	}
+
+	return nil
+}
+
+// publish appends the events of committed changes to the log. db reads the committed
+// users. Publishing is best effort, the change itself has already been committed.
+func (h *userWatchHub) publish(ctx context.Context, db *gorm.DB, changes ...userChange) {
+	var ids []uint64
+	for _, change := range changes {
+		for _, user := range change.users {
+			ids = append(ids, user.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return
+	}
+
+	// Partial updates only carry the changed columns, report the users as committed.
+	// Hard deleted users are reported as they were last seen by the statement.
+	var current []*model.User
+	if err := db.Unscoped().Where("id IN ?", ids).Find(&current).Error; err != nil {
+		log.Warnf("read changed users failed: %s", err.Error())
+	}
+	byID := make(map[uint64]*model.User, len(current))
+	for _, user := range current {
+		byID[user.ID] = user
+	}
+
+	for _, change := range changes {
+		for _, user := range change.users {
+			if committed, ok := byID[user.ID]; ok {
+				user = committed
+			}
+
+			object := *user
+			object.Password = ""
+			object.RememberToken = ""
+
+			if err := h.log.Append(ctx, &model.UserWatchEvent{Type: change.typ, User: &object}); err != nil {
+				log.Warnf("publish %s event of user %d failed: %s", change.typ, user.ID, err.Error())
+			}
+		}
+	}
+}
@@ -0,0 +1,260 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/skeleton1231/gotal/internal/apiserver/store/model"
+	"github.com/skeleton1231/gotal/internal/pkg/code"
+	"github.com/skeleton1231/gotal/internal/pkg/errors"
+	pb "github.com/skeleton1231/gotal/internal/proto/user"
+	"github.com/skeleton1231/gotal/pkg/cache"
+	"github.com/skeleton1231/gotal/pkg/log"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// userEventLog keeps the recent user events and fans new ones out to every user_service replica.
+type userEventLog interface {
+	// Append assigns the next resource version to the event and publishes it.
+	Append(ctx context.Context, event *model.UserWatchEvent) error
+
+	// Version returns the resource version of the latest event.
+	Version(ctx context.Context) (uint64, error)
+
+	// Since returns the events after the resource version, oldest first. It fails with
+	// ErrUserWatchExpired when some of them are not retained any more.
+	Since(ctx context.Context, version uint64) ([]*model.UserWatchEvent, error)
+
+	// Subscribe calls fn with every appended event, in resource version order, until ctx
+	// is done or the subscription fails.
+	Subscribe(ctx context.Context, fn func(event *model.UserWatchEvent)) error
+}
+
+// userWatchHistorySize is how many events are retained to resume watches from.
+const userWatchHistorySize = 1000
+
+// checkRetained verifies that events, which follow version, start right after it.
+// Resource versions have no gaps, so a gap means events have been dropped.
+func checkRetained(version uint64, events []*model.UserWatchEvent) error {
+	if len(events) > 0 && events[0].ResourceVersion > version+1 {
+		return errors.WithCode(code.ErrUserWatchExpired,
+			"resource version %d is too old, the oldest retained one is %d", version, events[0].ResourceVersion-1)
+	}
+
+	return nil
+}
+
+// memoryUserEventLog is a userEventLog local to the process.
+type memoryUserEventLog struct {
+	mu          sync.Mutex
+	size        int
+	version     uint64
+	events      []*model.UserWatchEvent
+	subscribers map[int]func(event *model.UserWatchEvent)
+	next        int
+}
+
+func newMemoryUserEventLog(size int) *memoryUserEventLog {
+	return &memoryUserEventLog{size: size, subscribers: map[int]func(*model.UserWatchEvent){}}
+}
+
+// Append implements userEventLog.
+func (l *memoryUserEventLog) Append(ctx context.Context, event *model.UserWatchEvent) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.version++
+	e := *event
+	e.ResourceVersion = l.version
+
+	l.events = append(l.events, &e)
+	if len(l.events) > l.size {
+		l.events = l.events[len(l.events)-l.size:]
+	}
+
+	// Subscribers are called under the lock so that they see the events in order.
+	for _, fn := range l.subscribers {
+		fn(&e)
+	}
+
+	return nil
+}
+
+// Version implements userEventLog.
+func (l *memoryUserEventLog) Version(ctx context.Context) (uint64, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.version, nil
+}
+
+// Since implements userEventLog.
+func (l *memoryUserEventLog) Since(ctx context.Context, version uint64) ([]*model.UserWatchEvent, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var events []*model.UserWatchEvent
+	for _, e := range l.events {
+		if e.ResourceVersion > version {
+			events = append(events, e)
+		}
+	}
+
+	// Everything is gone when even the latest event is older than version.
+	if len(events) == 0 && version < l.version {
+		return nil, errors.WithCode(code.ErrUserWatchExpired, "resource version %d is too old", version)
+	}
+
+	return events, checkRetained(version, events)
+}
+
+// Subscribe implements userEventLog.
+func (l *memoryUserEventLog) Subscribe(ctx context.Context, fn func(event *model.UserWatchEvent)) error {
+	l.mu.Lock()
+	id := l.next
+	l.next++
+	l.subscribers[id] = fn
+	l.mu.Unlock()
+
+	<-ctx.Done()
+
+	l.mu.Lock()
+	delete(l.subscribers, id)
+	l.mu.Unlock()
+
+	return ctx.Err()
+}
+
+const (
+	userWatchKeyPrefix = "gotal-user-watch-"
+	// The keys share a hash tag so that the script can use both of them on a redis cluster.
+	userWatchVersionKey = "{users}-version"
+	userWatchHistoryKey = "{users}-history"
+	userWatchChannel    = "gotal-user-watch-events"
+)
+
+// appendUserEventScript assigns the version, records and publishes an event in one step,
+// so that the events reach every subscriber in version order.
+var appendUserEventScript = redis.NewScript(`
+local version = redis.call('INCR', KEYS[1])
+local message = version .. ' ' .. ARGV[1]
+redis.call('ZADD', KEYS[2], version, message)
+redis.call('ZREMRANGEBYRANK', KEYS[2], 0, -(tonumber(ARGV[2]) + 1))
+redis.call('PUBLISH', ARGV[3], message)
+return version
+`)
+
+// redisUserEventLog is a userEventLog shared by the replicas through the redis connection.
+// Events are recorded in a sorted set scored by their version and announced on a channel.
+type redisUserEventLog struct {
+	cli *cache.RedisClusterV2
+}
+
+func newRedisUserEventLog() *redisUserEventLog {
+	return &redisUserEventLog{cli: &cache.RedisClusterV2{KeyPrefix: userWatchKeyPrefix}}
+}
+
+// Append implements userEventLog.
+func (l *redisUserEventLog) Append(ctx context.Context, event *model.UserWatchEvent) error {
+	data, err := protojson.Marshal(model.UserToProto(event.User))
+	if err != nil {
+		return err
+	}
+
+	_, err = l.cli.RunScript(ctx, appendUserEventScript,
+		[]string{userWatchVersionKey, userWatchHistoryKey},
+		string(event.Type)+" "+string(data), userWatchHistorySize, userWatchChannel)
+
+	return err
+}
+
+// Version implements userEventLog.
+func (l *redisUserEventLog) Version(ctx context.Context) (uint64, error) {
+	data, err := l.cli.GetKey(ctx, userWatchVersionKey)
+	if err != nil {
+		if errors.Is(err, cache.ErrKeyNotFound) {
+			return 0, nil
+		}
+
+		return 0, err
+	}
+
+	return strconv.ParseUint(data, 10, 64)
+}
+
+// Since implements userEventLog.
+func (l *redisUserEventLog) Since(ctx context.Context, version uint64) ([]*model.UserWatchEvent, error) {
+	messages, _, err := l.cli.GetSortedSetRange(ctx, userWatchHistoryKey, "("+strconv.FormatUint(version, 10), "+inf")
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*model.UserWatchEvent, 0, len(messages))
+	for _, message := range messages {
+		event, err := decodeUserEvent(message)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	if len(events) == 0 {
+		latest, err := l.Version(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if version < latest {
+			return nil, errors.WithCode(code.ErrUserWatchExpired, "resource version %d is too old", version)
+		}
+	}
+
+	return events, checkRetained(version, events)
+}
+
+// Subscribe implements userEventLog.
+func (l *redisUserEventLog) Subscribe(ctx context.Context, fn func(event *model.UserWatchEvent)) error {
+	return l.cli.StartPubSubHandler(ctx, userWatchChannel, func(v interface{}) {
+		msg, ok := v.(*redis.Message)
+		if !ok {
+			return
+		}
+
+		event, err := decodeUserEvent(msg.Payload)
+		if err != nil {
+			log.Warnf("ignore malformed user event: %s", err.Error())
+
+			return
+		}
+
+		fn(event)
+	})
+}
+
+// decodeUserEvent parses a `<version> <type> <user as protojson>` message.
+func decodeUserEvent(message string) (*model.UserWatchEvent, error) {
+	parts := strings.SplitN(message, " ", 3)
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("invalid user event `%s`", message)
+	}
+
+	version, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user event version `%s`", parts[0])
+	}
+
+	pbUser := &pb.User{}
+	if err := protojson.Unmarshal([]byte(parts[2]), pbUser); err != nil {
+		return nil, err
+	}
+
+	user, err := model.ProtoToUser(pbUser)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.UserWatchEvent{Type: model.WatchEventType(parts[1]), ResourceVersion: version, User: user}, nil
+}
@@ -0,0 +1,226 @@
+package database
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/skeleton1231/gotal/internal/apiserver/store/model"
+	"github.com/skeleton1231/gotal/internal/pkg/code"
+	"github.com/skeleton1231/gotal/internal/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/fields"
+)
+
+// startMemoryUserWatch replaces the redis backed hub with one local to the test.
+func startMemoryUserWatch(t *testing.T, size int) *memoryUserEventLog {
+	l := newMemoryUserEventLog(size)
+	hub := newUserWatchHub(l)
+
+	previous := userWatch
+	userWatch = hub
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go hub.run(ctx)
+	t.Cleanup(func() {
+		cancel()
+		userWatch = previous
+	})
+
+	require.Eventually(t, func() bool {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		return len(l.subscribers) == 1
+	}, time.Second, time.Millisecond)
+
+	return l
+}
+
+func nextUserEvent(t *testing.T, w model.UserWatcher) *model.UserWatchEvent {
+	select {
+	case event, ok := <-w.ResultChan():
+		require.True(t, ok, "watch ended: %v", w.Err())
+
+		return event
+	case <-time.After(time.Second):
+		require.FailNow(t, "no event received")
+
+		return nil
+	}
+}
+
+func TestUserWatch_CommittedChanges(t *testing.T) {
+	startMemoryUserWatch(t, 10)
+
+	db, mock, err := setupMockDB()
+	require.NoError(t, err)
+	require.NoError(t, registerUserWatchCallbacks(db))
+
+	u := newUsers(&datastore{db})
+	w, err := u.Watch(context.Background(), model.WatchOptions{})
+	require.NoError(t, err)
+	defer w.Stop()
+
+	columns := []string{"id", "name", "password", "extendShadow"}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `users`").WithArgs(anyArgs(16)...).WillReturnResult(sqlmock.NewResult(5, 1))
+	mock.ExpectCommit()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE id IN (?)")).
+		WithArgs(5).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(5, "alice", "hash", "{}"))
+
+	require.NoError(t, u.Create(context.Background(), &model.User{Name: "alice"}, model.CreateOptions{}))
+
+	event := nextUserEvent(t, w)
+	assert.Equal(t, model.WatchEventAdded, event.Type)
+	assert.Equal(t, uint64(1), event.ResourceVersion)
+	assert.Equal(t, "alice", event.User.Name)
+	assert.Empty(t, event.User.Password)
+
+	// Nothing is published for a change which is rolled back.
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `users`").WithArgs(anyArgs(16)...).WillReturnResult(sqlmock.NewResult(6, 1))
+	mock.ExpectRollback()
+
+	dryRun := model.CreateOptions{DryRun: []string{model.DryRunAll}}
+	require.NoError(t, u.Create(context.Background(), &model.User{Name: "bob"}, dryRun))
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE `users` SET `deleted_at`=").WithArgs(sqlmock.AnyArg(), 5).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE id IN (?)")).
+		WithArgs(5).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(5, "alice", "hash", "{}"))
+
+	require.NoError(t, u.Delete(context.Background(), 5, model.DeleteOptions{}))
+
+	event = nextUserEvent(t, w)
+	assert.Equal(t, model.WatchEventDeleted, event.Type)
+	assert.Equal(t, uint64(2), event.ResourceVersion)
+	assert.Equal(t, uint64(5), event.User.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserWatch_DeleteCollection(t *testing.T) {
+	startMemoryUserWatch(t, 10)
+
+	db, mock, err := setupMockDB()
+	require.NoError(t, err)
+	require.NoError(t, registerUserWatchCallbacks(db))
+
+	u := newUsers(&datastore{db})
+	w, err := u.Watch(context.Background(), model.WatchOptions{})
+	require.NoError(t, err)
+	defer w.Stop()
+
+	columns := []string{"id", "name", "extendShadow"}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE id IN (?,?)")).
+		WithArgs(1, 2).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(1, "alice", "{}").AddRow(2, "bob", "{}"))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `users` WHERE `users`.`id` IN (?,?)")).
+		WithArgs(1, 2).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+	// Both users are gone, so they are reported as they were read before the delete.
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE id IN (?,?)")).
+		WithArgs(1, 2).
+		WillReturnRows(sqlmock.NewRows(columns))
+
+	_, err = u.DeleteCollection(context.Background(), []uint64{1, 2}, model.DeleteCollectionOptions{
+		DeleteOptions: model.DeleteOptions{Unscoped: true},
+	})
+	require.NoError(t, err)
+
+	for _, name := range []string{"alice", "bob"} {
+		event := nextUserEvent(t, w)
+		assert.Equal(t, model.WatchEventDeleted, event.Type)
+		assert.Equal(t, name, event.User.Name)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserWatch_Resume(t *testing.T) {
+	l := startMemoryUserWatch(t, 3)
+	ctx := context.Background()
+
+	for _, name := range []string{"alice", "bob", "carol", "dave"} {
+		require.NoError(t, l.Append(ctx, &model.UserWatchEvent{Type: model.WatchEventAdded, User: &model.User{Name: name}}))
+	}
+
+	t.Run("replays the retained history", func(t *testing.T) {
+		w, err := userWatch.watch(ctx, model.WatchOptions{ResourceVersion: 2, FieldSelector: "name=a"})
+		require.NoError(t, err)
+		defer w.Stop()
+
+		// bob does not match the selector, carol is the first event after version 2.
+		event := nextUserEvent(t, w)
+		assert.Equal(t, "carol", event.User.Name)
+		assert.Equal(t, uint64(3), event.ResourceVersion)
+
+		event = nextUserEvent(t, w)
+		assert.Equal(t, "dave", event.User.Name)
+
+		require.NoError(t, l.Append(ctx, &model.UserWatchEvent{Type: model.WatchEventModified, User: &model.User{Name: "alice"}}))
+		event = nextUserEvent(t, w)
+		assert.Equal(t, model.WatchEventModified, event.Type)
+		assert.Equal(t, uint64(5), event.ResourceVersion)
+	})
+
+	t.Run("expired version", func(t *testing.T) {
+		_, err := userWatch.watch(ctx, model.WatchOptions{ResourceVersion: 1})
+		assert.True(t, errors.IsCode(err, code.ErrUserWatchExpired), "%v", err)
+	})
+
+	t.Run("invalid selector", func(t *testing.T) {
+		_, err := userWatch.watch(ctx, model.WatchOptions{FieldSelector: "name"})
+		assert.True(t, errors.IsCode(err, code.ErrValidation), "%v", err)
+	})
+}
+
+func TestUserWatch_SlowWatcherIsClosed(t *testing.T) {
+	l := startMemoryUserWatch(t, userWatchBuffer*2)
+	ctx := context.Background()
+
+	w, err := userWatch.watch(ctx, model.WatchOptions{})
+	require.NoError(t, err)
+	defer w.Stop()
+
+	// The first event is held by the watcher itself, the rest fills the buffer up.
+	for i := 0; i <= userWatchBuffer+1; i++ {
+		require.NoError(t, l.Append(ctx, &model.UserWatchEvent{Type: model.WatchEventAdded, User: &model.User{}}))
+	}
+
+	for range w.ResultChan() {
+	}
+	assert.True(t, errors.IsCode(w.Err(), code.ErrUserWatchClosed), "%v", w.Err())
+}
+
+func TestUserMatchesFieldSelector(t *testing.T) {
+	user := &model.User{ObjectMeta: model.ObjectMeta{Status: 1}, Name: "Alice", Email: "alice@example.com", DiscordID: 42}
+
+	tests := []struct {
+		selector string
+		match    bool
+	}{
+		{selector: "", match: true},
+		{selector: "name=ali", match: true},
+		{selector: "name=bob", match: false},
+		{selector: "email=EXAMPLE", match: true},
+		{selector: "discordId=42,status=1", match: true},
+		{selector: "status=0", match: false},
+		{selector: "stripeId=cus_1", match: false},
+	}
+
+	for _, tt := range tests {
+		selector, err := fields.ParseSelector(tt.selector)
+		require.NoError(t, err)
+		assert.Equal(t, tt.match, userMatchesFieldSelector(user, selector), tt.selector)
+	}
+}
@@ -0,0 +1,137 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/skeleton1231/gotal/internal/apiserver/store (interfaces: RoleStore)
+
+// Package mock_store is a generated GoMock package.
+package mock_store
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/skeleton1231/gotal/internal/apiserver/store/model"
+)
+
+// MockRoleStore is a mock of RoleStore interface.
+type MockRoleStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockRoleStoreMockRecorder
+}
+
+// MockRoleStoreMockRecorder is the mock recorder for MockRoleStore.
+type MockRoleStoreMockRecorder struct {
+	mock *MockRoleStore
+}
+
+// NewMockRoleStore creates a new mock instance.
+func NewMockRoleStore(ctrl *gomock.Controller) *MockRoleStore {
+	mock := &MockRoleStore{ctrl: ctrl}
+	mock.recorder = &MockRoleStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRoleStore) EXPECT() *MockRoleStoreMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockRoleStore) Create(arg0 context.Context, arg1 *model.Role, arg2 model.CreateOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRoleStoreMockRecorder) Create(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRoleStore)(nil).Create), arg0, arg1, arg2)
+}
+
+// Delete mocks base method.
+func (m *MockRoleStore) Delete(arg0 context.Context, arg1 uint64, arg2 model.DeleteOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRoleStoreMockRecorder) Delete(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRoleStore)(nil).Delete), arg0, arg1, arg2)
+}
+
+// Get mocks base method.
+func (m *MockRoleStore) Get(arg0 context.Context, arg1 uint64, arg2 model.GetOptions) (*model.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockRoleStoreMockRecorder) Get(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRoleStore)(nil).Get), arg0, arg1, arg2)
+}
+
+// Grant mocks base method.
+func (m *MockRoleStore) Grant(arg0 context.Context, arg1, arg2 uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Grant", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Grant indicates an expected call of Grant.
+func (mr *MockRoleStoreMockRecorder) Grant(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Grant", reflect.TypeOf((*MockRoleStore)(nil).Grant), arg0, arg1, arg2)
+}
+
+// List mocks base method.
+func (m *MockRoleStore) List(arg0 context.Context, arg1 model.ListOptions) (*model.RoleList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0, arg1)
+	ret0, _ := ret[0].(*model.RoleList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockRoleStoreMockRecorder) List(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRoleStore)(nil).List), arg0, arg1)
+}
+
+// ListUserRoles mocks base method.
+func (m *MockRoleStore) ListUserRoles(arg0 context.Context, arg1 uint64) (*model.RoleList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserRoles", arg0, arg1)
+	ret0, _ := ret[0].(*model.RoleList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserRoles indicates an expected call of ListUserRoles.
+func (mr *MockRoleStoreMockRecorder) ListUserRoles(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserRoles", reflect.TypeOf((*MockRoleStore)(nil).ListUserRoles), arg0, arg1)
+}
+
+// Revoke mocks base method.
+func (m *MockRoleStore) Revoke(arg0 context.Context, arg1, arg2 uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockRoleStoreMockRecorder) Revoke(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockRoleStore)(nil).Revoke), arg0, arg1, arg2)
+}
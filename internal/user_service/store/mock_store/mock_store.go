@@ -34,6 +34,20 @@ func (m *MockFactory) EXPECT() *MockFactoryMockRecorder {
 	return m.recorder
 }
 
+// APIKeys mocks base method.
+func (m *MockFactory) APIKeys() store.APIKeyStore {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "APIKeys")
+	ret0, _ := ret[0].(store.APIKeyStore)
+	return ret0
+}
+
+// APIKeys indicates an expected call of APIKeys.
+func (mr *MockFactoryMockRecorder) APIKeys() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "APIKeys", reflect.TypeOf((*MockFactory)(nil).APIKeys))
+}
+
 // Close mocks base method.
 func (m *MockFactory) Close() error {
 	m.ctrl.T.Helper()
@@ -48,6 +62,48 @@ func (mr *MockFactoryMockRecorder) Close() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockFactory)(nil).Close))
 }
 
+// Roles mocks base method.
+func (m *MockFactory) Roles() store.RoleStore {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Roles")
+	ret0, _ := ret[0].(store.RoleStore)
+	return ret0
+}
+
+// Roles indicates an expected call of Roles.
+func (mr *MockFactoryMockRecorder) Roles() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Roles", reflect.TypeOf((*MockFactory)(nil).Roles))
+}
+
+// Sessions mocks base method.
+func (m *MockFactory) Sessions() store.SessionStore {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sessions")
+	ret0, _ := ret[0].(store.SessionStore)
+	return ret0
+}
+
+// Sessions indicates an expected call of Sessions.
+func (mr *MockFactoryMockRecorder) Sessions() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sessions", reflect.TypeOf((*MockFactory)(nil).Sessions))
+}
+
+// TwoFactors mocks base method.
+func (m *MockFactory) TwoFactors() store.TwoFactorStore {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TwoFactors")
+	ret0, _ := ret[0].(store.TwoFactorStore)
+	return ret0
+}
+
+// TwoFactors indicates an expected call of TwoFactors.
+func (mr *MockFactoryMockRecorder) TwoFactors() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TwoFactors", reflect.TypeOf((*MockFactory)(nil).TwoFactors))
+}
+
 // Users mocks base method.
 func (m *MockFactory) Users() store.UserStore {
 	m.ctrl.T.Helper()
@@ -0,0 +1,123 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/skeleton1231/gotal/internal/apiserver/store (interfaces: TwoFactorStore)
+
+// Package mock_store is a generated GoMock package.
+package mock_store
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/skeleton1231/gotal/internal/apiserver/store/model"
+)
+
+// MockTwoFactorStore is a mock of TwoFactorStore interface.
+type MockTwoFactorStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockTwoFactorStoreMockRecorder
+}
+
+// MockTwoFactorStoreMockRecorder is the mock recorder for MockTwoFactorStore.
+type MockTwoFactorStoreMockRecorder struct {
+	mock *MockTwoFactorStore
+}
+
+// NewMockTwoFactorStore creates a new mock instance.
+func NewMockTwoFactorStore(ctrl *gomock.Controller) *MockTwoFactorStore {
+	mock := &MockTwoFactorStore{ctrl: ctrl}
+	mock.recorder = &MockTwoFactorStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTwoFactorStore) EXPECT() *MockTwoFactorStoreMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockTwoFactorStore) Delete(arg0 context.Context, arg1 uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockTwoFactorStoreMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTwoFactorStore)(nil).Delete), arg0, arg1)
+}
+
+// Enable mocks base method.
+func (m *MockTwoFactorStore) Enable(arg0 context.Context, arg1 uint64, arg2 int64, arg3 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enable", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Enable indicates an expected call of Enable.
+func (mr *MockTwoFactorStoreMockRecorder) Enable(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enable", reflect.TypeOf((*MockTwoFactorStore)(nil).Enable), arg0, arg1, arg2, arg3)
+}
+
+// Get mocks base method.
+func (m *MockTwoFactorStore) Get(arg0 context.Context, arg1 uint64) (*model.TwoFactor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].(*model.TwoFactor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockTwoFactorStoreMockRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockTwoFactorStore)(nil).Get), arg0, arg1)
+}
+
+// Save mocks base method.
+func (m *MockTwoFactorStore) Save(arg0 context.Context, arg1 *model.TwoFactor) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockTwoFactorStoreMockRecorder) Save(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockTwoFactorStore)(nil).Save), arg0, arg1)
+}
+
+// UseRecoveryCode mocks base method.
+func (m *MockTwoFactorStore) UseRecoveryCode(arg0 context.Context, arg1 uint64, arg2 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseRecoveryCode", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseRecoveryCode indicates an expected call of UseRecoveryCode.
+func (mr *MockTwoFactorStoreMockRecorder) UseRecoveryCode(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseRecoveryCode", reflect.TypeOf((*MockTwoFactorStore)(nil).UseRecoveryCode), arg0, arg1, arg2)
+}
+
+// UseStep mocks base method.
+func (m *MockTwoFactorStore) UseStep(arg0 context.Context, arg1 uint64, arg2 int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseStep", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseStep indicates an expected call of UseStep.
+func (mr *MockTwoFactorStoreMockRecorder) UseStep(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseStep", reflect.TypeOf((*MockTwoFactorStore)(nil).UseStep), arg0, arg1, arg2)
+}
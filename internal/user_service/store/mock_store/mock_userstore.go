@@ -35,6 +35,34 @@ func (m *MockUserStore) EXPECT() *MockUserStoreMockRecorder {
 	return m.recorder
 }
 
+// ChangeCredits mocks base method.
+func (m *MockUserStore) ChangeCredits(arg0 context.Context, arg1 *model.CreditTransaction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeCredits", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangeCredits indicates an expected call of ChangeCredits.
+func (mr *MockUserStoreMockRecorder) ChangeCredits(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeCredits", reflect.TypeOf((*MockUserStore)(nil).ChangeCredits), arg0, arg1)
+}
+
+// ChangePassword mocks base method.
+func (m *MockUserStore) ChangePassword(arg0 context.Context, arg1 uint64, arg2 string, arg3 model.ChangePasswordOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePassword", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangePassword indicates an expected call of ChangePassword.
+func (mr *MockUserStoreMockRecorder) ChangePassword(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockUserStore)(nil).ChangePassword), arg0, arg1, arg2, arg3)
+}
+
 // Create mocks base method.
 func (m *MockUserStore) Create(arg0 context.Context, arg1 *model.User, arg2 model.CreateOptions) error {
 	m.ctrl.T.Helper()
@@ -63,6 +91,35 @@ func (mr *MockUserStoreMockRecorder) Delete(arg0, arg1, arg2 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUserStore)(nil).Delete), arg0, arg1, arg2)
 }
 
+// DeleteCollection mocks base method.
+func (m *MockUserStore) DeleteCollection(arg0 context.Context, arg1 []uint64, arg2 model.DeleteCollectionOptions) ([]*model.UserDeleteResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCollection", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.UserDeleteResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCollection indicates an expected call of DeleteCollection.
+func (mr *MockUserStoreMockRecorder) DeleteCollection(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCollection", reflect.TypeOf((*MockUserStore)(nil).DeleteCollection), arg0, arg1, arg2)
+}
+
+// Export mocks base method.
+func (m *MockUserStore) Export(arg0 context.Context, arg1 model.ListOptions, arg2 func(*model.User) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockUserStoreMockRecorder) Export(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockUserStore)(nil).Export), arg0, arg1, arg2)
+}
+
 // Get mocks base method.
 func (m *MockUserStore) Get(arg0 context.Context, arg1 uint64, arg2 model.GetOptions) (*model.User, error) {
 	m.ctrl.T.Helper()
@@ -78,6 +135,36 @@ func (mr *MockUserStoreMockRecorder) Get(arg0, arg1, arg2 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockUserStore)(nil).Get), arg0, arg1, arg2)
 }
 
+// GetByDiscordID mocks base method.
+func (m *MockUserStore) GetByDiscordID(arg0 context.Context, arg1 uint64, arg2 model.GetOptions) (*model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByDiscordID", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByDiscordID indicates an expected call of GetByDiscordID.
+func (mr *MockUserStoreMockRecorder) GetByDiscordID(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByDiscordID", reflect.TypeOf((*MockUserStore)(nil).GetByDiscordID), arg0, arg1, arg2)
+}
+
+// GetByEmail mocks base method.
+func (m *MockUserStore) GetByEmail(arg0 context.Context, arg1 string, arg2 model.GetOptions) (*model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByEmail", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByEmail indicates an expected call of GetByEmail.
+func (mr *MockUserStoreMockRecorder) GetByEmail(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByEmail", reflect.TypeOf((*MockUserStore)(nil).GetByEmail), arg0, arg1, arg2)
+}
+
 // GetByUsername mocks base method.
 func (m *MockUserStore) GetByUsername(arg0 context.Context, arg1 string, arg2 model.GetOptions) (*model.User, error) {
 	m.ctrl.T.Helper()
@@ -93,6 +180,21 @@ func (mr *MockUserStoreMockRecorder) GetByUsername(arg0, arg1, arg2 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUsername", reflect.TypeOf((*MockUserStore)(nil).GetByUsername), arg0, arg1, arg2)
 }
 
+// Import mocks base method.
+func (m *MockUserStore) Import(arg0 context.Context, arg1 []*model.User, arg2 model.ImportOptions) (*model.UserImportSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.UserImportSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockUserStoreMockRecorder) Import(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockUserStore)(nil).Import), arg0, arg1, arg2)
+}
+
 // List mocks base method.
 func (m *MockUserStore) List(arg0 context.Context, arg1 model.ListOptions) (*model.UserList, error) {
 	m.ctrl.T.Helper()
@@ -108,6 +210,35 @@ func (mr *MockUserStoreMockRecorder) List(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockUserStore)(nil).List), arg0, arg1)
 }
 
+// ListTransactions mocks base method.
+func (m *MockUserStore) ListTransactions(arg0 context.Context, arg1 uint64, arg2 model.ListOptions) (*model.CreditTransactionList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransactions", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.CreditTransactionList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransactions indicates an expected call of ListTransactions.
+func (mr *MockUserStoreMockRecorder) ListTransactions(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransactions", reflect.TypeOf((*MockUserStore)(nil).ListTransactions), arg0, arg1, arg2)
+}
+
+// Patch mocks base method.
+func (m *MockUserStore) Patch(arg0 context.Context, arg1 *model.User, arg2 []string, arg3 model.PatchOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Patch", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Patch indicates an expected call of Patch.
+func (mr *MockUserStoreMockRecorder) Patch(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Patch", reflect.TypeOf((*MockUserStore)(nil).Patch), arg0, arg1, arg2, arg3)
+}
+
 // Update mocks base method.
 func (m *MockUserStore) Update(arg0 context.Context, arg1 *model.User, arg2 model.UpdateOptions) error {
 	m.ctrl.T.Helper()
@@ -121,3 +252,18 @@ func (mr *MockUserStoreMockRecorder) Update(arg0, arg1, arg2 interface{}) *gomoc
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockUserStore)(nil).Update), arg0, arg1, arg2)
 }
+
+// Watch mocks base method.
+func (m *MockUserStore) Watch(arg0 context.Context, arg1 model.WatchOptions) (model.UserWatcher, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Watch", arg0, arg1)
+	ret0, _ := ret[0].(model.UserWatcher)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Watch indicates an expected call of Watch.
+func (mr *MockUserStoreMockRecorder) Watch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Watch", reflect.TypeOf((*MockUserStore)(nil).Watch), arg0, arg1)
+}
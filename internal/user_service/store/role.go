@@ -0,0 +1,18 @@
+package store
+
+import (
+	"context"
+
+	"github.com/skeleton1231/gotal/internal/apiserver/store/model"
+)
+
+// RoleStore defines the role storage interface.
+type RoleStore interface {
+	Create(ctx context.Context, role *model.Role, opts model.CreateOptions) error
+	Get(ctx context.Context, roleId uint64, opts model.GetOptions) (*model.Role, error)
+	List(ctx context.Context, opts model.ListOptions) (*model.RoleList, error)
+	Delete(ctx context.Context, roleId uint64, opts model.DeleteOptions) error
+	Grant(ctx context.Context, userId uint64, roleId uint64) error
+	Revoke(ctx context.Context, userId uint64, roleId uint64) error
+	ListUserRoles(ctx context.Context, userId uint64) (*model.RoleList, error)
+}
@@ -0,0 +1,24 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/skeleton1231/gotal/internal/apiserver/store/model"
+)
+
+// TrialStore defines the storage of the trial lifecycle. Only active users are considered,
+// and each transition is recorded, so it happens once per trial across every replica.
+type TrialStore interface {
+	// Ending returns up to limit users whose trial ends in (from, to] and who were not reminded yet.
+	Ending(ctx context.Context, from, to time.Time, limit int) ([]*model.User, error)
+	// Expired returns up to limit users without a payment method whose trial ended by now.
+	Expired(ctx context.Context, now time.Time, limit int) ([]*model.User, error)
+	// Remind records the reminder of the trial of the user, then calls notify outside of any
+	// transaction and records the delivery. A reminder is tried again when notify fails. It
+	// reports false when the reminder was delivered or is being sent by another replica.
+	Remind(ctx context.Context, user *model.User, notify func(ctx context.Context) error) (bool, error)
+	// Expire gives the user the status and records the expiry. It reports false when the
+	// expiry was already recorded, or the trial of the user changed meanwhile.
+	Expire(ctx context.Context, user *model.User, status int) (bool, error)
+}
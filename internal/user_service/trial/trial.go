@@ -0,0 +1,166 @@
+// Copyright 2023 Talhuang<talhuang1231@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+// Package trial reminds users that their trial ends soon, and gives the users whose
+// trial ended without a payment method the post-trial status.
+package trial
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/skeleton1231/gotal/internal/apiserver/store/model"
+	"github.com/skeleton1231/gotal/internal/pkg/options"
+	"github.com/skeleton1231/gotal/internal/user_service/store"
+	"github.com/skeleton1231/gotal/pkg/log"
+	"github.com/skeleton1231/gotal/pkg/mail"
+)
+
+// Notifier tells users about their trial.
+type Notifier interface {
+	TrialEnding(ctx context.Context, user *model.User) error
+}
+
+// MailNotifier emails the reminders.
+type MailNotifier struct {
+	mailer mail.Mailer
+	from   string
+}
+
+// NewMailNotifier creates a notifier sending emails from the address.
+func NewMailNotifier(mailer mail.Mailer, from string) *MailNotifier {
+	return &MailNotifier{mailer: mailer, from: from}
+}
+
+// TrialEnding implements Notifier.
+func (n *MailNotifier) TrialEnding(ctx context.Context, user *model.User) error {
+	return n.mailer.Send(ctx, &mail.Message{
+		From:    n.from,
+		To:      []string{user.Email},
+		Subject: "Your trial ends soon",
+		Body: fmt.Sprintf("Hello %s,\n\nYour trial ends on %s. Add a payment method before then "+
+			"to keep using your account.\n", user.Name, user.TrialEndsAt.UTC().Format("January 2, 2006 15:04 MST")),
+	})
+}
+
+// LogNotifier only logs the reminders, it is used when no mail driver is configured.
+type LogNotifier struct{}
+
+// TrialEnding implements Notifier.
+func (LogNotifier) TrialEnding(_ context.Context, user *model.User) error {
+	log.Infof("trial of user %d ends at %s", user.ID, user.TrialEndsAt.UTC().Format(time.RFC3339))
+
+	return nil
+}
+
+// Processor moves the trials of the users through their lifecycle. Several replicas may run
+// a processor, the store makes sure every transition happens once.
+type Processor struct {
+	store    store.TrialStore
+	notifier Notifier
+	opts     *options.TrialOptions
+	now      func() time.Time
+}
+
+// NewProcessor creates a processor of the trials in the store.
+func NewProcessor(store store.TrialStore, notifier Notifier, opts *options.TrialOptions) *Processor {
+	return &Processor{
+		store:    store,
+		notifier: notifier,
+		opts:     opts,
+		now:      time.Now,
+	}
+}
+
+// Run processes the trials every interval until ctx is canceled.
+func (p *Processor) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := p.RunOnce(ctx); err != nil {
+			log.Errorf("process trials failed: %s", err.Error())
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce sends the reminders which are due and expires the ended trials. Users whose
+// transition failed are left for the next run.
+func (p *Processor) RunOnce(ctx context.Context) error {
+	now := p.now()
+
+	if p.opts.RemindDays > 0 {
+		remindBefore := now.Add(time.Duration(p.opts.RemindDays) * 24 * time.Hour)
+		err := p.batches(ctx, func() ([]*model.User, error) {
+			return p.store.Ending(ctx, now, remindBefore, p.opts.BatchSize)
+		}, p.remind)
+		if err != nil {
+			return err
+		}
+	}
+
+	return p.batches(ctx, func() ([]*model.User, error) {
+		return p.store.Expired(ctx, now, p.opts.BatchSize)
+	}, p.expire)
+}
+
+// batches transitions the users of the batches until one is not full. Processed users
+// drop out of the query, so it is run again rather than paged through.
+func (p *Processor) batches(ctx context.Context, next func() ([]*model.User, error),
+	transition func(ctx context.Context, user *model.User) error) error {
+	for ctx.Err() == nil {
+		users, err := next()
+		if err != nil {
+			return err
+		}
+
+		failed := 0
+		for _, user := range users {
+			if err := transition(ctx, user); err != nil {
+				log.Errorf("trial of user %d: %s", user.ID, err.Error())
+				failed++
+			}
+		}
+
+		// Failed users would be returned again right away.
+		if len(users) < p.opts.BatchSize || failed > 0 {
+			return nil
+		}
+	}
+
+	return ctx.Err()
+}
+
+func (p *Processor) remind(ctx context.Context, user *model.User) error {
+	ok, err := p.store.Remind(ctx, user, func(ctx context.Context) error {
+		return p.notifier.TrialEnding(ctx, user)
+	})
+	if err != nil {
+		return fmt.Errorf("remind: %w", err)
+	}
+	if ok {
+		log.Infof("reminded user %d of the end of the trial", user.ID)
+	}
+
+	return nil
+}
+
+func (p *Processor) expire(ctx context.Context, user *model.User) error {
+	ok, err := p.store.Expire(ctx, user, p.opts.PostTrialStatus)
+	if err != nil {
+		return fmt.Errorf("expire: %w", err)
+	}
+	if ok {
+		log.Infof("trial of user %d expired, status changed to %d", user.ID, p.opts.PostTrialStatus)
+	}
+
+	return nil
+}
@@ -0,0 +1,193 @@
+package trial
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/skeleton1231/gotal/internal/apiserver/store/model"
+	"github.com/skeleton1231/gotal/internal/pkg/options"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryStore keeps the users and the recorded transitions in memory. Like the database,
+// it serializes the transitions of a trial, so replicas sharing it never repeat one.
+type memoryStore struct {
+	mu          sync.Mutex
+	users       []*model.User
+	transitions map[string]bool
+}
+
+func newMemoryStore(users ...*model.User) *memoryStore {
+	return &memoryStore{users: users, transitions: map[string]bool{}}
+}
+
+func key(user *model.User, kind string) string {
+	return fmt.Sprintf("%d/%s/%d", user.ID, kind, user.TrialEndsAt.Unix())
+}
+
+func (m *memoryStore) find(kind string, match func(user *model.User) bool, limit int) []*model.User {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var users []*model.User
+	for _, user := range m.users {
+		if user.Status == 1 && match(user) && !m.transitions[key(user, kind)] && len(users) < limit {
+			copied := *user
+			users = append(users, &copied)
+		}
+	}
+
+	return users
+}
+
+func (m *memoryStore) Ending(_ context.Context, from, to time.Time, limit int) ([]*model.User, error) {
+	return m.find(model.TrialReminded, func(user *model.User) bool {
+		return user.TrialEndsAt.After(from) && !user.TrialEndsAt.After(to)
+	}, limit), nil
+}
+
+func (m *memoryStore) Expired(_ context.Context, now time.Time, limit int) ([]*model.User, error) {
+	return m.find(model.TrialExpired, func(user *model.User) bool {
+		return user.PMType == "" && user.TrialEndsAt.After(time.Unix(0, 0)) && !user.TrialEndsAt.After(now)
+	}, limit), nil
+}
+
+func (m *memoryStore) Remind(ctx context.Context, user *model.User, notify func(ctx context.Context) error) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.transitions[key(user, model.TrialReminded)] {
+		return false, nil
+	}
+	if err := notify(ctx); err != nil {
+		return false, err
+	}
+	m.transitions[key(user, model.TrialReminded)] = true
+
+	return true, nil
+}
+
+func (m *memoryStore) Expire(_ context.Context, user *model.User, status int) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.transitions[key(user, model.TrialExpired)] {
+		return false, nil
+	}
+	for _, stored := range m.users {
+		if stored.ID == user.ID && stored.Status == 1 && stored.TrialEndsAt.Equal(user.TrialEndsAt) {
+			stored.Status = status
+			m.transitions[key(user, model.TrialExpired)] = true
+
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// countingNotifier counts the reminders of every user, and fails for those in fail.
+type countingNotifier struct {
+	mu    sync.Mutex
+	sent  map[uint64]int
+	fail  map[uint64]bool
+	calls int
+}
+
+func (n *countingNotifier) TrialEnding(_ context.Context, user *model.User) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.calls++
+	if n.fail[user.ID] {
+		return errors.New("mail server unavailable")
+	}
+	n.sent[user.ID]++
+
+	return nil
+}
+
+func newUser(id uint64, trialEndsAt time.Time) *model.User {
+	user := &model.User{Name: "user", TrialEndsAt: trialEndsAt}
+	user.ID = id
+	user.Status = 1
+
+	return user
+}
+
+func TestProcessor_RunOnce(t *testing.T) {
+	now := time.Now()
+	store := newMemoryStore(
+		newUser(1, now.Add(2*24*time.Hour)),  // reminded
+		newUser(2, now.Add(10*24*time.Hour)), // too early
+		newUser(3, now.Add(-time.Hour)),      // expired
+		newUser(4, time.Time{}),              // no trial
+	)
+	paying := newUser(5, now.Add(-time.Hour))
+	paying.PMType = "visa"
+	store.users = append(store.users, paying)
+
+	opts := options.NewTrialOptions()
+	opts.BatchSize = 1
+	notifier := &countingNotifier{sent: map[uint64]int{}}
+
+	// Replicas running at the same time share the transitions.
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p := NewProcessor(store, notifier, opts)
+			p.now = func() time.Time { return now }
+			assert.NoError(t, p.RunOnce(context.Background()))
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, map[uint64]int{1: 1}, notifier.sent)
+	assert.Equal(t, []int{1, 1, opts.PostTrialStatus, 1, 1},
+		[]int{store.users[0].Status, store.users[1].Status, store.users[2].Status, store.users[3].Status, store.users[4].Status})
+}
+
+func TestProcessor_NotifyFailure(t *testing.T) {
+	now := time.Now()
+	store := newMemoryStore(newUser(1, now.Add(time.Hour)), newUser(2, now.Add(2*time.Hour)))
+	notifier := &countingNotifier{sent: map[uint64]int{}, fail: map[uint64]bool{1: true}}
+
+	opts := options.NewTrialOptions()
+	opts.BatchSize = 2
+	p := NewProcessor(store, notifier, opts)
+	p.now = func() time.Time { return now }
+
+	// The failed user does not hold the run up forever, and is tried again next run.
+	require.NoError(t, p.RunOnce(context.Background()))
+	assert.Equal(t, map[uint64]int{2: 1}, notifier.sent)
+	assert.Equal(t, 2, notifier.calls)
+
+	notifier.fail = nil
+	require.NoError(t, p.RunOnce(context.Background()))
+	assert.Equal(t, map[uint64]int{1: 1, 2: 1}, notifier.sent)
+}
+
+func TestProcessor_ExtendedTrial(t *testing.T) {
+	now := time.Now()
+	user := newUser(1, now.Add(time.Hour))
+	store := newMemoryStore(user)
+	notifier := &countingNotifier{sent: map[uint64]int{}}
+
+	p := NewProcessor(store, notifier, options.NewTrialOptions())
+	p.now = func() time.Time { return now }
+	require.NoError(t, p.RunOnce(context.Background()))
+	require.NoError(t, p.RunOnce(context.Background()))
+	assert.Equal(t, 1, notifier.sent[1])
+
+	// An extended trial is a new trial, with its own reminder.
+	user.TrialEndsAt = now.Add(2 * time.Hour)
+	require.NoError(t, p.RunOnce(context.Background()))
+	assert.Equal(t, 2, notifier.sent[1])
+}
@@ -6,7 +6,9 @@ package app
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"strings"
 
 	"github.com/fatih/color"
 	"github.com/sirupsen/logrus"
@@ -252,13 +254,31 @@ func addCmdTemplate(cmd *cobra.Command, namedFlagSets flag.NamedFlagSets) {
 	cols, _, _ := term.TerminalSize(cmd.OutOrStdout())
 	cmd.SetUsageFunc(func(cmd *cobra.Command) error {
 		fmt.Fprintf(cmd.OutOrStderr(), usageFmt, cmd.UseLine())
+		printSubcommands(cmd.OutOrStderr(), cmd)
 		flag.PrintSections(cmd.OutOrStderr(), namedFlagSets, cols)
 
 		return nil
 	})
 	cmd.SetHelpFunc(func(cmd *cobra.Command, args []string) {
 		fmt.Fprintf(cmd.OutOrStdout(), "%s\n\n"+usageFmt, cmd.Long, cmd.UseLine())
+		printSubcommands(cmd.OutOrStdout(), cmd)
 		flag.PrintSections(cmd.OutOrStdout(), namedFlagSets, cols)
 
 	})
 }
+
+// printSubcommands lists the subcommands of the command, if it has any.
+func printSubcommands(w io.Writer, cmd *cobra.Command) {
+	if !cmd.HasAvailableSubCommands() {
+		return
+	}
+
+	fmt.Fprintf(w, "\nAvailable Commands:\n")
+	for _, sub := range cmd.Commands() {
+		if sub.IsAvailableCommand() {
+			summary, _, _ := strings.Cut(sub.Short, "\n")
+			fmt.Fprintf(w, "  %-*s %s\n", sub.NamePadding(), sub.Name(), summary)
+		}
+	}
+	fmt.Fprintln(w)
+}
@@ -11,7 +11,10 @@ import (
 	"strings"
 
 	"github.com/fatih/color"
+	"github.com/skeleton1231/gotal/pkg/util/flag"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
 )
 
 // Command struct represents an individual CLI command.
@@ -85,9 +88,16 @@ func (c *Command) cobraCommand() *cobra.Command {
 		cmd.Run = c.runCommand
 	}
 	if c.options != nil {
-		for _, f := range c.options.Flags().FlagSets {
+		namedFlagSets := c.options.Flags()
+		// Commands with options read the same configuration file as the App.
+		namedFlagSets.FlagSet("global").AddFlag(pflag.Lookup(configFlagName))
+		for _, f := range namedFlagSets.FlagSets {
 			cmd.Flags().AddFlagSet(f)
 		}
+		addCmdTemplate(cmd, namedFlagSets)
+	} else if len(c.commands) > 0 {
+		// Groups of commands list their subcommands rather than the flags of the App.
+		addCmdTemplate(cmd, flag.NamedFlagSets{})
 	}
 	addHelpCommandFlag(c.usage, cmd.Flags())
 
@@ -96,6 +106,10 @@ func (c *Command) cobraCommand() *cobra.Command {
 
 // runCommand is the function to run when the cobra command is executed.
 func (c *Command) runCommand(cmd *cobra.Command, args []string) {
+	if err := c.applyOptions(cmd); err != nil {
+		fmt.Printf("%v %v\n", color.RedString("Error:"), err)
+		os.Exit(1)
+	}
 	if c.runFunc != nil {
 		if err := c.runFunc(args); err != nil {
 			// Print the error and exit the program with an error code.
@@ -105,16 +119,44 @@ func (c *Command) runCommand(cmd *cobra.Command, args []string) {
 	}
 }
 
-// App functions were not defined in the given code, but are used for adding commands to an App instance.
+// applyOptions fills the options of the command from the flags and the configuration file,
+// the same way the App does for its own options.
+func (c *Command) applyOptions(cmd *cobra.Command) error {
+	if c.options == nil {
+		return nil
+	}
+
+	if err := viper.BindPFlags(cmd.Flags()); err != nil {
+		return err
+	}
+	if err := viper.Unmarshal(c.options); err != nil {
+		return err
+	}
+	if completeableOptions, ok := c.options.(CompleteableOptions); ok {
+		if err := completeableOptions.Complete(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
 
 // AddCommand adds a command to the App.
 func (a *App) AddCommand(cmd *Command) {
-	a.commands = append(a.commands, cmd)
+	a.AddCommands(cmd)
 }
 
-// AddCommands adds multiple commands to the App.
+// AddCommands adds multiple commands to the App. The cobra command is built by NewApp, so
+// commands added afterwards are attached to it directly.
 func (a *App) AddCommands(cmds ...*Command) {
 	a.commands = append(a.commands, cmds...)
+	if a.cmd == nil {
+		return
+	}
+	for _, command := range cmds {
+		a.cmd.AddCommand(command.cobraCommand())
+	}
+	a.cmd.SetHelpCommand(helpCommand(FormatBaseName(a.basename)))
 }
 
 // FormatBaseName cleans up and formats the basename, especially for windows OS.
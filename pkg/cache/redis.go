@@ -513,6 +513,23 @@ func (r *RedisClusterV2) SetRawKey(ctx context.Context, keyName, session string,
 	return nil
 }
 
+// SetRawKeyIfNotExists sets the value of the given key only when the key does not exist,
+// and reports whether it was set.
+func (r *RedisClusterV2) SetRawKeyIfNotExists(ctx context.Context, keyName, value string, timeout time.Duration) (bool, error) {
+	if err := r.up(); err != nil {
+		return false, err
+	}
+	log.Debugf("[STORE] SETNX Raw key is: %s", keyName)
+	ok, err := r.singleton().SetNX(ctx, keyName, value, timeout).Result()
+	if err != nil {
+		log.Errorf("Error trying to set value: %s", err.Error())
+
+		return false, err
+	}
+
+	return ok, nil
+}
+
 // Decrement will decrement a key in redis.
 func (r *RedisClusterV2) Decrement(ctx context.Context, keyName string) {
 	keyName = r.fixKey(keyName)
@@ -895,6 +912,20 @@ func (r *RedisClusterV2) Publish(ctx context.Context, channel, message string) e
 	return nil
 }
 
+// RunScript runs the lua script atomically. Keys are prefixed like every other key.
+func (r *RedisClusterV2) RunScript(ctx context.Context, script *redis.Script, keys []string, args ...interface{}) (interface{}, error) {
+	if err := r.up(); err != nil {
+		return nil, err
+	}
+
+	fixedKeys := make([]string, 0, len(keys))
+	for _, key := range keys {
+		fixedKeys = append(fixedKeys, r.fixKey(key))
+	}
+
+	return script.Run(ctx, r.singleton(), fixedKeys, args...).Result()
+}
+
 // GetAndDeleteSet get and delete a key.
 func (r *RedisClusterV2) GetAndDeleteSet(ctx context.Context, keyName string) []interface{} {
 	log.Debugf("Getting raw key set: %s", keyName)
@@ -0,0 +1,100 @@
+// Copyright 2023 Talhuang<talhuang1231@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+// Package mail sends plain text emails through SMTP, or keeps them in an outbox
+// directory for development and tests.
+package mail
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+	"time"
+)
+
+// Message is a plain text email.
+type Message struct {
+	From    string
+	To      []string
+	Subject string
+	Body    string
+	Date    time.Time
+}
+
+// Mailer delivers messages.
+type Mailer interface {
+	Send(ctx context.Context, msg *Message) error
+}
+
+// Bytes renders the message in the RFC 5322 format, as it is handed to a mail server.
+func (m *Message) Bytes() ([]byte, error) {
+	if m.From == "" || len(m.To) == 0 {
+		return nil, fmt.Errorf("mail: message needs a sender and at least one recipient")
+	}
+
+	date := m.Date
+	if date.IsZero() {
+		date = time.Now()
+	}
+
+	var buf bytes.Buffer
+	header := func(name, value string) {
+		// Line breaks would start a new header.
+		value = strings.NewReplacer("\r", "", "\n", "").Replace(value)
+		fmt.Fprintf(&buf, "%s: %s\r\n", name, value)
+	}
+	header("From", m.From)
+	header("To", strings.Join(m.To, ", "))
+	header("Subject", mime.QEncoding.Encode("utf-8", m.Subject))
+	header("Date", date.Format(time.RFC1123Z))
+	header("MIME-Version", "1.0")
+	header("Content-Type", "text/plain; charset=utf-8")
+	header("Content-Transfer-Encoding", "quoted-printable")
+	buf.WriteString("\r\n")
+
+	w := quotedprintable.NewWriter(&buf)
+	if _, err := io.WriteString(w, m.Body); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Parse reads a message rendered by Message.Bytes.
+func Parse(data []byte) (*Message, error) {
+	raw, err := mail.ReadMessage(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	var decoder mime.WordDecoder
+	subject, err := decoder.DecodeHeader(raw.Header.Get("Subject"))
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(quotedprintable.NewReader(raw.Body))
+	if err != nil {
+		return nil, err
+	}
+
+	// Lines of the body travel with CRLF endings.
+	msg := &Message{From: raw.Header.Get("From"), Subject: subject, Body: strings.ReplaceAll(string(body), "\r\n", "\n")}
+	for _, to := range strings.Split(raw.Header.Get("To"), ",") {
+		msg.To = append(msg.To, strings.TrimSpace(to))
+	}
+	if date, err := raw.Header.Date(); err == nil {
+		msg.Date = date
+	}
+
+	return msg, nil
+}
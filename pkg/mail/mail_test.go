@@ -0,0 +1,133 @@
+package mail
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutbox(t *testing.T) {
+	outbox, err := NewOutbox(t.TempDir())
+	require.NoError(t, err)
+
+	body := "Confirm your address: https://example.com/v1/verify?token=a.b=c\n" + strings.Repeat("x", 120)
+	for _, subject := range []string{"Vérifiez votre adresse", "Second"} {
+		require.NoError(t, outbox.Send(context.Background(), &Message{
+			From:    "gotal <no-reply@example.com>",
+			To:      []string{"alice@example.com"},
+			Subject: subject,
+			Body:    body,
+		}))
+	}
+
+	messages, err := outbox.Messages()
+	require.NoError(t, err)
+	require.Len(t, messages, 2)
+	assert.Equal(t, "gotal <no-reply@example.com>", messages[0].From)
+	assert.Equal(t, []string{"alice@example.com"}, messages[0].To)
+	assert.Equal(t, "Vérifiez votre adresse", messages[0].Subject)
+	assert.Equal(t, body, messages[0].Body)
+	assert.Equal(t, "Second", messages[1].Subject)
+}
+
+func TestMessage_HeaderInjection(t *testing.T) {
+	data, err := (&Message{
+		From:    "no-reply@example.com",
+		To:      []string{"alice@example.com"},
+		Subject: "hello\r\nBcc: eve@example.com",
+	}).Bytes()
+	require.NoError(t, err)
+
+	msg, err := Parse(data)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"alice@example.com"}, msg.To)
+	assert.NotContains(t, string(data), "\r\nBcc:")
+
+	_, err = (&Message{From: "no-reply@example.com"}).Bytes()
+	assert.Error(t, err)
+}
+
+// serveSMTP answers a single SMTP session with the happy path and returns what the client sent.
+func serveSMTP(t *testing.T, ln net.Listener) <-chan string {
+	received := make(chan string, 1)
+
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		r := bufio.NewReader(conn)
+		reply := func(line string) { _, _ = conn.Write([]byte(line + "\r\n")) }
+
+		var transcript strings.Builder
+		reply("220 localhost ESMTP")
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			transcript.WriteString(line)
+
+			switch cmd := strings.ToUpper(strings.TrimSpace(line)); {
+			case strings.HasPrefix(cmd, "EHLO"):
+				reply("250 localhost")
+			case cmd == "DATA":
+				reply("354 go ahead")
+				for {
+					line, err := r.ReadString('\n')
+					if err != nil {
+						return
+					}
+					transcript.WriteString(line)
+					if line == ".\r\n" {
+						break
+					}
+				}
+				reply("250 queued")
+			case cmd == "QUIT":
+				reply("221 bye")
+				received <- transcript.String()
+
+				return
+			default:
+				reply("250 ok")
+			}
+		}
+	}()
+
+	return received
+}
+
+func TestSMTP_Send(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	received := serveSMTP(t, ln)
+
+	mailer := &SMTP{Addr: ln.Addr().String(), Timeout: 5 * time.Second}
+	require.NoError(t, mailer.Send(context.Background(), &Message{
+		From:    "no-reply@example.com",
+		To:      []string{"alice@example.com", "bob@example.com"},
+		Subject: "Welcome",
+		Body:    "Hello",
+	}))
+
+	select {
+	case transcript := <-received:
+		assert.Contains(t, transcript, "MAIL FROM:<no-reply@example.com>")
+		assert.Contains(t, transcript, "RCPT TO:<alice@example.com>")
+		assert.Contains(t, transcript, "RCPT TO:<bob@example.com>")
+		assert.Contains(t, transcript, "Subject: Welcome\r\n")
+	case <-time.After(5 * time.Second):
+		t.Fatal("smtp session did not complete")
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright 2023 Talhuang<talhuang1231@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package mail
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Outbox keeps the messages as .eml files of a directory instead of delivering them.
+type Outbox struct {
+	dir string
+
+	mu  sync.Mutex
+	seq int
+}
+
+var _ Mailer = (*Outbox)(nil)
+
+// NewOutbox creates the directory when it does not exist yet.
+func NewOutbox(dir string) (*Outbox, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+
+	return &Outbox{dir: dir}, nil
+}
+
+// Send implements Mailer.
+func (o *Outbox) Send(ctx context.Context, msg *Message) error {
+	data, err := msg.Bytes()
+	if err != nil {
+		return err
+	}
+
+	o.mu.Lock()
+	o.seq++
+	seq := o.seq
+	o.mu.Unlock()
+
+	// The names sort in the order the messages were sent.
+	name := fmt.Sprintf("%s-%06d.eml", time.Now().UTC().Format("20060102T150405.000000000"), seq)
+
+	return os.WriteFile(filepath.Join(o.dir, name), data, 0o600)
+}
+
+// Messages returns the messages of the outbox, oldest first.
+func (o *Outbox) Messages() ([]*Message, error) {
+	entries, err := os.ReadDir(o.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".eml") {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+
+	messages := make([]*Message, 0, len(names))
+	for _, name := range names {
+		data, err := os.ReadFile(filepath.Join(o.dir, name))
+		if err != nil {
+			return nil, err
+		}
+
+		msg, err := Parse(data)
+		if err != nil {
+			return nil, fmt.Errorf("mail: parse %s: %w", name, err)
+		}
+		messages = append(messages, msg)
+	}
+
+	return messages, nil
+}
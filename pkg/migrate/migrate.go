@@ -0,0 +1,417 @@
+// Copyright 2023 Talhuang<talhuang1231@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+// Package migrate applies versioned SQL migrations to a MySQL database. Migrations are read
+// from files named <version>_<name>.up.sql and <version>_<name>.down.sql, and the applied
+// versions are recorded in the schema_migrations table.
+//
+// Changes hold a MySQL advisory lock, so concurrent deploys apply every migration once.
+// MySQL commits DDL statements one by one: a migration failing halfway is not recorded and
+// leaves its first statements applied, migrations are better written to be run again.
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Table is the table recording the applied migrations.
+const Table = "schema_migrations"
+
+// ErrLocked is returned when another process holds the migration lock for the whole LockTimeout.
+var ErrLocked = errors.New("migrate: another process is migrating the database")
+
+// Migration is a version of the schema.
+type Migration struct {
+	Version uint64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Status is the state of a migration in the database.
+type Status struct {
+	Version uint64
+	Name    string
+	// AppliedAt is nil for pending migrations.
+	AppliedAt *time.Time
+	// Unknown is set for the versions recorded in the database without a migration, applied
+	// by a newer release.
+	Unknown bool
+}
+
+// Load reads the migrations in the root directory of fsys, in the order of their versions.
+func Load(fsys fs.FS) ([]*Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[uint64]*Migration{}
+	for _, entry := range entries {
+		file := entry.Name()
+		if entry.IsDir() || path.Ext(file) != ".sql" {
+			continue
+		}
+
+		base := strings.TrimSuffix(file, ".sql")
+		direction := path.Ext(base)
+		if direction != ".up" && direction != ".down" {
+			return nil, fmt.Errorf("migrate: %s is neither an up nor a down migration", file)
+		}
+		id, name, ok := strings.Cut(strings.TrimSuffix(base, direction), "_")
+		version, err := strconv.ParseUint(id, 10, 64)
+		if !ok || err != nil || version == 0 {
+			return nil, fmt.Errorf("migrate: %s does not start with a version", file)
+		}
+
+		data, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: name}
+			byVersion[version] = m
+		}
+		if m.Name != name {
+			return nil, fmt.Errorf("migrate: version %d is used by %s and %s", version, m.Name, name)
+		}
+		if direction == ".up" {
+			m.Up = string(data)
+		} else {
+			m.Down = string(data)
+		}
+	}
+
+	migrations := make([]*Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if strings.TrimSpace(m.Up) == "" {
+			return nil, fmt.Errorf("migrate: migration %d_%s has no up statements", m.Version, m.Name)
+		}
+		migrations = append(migrations, m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	return migrations, nil
+}
+
+// statements splits the SQL of a migration into its statements, which end with a semicolon
+// at the end of a line.
+func statements(script string) []string {
+	var stmts []string
+	var current strings.Builder
+	flush := func() {
+		stmt := strings.TrimSpace(current.String())
+		current.Reset()
+
+		for _, line := range strings.Split(stmt, "\n") {
+			line = strings.TrimSpace(line)
+			if line != "" && !strings.HasPrefix(line, "--") {
+				stmts = append(stmts, stmt)
+
+				return
+			}
+		}
+	}
+
+	for _, line := range strings.SplitAfter(script, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasSuffix(trimmed, ";") {
+			current.WriteString(strings.TrimSuffix(trimmed, ";"))
+			flush()
+
+			continue
+		}
+		current.WriteString(line)
+	}
+	flush()
+
+	return stmts
+}
+
+// Migrator applies the migrations to a database.
+type Migrator struct {
+	db         *sql.DB
+	migrations []*Migration
+	// LockTimeout is how long changes wait for a concurrent migration.
+	LockTimeout time.Duration
+}
+
+// New returns a migrator applying the migrations, loaded with Load, to the database.
+func New(db *sql.DB, migrations []*Migration) *Migrator {
+	return &Migrator{db: db, migrations: migrations, LockTimeout: time.Minute}
+}
+
+// Status returns the migrations with their state, followed by the unknown versions found in
+// the database.
+func (m *Migrator) Status(ctx context.Context) ([]*Status, error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if err := ensureTable(ctx, conn); err != nil {
+		return nil, err
+	}
+
+	return m.status(ctx, conn)
+}
+
+// Pending returns the migrations not applied yet.
+func (m *Migrator) Pending(ctx context.Context) ([]*Migration, error) {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []*Migration
+	for i, s := range statuses {
+		if s.AppliedAt == nil && !s.Unknown {
+			pending = append(pending, m.migrations[i])
+		}
+	}
+
+	return pending, nil
+}
+
+// Up applies the pending migrations, and returns them.
+func (m *Migrator) Up(ctx context.Context) ([]*Migration, error) {
+	var applied []*Migration
+	err := m.locked(ctx, func(conn *sql.Conn, versions map[uint64]time.Time) error {
+		for _, migration := range m.migrations {
+			if _, ok := versions[migration.Version]; !ok {
+				if err := apply(ctx, conn, migration); err != nil {
+					return err
+				}
+				applied = append(applied, migration)
+			}
+		}
+
+		return nil
+	})
+
+	return applied, err
+}
+
+// Down reverts the latest applied migration, and returns it. It returns nil when no
+// migration is applied.
+func (m *Migrator) Down(ctx context.Context) (*Migration, error) {
+	var reverted *Migration
+	err := m.locked(ctx, func(conn *sql.Conn, versions map[uint64]time.Time) error {
+		if err := m.checkUnknown(versions, 0); err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0; i-- {
+			if _, ok := versions[m.migrations[i].Version]; ok {
+				reverted = m.migrations[i]
+
+				return revert(ctx, conn, reverted)
+			}
+		}
+
+		return nil
+	})
+
+	return reverted, err
+}
+
+// To applies the pending migrations up to the version, and reverts the applied migrations
+// above it, newest first. It returns the migrations applied or reverted, version 0 reverts
+// all of them.
+func (m *Migrator) To(ctx context.Context, version uint64) ([]*Migration, error) {
+	if version != 0 && m.find(version) == nil {
+		return nil, fmt.Errorf("migrate: no migration has version %d", version)
+	}
+
+	var changed []*Migration
+	err := m.locked(ctx, func(conn *sql.Conn, versions map[uint64]time.Time) error {
+		if err := m.checkUnknown(versions, version); err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0; i-- {
+			migration := m.migrations[i]
+			if _, ok := versions[migration.Version]; ok && migration.Version > version {
+				if err := revert(ctx, conn, migration); err != nil {
+					return err
+				}
+				changed = append(changed, migration)
+			}
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := versions[migration.Version]; !ok && migration.Version <= version {
+				if err := apply(ctx, conn, migration); err != nil {
+					return err
+				}
+				changed = append(changed, migration)
+			}
+		}
+
+		return nil
+	})
+
+	return changed, err
+}
+
+// checkUnknown refuses to revert past versions applied by a newer release, whose down
+// statements this release does not have.
+func (m *Migrator) checkUnknown(versions map[uint64]time.Time, above uint64) error {
+	for version := range versions {
+		if version > above && m.find(version) == nil {
+			return fmt.Errorf("migrate: version %d was applied by a newer release, revert it with that release", version)
+		}
+	}
+
+	return nil
+}
+
+func (m *Migrator) find(version uint64) *Migration {
+	for _, migration := range m.migrations {
+		if migration.Version == version {
+			return migration
+		}
+	}
+
+	return nil
+}
+
+// locked runs fn on a connection holding the migration lock, with the applied versions read
+// under the lock. The lock is named after the database, it is shared by the whole server.
+func (m *Migrator) locked(ctx context.Context, fn func(conn *sql.Conn, applied map[uint64]time.Time) error) (err error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	var acquired sql.NullInt64
+	if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(CONCAT(DATABASE(), '."+Table+"'), ?)",
+		int(m.LockTimeout/time.Second)).Scan(&acquired); err != nil {
+		return err
+	}
+	if acquired.Int64 != 1 {
+		return ErrLocked
+	}
+	defer func() {
+		// The lock is released with the connection anyway, should this fail.
+		if _, releaseErr := conn.ExecContext(context.Background(),
+			"SELECT RELEASE_LOCK(CONCAT(DATABASE(), '."+Table+"'))"); err == nil {
+			err = releaseErr
+		}
+	}()
+
+	if err := ensureTable(ctx, conn); err != nil {
+		return err
+	}
+	applied, err := appliedVersions(ctx, conn)
+	if err != nil {
+		return err
+	}
+
+	return fn(conn, applied)
+}
+
+func (m *Migrator) status(ctx context.Context, conn *sql.Conn) ([]*Status, error) {
+	applied, err := appliedVersions(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]*Status, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		s := &Status{Version: migration.Version, Name: migration.Name}
+		if at, ok := applied[migration.Version]; ok {
+			s.AppliedAt = &at
+			delete(applied, migration.Version)
+		}
+		statuses = append(statuses, s)
+	}
+
+	unknown := make([]*Status, 0, len(applied))
+	for version, at := range applied {
+		at := at
+		unknown = append(unknown, &Status{Version: version, AppliedAt: &at, Unknown: true})
+	}
+	sort.Slice(unknown, func(i, j int) bool { return unknown[i].Version < unknown[j].Version })
+
+	return append(statuses, unknown...), nil
+}
+
+func ensureTable(ctx context.Context, conn *sql.Conn) error {
+	_, err := conn.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS `"+Table+"` ("+
+		"`version` bigint unsigned NOT NULL, "+
+		"`name` varchar(255) NOT NULL, "+
+		"`applied_at` datetime(3) NOT NULL, "+
+		"PRIMARY KEY (`version`)"+
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4")
+
+	return err
+}
+
+func appliedVersions(ctx context.Context, conn *sql.Conn) (map[uint64]time.Time, error) {
+	rows, err := conn.QueryContext(ctx, "SELECT `version`, `applied_at` FROM `"+Table+"`")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := map[uint64]time.Time{}
+	for rows.Next() {
+		var version uint64
+		var at time.Time
+		if err := rows.Scan(&version, &at); err != nil {
+			return nil, err
+		}
+		applied[version] = at
+	}
+
+	return applied, rows.Err()
+}
+
+func apply(ctx context.Context, conn *sql.Conn, migration *Migration) error {
+	if err := run(ctx, conn, migration, migration.Up); err != nil {
+		return err
+	}
+
+	_, err := conn.ExecContext(ctx, "INSERT INTO `"+Table+"` (`version`, `name`, `applied_at`) VALUES (?, ?, ?)",
+		migration.Version, migration.Name, time.Now())
+
+	return err
+}
+
+func revert(ctx context.Context, conn *sql.Conn, migration *Migration) error {
+	if strings.TrimSpace(migration.Down) == "" {
+		return fmt.Errorf("migrate: migration %d_%s cannot be reverted", migration.Version, migration.Name)
+	}
+	if err := run(ctx, conn, migration, migration.Down); err != nil {
+		return err
+	}
+
+	_, err := conn.ExecContext(ctx, "DELETE FROM `"+Table+"` WHERE `version` = ?", migration.Version)
+
+	return err
+}
+
+func run(ctx context.Context, conn *sql.Conn, migration *Migration, script string) error {
+	for _, stmt := range statements(script) {
+		if _, err := conn.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("migrate: migration %d_%s: %w", migration.Version, migration.Name, err)
+		}
+	}
+
+	return nil
+}
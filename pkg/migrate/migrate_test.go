@@ -0,0 +1,168 @@
+// Copyright 2023 Talhuang<talhuang1231@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package migrate
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testFiles = fstest.MapFS{
+	"0001_create_users.up.sql":   {Data: []byte("-- users\nCREATE TABLE users (\n  id bigint\n);\n")},
+	"0001_create_users.down.sql": {Data: []byte("DROP TABLE users;\n")},
+	"0002_add_index.up.sql":      {Data: []byte("ALTER TABLE users ADD INDEX idx_id (id);\nCREATE TABLE roles (id bigint);")},
+	"0002_add_index.down.sql":    {Data: []byte("ALTER TABLE users DROP INDEX idx_id;\nDROP TABLE roles;\n")},
+	"0003_seed.up.sql":           {Data: []byte("INSERT INTO roles VALUES (1);\n")},
+	"README.md":                  {Data: []byte("not a migration")},
+}
+
+var (
+	getLock     = regexp.QuoteMeta("SELECT GET_LOCK(CONCAT(DATABASE(), '.schema_migrations'), ?)")
+	releaseLock = regexp.QuoteMeta("SELECT RELEASE_LOCK(CONCAT(DATABASE(), '.schema_migrations'))")
+	createTable = regexp.QuoteMeta("CREATE TABLE IF NOT EXISTS `schema_migrations`")
+	selectRows  = regexp.QuoteMeta("SELECT `version`, `applied_at` FROM `schema_migrations`")
+	insertRow   = regexp.QuoteMeta("INSERT INTO `schema_migrations` (`version`, `name`, `applied_at`) VALUES (?, ?, ?)")
+	deleteRow   = regexp.QuoteMeta("DELETE FROM `schema_migrations` WHERE `version` = ?")
+)
+
+func newTestMigrator(t *testing.T) (*Migrator, sqlmock.Sqlmock) {
+	migrations, err := Load(testFiles)
+	require.NoError(t, err)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	return New(db, migrations), mock
+}
+
+// expectLocked expects the lock to be taken and the applied versions to be read.
+func expectLocked(mock sqlmock.Sqlmock, versions ...uint64) {
+	mock.ExpectQuery(getLock).WithArgs(60).WillReturnRows(sqlmock.NewRows([]string{"lock"}).AddRow(1))
+	mock.ExpectExec(createTable).WillReturnResult(sqlmock.NewResult(0, 0))
+	rows := sqlmock.NewRows([]string{"version", "applied_at"})
+	for _, version := range versions {
+		rows.AddRow(version, time.Now())
+	}
+	mock.ExpectQuery(selectRows).WillReturnRows(rows)
+}
+
+func TestLoad(t *testing.T) {
+	migrations, err := Load(testFiles)
+	require.NoError(t, err)
+	require.Len(t, migrations, 3)
+	assert.Equal(t, uint64(1), migrations[0].Version)
+	assert.Equal(t, "create_users", migrations[0].Name)
+	assert.Equal(t, "", migrations[2].Down)
+
+	assert.Equal(t, []string{"-- users\nCREATE TABLE users (\n  id bigint\n)"}, statements(migrations[0].Up))
+	assert.Equal(t, []string{"ALTER TABLE users ADD INDEX idx_id (id)", "CREATE TABLE roles (id bigint)"}, statements(migrations[1].Up))
+
+	for name, files := range map[string]fstest.MapFS{
+		"no version":      {"create_users.up.sql": {Data: []byte("SELECT 1;")}},
+		"no direction":    {"0001_create_users.sql": {Data: []byte("SELECT 1;")}},
+		"same version":    {"0001_a.up.sql": {Data: []byte("SELECT 1;")}, "0001_b.up.sql": {Data: []byte("SELECT 1;")}},
+		"down without up": {"0001_a.down.sql": {Data: []byte("SELECT 1;")}},
+	} {
+		_, err := Load(files)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestMigrator_Up(t *testing.T) {
+	m, mock := newTestMigrator(t)
+
+	expectLocked(mock, 1)
+	mock.ExpectExec(regexp.QuoteMeta("ALTER TABLE users ADD INDEX idx_id (id)")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE roles (id bigint)")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(insertRow).WithArgs(2, "add_index", sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO roles VALUES (1)")).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(insertRow).WithArgs(3, "seed", sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(releaseLock).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	applied, err := m.Up(context.Background())
+	require.NoError(t, err)
+	require.Len(t, applied, 2)
+	assert.Equal(t, uint64(2), applied[0].Version)
+	assert.Equal(t, uint64(3), applied[1].Version)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrator_UpFailure(t *testing.T) {
+	m, mock := newTestMigrator(t)
+
+	// The failed migration is not recorded, and the lock is released.
+	expectLocked(mock, 1)
+	mock.ExpectExec(regexp.QuoteMeta("ALTER TABLE users ADD INDEX idx_id (id)")).WillReturnError(assert.AnError)
+	mock.ExpectExec(releaseLock).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	applied, err := m.Up(context.Background())
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.Contains(t, err.Error(), "2_add_index")
+	assert.Empty(t, applied)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrator_Locked(t *testing.T) {
+	m, mock := newTestMigrator(t)
+
+	mock.ExpectQuery(getLock).WithArgs(60).WillReturnRows(sqlmock.NewRows([]string{"lock"}).AddRow(0))
+
+	_, err := m.Up(context.Background())
+	assert.ErrorIs(t, err, ErrLocked)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrator_To(t *testing.T) {
+	m, mock := newTestMigrator(t)
+
+	_, err := m.To(context.Background(), 9)
+	assert.Error(t, err)
+
+	// Reverting the seed is impossible, it has no down statements.
+	expectLocked(mock, 1, 2, 3)
+	mock.ExpectExec(releaseLock).WillReturnResult(sqlmock.NewResult(0, 0))
+	_, err = m.To(context.Background(), 1)
+	assert.Contains(t, err.Error(), "3_seed cannot be reverted")
+
+	expectLocked(mock, 1, 2)
+	mock.ExpectExec(regexp.QuoteMeta("ALTER TABLE users DROP INDEX idx_id")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("DROP TABLE roles")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(deleteRow).WithArgs(2).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(releaseLock).WillReturnResult(sqlmock.NewResult(0, 0))
+	changed, err := m.To(context.Background(), 1)
+	require.NoError(t, err)
+	require.Len(t, changed, 1)
+	assert.Equal(t, uint64(2), changed[0].Version)
+
+	// Versions of a newer release are not reverted by this one.
+	expectLocked(mock, 1, 4)
+	mock.ExpectExec(releaseLock).WillReturnResult(sqlmock.NewResult(0, 0))
+	_, err = m.Down(context.Background())
+	assert.Contains(t, err.Error(), "version 4 was applied by a newer release")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrator_Pending(t *testing.T) {
+	m, mock := newTestMigrator(t)
+
+	mock.ExpectExec(createTable).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(selectRows).WillReturnRows(sqlmock.NewRows([]string{"version", "applied_at"}).
+		AddRow(1, time.Now()).AddRow(4, time.Now()))
+
+	pending, err := m.Pending(context.Background())
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+	assert.Equal(t, uint64(2), pending[0].Version)
+	assert.Equal(t, uint64(3), pending[1].Version)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,229 @@
+// Copyright 2023 Talhuang<talhuang1231@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Memory keeps the queues in memory, for tests and development. It behaves like Redis,
+// jobs are copied in and out so callers never share them with the backend.
+type Memory struct {
+	mu     sync.Mutex
+	queues map[string]*memoryQueue
+}
+
+type memoryQueue struct {
+	jobs     map[string][]byte
+	attempts map[string]int
+	ready    []string
+	delayed  map[string]int64
+	inflight map[string]int64
+	dead     []string
+}
+
+var _ Backend = (*Memory)(nil)
+
+// NewMemory returns an empty in-memory backend.
+func NewMemory() *Memory {
+	return &Memory{queues: map[string]*memoryQueue{}}
+}
+
+func (m *Memory) queue(name string) *memoryQueue {
+	q, ok := m.queues[name]
+	if !ok {
+		q = &memoryQueue{
+			jobs:     map[string][]byte{},
+			attempts: map[string]int{},
+			delayed:  map[string]int64{},
+			inflight: map[string]int64{},
+		}
+		m.queues[name] = q
+	}
+
+	return q
+}
+
+// Push implements Backend.
+func (m *Memory) Push(_ context.Context, queue string, job *Job, now time.Time) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	q := m.queue(queue)
+	if _, ok := q.jobs[job.ID]; ok {
+		return ErrDuplicateJob
+	}
+	q.jobs[job.ID] = data
+	if !job.RunAt.After(now) {
+		q.ready = append(q.ready, job.ID)
+	} else {
+		q.delayed[job.ID] = job.RunAt.UnixMilli()
+	}
+
+	return nil
+}
+
+// due removes from the set the IDs whose score is at most now, in the order of their scores.
+func due(set map[string]int64, now int64) []string {
+	var ids []string
+	for id, score := range set {
+		if score <= now {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if set[ids[i]] != set[ids[j]] {
+			return set[ids[i]] < set[ids[j]]
+		}
+
+		return ids[i] < ids[j]
+	})
+	for _, id := range ids {
+		delete(set, id)
+	}
+
+	return ids
+}
+
+// Pop implements Backend.
+func (m *Memory) Pop(_ context.Context, queue string, now time.Time, visibility time.Duration) (*Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	q := m.queue(queue)
+	q.ready = append(q.ready, due(q.delayed, now.UnixMilli())...)
+	q.ready = append(q.ready, due(q.inflight, now.UnixMilli())...)
+
+	for len(q.ready) > 0 {
+		id := q.ready[0]
+		q.ready = q.ready[1:]
+
+		data, ok := q.jobs[id]
+		if !ok {
+			continue
+		}
+		job, err := decodeJob(data)
+		if err != nil {
+			return nil, err
+		}
+		q.attempts[id]++
+		job.Attempts = q.attempts[id]
+		job.lease = now.Add(visibility).UnixMilli()
+		q.inflight[id] = job.lease
+
+		return job, nil
+	}
+
+	return nil, nil
+}
+
+// finish ends the delivery of the job when its lease is still the one of the delivery.
+func (m *Memory) finish(queue string, job *Job, action func(q *memoryQueue, data []byte)) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	q := m.queue(queue)
+	if lease, ok := q.inflight[job.ID]; !ok || lease != job.lease {
+		return ErrLeaseLost
+	}
+	delete(q.inflight, job.ID)
+	action(q, data)
+
+	return nil
+}
+
+// Ack implements Backend.
+func (m *Memory) Ack(_ context.Context, queue string, job *Job) error {
+	return m.finish(queue, job, func(q *memoryQueue, _ []byte) {
+		delete(q.jobs, job.ID)
+		delete(q.attempts, job.ID)
+	})
+}
+
+// Retry implements Backend.
+func (m *Memory) Retry(_ context.Context, queue string, job *Job, at time.Time) error {
+	return m.finish(queue, job, func(q *memoryQueue, data []byte) {
+		q.jobs[job.ID] = data
+		q.delayed[job.ID] = at.UnixMilli()
+	})
+}
+
+// Bury implements Backend.
+func (m *Memory) Bury(_ context.Context, queue string, job *Job) error {
+	return m.finish(queue, job, func(q *memoryQueue, data []byte) {
+		q.jobs[job.ID] = data
+		delete(q.attempts, job.ID)
+		q.dead = append(q.dead, job.ID)
+	})
+}
+
+// Dead implements Backend.
+func (m *Memory) Dead(_ context.Context, queue string, limit int) ([]*Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	q := m.queue(queue)
+
+	var jobs []*Job
+	for _, id := range q.dead {
+		if len(jobs) >= limit {
+			break
+		}
+		job, err := decodeJob(q.jobs[id])
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+
+	return jobs, nil
+}
+
+// Revive implements Backend.
+func (m *Memory) Revive(_ context.Context, queue, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	q := m.queue(queue)
+	for i, dead := range q.dead {
+		if dead == id {
+			q.dead = append(q.dead[:i], q.dead[i+1:]...)
+			q.ready = append(q.ready, id)
+
+			return nil
+		}
+	}
+
+	return ErrJobNotFound
+}
+
+// Stats implements Backend.
+func (m *Memory) Stats(_ context.Context, queue string) (*Stats, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	q := m.queue(queue)
+
+	return &Stats{
+		Ready:    int64(len(q.ready)),
+		Delayed:  int64(len(q.delayed)),
+		InFlight: int64(len(q.inflight)),
+		Dead:     int64(len(q.dead)),
+	}, nil
+}
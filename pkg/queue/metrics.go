@@ -0,0 +1,101 @@
+// Copyright 2023 Talhuang<talhuang1231@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package queue
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/skeleton1231/gotal/pkg/log"
+)
+
+// Results of the deliveries, as counted by queue_jobs_total.
+const (
+	resultSucceeded = "succeeded"
+	resultRetried   = "retried"
+	resultDead      = "dead"
+	resultLeaseLost = "lease_lost"
+)
+
+var (
+	jobsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: "queue",
+		Name:      "jobs_total",
+		Help:      "Deliveries of jobs by queue and result: succeeded, retried, dead or lease_lost.",
+	}, []string{"queue", "result"})
+
+	jobDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Subsystem: "queue",
+		Name:      "job_duration_seconds",
+		Help:      "Time taken by the handlers of the jobs.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"queue"})
+
+	depthDesc = prometheus.NewDesc("queue_depth", "Jobs by queue and state: ready, delayed, in_flight or dead.",
+		[]string{"queue", "state"}, nil)
+
+	depth = &depthCollector{queues: map[string]*Queue{}}
+)
+
+func init() {
+	prometheus.MustRegister(jobsTotal, jobDuration, depth)
+}
+
+// depthCollector counts the jobs of the queues having workers when metrics are scraped.
+type depthCollector struct {
+	mu     sync.Mutex
+	queues map[string]*Queue
+}
+
+func (c *depthCollector) add(q *Queue) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.queues[q.name] = q
+}
+
+func (c *depthCollector) remove(q *Queue) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.queues, q.name)
+}
+
+// Describe implements prometheus.Collector.
+func (c *depthCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- depthDesc
+}
+
+// Collect implements prometheus.Collector.
+func (c *depthCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	queues := make([]*Queue, 0, len(c.queues))
+	for _, q := range c.queues {
+		queues = append(queues, q)
+	}
+	c.mu.Unlock()
+
+	for _, q := range queues {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		stats, err := q.Stats(ctx)
+		cancel()
+		if err != nil {
+			log.Warnf("count the jobs of queue %s failed: %s", q.name, err.Error())
+
+			continue
+		}
+
+		for state, count := range map[string]int64{
+			"ready":     stats.Ready,
+			"delayed":   stats.Delayed,
+			"in_flight": stats.InFlight,
+			"dead":      stats.Dead,
+		} {
+			ch <- prometheus.MustNewConstMetric(depthDesc, prometheus.GaugeValue, float64(count), q.name, state)
+		}
+	}
+}
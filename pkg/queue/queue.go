@@ -0,0 +1,201 @@
+// Copyright 2023 Talhuang<talhuang1231@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+// Package queue runs background jobs. Jobs are kept in Redis, or in memory for tests,
+// and are run by a pool of workers which retries failed jobs with an exponential backoff
+// and moves the jobs failing for good to a dead-letter queue.
+//
+// A job is delivered at least once: a worker holds it for a visibility timeout, after which
+// the job is delivered again, so handlers must be safe to run twice.
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// DefaultMaxAttempts is the number of times a job runs before it is dead, unless set with
+// WithMaxAttempts.
+const DefaultMaxAttempts = 5
+
+var (
+	// ErrDuplicateJob is returned when a job with the same ID is already in the queue.
+	ErrDuplicateJob = errors.New("queue: job already queued")
+
+	// ErrLeaseLost is returned when a worker finishes a job after its visibility timeout,
+	// the job is then delivered again or already done by another worker.
+	ErrLeaseLost = errors.New("queue: job lease lost")
+
+	// ErrJobNotFound is returned when reviving a job which is not in the dead-letter queue.
+	ErrJobNotFound = errors.New("queue: job not found")
+)
+
+// Job is a unit of background work.
+type Job struct {
+	ID          string          `json:"id"`
+	Type        string          `json:"type"`
+	Payload     json.RawMessage `json:"payload,omitempty"`
+	MaxAttempts int             `json:"maxAttempts"`
+	EnqueuedAt  time.Time       `json:"enqueuedAt"`
+	RunAt       time.Time       `json:"runAt"`
+	// Attempts counts the deliveries of the job, the current one included.
+	Attempts  int    `json:"attempts"`
+	LastError string `json:"lastError,omitempty"`
+
+	// lease identifies the delivery, it is the end of the visibility timeout in milliseconds.
+	lease int64
+}
+
+// Decode unmarshals the payload of the job into v.
+func (j *Job) Decode(v interface{}) error {
+	return json.Unmarshal(j.Payload, v)
+}
+
+// Deadline returns the end of the visibility timeout of the delivery.
+func (j *Job) Deadline() time.Time {
+	return time.UnixMilli(j.lease)
+}
+
+// Stats counts the jobs of a queue by state.
+type Stats struct {
+	// Ready jobs wait for a worker.
+	Ready int64 `json:"ready"`
+	// Delayed jobs wait for their time, or for their next attempt.
+	Delayed int64 `json:"delayed"`
+	// InFlight jobs are held by a worker.
+	InFlight int64 `json:"inFlight"`
+	// Dead jobs failed for good.
+	Dead int64 `json:"dead"`
+}
+
+// Backend stores the jobs of the queues. Every method is atomic. Methods finishing a
+// delivery return ErrLeaseLost when the lease of the job is not the one of the delivery.
+type Backend interface {
+	// Push adds the job, to run at job.RunAt. It returns ErrDuplicateJob when the ID is taken.
+	Push(ctx context.Context, queue string, job *Job, now time.Time) error
+	// Pop delivers the next ready job for the visibility timeout, or returns nil when no job is
+	// ready. Delayed jobs which are due and jobs whose visibility timeout ended are ready.
+	Pop(ctx context.Context, queue string, now time.Time, visibility time.Duration) (*Job, error)
+	// Ack removes the delivered job.
+	Ack(ctx context.Context, queue string, job *Job) error
+	// Retry saves the delivered job and delays it until at.
+	Retry(ctx context.Context, queue string, job *Job, at time.Time) error
+	// Bury saves the delivered job and moves it to the dead-letter queue.
+	Bury(ctx context.Context, queue string, job *Job) error
+	// Dead returns the oldest jobs of the dead-letter queue, at most limit.
+	Dead(ctx context.Context, queue string, limit int) ([]*Job, error)
+	// Revive moves the job from the dead-letter queue back to the ready jobs, with its
+	// attempts reset. It returns ErrJobNotFound when the job is not dead.
+	Revive(ctx context.Context, queue, id string) error
+	// Stats counts the jobs of the queue.
+	Stats(ctx context.Context, queue string) (*Stats, error)
+}
+
+// Queue is a named queue of jobs.
+type Queue struct {
+	name    string
+	backend Backend
+	now     func() time.Time
+}
+
+// New returns the queue with the name in the backend.
+func New(name string, backend Backend) *Queue {
+	return &Queue{name: name, backend: backend, now: time.Now}
+}
+
+// Name returns the name of the queue.
+func (q *Queue) Name() string {
+	return q.name
+}
+
+// EnqueueOption changes the job being enqueued.
+type EnqueueOption func(*Job)
+
+// WithID sets the ID of the job, so the same job is not queued twice. By default jobs get a
+// random ID.
+func WithID(id string) EnqueueOption {
+	return func(j *Job) {
+		j.ID = id
+	}
+}
+
+// WithDelay runs the job once the delay elapsed.
+func WithDelay(delay time.Duration) EnqueueOption {
+	return func(j *Job) {
+		j.RunAt = j.EnqueuedAt.Add(delay)
+	}
+}
+
+// WithRunAt schedules the job at the time.
+func WithRunAt(at time.Time) EnqueueOption {
+	return func(j *Job) {
+		j.RunAt = at
+	}
+}
+
+// WithMaxAttempts sets the number of times the job runs before it is dead.
+func WithMaxAttempts(attempts int) EnqueueOption {
+	return func(j *Job) {
+		j.MaxAttempts = attempts
+	}
+}
+
+// Enqueue adds a job of the type, whose payload is the JSON encoding of payload.
+func (q *Queue) Enqueue(ctx context.Context, jobType string, payload interface{}, opts ...EnqueueOption) (*Job, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("queue: encode payload: %w", err)
+	}
+
+	now := q.now()
+	job := &Job{
+		ID:          uuid.NewV4().String(),
+		Type:        jobType,
+		Payload:     data,
+		MaxAttempts: DefaultMaxAttempts,
+		EnqueuedAt:  now,
+		RunAt:       now,
+	}
+	for _, opt := range opts {
+		opt(job)
+	}
+	if job.MaxAttempts < 1 {
+		job.MaxAttempts = 1
+	}
+
+	if err := q.backend.Push(ctx, q.name, job, now); err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// Stats counts the jobs of the queue.
+func (q *Queue) Stats(ctx context.Context) (*Stats, error) {
+	return q.backend.Stats(ctx, q.name)
+}
+
+// Dead returns the oldest jobs of the dead-letter queue, at most limit.
+func (q *Queue) Dead(ctx context.Context, limit int) ([]*Job, error) {
+	return q.backend.Dead(ctx, q.name, limit)
+}
+
+// Revive runs a dead job again.
+func (q *Queue) Revive(ctx context.Context, id string) error {
+	return q.backend.Revive(ctx, q.name, id)
+}
+
+func decodeJob(data []byte) (*Job, error) {
+	job := &Job{}
+	if err := json.Unmarshal(data, job); err != nil {
+		return nil, fmt.Errorf("queue: decode job: %w", err)
+	}
+
+	return job, nil
+}
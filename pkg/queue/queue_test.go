@@ -0,0 +1,271 @@
+// Copyright 2023 Talhuang<talhuang1231@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package queue
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// clock is a time the tests move forward by hand.
+type clock struct {
+	now time.Time
+}
+
+func (c *clock) Now() time.Time { return c.now }
+
+func newTestQueue(name string) (*Queue, *clock) {
+	c := &clock{now: time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)}
+	q := New(name, NewMemory())
+	q.now = c.Now
+
+	return q, c
+}
+
+func pop(t *testing.T, q *Queue, visibility time.Duration) *Job {
+	job, err := q.backend.Pop(context.Background(), q.name, q.now(), visibility)
+	require.NoError(t, err)
+
+	return job
+}
+
+func TestQueue_Enqueue(t *testing.T) {
+	q, c := newTestQueue("emails")
+	ctx := context.Background()
+
+	_, err := q.Enqueue(ctx, "welcome", map[string]int{"userId": 42}, WithID("welcome-42"))
+	require.NoError(t, err)
+	_, err = q.Enqueue(ctx, "welcome", map[string]int{"userId": 42}, WithID("welcome-42"))
+	assert.ErrorIs(t, err, ErrDuplicateJob)
+
+	_, err = q.Enqueue(ctx, "digest", nil, WithDelay(time.Hour))
+	require.NoError(t, err)
+	_, err = q.Enqueue(ctx, "report", nil, WithRunAt(c.now.Add(30*time.Minute)))
+	require.NoError(t, err)
+
+	stats, err := q.Stats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, &Stats{Ready: 1, Delayed: 2}, stats)
+
+	job := pop(t, q, time.Minute)
+	require.NotNil(t, job)
+	assert.Equal(t, "welcome-42", job.ID)
+	assert.Equal(t, 1, job.Attempts)
+	var payload struct{ UserID int }
+	require.NoError(t, job.Decode(&payload))
+	assert.Equal(t, 42, payload.UserID)
+	assert.Nil(t, pop(t, q, time.Minute))
+
+	// Scheduled jobs become ready in the order of their time.
+	c.now = c.now.Add(2 * time.Hour)
+	assert.Equal(t, "report", pop(t, q, time.Minute).Type)
+	assert.Equal(t, "digest", pop(t, q, time.Minute).Type)
+}
+
+func TestQueue_Visibility(t *testing.T) {
+	q, c := newTestQueue("emails")
+	ctx := context.Background()
+
+	_, err := q.Enqueue(ctx, "welcome", nil)
+	require.NoError(t, err)
+
+	first := pop(t, q, time.Minute)
+	require.NotNil(t, first)
+	c.now = c.now.Add(30 * time.Second)
+	assert.Nil(t, pop(t, q, time.Minute))
+
+	// The worker holding the job is gone, the job is delivered again.
+	c.now = c.now.Add(time.Minute)
+	second := pop(t, q, time.Minute)
+	require.NotNil(t, second)
+	assert.Equal(t, first.ID, second.ID)
+	assert.Equal(t, 2, second.Attempts)
+
+	assert.ErrorIs(t, q.backend.Ack(ctx, q.name, first), ErrLeaseLost)
+	require.NoError(t, q.backend.Ack(ctx, q.name, second))
+
+	stats, err := q.Stats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, &Stats{}, stats)
+}
+
+func TestQueue_DeadLetter(t *testing.T) {
+	q, _ := newTestQueue("emails")
+	ctx := context.Background()
+
+	enqueued, err := q.Enqueue(ctx, "welcome", nil)
+	require.NoError(t, err)
+
+	job := pop(t, q, time.Minute)
+	job.LastError = "mailbox full"
+	require.NoError(t, q.backend.Bury(ctx, q.name, job))
+
+	dead, err := q.Dead(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, dead, 1)
+	assert.Equal(t, "mailbox full", dead[0].LastError)
+
+	require.NoError(t, q.Revive(ctx, enqueued.ID))
+	assert.ErrorIs(t, q.Revive(ctx, enqueued.ID), ErrJobNotFound)
+
+	// A revived job starts over.
+	assert.Equal(t, 1, pop(t, q, time.Minute).Attempts)
+}
+
+func TestOptions_Backoff(t *testing.T) {
+	opts := &Options{MinBackoff: time.Second, MaxBackoff: time.Minute}
+
+	assert.Equal(t, time.Second, opts.Backoff(1))
+	assert.Equal(t, 2*time.Second, opts.Backoff(2))
+	assert.Equal(t, 32*time.Second, opts.Backoff(6))
+	assert.Equal(t, time.Minute, opts.Backoff(7))
+	assert.Equal(t, time.Minute, opts.Backoff(100))
+}
+
+func newTestWorker(q *Queue) *Worker {
+	opts := NewOptions()
+	opts.Concurrency = 2
+	opts.PollInterval = time.Millisecond
+	opts.MinBackoff = time.Millisecond
+	opts.MaxBackoff = 4 * time.Millisecond
+
+	return NewWorker(q, opts)
+}
+
+func TestWorker_Retry(t *testing.T) {
+	q := New("retry", NewMemory())
+	ctx := context.Background()
+
+	var flaky, broken, invalid int32
+	w := newTestWorker(q)
+	w.Handle("flaky", func(context.Context, *Job) error {
+		if atomic.AddInt32(&flaky, 1) < 3 {
+			return errors.New("timeout")
+		}
+
+		return nil
+	})
+	w.Handle("broken", func(context.Context, *Job) error {
+		atomic.AddInt32(&broken, 1)
+		panic("nil map")
+	})
+	w.Handle("invalid", func(context.Context, *Job) error {
+		atomic.AddInt32(&invalid, 1)
+
+		return Permanent(errors.New("bad payload"))
+	})
+
+	for _, jobType := range []string{"flaky", "broken", "invalid", "unknown"} {
+		_, err := q.Enqueue(ctx, jobType, nil, WithID(jobType), WithMaxAttempts(3))
+		require.NoError(t, err)
+	}
+
+	results := func() []float64 {
+		var counts []float64
+		for _, result := range []string{resultSucceeded, resultRetried, resultDead} {
+			counts = append(counts, testutil.ToFloat64(jobsTotal.WithLabelValues("retry", result)))
+		}
+
+		return counts
+	}
+	before := results()
+
+	w.Start()
+	assert.Eventually(t, func() bool {
+		stats, err := q.Stats(ctx)
+
+		return err == nil && *stats == Stats{Dead: 3}
+	}, 5*time.Second, time.Millisecond)
+	require.NoError(t, w.Stop(ctx))
+
+	assert.Equal(t, int32(3), atomic.LoadInt32(&flaky))
+	assert.Equal(t, int32(3), atomic.LoadInt32(&broken))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&invalid))
+
+	dead, err := q.Dead(ctx, 10)
+	require.NoError(t, err)
+	errs := map[string]string{}
+	for _, job := range dead {
+		errs[job.ID] = job.LastError
+	}
+	assert.Equal(t, map[string]string{
+		"broken":  "panic: nil map",
+		"invalid": "bad payload",
+		"unknown": "no handler for jobs of type unknown",
+	}, errs)
+
+	after := results()
+	for i, delta := range []float64{1, 4, 3} {
+		assert.Equal(t, delta, after[i]-before[i])
+	}
+}
+
+func TestWorker_Drain(t *testing.T) {
+	q := New("drain", NewMemory())
+	ctx := context.Background()
+
+	started := make(chan struct{}, 2)
+	release := make(chan struct{})
+	w := newTestWorker(q)
+	w.Handle("slow", func(ctx context.Context, _ *Job) error {
+		started <- struct{}{}
+		select {
+		case <-release:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+
+	_, err := q.Enqueue(ctx, "slow", nil, WithID("finished"))
+	require.NoError(t, err)
+	w.Start()
+	<-started
+
+	// The running job finishes before the workers stop.
+	close(release)
+	require.NoError(t, w.OnShutdown("test"))
+	stats, err := q.Stats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, &Stats{}, stats)
+
+	// Jobs still running when draining ends are cancelled, and retried later.
+	w = newTestWorker(q)
+	w.Handle("slow", func(ctx context.Context, _ *Job) error {
+		started <- struct{}{}
+		<-ctx.Done()
+
+		return ctx.Err()
+	})
+	_, err = q.Enqueue(ctx, "slow", nil, WithID("cancelled"))
+	require.NoError(t, err)
+	w.Start()
+	<-started
+
+	drainCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, w.Stop(drainCtx), context.DeadlineExceeded)
+	stats, err = q.Stats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, &Stats{Delayed: 1}, stats)
+}
+
+func TestDepthCollector(t *testing.T) {
+	q := New("depth", NewMemory())
+	_, err := q.Enqueue(context.Background(), "welcome", nil)
+	require.NoError(t, err)
+
+	depth.add(q)
+	defer depth.remove(q)
+
+	assert.Equal(t, 4, testutil.CollectAndCount(depth, "queue_depth"))
+}
@@ -0,0 +1,261 @@
+// Copyright 2023 Talhuang<talhuang1231@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/skeleton1231/gotal/pkg/cache"
+)
+
+// A queue is kept in six keys sharing a hash tag, so they live in the same cluster slot:
+//   - jobs, a hash of the jobs by ID;
+//   - attempts, a hash of the deliveries of the jobs by ID;
+//   - ready, a list of the IDs of the jobs waiting for a worker;
+//   - delayed, a sorted set of the IDs of the delayed jobs, scored by their time in milliseconds;
+//   - inflight, a sorted set of the IDs of the delivered jobs, scored by their lease;
+//   - dead, a list of the IDs of the dead jobs.
+func redisKeys(queue string) []string {
+	prefix := "queue:{" + queue + "}:"
+
+	return []string{prefix + "jobs", prefix + "attempts", prefix + "ready", prefix + "delayed", prefix + "inflight", prefix + "dead"}
+}
+
+var pushScript = redis.NewScript(`
+if redis.call('HSETNX', KEYS[1], ARGV[1], ARGV[2]) == 0 then
+	return 0
+end
+if tonumber(ARGV[3]) <= tonumber(ARGV[4]) then
+	redis.call('RPUSH', KEYS[3], ARGV[1])
+else
+	redis.call('ZADD', KEYS[4], ARGV[3], ARGV[1])
+end
+return 1
+`)
+
+// popScript makes the due delayed jobs and the jobs whose lease ended ready, a batch at a
+// time, then delivers the first ready job.
+var popScript = redis.NewScript(`
+for _, key in ipairs({KEYS[4], KEYS[5]}) do
+	local due = redis.call('ZRANGEBYSCORE', key, '-inf', ARGV[1], 'LIMIT', 0, 100)
+	for _, id in ipairs(due) do
+		redis.call('ZREM', key, id)
+		redis.call('RPUSH', KEYS[3], id)
+	end
+end
+while true do
+	local id = redis.call('LPOP', KEYS[3])
+	if not id then
+		return false
+	end
+	local job = redis.call('HGET', KEYS[1], id)
+	if job then
+		local attempts = redis.call('HINCRBY', KEYS[2], id, 1)
+		redis.call('ZADD', KEYS[5], ARGV[2], id)
+		return {job, attempts}
+	end
+end
+`)
+
+// finishScript ends a delivery: ARGV[3] is ack, retry or bury.
+var finishScript = redis.NewScript(`
+local lease = redis.call('ZSCORE', KEYS[5], ARGV[1])
+if not lease or tonumber(lease) ~= tonumber(ARGV[2]) then
+	return 0
+end
+redis.call('ZREM', KEYS[5], ARGV[1])
+if ARGV[3] == 'ack' then
+	redis.call('HDEL', KEYS[1], ARGV[1])
+	redis.call('HDEL', KEYS[2], ARGV[1])
+elseif ARGV[3] == 'retry' then
+	redis.call('HSET', KEYS[1], ARGV[1], ARGV[4])
+	redis.call('ZADD', KEYS[4], ARGV[5], ARGV[1])
+else
+	redis.call('HSET', KEYS[1], ARGV[1], ARGV[4])
+	redis.call('HDEL', KEYS[2], ARGV[1])
+	redis.call('RPUSH', KEYS[6], ARGV[1])
+end
+return 1
+`)
+
+var deadScript = redis.NewScript(`
+local ids = redis.call('LRANGE', KEYS[6], 0, tonumber(ARGV[1]) - 1)
+if #ids == 0 then
+	return {}
+end
+return redis.call('HMGET', KEYS[1], unpack(ids))
+`)
+
+var reviveScript = redis.NewScript(`
+if redis.call('LREM', KEYS[6], 1, ARGV[1]) == 0 then
+	return 0
+end
+redis.call('RPUSH', KEYS[3], ARGV[1])
+return 1
+`)
+
+var statsScript = redis.NewScript(`
+return {redis.call('LLEN', KEYS[3]), redis.call('ZCARD', KEYS[4]), redis.call('ZCARD', KEYS[5]), redis.call('LLEN', KEYS[6])}
+`)
+
+// Redis keeps the queues in Redis, through the lists and sorted sets of a RedisClusterV2.
+type Redis struct {
+	store *cache.RedisClusterV2
+}
+
+var _ Backend = (*Redis)(nil)
+
+// NewRedis returns the backend keeping the queues in the store, whose connection is opened
+// by cache.ConnectToRedisV2. The store must not hash its keys, which would split the keys
+// of a queue across cluster slots.
+func NewRedis(store *cache.RedisClusterV2) *Redis {
+	return &Redis{store: store}
+}
+
+// Push implements Backend.
+func (r *Redis) Push(ctx context.Context, queue string, job *Job, now time.Time) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+
+	pushed, err := r.store.RunScript(ctx, pushScript, redisKeys(queue), job.ID, data, job.RunAt.UnixMilli(), now.UnixMilli())
+	if err != nil {
+		return err
+	}
+	if pushed.(int64) == 0 {
+		return ErrDuplicateJob
+	}
+
+	return nil
+}
+
+// Pop implements Backend.
+func (r *Redis) Pop(ctx context.Context, queue string, now time.Time, visibility time.Duration) (*Job, error) {
+	lease := now.Add(visibility).UnixMilli()
+
+	result, err := r.store.RunScript(ctx, popScript, redisKeys(queue), now.UnixMilli(), lease)
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	values, ok := result.([]interface{})
+	if !ok || len(values) != 2 {
+		return nil, fmt.Errorf("queue: unexpected reply %v", result)
+	}
+	job, err := decodeJob([]byte(values[0].(string)))
+	if err != nil {
+		return nil, err
+	}
+	job.Attempts = int(values[1].(int64))
+	job.lease = lease
+
+	return job, nil
+}
+
+// Ack implements Backend.
+func (r *Redis) Ack(ctx context.Context, queue string, job *Job) error {
+	return r.finish(ctx, queue, job, "ack", "", 0)
+}
+
+// Retry implements Backend.
+func (r *Redis) Retry(ctx context.Context, queue string, job *Job, at time.Time) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+
+	return r.finish(ctx, queue, job, "retry", string(data), at.UnixMilli())
+}
+
+// Bury implements Backend.
+func (r *Redis) Bury(ctx context.Context, queue string, job *Job) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+
+	return r.finish(ctx, queue, job, "bury", string(data), 0)
+}
+
+func (r *Redis) finish(ctx context.Context, queue string, job *Job, action, data string, at int64) error {
+	finished, err := r.store.RunScript(ctx, finishScript, redisKeys(queue), job.ID, job.lease, action, data, at)
+	if err != nil {
+		return err
+	}
+	if finished.(int64) == 0 {
+		return ErrLeaseLost
+	}
+
+	return nil
+}
+
+// Dead implements Backend.
+func (r *Redis) Dead(ctx context.Context, queue string, limit int) ([]*Job, error) {
+	if limit <= 0 {
+		return nil, nil
+	}
+
+	result, err := r.store.RunScript(ctx, deadScript, redisKeys(queue), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	var jobs []*Job
+	for _, value := range result.([]interface{}) {
+		data, ok := value.(string)
+		if !ok {
+			continue
+		}
+		job, err := decodeJob([]byte(data))
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+
+	return jobs, nil
+}
+
+// Revive implements Backend.
+func (r *Redis) Revive(ctx context.Context, queue, id string) error {
+	revived, err := r.store.RunScript(ctx, reviveScript, redisKeys(queue), id)
+	if err != nil {
+		return err
+	}
+	if revived.(int64) == 0 {
+		return ErrJobNotFound
+	}
+
+	return nil
+}
+
+// Stats implements Backend.
+func (r *Redis) Stats(ctx context.Context, queue string) (*Stats, error) {
+	result, err := r.store.RunScript(ctx, statsScript, redisKeys(queue))
+	if err != nil {
+		return nil, err
+	}
+
+	values, ok := result.([]interface{})
+	if !ok || len(values) != 4 {
+		return nil, fmt.Errorf("queue: unexpected reply %v", result)
+	}
+
+	return &Stats{
+		Ready:    values[0].(int64),
+		Delayed:  values[1].(int64),
+		InFlight: values[2].(int64),
+		Dead:     values[3].(int64),
+	}, nil
+}
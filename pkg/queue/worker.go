@@ -0,0 +1,247 @@
+// Copyright 2023 Talhuang<talhuang1231@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package queue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/skeleton1231/gotal/pkg/log"
+	"github.com/skeleton1231/gotal/pkg/shutdown"
+)
+
+// HandlerFunc runs a job. The context ends with the visibility timeout of the delivery,
+// or when the worker gives up draining.
+type HandlerFunc func(ctx context.Context, job *Job) error
+
+// permanentError is an error retrying does not fix.
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent wraps the error of a handler to move the job to the dead-letter queue at once,
+// without retrying it.
+func Permanent(err error) error {
+	return &permanentError{err: err}
+}
+
+// Options defines options for a pool of workers.
+type Options struct {
+	// Concurrency is the number of jobs run at the same time.
+	Concurrency int
+	// Visibility is how long a job is held by a worker before it is delivered again.
+	Visibility time.Duration
+	// PollInterval is how long workers wait when no job is ready.
+	PollInterval time.Duration
+	// MinBackoff is the delay before the second attempt of a job, it doubles with every
+	// attempt up to MaxBackoff.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	// DrainTimeout is how long a shutdown waits for the running jobs before cancelling them.
+	DrainTimeout time.Duration
+}
+
+// NewOptions returns the default options of a pool of workers.
+func NewOptions() *Options {
+	return &Options{
+		Concurrency:  4,
+		Visibility:   5 * time.Minute,
+		PollInterval: time.Second,
+		MinBackoff:   5 * time.Second,
+		MaxBackoff:   time.Hour,
+		DrainTimeout: 30 * time.Second,
+	}
+}
+
+// Backoff returns the delay before the attempt following the given one.
+func (o *Options) Backoff(attempt int) time.Duration {
+	delay := o.MinBackoff
+	for i := 1; i < attempt && delay < o.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > o.MaxBackoff {
+		delay = o.MaxBackoff
+	}
+
+	return delay
+}
+
+// Worker is a pool of workers running the jobs of a queue.
+type Worker struct {
+	queue    *Queue
+	opts     *Options
+	handlers map[string]HandlerFunc
+
+	// ctx is the parent of the contexts of the handlers, it is cancelled when draining ends.
+	ctx      context.Context
+	cancel   context.CancelFunc
+	stopping chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+var _ shutdown.ShutdownCallback = (*Worker)(nil)
+
+// NewWorker returns a pool of workers for the queue.
+func NewWorker(queue *Queue, opts *Options) *Worker {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Worker{
+		queue:    queue,
+		opts:     opts,
+		handlers: map[string]HandlerFunc{},
+		ctx:      ctx,
+		cancel:   cancel,
+		stopping: make(chan struct{}),
+	}
+}
+
+// Handle runs the jobs of the type with the handler. Handlers are set before Start.
+func (w *Worker) Handle(jobType string, handler HandlerFunc) {
+	w.handlers[jobType] = handler
+}
+
+// Start starts the workers. They run until Stop, which is called on shutdown when the worker
+// is added as a callback of a shutdown.GracefulShutdown.
+func (w *Worker) Start() {
+	depth.add(w.queue)
+
+	for i := 0; i < w.opts.Concurrency; i++ {
+		w.wg.Add(1)
+		go func() {
+			defer w.wg.Done()
+			w.run()
+		}()
+	}
+}
+
+// Stop stops taking jobs and waits for the running jobs, which are cancelled once ctx ends.
+// Jobs which did not finish are delivered again after their visibility timeout.
+func (w *Worker) Stop(ctx context.Context) error {
+	w.stopOnce.Do(func() {
+		close(w.stopping)
+		depth.remove(w.queue)
+	})
+
+	done := make(chan struct{})
+	go func() {
+		w.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		w.cancel()
+
+		return nil
+	case <-ctx.Done():
+		w.cancel()
+		<-done
+
+		return fmt.Errorf("queue: drain %s: %w", w.queue.name, ctx.Err())
+	}
+}
+
+// OnShutdown implements shutdown.ShutdownCallback, it drains the workers for DrainTimeout.
+func (w *Worker) OnShutdown(string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), w.opts.DrainTimeout)
+	defer cancel()
+
+	return w.Stop(ctx)
+}
+
+func (w *Worker) run() {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-w.stopping:
+			return
+		case <-timer.C:
+		}
+
+		job, err := w.queue.backend.Pop(w.ctx, w.queue.name, w.queue.now(), w.opts.Visibility)
+		if err != nil {
+			log.Errorf("take a job from queue %s failed: %s", w.queue.name, err.Error())
+		}
+		if job == nil {
+			timer.Reset(w.opts.PollInterval)
+
+			continue
+		}
+
+		w.process(job)
+		timer.Reset(0)
+	}
+}
+
+// process runs the job and records the outcome. A job delivered more than MaxAttempts
+// times, because workers holding it stopped, is dead without running.
+func (w *Worker) process(job *Job) {
+	var err error
+	if job.Attempts > job.MaxAttempts {
+		err = Permanent(errors.New("visibility timeout expired on the last attempt"))
+	} else {
+		start := time.Now()
+		err = w.handle(job)
+		jobDuration.WithLabelValues(w.queue.name).Observe(time.Since(start).Seconds())
+	}
+
+	// Finishing does not use the context of the handlers, so it outlives the drain.
+	ctx := context.Background()
+	result := resultSucceeded
+
+	var permanent *permanentError
+	switch {
+	case err == nil:
+		err = w.queue.backend.Ack(ctx, w.queue.name, job)
+	case errors.As(err, &permanent) || job.Attempts >= job.MaxAttempts:
+		result = resultDead
+		job.LastError = err.Error()
+		log.Warnf("job %s of queue %s is dead after %d attempts: %s", job.ID, w.queue.name, job.Attempts, err.Error())
+		err = w.queue.backend.Bury(ctx, w.queue.name, job)
+	default:
+		result = resultRetried
+		job.LastError = err.Error()
+		at := w.queue.now().Add(w.opts.Backoff(job.Attempts))
+		log.Infof("job %s of queue %s failed, retrying at %s: %s", job.ID, w.queue.name, at.Format(time.RFC3339), err.Error())
+		err = w.queue.backend.Retry(ctx, w.queue.name, job, at)
+	}
+
+	if errors.Is(err, ErrLeaseLost) {
+		result = resultLeaseLost
+		log.Warnf("job %s of queue %s outlived its visibility timeout", job.ID, w.queue.name)
+	} else if err != nil {
+		log.Errorf("finish job %s of queue %s failed: %s", job.ID, w.queue.name, err.Error())
+	}
+	jobsTotal.WithLabelValues(w.queue.name, result).Inc()
+}
+
+// handle runs the handler of the job, a panic is a failure of the job.
+func (w *Worker) handle(job *Job) (err error) {
+	handler, ok := w.handlers[job.Type]
+	if !ok {
+		return Permanent(fmt.Errorf("no handler for jobs of type %s", job.Type))
+	}
+
+	ctx, cancel := context.WithDeadline(w.ctx, job.Deadline())
+	defer cancel()
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+
+	return handler(ctx, job)
+}
@@ -0,0 +1,55 @@
+package mergepatch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test cases from RFC 7396 Appendix A.
+func TestApply(t *testing.T) {
+	tests := []struct {
+		doc, patch, want string
+	}{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`["a","b"]`, `["c","d"]`, `["c","d"]`},
+		{`{"a":"b"}`, `["c"]`, `["c"]`},
+		{`{"a":"foo"}`, `null`, `null`},
+		{`{"a":"foo"}`, `"bar"`, `"bar"`},
+		{`{"e":null}`, `{"a":1}`, `{"a":1,"e":null}`},
+		{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+	}
+
+	for _, tt := range tests {
+		got, err := Apply([]byte(tt.doc), []byte(tt.patch))
+		assert.NoError(t, err)
+		assert.JSONEq(t, tt.want, string(got), "%s + %s", tt.doc, tt.patch)
+	}
+}
+
+func TestApply_Invalid(t *testing.T) {
+	_, err := Apply([]byte(`{}`), []byte(`{`))
+	assert.Error(t, err)
+
+	_, err = Apply([]byte(`{`), []byte(`{}`))
+	assert.Error(t, err)
+}
+
+func TestKeys(t *testing.T) {
+	keys, err := Keys([]byte(`{"name":"alice","extend":null}`))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"extend", "name"}, keys)
+
+	for _, patch := range []string{`null`, `["a"]`, `"a"`, `{`} {
+		_, err := Keys([]byte(patch))
+		assert.Error(t, err, patch)
+	}
+}